@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/google/gopacket/tcpassembly"
+	"github.com/google/gopacket/tcpassembly/tcpreader"
+	"github.com/spf13/cobra"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/alex-ilgayev/mcpspy/pkg/mcp"
+	"github.com/alex-ilgayev/mcpspy/pkg/output"
+)
+
+// Analyze command flags
+var (
+	analyzePcapFile   string
+	analyzeNoColor    bool
+	analyzeColorTheme string
+)
+
+func newAnalyzeCmd() *cobra.Command {
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Reconstruct MCP traffic from a saved pcap capture (no eBPF, no root)",
+		Long: `Analyze reads a pcap file of plaintext (HTTP, not HTTPS) MCP traffic - for
+example one taken elsewhere with tcpdump - reassembles each TCP stream with
+gopacket, parses the reassembled bytes as HTTP requests/responses, and feeds
+the resulting bodies through the same MCP parser used by "run". This lets a
+capture be analyzed offline, without eBPF or root.
+
+Process identity (PID, command name) is unavailable offline and is reported
+as unknown. TLS-encrypted captures are not supported: run "mcpspy" live
+against the process instead, where SSL uprobes can see the plaintext.
+
+Direction is inferred per TCP stream from port numbers: the side connecting
+to the numerically lower port is assumed to be the client, so a capture
+using an unusual port layout may be read backwards.`,
+		Args: cobra.NoArgs,
+		RunE: runAnalyze,
+	}
+
+	analyzeCmd.Flags().StringVar(&analyzePcapFile, "pcap", "", "Path to a pcap file to analyze (required)")
+	analyzeCmd.Flags().BoolVar(&analyzeNoColor, "no-color", false, "Disable colored console output")
+	analyzeCmd.Flags().StringVar(&analyzeColorTheme, "color-theme", "dark", fmt.Sprintf("Console color theme: %s", strings.Join(output.ColorThemeNames, "|")))
+	analyzeCmd.MarkFlagRequired("pcap")
+
+	return analyzeCmd
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(analyzePcapFile)
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", analyzePcapFile, err)
+	}
+	defer f.Close()
+
+	if err := output.SetColorTheme(analyzeColorTheme); err != nil {
+		return err
+	}
+	if analyzeNoColor {
+		color.NoColor = true
+	}
+
+	eventBus := bus.New()
+	defer eventBus.Close()
+
+	parser, err := mcp.NewParser(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create MCP parser: %w", err)
+	}
+	defer parser.Close()
+
+	consoleDisplay, err := output.NewConsoleDisplay(os.Stdout, false, eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create console display: %w", err)
+	}
+	consoleDisplay.PrintHeader()
+
+	methodCounts := make(map[string]int)
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, func(e event.Event) {
+		if msg, ok := e.(*event.MCPEvent); ok {
+			methodCounts[msg.Method]++
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to subscribe to MCP events: %w", err)
+	}
+
+	start := time.Now()
+	streams, err := analyzePcap(f, eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to analyze '%s': %w", analyzePcapFile, err)
+	}
+
+	// HTTP message goroutines publish onto eventBus as they finish parsing
+	// each reassembled stream; give the last few a moment to land before we
+	// print the summary, same as replay does after replayJSONL returns.
+	time.Sleep(50 * time.Millisecond)
+
+	consoleDisplay.PrintStats(methodCounts)
+	consoleDisplay.PrintSummary(streams, len(methodCounts), 0, time.Since(start), false)
+	return nil
+}
+
+// analyzePcap reads every packet in r, reassembles each TCP stream with
+// gopacket/tcpassembly, and publishes a HttpRequestEvent or HttpResponseEvent
+// for every complete HTTP message recovered from it onto eventBus. It
+// returns the number of TCP streams seen once the capture is exhausted.
+func analyzePcap(r io.Reader, eventBus bus.EventBus) (int, error) {
+	pcapReader, err := pcapgo.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("not a pcap file: %w", err)
+	}
+
+	factory := &httpStreamFactory{eventBus: eventBus}
+	streamPool := tcpassembly.NewStreamPool(factory)
+	assembler := tcpassembly.NewAssembler(streamPool)
+
+	for {
+		data, ci, err := pcapReader.ReadPacketData()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read packet: %w", err)
+		}
+
+		packet := gopacket.NewPacket(data, pcapReader.LinkType(), gopacket.Lazy)
+		netLayer := packet.NetworkLayer()
+		tcpLayer := packet.Layer(layers.LayerTypeTCP)
+		if netLayer == nil || tcpLayer == nil {
+			continue
+		}
+
+		assembler.AssembleWithTimestamp(netLayer.NetworkFlow(), tcpLayer.(*layers.TCP), ci.Timestamp)
+	}
+
+	assembler.FlushAll()
+	factory.wg.Wait()
+
+	return factory.streamCount(), nil
+}
+
+// httpStreamFactory hands tcpassembly a fresh tcpreader.ReaderStream for
+// every half of every TCP connection it sees, and drains each one on its own
+// goroutine by looping the stdlib HTTP reader over it - the pattern
+// documented by tcpreader itself, since the assembler blocks until a
+// stream's Reassembled data is consumed.
+type httpStreamFactory struct {
+	eventBus bus.EventBus
+
+	mu    sync.Mutex
+	count int
+	wg    sync.WaitGroup
+}
+
+func (f *httpStreamFactory) New(netFlow, tcpFlow gopacket.Flow) tcpassembly.Stream {
+	f.mu.Lock()
+	f.count++
+	f.mu.Unlock()
+
+	stream := tcpreader.NewReaderStream()
+
+	f.wg.Add(1)
+	if isClientToServer(tcpFlow) {
+		go f.readRequests(&stream)
+	} else {
+		go f.readResponses(&stream)
+	}
+
+	return &stream
+}
+
+func (f *httpStreamFactory) streamCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.count
+}
+
+func (f *httpStreamFactory) readRequests(r io.Reader) {
+	defer f.wg.Done()
+
+	buf := bufio.NewReader(r)
+	for {
+		req, err := http.ReadRequest(buf)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return
+		}
+		if err != nil {
+			tcpreader.DiscardBytesToEOF(buf)
+			return
+		}
+
+		body, _ := io.ReadAll(req.Body)
+		req.Body.Close()
+
+		authScheme, hasCredentials := "", false
+		if auth := req.Header.Get("Authorization"); auth != "" {
+			authScheme, _, _ = strings.Cut(auth, " ")
+			hasCredentials = true
+		}
+
+		f.eventBus.Publish(&event.HttpRequestEvent{
+			EventHeader: event.EventHeader{
+				EventType: event.EventTypeHttpRequest,
+			},
+			Method:         req.Method,
+			Host:           req.Host,
+			Path:           req.URL.Path,
+			RequestHeaders: firstValues(req.Header),
+			RequestPayload: body,
+			AuthScheme:     authScheme,
+			HasCredentials: hasCredentials,
+		})
+	}
+}
+
+func (f *httpStreamFactory) readResponses(r io.Reader) {
+	defer f.wg.Done()
+
+	buf := bufio.NewReader(r)
+	for {
+		resp, err := http.ReadResponse(buf, nil)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return
+		}
+		if err != nil {
+			tcpreader.DiscardBytesToEOF(buf)
+			return
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		f.eventBus.Publish(&event.HttpResponseEvent{
+			EventHeader: event.EventHeader{
+				EventType: event.EventTypeHttpResponse,
+			},
+			HttpRequestEvent: event.HttpRequestEvent{
+				EventHeader: event.EventHeader{
+					EventType: event.EventTypeHttpRequest,
+				},
+			},
+			Code:            resp.StatusCode,
+			IsChunked:       len(resp.TransferEncoding) > 0,
+			ResponseHeaders: firstValues(resp.Header),
+			ResponsePayload: body,
+		})
+	}
+}
+
+// isClientToServer guesses whether a reassembled TCP half-stream carries
+// requests or responses, since tcpassembly only hands us the flow tuple, not
+// the SYN that opened the connection. The side connecting to the numerically
+// lower port is assumed to be the server, matching the common case of a
+// well-known or fixed listening port and an ephemeral client port.
+func isClientToServer(tcpFlow gopacket.Flow) bool {
+	return binary.BigEndian.Uint16(tcpFlow.Dst().Raw()) < binary.BigEndian.Uint16(tcpFlow.Src().Raw())
+}
+
+// firstValues flattens a net/http header map (one or more values per key)
+// down to the single-value-per-key shape HttpRequestEvent/HttpResponseEvent
+// use elsewhere in the codebase, keeping the first occurrence of each key.
+func firstValues(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if len(v) > 0 {
+			out[k] = v[0]
+		}
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// buildLoopbackHTTPPcap serializes a single loopback IPv4/TCP packet from
+// clientPort to serverPort carrying payload as its segment, matching the
+// synthetic addressing PcapDisplay.buildPacket uses for MCP pcap output.
+func buildLoopbackHTTPPcap(t *testing.T, clientPort, serverPort layers.TCPPort, payload []byte) []byte {
+	t.Helper()
+
+	var pcapBuf bytes.Buffer
+	w := pcapgo.NewWriter(&pcapBuf)
+	if err := w.WriteFileHeader(65536, layers.LinkTypeLoop); err != nil {
+		t.Fatalf("WriteFileHeader() error = %v", err)
+	}
+
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolTCP,
+		SrcIP:    []byte{127, 0, 0, 1},
+		DstIP:    []byte{127, 0, 0, 1},
+	}
+	tcp := &layers.TCP{
+		SrcPort: clientPort,
+		DstPort: serverPort,
+		PSH:     true,
+		ACK:     true,
+		Window:  65535,
+	}
+	if err := tcp.SetNetworkLayerForChecksum(ip); err != nil {
+		t.Fatalf("SetNetworkLayerForChecksum() error = %v", err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts,
+		&layers.Loopback{Family: layers.ProtocolFamilyIPv4},
+		ip,
+		tcp,
+		gopacket.Payload(payload),
+	); err != nil {
+		t.Fatalf("SerializeLayers() error = %v", err)
+	}
+
+	data := buf.Bytes()
+	if err := w.WritePacket(gopacket.CaptureInfo{
+		Timestamp:     time.Unix(0, 0),
+		CaptureLength: len(data),
+		Length:        len(data),
+	}, data); err != nil {
+		t.Fatalf("WritePacket() error = %v", err)
+	}
+
+	return pcapBuf.Bytes()
+}
+
+func TestAnalyzePcap_ReconstructsHTTPRequest(t *testing.T) {
+	request := "POST /mcp HTTP/1.1\r\nHost: localhost\r\nContent-Length: 2\r\n\r\n{}"
+	pcapData := buildLoopbackHTTPPcap(t, 54321, 8080, []byte(request))
+
+	eventBus := tu.NewMockBus()
+	var got *event.HttpRequestEvent
+	if err := eventBus.Subscribe(event.EventTypeHttpRequest, func(e event.Event) {
+		if req, ok := e.(*event.HttpRequestEvent); ok {
+			got = req
+		}
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	streams, err := analyzePcap(bytes.NewReader(pcapData), eventBus)
+	if err != nil {
+		t.Fatalf("analyzePcap() error = %v", err)
+	}
+	if streams != 1 {
+		t.Errorf("expected 1 TCP stream, got %d", streams)
+	}
+
+	// The event bus dispatches asynchronously; give the reassembly
+	// goroutine a moment to finish parsing and publish.
+	deadline := time.Now().Add(time.Second)
+	for got == nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if got == nil {
+		t.Fatal("expected an HttpRequestEvent to be published")
+	}
+	if got.Method != "POST" || got.Path != "/mcp" {
+		t.Errorf("expected POST /mcp, got %s %s", got.Method, got.Path)
+	}
+	if string(got.RequestPayload) != "{}" {
+		t.Errorf("expected body %q, got %q", "{}", got.RequestPayload)
+	}
+}
+
+func TestIsClientToServer(t *testing.T) {
+	tcpFlow := (&layers.TCP{SrcPort: 54321, DstPort: 8080}).TransportFlow()
+	if !isClientToServer(tcpFlow) {
+		t.Error("expected the higher ephemeral port to be treated as the client")
+	}
+	if isClientToServer(tcpFlow.Reverse()) {
+		t.Error("expected the reversed flow (server replying) not to be treated as client-to-server")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/baseline"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+var baselineRecordOut string
+
+func newBaselineCmd() *cobra.Command {
+	baselineCmd := &cobra.Command{
+		Use:   "baseline",
+		Short: "Record and compare against a known-good traffic profile",
+		Long: `Baseline profiles capture the set of JSON-RPC methods, tool names, and
+hosts a known-good MCP session uses. Feed the resulting profile to a live
+capture via --baseline to flag any method, tool, or host that falls outside
+it - a sign of drift or compromise of the monitored server.`,
+	}
+
+	baselineCmd.AddCommand(newBaselineRecordCmd())
+
+	return baselineCmd
+}
+
+func newBaselineRecordCmd() *cobra.Command {
+	recordCmd := &cobra.Command{
+		Use:   "record <capture.jsonl>",
+		Short: "Build a baseline profile from a known-good JSONL capture",
+		Long: `Record reads a JSONL file produced by --output/-o during a known-good
+session and writes the set of methods, tool names, and hosts it observed to
+a baseline profile JSON file, for later use with --baseline.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runBaselineRecord,
+	}
+
+	recordCmd.Flags().StringVar(&baselineRecordOut, "out", "baseline.json", "Path to write the baseline profile to")
+
+	return recordCmd
+}
+
+func runBaselineRecord(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", args[0], err)
+	}
+	defer f.Close()
+
+	profile, err := baselineProfileFromJSONL(f)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", args[0], err)
+	}
+
+	if err := profile.Save(baselineRecordOut); err != nil {
+		return fmt.Errorf("failed to write baseline profile to '%s': %w", baselineRecordOut, err)
+	}
+
+	fmt.Printf("Recorded baseline to %s: %d method(s), %d tool(s), %d host(s)\n",
+		baselineRecordOut, len(profile.Methods), len(profile.Tools), len(profile.Hosts))
+	return nil
+}
+
+// baselineProfileFromJSONL reads a JSONL capture and observes every
+// mcp_message line into a new baseline profile.
+func baselineProfileFromJSONL(r io.Reader) (*baseline.Profile, error) {
+	profile := baseline.NewProfile()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var generic map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &generic); err != nil {
+			continue
+		}
+		if sniffEventTypeName(generic) != "mcp_message" {
+			continue
+		}
+
+		var msg event.MCPEvent
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			continue
+		}
+		profile.Observe(&msg)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
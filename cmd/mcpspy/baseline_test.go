@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBaselineProfileFromJSONL_RecordsMethodsToolsAndHosts(t *testing.T) {
+	input := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z","transport_type":"stdio","stdio_transport":{"from_pid":1,"from_comm":"a","to_pid":2,"to_comm":"b"},"type":"request","id":1,"method":"tools/list","raw":"{}"}`,
+		`{"timestamp":"2025-01-01T00:00:01Z","transport_type":"http","http_transport":{"pid":3,"comm":"c","host":"api.example.com"},"type":"request","id":2,"method":"tools/call","params":{"name":"read_file"},"raw":"{}"}`,
+		// Not an mcp_message line; shouldn't be observed into the profile.
+		`{"timestamp":"2025-01-01T00:00:02Z","risk_level":"high","risk_score":0.9,"category":"prompt_injection","analyzed_text":"..."}`,
+	}, "\n")
+
+	profile, err := baselineProfileFromJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("baselineProfileFromJSONL() error = %v", err)
+	}
+
+	if !profile.Methods["tools/list"] || !profile.Methods["tools/call"] {
+		t.Errorf("expected both methods recorded, got %+v", profile.Methods)
+	}
+	if !profile.Tools["read_file"] {
+		t.Errorf("expected tool read_file recorded, got %+v", profile.Tools)
+	}
+	if !profile.Hosts["api.example.com"] {
+		t.Errorf("expected host api.example.com recorded, got %+v", profile.Hosts)
+	}
+}
+
+func TestBaselineProfileFromJSONL_IgnoresMalformedLines(t *testing.T) {
+	input := strings.Join([]string{
+		`{"timestamp":`, // truncated
+		`not json at all`,
+	}, "\n")
+
+	profile, err := baselineProfileFromJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("baselineProfileFromJSONL() error = %v", err)
+	}
+	if len(profile.Methods) != 0 || len(profile.Tools) != 0 || len(profile.Hosts) != 0 {
+		t.Errorf("expected an empty profile for malformed input, got %+v", profile)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// loadConfig fills in any flag on flags that wasn't explicitly passed on the
+// command line, from (in increasing priority) ~/.mcpspy.yaml or
+// ./mcpspy.yaml, then MCPSPY_* environment variables. Flags the user actually
+// typed are left untouched, so the final precedence is:
+//
+//	defaults < config file < environment variables < command-line flags
+func loadConfig(flags *pflag.FlagSet) error {
+	v := viper.New()
+	v.SetConfigName("mcpspy")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(".")
+	if home, err := os.UserHomeDir(); err == nil {
+		v.AddConfigPath(home)
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	v.SetEnvPrefix("MCPSPY")
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.AutomaticEnv()
+
+	if err := v.BindPFlags(flags); err != nil {
+		return fmt.Errorf("failed to bind flags for config loading: %w", err)
+	}
+
+	var firstErr error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Changed || firstErr != nil || !v.IsSet(f.Name) {
+			return
+		}
+		if err := flags.Set(f.Name, flagStringValue(f, v.Get(f.Name))); err != nil {
+			firstErr = fmt.Errorf("failed to apply config value for --%s: %w", f.Name, err)
+		}
+	})
+	return firstErr
+}
+
+// flagStringValue renders a value decoded from the config file or an
+// environment variable back into the string form pflag.Set expects,
+// matching the CSV/key=value encoding each flag type's own Set parses.
+func flagStringValue(f *pflag.Flag, val any) string {
+	switch f.Value.Type() {
+	case "stringSlice", "stringArray", "uintSlice", "intSlice":
+		return strings.Join(cast.ToStringSlice(val), ",")
+	case "stringToString":
+		m := cast.ToStringMapString(val)
+		pairs := make([]string, 0, len(m))
+		for k, v := range m {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+		}
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// newConfigCmd returns the "config" command group for inspecting how
+// MCPSpy's persistent configuration (~/.mcpspy.yaml, ./mcpspy.yaml,
+// MCPSPY_* env vars) resolves against its flags.
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect MCPSpy's configuration sources",
+	}
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "print",
+		Short: "Print the effective value of every flag after merging all config sources",
+		Long: `print resolves every root flag the same way a real run would - defaults
+overridden by ~/.mcpspy.yaml or ./mcpspy.yaml, overridden by MCPSPY_*
+environment variables, overridden by whatever was passed on the command
+line - and prints the result, one flag per line.`,
+		RunE: runConfigPrint,
+	})
+
+	return configCmd
+}
+
+func runConfigPrint(cmd *cobra.Command, _ []string) error {
+	root := cmd.Root()
+
+	var names []string
+	root.Flags().VisitAll(func(f *pflag.Flag) {
+		names = append(names, f.Name)
+	})
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%s: %s\n", name, root.Flags().Lookup(name).Value.String())
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func newTestFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("log-level", "info", "")
+	flags.StringSlice("redact", []string{"token", "password"}, "")
+	flags.String("metrics-addr", "", "")
+	return flags
+}
+
+func TestLoadConfig_FileFillsUnsetFlags(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+
+	configBody := "log-level: debug\nredact:\n  - apikey\n  - secret\n"
+	if err := os.WriteFile(filepath.Join(dir, "mcpspy.yaml"), []byte(configBody), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	flags := newTestFlags()
+	if err := loadConfig(flags); err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+
+	if got, _ := flags.GetString("log-level"); got != "debug" {
+		t.Errorf("log-level = %q, want %q", got, "debug")
+	}
+	if got, _ := flags.GetStringSlice("redact"); len(got) != 2 || got[0] != "apikey" || got[1] != "secret" {
+		t.Errorf("redact = %v, want [apikey secret]", got)
+	}
+}
+
+func TestLoadConfig_EnvVarOverridesConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("MCPSPY_LOG_LEVEL", "trace")
+
+	configBody := "log-level: debug\n"
+	if err := os.WriteFile(filepath.Join(dir, "mcpspy.yaml"), []byte(configBody), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	flags := newTestFlags()
+	if err := loadConfig(flags); err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+
+	if got, _ := flags.GetString("log-level"); got != "trace" {
+		t.Errorf("log-level = %q, want %q (env should beat config file)", got, "trace")
+	}
+}
+
+func TestLoadConfig_ExplicitFlagOverridesEverything(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("MCPSPY_LOG_LEVEL", "trace")
+
+	configBody := "log-level: debug\n"
+	if err := os.WriteFile(filepath.Join(dir, "mcpspy.yaml"), []byte(configBody), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	flags := newTestFlags()
+	if err := flags.Set("log-level", "warn"); err != nil {
+		t.Fatalf("failed to set flag: %v", err)
+	}
+
+	if err := loadConfig(flags); err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+
+	if got, _ := flags.GetString("log-level"); got != "warn" {
+		t.Errorf("log-level = %q, want %q (explicit flag should beat everything)", got, "warn")
+	}
+}
+
+func TestLoadConfig_NoConfigFileIsNotAnError(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+
+	flags := newTestFlags()
+	if err := loadConfig(flags); err != nil {
+		t.Fatalf("loadConfig() error = %v, want nil when no config file is present", err)
+	}
+	if got, _ := flags.GetString("metrics-addr"); got != "" {
+		t.Errorf("metrics-addr = %q, want default %q", got, "")
+	}
+}
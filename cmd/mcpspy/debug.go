@@ -20,6 +20,7 @@ import (
 	"github.com/alex-ilgayev/mcpspy/pkg/mcp"
 	"github.com/alex-ilgayev/mcpspy/pkg/namespace"
 	"github.com/alex-ilgayev/mcpspy/pkg/output"
+	"github.com/alex-ilgayev/mcpspy/pkg/tcp"
 )
 
 // Debug command flags
@@ -30,6 +31,8 @@ var (
 	debugHost        string   // Filter by host (regex)
 	debugShowPayload bool     // Show payload/buffer data
 	debugShowHeaders bool     // Show HTTP headers
+
+	debugNoNamespaceSwitch bool // Safe mode: never setns into other mount namespaces
 )
 
 func newDebugCmd() *cobra.Command {
@@ -48,14 +51,17 @@ Event Types (Raw eBPF):
   tls_send            - TLS payload send
   tls_recv            - TLS payload receive
   tls_free            - TLS context free
+  tcp_data            - Bare JSON-RPC-over-TCP payload (no HTTP framing)
 
 Event Types (Derived):
   http_request        - HTTP request parsed
   http_response       - HTTP response parsed
   http_sse            - HTTP Server-Sent Event
+  http_websocket      - WebSocket message on an upgraded HTTP connection
   mcp_message         - MCP JSON-RPC message
   fs_aggregated_read  - Aggregated FS read (complete JSON)
   fs_aggregated_write - Aggregated FS write (complete JSON)
+  tcp_aggregated      - Aggregated TCP payload (complete JSON)
   security_alert      - Security/injection alert
   llm_message         - LLM API message
   tool_usage          - Tool usage event
@@ -104,6 +110,8 @@ Examples:
 		"Show payload/buffer data for events")
 	debugCmd.Flags().BoolVar(&debugShowHeaders, "headers", false,
 		"Show HTTP headers for HTTP events")
+	debugCmd.Flags().BoolVar(&debugNoNamespaceSwitch, "no-namespace-switch", false,
+		"Safe mode: never setns into other mount namespaces; skip containerized libssl instead")
 
 	return debugCmd
 }
@@ -147,14 +155,14 @@ func runDebug(cmd *cobra.Command, args []string) error {
 	debugDisplay.PrintFilters()
 
 	// Create and load eBPF program
-	loader, err := ebpf.New(uint32(os.Getpid()), eventBus)
+	loader, err := ebpf.New(uint32(os.Getpid()), 0, eventBus)
 	if err != nil {
 		return fmt.Errorf("failed to create eBPF loader: %w", err)
 	}
 	defer loader.Close()
 
 	// Process library events and create uprobe hooks
-	libManager, err := ebpf.NewLibraryManager(eventBus, loader, mountNS)
+	libManager, err := ebpf.NewLibraryManager(eventBus, loader, mountNS, debugNoNamespaceSwitch)
 	if err != nil {
 		return fmt.Errorf("failed to create library manager: %w", err)
 	}
@@ -174,6 +182,13 @@ func runDebug(cmd *cobra.Command, args []string) error {
 	}
 	defer fsManager.Close()
 
+	// Manage bare JSON-RPC-over-TCP sessions
+	tcpManager, err := tcp.NewSessionManager(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create TCP session manager: %w", err)
+	}
+	defer tcpManager.Close()
+
 	fmt.Fprintln(os.Stdout, "Loading eBPF programs...")
 
 	if err := loader.Load(); err != nil {
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func newDiffCmd() *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff <a.jsonl> <b.jsonl>",
+		Short: "Compare two JSONL captures by method and report differences",
+		Long: `Diff reads two JSONL files produced by --output/-o and compares the MCP
+messages they contain, grouped by method: methods present in one capture but
+not the other, latency deltas for tools/call-style request/response pairs,
+and error-rate changes. Timestamps and JSON-RPC ids are ignored, since they
+are never equal across two independent captures of the same workload.
+
+This is meant for A/B or before/after comparisons of an MCP server, not for
+diffing two captures of unrelated sessions.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDiff,
+	}
+
+	return diffCmd
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	statsA, err := methodStatsFromFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", args[0], err)
+	}
+	statsB, err := methodStatsFromFile(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", args[1], err)
+	}
+
+	report := diffMethodStats(statsA, statsB)
+
+	if len(report.OnlyInA) > 0 {
+		fmt.Printf("Methods only in %s:\n", args[0])
+		for _, m := range report.OnlyInA {
+			fmt.Printf("  - %s\n", m)
+		}
+	}
+	if len(report.OnlyInB) > 0 {
+		fmt.Printf("Methods only in %s:\n", args[1])
+		for _, m := range report.OnlyInB {
+			fmt.Printf("  - %s\n", m)
+		}
+	}
+	for _, d := range report.Methods {
+		fmt.Printf("%s: count %d -> %d, error rate %.1f%% -> %.1f%%, avg latency %s -> %s\n",
+			d.Method, d.CountA, d.CountB, d.ErrorRateA*100, d.ErrorRateB*100, d.AvgLatencyA, d.AvgLatencyB)
+	}
+
+	return nil
+}
+
+// methodStats summarizes the request/response traffic observed for a single
+// JSON-RPC method across a capture.
+type methodStats struct {
+	count          int
+	errors         int
+	responses      int
+	totalLatencyMs int64
+}
+
+func (s methodStats) errorRate() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return float64(s.errors) / float64(s.count)
+}
+
+func (s methodStats) avgLatencyMs() int64 {
+	if s.responses == 0 {
+		return 0
+	}
+	return s.totalLatencyMs / int64(s.responses)
+}
+
+// methodStatsFromFile reads a JSONL capture and aggregates methodStats keyed
+// by JSON-RPC method, ignoring timestamps, ids, and anything that isn't an
+// mcp_message line.
+func methodStatsFromFile(path string) (map[string]methodStats, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return methodStatsFromJSONL(f)
+}
+
+func methodStatsFromJSONL(r io.Reader) (map[string]methodStats, error) {
+	stats := make(map[string]methodStats)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var generic map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &generic); err != nil {
+			continue
+		}
+		if sniffEventTypeName(generic) != "mcp_message" {
+			continue
+		}
+
+		var msg event.MCPEvent
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			continue
+		}
+
+		method := msg.Method
+		if msg.MessageType == event.JSONRPCMessageTypeResponse && msg.Request != nil {
+			method = msg.Request.Method
+		}
+		if method == "" {
+			continue
+		}
+
+		s := stats[method]
+		s.count++
+		if msg.MessageType == event.JSONRPCMessageTypeResponse {
+			s.responses++
+			s.totalLatencyMs += msg.Latency.Milliseconds()
+			if msg.Error.Message != "" || msg.Error.Code != 0 {
+				s.errors++
+			}
+		}
+		stats[method] = s
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// methodDiff reports how a single method's traffic changed between two
+// captures.
+type methodDiff struct {
+	Method      string
+	CountA      int
+	CountB      int
+	ErrorRateA  float64
+	ErrorRateB  float64
+	AvgLatencyA string
+	AvgLatencyB string
+}
+
+// diffReport summarizes the comparison of two captures' method stats.
+type diffReport struct {
+	OnlyInA []string
+	OnlyInB []string
+	Methods []methodDiff
+}
+
+// diffMethodStats compares two captures' per-method stats, reporting
+// methods unique to either side and, for methods present in both, how their
+// count/error-rate/latency differ.
+func diffMethodStats(a, b map[string]methodStats) diffReport {
+	var report diffReport
+
+	for method := range a {
+		if _, ok := b[method]; !ok {
+			report.OnlyInA = append(report.OnlyInA, method)
+		}
+	}
+	for method := range b {
+		if _, ok := a[method]; !ok {
+			report.OnlyInB = append(report.OnlyInB, method)
+		}
+	}
+	sort.Strings(report.OnlyInA)
+	sort.Strings(report.OnlyInB)
+
+	for method, sa := range a {
+		sb, ok := b[method]
+		if !ok {
+			continue
+		}
+		report.Methods = append(report.Methods, methodDiff{
+			Method:      method,
+			CountA:      sa.count,
+			CountB:      sb.count,
+			ErrorRateA:  sa.errorRate(),
+			ErrorRateB:  sb.errorRate(),
+			AvgLatencyA: fmt.Sprintf("%dms", sa.avgLatencyMs()),
+			AvgLatencyB: fmt.Sprintf("%dms", sb.avgLatencyMs()),
+		})
+	}
+	sort.Slice(report.Methods, func(i, j int) bool {
+		return report.Methods[i].Method < report.Methods[j].Method
+	})
+
+	return report
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffMethodStats_OnlyInOneSide(t *testing.T) {
+	a := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z","transport_type":"stdio","type":"request","id":1,"method":"tools/list","raw":"{}"}`,
+	}, "\n")
+	b := strings.Join([]string{
+		`{"timestamp":"2025-06-01T00:00:00Z","transport_type":"stdio","type":"request","id":1,"method":"resources/list","raw":"{}"}`,
+	}, "\n")
+
+	statsA, err := methodStatsFromJSONL(strings.NewReader(a))
+	if err != nil {
+		t.Fatalf("methodStatsFromJSONL(a) error = %v", err)
+	}
+	statsB, err := methodStatsFromJSONL(strings.NewReader(b))
+	if err != nil {
+		t.Fatalf("methodStatsFromJSONL(b) error = %v", err)
+	}
+
+	report := diffMethodStats(statsA, statsB)
+	if len(report.OnlyInA) != 1 || report.OnlyInA[0] != "tools/list" {
+		t.Errorf("expected tools/list only in A, got %v", report.OnlyInA)
+	}
+	if len(report.OnlyInB) != 1 || report.OnlyInB[0] != "resources/list" {
+		t.Errorf("expected resources/list only in B, got %v", report.OnlyInB)
+	}
+	if len(report.Methods) != 0 {
+		t.Errorf("expected no shared methods, got %v", report.Methods)
+	}
+}
+
+func TestDiffMethodStats_SharedMethodDeltas(t *testing.T) {
+	a := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z","transport_type":"stdio","type":"request","id":1,"method":"tools/call","raw":"{}"}`,
+		`{"timestamp":"2025-01-01T00:00:00.050Z","transport_type":"stdio","type":"response","id":1,"request":{"type":"request","id":1,"method":"tools/call"},"latency":50000000,"raw":"{}"}`,
+	}, "\n")
+	b := strings.Join([]string{
+		`{"timestamp":"2025-06-01T00:00:00Z","transport_type":"stdio","type":"request","id":1,"method":"tools/call","raw":"{}"}`,
+		`{"timestamp":"2025-06-01T00:00:00.200Z","transport_type":"stdio","type":"response","id":1,"request":{"type":"request","id":1,"method":"tools/call"},"latency":200000000,"error":{"code":-32000,"message":"boom"},"raw":"{}"}`,
+	}, "\n")
+
+	statsA, err := methodStatsFromJSONL(strings.NewReader(a))
+	if err != nil {
+		t.Fatalf("methodStatsFromJSONL(a) error = %v", err)
+	}
+	statsB, err := methodStatsFromJSONL(strings.NewReader(b))
+	if err != nil {
+		t.Fatalf("methodStatsFromJSONL(b) error = %v", err)
+	}
+
+	report := diffMethodStats(statsA, statsB)
+	if len(report.OnlyInA) != 0 || len(report.OnlyInB) != 0 {
+		t.Fatalf("expected no unique methods, got onlyA=%v onlyB=%v", report.OnlyInA, report.OnlyInB)
+	}
+	if len(report.Methods) != 1 {
+		t.Fatalf("expected one shared method diff, got %v", report.Methods)
+	}
+
+	d := report.Methods[0]
+	if d.Method != "tools/call" {
+		t.Errorf("expected method tools/call, got %q", d.Method)
+	}
+	if d.ErrorRateA != 0 {
+		t.Errorf("expected 0 error rate in A, got %v", d.ErrorRateA)
+	}
+	if d.ErrorRateB != 1 {
+		t.Errorf("expected 100%% error rate in B, got %v", d.ErrorRateB)
+	}
+	if d.AvgLatencyA != "50ms" {
+		t.Errorf("expected avg latency 50ms in A, got %q", d.AvgLatencyA)
+	}
+	if d.AvgLatencyB != "200ms" {
+		t.Errorf("expected avg latency 200ms in B, got %q", d.AvgLatencyB)
+	}
+}
+
+func TestMethodStatsFromJSONL_IgnoresNonMCPLines(t *testing.T) {
+	input := strings.Join([]string{
+		`{"risk_level":"high","risk_score":0.9,"category":"prompt_injection","analyzed_text":"..."}`,
+		`{"timestamp":"2025-01-01T00:00:00Z","transport_type":"stdio","type":"request","id":1,"method":"tools/list","raw":"{}"}`,
+	}, "\n")
+
+	stats, err := methodStatsFromJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("methodStatsFromJSONL() error = %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("expected only the mcp_message line to be counted, got %v", stats)
+	}
+	if stats["tools/list"].count != 1 {
+		t.Errorf("expected tools/list count 1, got %d", stats["tools/list"].count)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/mcp"
+)
+
+func newExplainCmd() *cobra.Command {
+	explainCmd := &cobra.Command{
+		Use:   "explain <capture-file>",
+		Short: "Dry-run a capture file through the MCP parser and report drop counts",
+		Long: `Explain mode runs a sample capture file (a raw stream of newline-delimited
+JSON-RPC messages, as produced by a stdio session) through the same parsing
+pipeline used during a live capture, without emitting any events. It reports
+how many messages were kept and how many were dropped by each stage
+(invalid JSON, unknown/invalid MCP method, duplicate, or unpaired response),
+so filter and capture configuration can be validated before a real run.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExplain,
+	}
+
+	return explainCmd
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read capture file '%s': %w", args[0], err)
+	}
+
+	eventBus := bus.New()
+	defer eventBus.Close()
+
+	parser, err := mcp.NewParser(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create MCP parser: %w", err)
+	}
+	defer parser.Close()
+
+	stats := parser.Explain(data)
+
+	fmt.Printf("Total messages:        %d\n", stats.Total)
+	fmt.Printf("Kept:                  %d\n", stats.Kept)
+	fmt.Printf("Dropped (invalid JSON):        %d\n", stats.InvalidJSON)
+	fmt.Printf("Dropped (invalid MCP message): %d\n", stats.InvalidMCPMessage)
+	fmt.Printf("Dropped (duplicate):           %d\n", stats.Duplicate)
+	fmt.Printf("Dropped (unpaired response):   %d\n", stats.UnpairedResponse)
+
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !kafka
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+)
+
+// setupKafkaOutput is a stub used when mcpspy is built without the `kafka`
+// build tag, so the default build doesn't pull in the kafka-go dependency.
+func setupKafkaOutput(brokers, topic string, eventBus bus.EventBus) (io.Closer, error) {
+	return nil, fmt.Errorf("mcpspy was built without Kafka support; rebuild with `-tags kafka` to use --kafka-brokers")
+}
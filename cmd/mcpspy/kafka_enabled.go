@@ -0,0 +1,23 @@
+//go:build kafka
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/output"
+)
+
+// setupKafkaOutput creates a Kafka output handler publishing captured events
+// to brokers/topic. Only available when built with `-tags kafka`.
+func setupKafkaOutput(brokers, topic string, eventBus bus.EventBus) (io.Closer, error) {
+	display, err := output.NewKafkaDisplay(strings.Split(brokers, ","), topic, eventBus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka display: %w", err)
+	}
+
+	return display, nil
+}
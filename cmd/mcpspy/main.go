@@ -6,34 +6,90 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/charmbracelet/x/term"
+	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/alex-ilgayev/mcpspy/pkg/aggregator"
+	"github.com/alex-ilgayev/mcpspy/pkg/alert"
+	"github.com/alex-ilgayev/mcpspy/pkg/baseline"
+	"github.com/alex-ilgayev/mcpspy/pkg/bridge"
 	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/correlate"
 	mcpspydebug "github.com/alex-ilgayev/mcpspy/pkg/debug"
 	"github.com/alex-ilgayev/mcpspy/pkg/ebpf"
+	"github.com/alex-ilgayev/mcpspy/pkg/egress"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
 	"github.com/alex-ilgayev/mcpspy/pkg/fs"
+	"github.com/alex-ilgayev/mcpspy/pkg/handshake"
 	"github.com/alex-ilgayev/mcpspy/pkg/http"
 	"github.com/alex-ilgayev/mcpspy/pkg/llm"
 	"github.com/alex-ilgayev/mcpspy/pkg/mcp"
+	"github.com/alex-ilgayev/mcpspy/pkg/metrics"
 	"github.com/alex-ilgayev/mcpspy/pkg/namespace"
 	"github.com/alex-ilgayev/mcpspy/pkg/output"
+	"github.com/alex-ilgayev/mcpspy/pkg/proctree"
+	"github.com/alex-ilgayev/mcpspy/pkg/rules"
 	"github.com/alex-ilgayev/mcpspy/pkg/security"
+	"github.com/alex-ilgayev/mcpspy/pkg/session"
+	"github.com/alex-ilgayev/mcpspy/pkg/tcp"
 	"github.com/alex-ilgayev/mcpspy/pkg/version"
+	"github.com/alex-ilgayev/mcpspy/pkg/webhook"
 )
 
 // Command line flags
 var (
-	showBuffers       bool
-	verbose           bool
-	outputFile        string
-	logLevel          string
-	tui               bool
-	enableLLMMonitor  bool
-	enableToolMonitor bool
+	showBuffers        bool
+	bufferMethods      []string
+	showArgs           bool
+	verbose            bool
+	outputFile         string
+	outputFormat       string
+	pcapOutFile        string
+	logFormat          string
+	logLevel           string
+	tui                bool
+	enableLLMMonitor   bool
+	enableToolMonitor  bool
+	kafkaBrokers       string
+	kafkaTopic         string
+	lenientJSONRPC     bool
+	correlationIDMode  bool
+	correlationTimeout time.Duration
+	strictSchema       bool
+	correlatedOnly     bool
+	noColor            bool
+	colorTheme         string
+	plainOutput        bool
+	noNamespaceSwitch  bool
+	profileType        string
+	pprofAddr          string
+	sessionDir         string
+	sessionCleanNoise  bool
+	suppressPings      bool
+	alertThreshold     float64
+	alertWindow        time.Duration
+	redactPatterns     []string
+	pidFilterValues    []uint
+	commFilterValues   []string
+	followChildrenPID  uint32
+	captureBytes       uint32
+	metricsAddr        string
+	captureLabels      map[string]string
+	otlpEndpoint       string
+	otlpInsecure       bool
+	checkHandshake     bool
+	maxDuration        time.Duration
+	maxEvents          int
+	baselinePath       string
+	rulesPath          string
+	webhookURL         string
 
 	// Security flags
 	securityEnabled   bool
@@ -52,16 +108,57 @@ communication by tracking stdio operations and analyzing JSON-RPC 2.0 messages.`
 		Version:      fmt.Sprintf("%s (commit: %s, built: %s)", version.Version, version.Commit, version.Date),
 		RunE:         run,
 		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return loadConfig(cmd.Root().Flags())
+		},
 	}
 
 	// Add flags
 	rootCmd.Flags().BoolVarP(&showBuffers, "buffers", "b", false, "Show raw message buffers (static mode only)")
+	rootCmd.Flags().StringSliceVar(&bufferMethods, "buffer-methods", nil, "Restrict raw buffer display (--buffers) to these comma-separated methods (e.g. 'tools/call,initialize'); other methods still show the compact line")
+	rootCmd.Flags().BoolVar(&showArgs, "show-args", false, "Show a compact, redacted summary of tools/call arguments inline next to the tool name (static mode only)")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging (debug level)")
 	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (JSONL format will be written to file)")
+	rootCmd.Flags().StringVar(&outputFormat, "format", "jsonl", "Format for --output: 'jsonl' (default) or 'inspector' (a single JSON array loadable into the MCP Inspector UI)")
+	rootCmd.Flags().StringVar(&pcapOutFile, "pcap-out", "", "Write each MCP message as a synthetic loopback packet to this pcap file, loadable in Wireshark with its JSON dissector")
 	rootCmd.Flags().StringVarP(&logLevel, "log-level", "l", "info", "Set log level (trace, debug, info, warn, error, fatal, panic)")
+	rootCmd.Flags().StringVar(&logFormat, "log-format", "text", "Format for MCPSpy's own operational logs (distinct from --output capture logs): 'text' (default) or 'json'")
 	rootCmd.Flags().BoolVar(&tui, "tui", true, "Enable TUI (Terminal UI) mode. Use --tui=false to disable and use static console output")
 	rootCmd.Flags().BoolVar(&enableLLMMonitor, "llm", false, "Enable LLM API monitoring (shows LLM request/response events)")
 	rootCmd.Flags().BoolVar(&enableToolMonitor, "tools", false, "Enable tool usage monitoring (shows tool invocations/results from LLM APIs)")
+	rootCmd.Flags().StringVar(&kafkaBrokers, "kafka-brokers", "", "Comma-separated Kafka broker addresses to stream events to (requires building with `-tags kafka`)")
+	rootCmd.Flags().StringVar(&kafkaTopic, "kafka-topic", "", "Kafka topic to publish events to (required with --kafka-brokers)")
+	rootCmd.Flags().BoolVar(&lenientJSONRPC, "lenient-jsonrpc", false, "Accept JSON-RPC messages with a missing or mismatching \"jsonrpc\":\"2.0\" field (for debugging non-conformant servers)")
+	rootCmd.Flags().BoolVar(&correlationIDMode, "correlation-id", false, "Tag request/response records with a stable correlation id instead of embedding the full request in the response (reduces output size for request-heavy captures)")
+	rootCmd.Flags().DurationVar(&correlationTimeout, "correlation-timeout", 5*time.Second, "How long to wait for a request's response before reporting it as an unanswered timeout")
+	rootCmd.Flags().BoolVar(&strictSchema, "strict", false, "Validate request params and response results against the MCP schema for initialize, tools/call, tools/list, and resources/read; violations are attached to the event and shown in red, the message is still emitted")
+	rootCmd.Flags().BoolVar(&correlatedOnly, "correlated-only", false, "Hold each request until its response (or timeout) arrives and emit a single combined record with the full exchange and latency, instead of emitting the request and response separately")
+	rootCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored console output (static mode only)")
+	rootCmd.Flags().StringVar(&colorTheme, "color-theme", "dark", fmt.Sprintf("Console color theme: %s (static mode only)", strings.Join(output.ColorThemeNames, "|")))
+	rootCmd.Flags().BoolVar(&plainOutput, "plain", false, "Print one compact, uncolored line per event with no box-drawing, for piping to grep/less/log collectors (implies --no-color, static mode only)")
+	rootCmd.Flags().BoolVar(&noNamespaceSwitch, "no-namespace-switch", false, "Safe mode: never setns into other mount namespaces; skip containerized libssl instead of entering the container to attach to it")
+	rootCmd.Flags().StringVar(&profileType, "profile", "", "Capture a pprof profile during the run (cpu, mem, or block), written to mcpspy.<type>.pprof on shutdown")
+	rootCmd.Flags().StringVar(&pprofAddr, "pprof-addr", "", "Serve live pprof endpoints at this address (e.g. localhost:6060) instead of writing a profile file")
+	rootCmd.Flags().StringVar(&sessionDir, "session-dir", "", "Write a self-contained JSON bundle per MCP session (messages, capabilities, tools used, stats) to this directory on session end")
+	rootCmd.Flags().BoolVar(&sessionCleanNoise, "session-clean", false, "Strip ping/progress/list_changed protocol noise from --session-dir bundle transcripts, keeping only substantive tool/resource/prompt interactions (message counts in stats are unaffected)")
+	rootCmd.Flags().BoolVar(&suppressPings, "suppress-pings", false, "Hide successful ping/pong keepalives from output (static mode only); pings that time out are still shown")
+	rootCmd.Flags().Float64Var(&alertThreshold, "alert-error-rate", 0.10, "Error-response rate (0.0-1.0), per method and overall, that triggers an alert")
+	rootCmd.Flags().DurationVar(&alertWindow, "alert-window", 60*time.Second, "Rolling time window the error rate is computed over")
+	rootCmd.Flags().StringSliceVar(&redactPatterns, "redact", output.DefaultRedactPatterns, "Mask values whose JSON key matches any of these comma-separated patterns (case-insensitive substring) in raw buffers (--buffers) and JSONL output; pass an empty string to disable")
+	rootCmd.Flags().UintSliceVar(&pidFilterValues, "pid", nil, "Restrict output to MCP messages attributable to these comma-separated PIDs (repeatable)")
+	rootCmd.Flags().StringSliceVar(&commFilterValues, "comm", nil, "Restrict output to MCP messages whose process name contains one of these comma-separated substrings")
+	rootCmd.Flags().Uint32Var(&followChildrenPID, "follow-children", 0, "Restrict output to this PID and its descendants, walking /proc ancestry as new processes appear (e.g. scope to an agent PID and the MCP servers it spawns); ANDed with --pid/--comm when those are also set")
+	rootCmd.Flags().Uint32Var(&captureBytes, "capture-bytes", 0, "Capture only the first N bytes of each read/write payload in the kernel, reducing ring buffer pressure and avoiding copying sensitive content past the method/id fields; 0 means no limit. Captures truncated this way only have method/id parseable, not full content")
+	rootCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics at this address (e.g. localhost:9090) for long-running monitoring, instead of relying on console/TUI output")
+	rootCmd.Flags().StringToStringVar(&captureLabels, "label", nil, "Attach a key=value label to every record in --output JSONL (repeatable), for telling runs apart once their JSONL is aggregated into one store")
+	rootCmd.Flags().StringVar(&otlpEndpoint, "otlp-endpoint", "", "OTLP/gRPC collector address (e.g. localhost:4317) to export a span per correlated MCP request/response pair to, for viewing MCP latency in Jaeger/Tempo (requires building with `-tags otel`)")
+	rootCmd.Flags().BoolVar(&otlpInsecure, "otlp-insecure", false, "Dial --otlp-endpoint without TLS (for a local collector/sidecar)")
+	rootCmd.Flags().BoolVar(&checkHandshake, "check-handshake", false, "Flag requests/responses/notifications observed before a session's initialize/notifications-initialized handshake has completed (conformance checking, for server/client developers)")
+	rootCmd.Flags().DurationVar(&maxDuration, "duration", 0, "Automatically stop after this long, printing stats and flushing output files as if Ctrl+C were pressed (e.g. '30s', '5m'); 0 means unlimited. Useful for CI captures and cron-based sampling")
+	rootCmd.Flags().IntVar(&maxEvents, "max-events", 0, "Automatically stop once this many MCP messages have been observed; 0 means unlimited")
+	rootCmd.Flags().StringVar(&baselinePath, "baseline", "", "Path to a baseline profile JSON file (written by `mcpspy baseline record`); flag any method, tool, or host seen live that isn't in it as a possible drift or compromise indicator")
+	rootCmd.Flags().StringVar(&rulesPath, "rules", "", "Path to a YAML rules file matching on method/tool/resource URI/param substrings, each rule triggering a log, highlighted alert, exit, or webhook POST on a match")
+	rootCmd.Flags().StringVar(&webhookURL, "webhook-url", "", "POST a batched JSON summary (method, transport, process, redacted+truncated raw content) of every MCP message and rule match to this HTTP endpoint, e.g. for piping activity into Slack/Teams/PagerDuty")
 
 	// Security flags
 	rootCmd.Flags().BoolVar(&securityEnabled, "security", false, "Enable prompt injection detection")
@@ -70,14 +167,52 @@ communication by tracking stdio operations and analyzing JSON-RPC 2.0 messages.`
 	rootCmd.Flags().Float64Var(&securityThreshold, "security-threshold", 0.5, "Risk score threshold for detection (0.0-1.0)")
 	rootCmd.Flags().BoolVar(&securityAsync, "security-async", true, "Run security analysis asynchronously (non-blocking)")
 
+	// Add analyze subcommand
+	rootCmd.AddCommand(newAnalyzeCmd())
+
+	// Add baseline subcommand
+	rootCmd.AddCommand(newBaselineCmd())
+
+	// Add config subcommand
+	rootCmd.AddCommand(newConfigCmd())
+
 	// Add debug subcommand
 	rootCmd.AddCommand(newDebugCmd())
 
+	// Add diff subcommand
+	rootCmd.AddCommand(newDiffCmd())
+
+	// Add explain subcommand
+	rootCmd.AddCommand(newExplainCmd())
+
+	// Add replay subcommand
+	rootCmd.AddCommand(newReplayCmd())
+
+	// Add run subcommand
+	rootCmd.AddCommand(newRunCmd())
+
+	// Add validate subcommand
+	rootCmd.AddCommand(newValidateCmd())
+
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
 }
 
+// toUint32s narrows a --pid flag's []uint values (pflag has no uint32 slice
+// type) down to []uint32, the type PIDs are carried as throughout the event
+// package.
+func toUint32s(vals []uint) []uint32 {
+	if len(vals) == 0 {
+		return nil
+	}
+	out := make([]uint32, len(vals))
+	for i, v := range vals {
+		out[i] = uint32(v)
+	}
+	return out
+}
+
 // chownToOriginalUser changes the ownership of a file to the original user
 // who invoked sudo. This allows the user to access files created by mcpspy
 // without needing sudo privileges.
@@ -124,6 +259,15 @@ func run(cmd *cobra.Command, args []string) error {
 		logLevel = "debug"
 	}
 
+	// TUI mode needs a real terminal to draw into; fall back to the plain
+	// console when stdout is redirected (a pipe, a file, CI logs) rather
+	// than leaving bubbletea to garble non-TTY output. Only auto-fallback
+	// when the user didn't explicitly pass --tui, so `--tui=true` still
+	// errors out the way bubbletea naturally would.
+	if tui && !cmd.Flags().Changed("tui") && !term.IsTerminal(os.Stdout.Fd()) {
+		tui = false
+	}
+
 	// Parse and set log level
 	level, err := logrus.ParseLevel(logLevel)
 	if err != nil {
@@ -138,11 +282,44 @@ func run(cmd *cobra.Command, args []string) error {
 
 	logrus.SetLevel(level)
 
+	switch logFormat {
+	case "text":
+		// logrus defaults to a TextFormatter; nothing to do.
+	case "json":
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return fmt.Errorf("unknown --log-format '%s': expected 'text' or 'json'", logFormat)
+	}
+
+	if err := output.SetColorTheme(colorTheme); err != nil {
+		return err
+	}
+
+	if noColor || plainOutput {
+		color.NoColor = true
+	}
+
 	// Setup trace pipe to debug eBPF programs if debug or trace level
 	if level >= logrus.DebugLevel {
 		go mcpspydebug.PrintTracePipe(logrus.StandardLogger())
 	}
 
+	// Start self-profiling if requested
+	if profileType != "" {
+		stopProfile, err := startProfile(profileType)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := stopProfile(); err != nil {
+				logrus.WithError(err).Error("Failed to write profile")
+			}
+		}()
+	}
+	if pprofAddr != "" {
+		go servePprof(pprofAddr)
+	}
+
 	// Fetch current mount namespace
 	mountNS, err := namespace.GetCurrentMountNamespace()
 	if err != nil {
@@ -150,10 +327,34 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 	logrus.WithField("mount_ns", mountNS).Debug("Current mount namespace")
 
-	// A publish/subscribe event bus for inter-component communication
-	eventBus := bus.New()
+	// A publish/subscribe event bus for inter-component communication.
+	// Wrapped in a PausableBus so `kill -USR1 <pid>` can pause/resume
+	// capture at runtime without tearing down eBPF hooks.
+	eventBus := bus.NewPausable(bus.New())
 	defer eventBus.Close()
 
+	sigPause := make(chan os.Signal, 1)
+	signal.Notify(sigPause, syscall.SIGUSR1)
+	go func() {
+		for range sigPause {
+			if eventBus.Paused() {
+				eventBus.Resume()
+				logrus.Info("Capture resumed")
+			} else {
+				eventBus.Pause()
+				logrus.Info("Capture paused")
+			}
+		}
+	}()
+
+	// --follow-children: scope output to a PID and its descendants, walking
+	// /proc ancestry lazily as new PIDs are seen (same mechanism `mcpspy run`
+	// uses to scope to its spawned child's process tree).
+	var followChildrenTree *proctree.Tree
+	if followChildrenPID != 0 {
+		followChildrenTree = proctree.New(followChildrenPID)
+	}
+
 	// Set up display based on mode
 	var tuiDisplay *output.TUIDisplay
 	var consoleDisplay *output.ConsoleDisplay
@@ -170,6 +371,15 @@ func run(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to create console display: %w", err)
 		}
+		consoleDisplay.SetBufferMethods(bufferMethods)
+		consoleDisplay.SetSuppressSuccessfulPings(suppressPings)
+		consoleDisplay.SetRedactPatterns(redactPatterns)
+		consoleDisplay.SetShowArgs(showArgs)
+		consoleDisplay.SetPIDCommFilter(toUint32s(pidFilterValues), commFilterValues)
+		if followChildrenTree != nil {
+			consoleDisplay.SetPIDFilter(followChildrenTree.Contains)
+		}
+		consoleDisplay.SetPlain(plainOutput)
 		consoleDisplay.PrintHeader()
 	}
 
@@ -184,19 +394,131 @@ func run(cmd *cobra.Command, args []string) error {
 		if err := chownToOriginalUser(outputFile); err != nil {
 			logrus.WithError(err).Debug("Failed to change ownership of output file")
 		}
-		_, err = output.NewJSONLDisplay(file, eventBus)
+
+		switch outputFormat {
+		case "inspector":
+			inspectorDisplay, err := output.NewMCPInspectorDisplay(file, eventBus)
+			if err != nil {
+				return fmt.Errorf("failed to create inspector file display: %w", err)
+			}
+			defer func() {
+				if err := inspectorDisplay.Close(); err != nil {
+					logrus.WithError(err).Error("Failed to flush inspector output file")
+				}
+				if err := file.Close(); err != nil {
+					logrus.WithError(err).Error("Failed to close output file")
+				}
+			}()
+		case "jsonl":
+			jsonlDisplay, err := output.NewJSONLDisplay(file, eventBus)
+			if err != nil {
+				return fmt.Errorf("failed to create file display: %w", err)
+			}
+			jsonlDisplay.SetRedactPatterns(redactPatterns)
+			jsonlDisplay.SetPIDCommFilter(toUint32s(pidFilterValues), commFilterValues)
+			if followChildrenTree != nil {
+				jsonlDisplay.SetPIDFilter(followChildrenTree.Contains)
+			}
+			jsonlDisplay.SetLabels(captureLabels)
+			defer func() {
+				if err := file.Close(); err != nil {
+					logrus.WithError(err).Error("Failed to close output file")
+				}
+			}()
+		default:
+			return fmt.Errorf("unknown --format '%s': expected 'jsonl' or 'inspector'", outputFormat)
+		}
+	}
+
+	// Set up pcap export if specified
+	if pcapOutFile != "" {
+		file, err := os.Create(pcapOutFile)
+		if err != nil {
+			return fmt.Errorf("failed to create pcap output file '%s': %w", pcapOutFile, err)
+		}
+		if err := chownToOriginalUser(pcapOutFile); err != nil {
+			logrus.WithError(err).Debug("Failed to change ownership of pcap output file")
+		}
+
+		pcapDisplay, err := output.NewPcapDisplay(file, eventBus)
 		if err != nil {
-			return fmt.Errorf("failed to create file display: %w", err)
+			return fmt.Errorf("failed to create pcap display: %w", err)
+		}
+		pcapDisplay.SetPIDCommFilter(toUint32s(pidFilterValues), commFilterValues)
+		if followChildrenTree != nil {
+			pcapDisplay.SetPIDFilter(followChildrenTree.Contains)
 		}
 		defer func() {
 			if err := file.Close(); err != nil {
-				logrus.WithError(err).Error("Failed to close output file")
+				logrus.WithError(err).Error("Failed to close pcap output file")
+			}
+		}()
+	}
+
+	// Set up Kafka output if specified
+	if kafkaBrokers != "" {
+		if kafkaTopic == "" {
+			return fmt.Errorf("--kafka-topic is required when --kafka-brokers is set")
+		}
+
+		kafkaDisplay, err := setupKafkaOutput(kafkaBrokers, kafkaTopic, eventBus)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := kafkaDisplay.Close(); err != nil {
+				logrus.WithError(err).Error("Failed to close Kafka producer")
+			}
+		}()
+	}
+
+	// Set up OTLP trace export if requested
+	if otlpEndpoint != "" {
+		otlpExporter, err := setupOTLPExporter(context.Background(), otlpEndpoint, otlpInsecure, eventBus)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := otlpExporter.Close(); err != nil {
+				logrus.WithError(err).Error("Failed to close OTLP trace exporter")
+			}
+		}()
+	}
+
+	// Set up per-session JSON bundle export if requested. This also backs
+	// the SIGUSR2 live session listing below, since it's the only component
+	// that tracks in-flight sessions.
+	var sessionBundler *session.Bundler
+	if sessionDir != "" {
+		if err := os.MkdirAll(sessionDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create session directory '%s': %w", sessionDir, err)
+		}
+		sessionBundler, err = session.NewWithConfig(eventBus, sessionDir, session.Config{StripNoise: sessionCleanNoise})
+		if err != nil {
+			return fmt.Errorf("failed to create session bundler: %w", err)
+		}
+		defer sessionBundler.Close()
+
+		// Print a final per-session breakdown on exit. Registered after the
+		// Close() defer above so it runs first (LIFO), while sessions are
+		// still tracked in memory.
+		defer func() {
+			printSessionSummary(sessionBundler.ActiveSessions(time.Now()))
+		}()
+
+		// Let operators inspect ongoing conversations without stopping
+		// capture: `kill -USR2 <pid>` prints a snapshot of active sessions.
+		sigUsr2 := make(chan os.Signal, 1)
+		signal.Notify(sigUsr2, syscall.SIGUSR2)
+		go func() {
+			for range sigUsr2 {
+				printActiveSessions(sessionBundler.ActiveSessions(time.Now()))
 			}
 		}()
 	}
 
 	// Create and load eBPF program
-	loader, err := ebpf.New(uint32(os.Getpid()), eventBus)
+	loader, err := ebpf.New(uint32(os.Getpid()), captureBytes, eventBus)
 	if err != nil {
 		return fmt.Errorf("failed to create eBPF loader: %w", err)
 	}
@@ -204,7 +526,7 @@ func run(cmd *cobra.Command, args []string) error {
 
 	// Process library events
 	// and creates uprobe hooks for dynamically loaded libraries
-	libManager, err := ebpf.NewLibraryManager(eventBus, loader, mountNS)
+	libManager, err := ebpf.NewLibraryManager(eventBus, loader, mountNS, noNamespaceSwitch)
 	if err != nil {
 		return fmt.Errorf("failed to create library manager: %w", err)
 	}
@@ -224,6 +546,13 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 	defer fsManager.Close()
 
+	// Manage bare JSON-RPC-over-TCP sessions (no HTTP framing)
+	tcpManager, err := tcp.NewSessionManager(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create TCP session manager: %w", err)
+	}
+	defer tcpManager.Close()
+
 	if !tui {
 		consoleDisplay.PrintInfo("Loading eBPF programs...")
 	}
@@ -242,11 +571,39 @@ func run(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
+	// --duration: auto-exit after a fixed time window, same shutdown path
+	// as Ctrl+C (stats printed, output files flushed via the deferred
+	// closes below).
+	if maxDuration > 0 {
+		var durationCancel context.CancelFunc
+		ctx, durationCancel = context.WithTimeout(ctx, maxDuration)
+		defer durationCancel()
+	}
+
 	// Start event processing
 	if err := loader.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start event processing: %w", err)
 	}
 
+	// Prometheus metrics for long-running, unattended monitoring (optional)
+	if metricsAddr != "" {
+		metricsServer, err := metrics.New(eventBus, metrics.Config{
+			Addr:           metricsAddr,
+			LibraryStats:   libManager.Stats,
+			EBPFReadErrors: loader.ReadErrors,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create metrics server: %w", err)
+		}
+		defer func() {
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+			if err := metricsServer.Close(shutdownCtx); err != nil {
+				logrus.WithError(err).Warn("Failed to shut down metrics server cleanly")
+			}
+		}()
+	}
+
 	// Enumerate all libraries for TLS inspection
 	logrus.Debug("Doing initial enumeration of libraries for TLS inspection")
 	if err := loader.RunIterLibEnum(); err != nil {
@@ -259,12 +616,115 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create MCP parser and statistics
-	parser, err := mcp.NewParser(eventBus)
+	parser, err := mcp.NewParserWithConfig(eventBus, mcp.Config{
+		LenientJSONRPC:    lenientJSONRPC,
+		CorrelationIDMode: correlationIDMode,
+		Strict:            strictSchema,
+		CorrelatedOnly:    correlatedOnly,
+		RequestTimeout:    correlationTimeout,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP parser: %w", err)
 	}
 	defer parser.Close()
 
+	// Detect messages relayed across a stdio-to-HTTP bridge process
+	// (mcp-proxy, supergateway, etc.), only meaningful when both transports
+	// are being monitored.
+	bridgeDetector, err := bridge.New(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create bridge detector: %w", err)
+	}
+	defer bridgeDetector.Close()
+
+	// Detect requests relayed by an MCP gateway/aggregator (mcp-hub, etc.)
+	// to one or more backend servers, labeling fan-out when more than one
+	// backend handles the same client request.
+	aggregatorDetector, err := aggregator.New(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create aggregator detector: %w", err)
+	}
+	defer aggregatorDetector.Close()
+
+	// Flag an MCP server process (inferred from its stdio role) making
+	// outbound HTTP/TLS requests beyond its client conversation, which
+	// could be a legitimate API-backed tool or could be call-home/
+	// exfiltration behavior.
+	egressDetector, err := egress.New(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create egress detector: %w", err)
+	}
+	defer egressDetector.Close()
+
+	// Surfaces a sustained error-response rate as it happens, instead of
+	// only showing up in the summary printed on exit.
+	alertMonitor, err := alert.New(eventBus, alert.Config{
+		Threshold: alertThreshold,
+		Window:    alertWindow,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create alert monitor: %w", err)
+	}
+	defer alertMonitor.Close()
+
+	// --max-events: auto-exit after observing this many MCP messages.
+	if maxEvents > 0 {
+		eventsGate, err := newMaxEventsGate(eventBus, maxEvents, cancel)
+		if err != nil {
+			return fmt.Errorf("failed to set up --max-events limit: %w", err)
+		}
+		defer eventsGate.Close()
+	}
+
+	// Initialize handshake conformance checking (optional)
+	if checkHandshake {
+		handshakeMonitor, err := handshake.New(eventBus)
+		if err != nil {
+			return fmt.Errorf("failed to create handshake monitor: %w", err)
+		}
+		defer handshakeMonitor.Close()
+	}
+
+	// Baseline anomaly detection (optional)
+	if baselinePath != "" {
+		profile, err := baseline.Load(baselinePath)
+		if err != nil {
+			return fmt.Errorf("failed to load --baseline profile: %w", err)
+		}
+
+		baselineMonitor, err := baseline.New(eventBus, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create baseline monitor: %w", err)
+		}
+		defer baselineMonitor.Close()
+	}
+
+	// User-defined alerting rules (optional)
+	if rulesPath != "" {
+		rulesConfig, err := rules.LoadConfig(rulesPath)
+		if err != nil {
+			return fmt.Errorf("failed to load --rules file: %w", err)
+		}
+
+		rulesEngine, err := rules.New(eventBus, rulesConfig, cancel)
+		if err != nil {
+			return fmt.Errorf("failed to create rules engine: %w", err)
+		}
+		defer rulesEngine.Close()
+	}
+
+	// Webhook notifier (optional)
+	if webhookURL != "" {
+		notifier, err := webhook.New(eventBus, webhook.Config{
+			URL:            webhookURL,
+			RedactPatterns: redactPatterns,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook notifier: %w", err)
+		}
+		defer notifier.Close()
+	}
+
 	// Security analyzer (optional)
 	if securityEnabled {
 		if securityHFToken == "" {
@@ -300,6 +760,16 @@ func run(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to create LLM parser: %w", err)
 		}
 		defer llmParser.Close()
+
+		// Link LLM tool_use invocations with the MCP tools/call requests
+		// they trigger, only meaningful when both sides are observed.
+		if enableToolMonitor {
+			toolCorrelator, err := correlate.New(eventBus)
+			if err != nil {
+				return fmt.Errorf("failed to create tool call correlator: %w", err)
+			}
+			defer toolCorrelator.Close()
+		}
 	}
 
 	// Run TUI or wait for context cancellation
@@ -317,5 +787,85 @@ func run(cmd *cobra.Command, args []string) error {
 	// Waiting for context cancellation (Ctrl+C or TUI exit).
 	<-ctx.Done()
 
+	if noNamespaceSwitch {
+		if skipped := libManager.SkippedLibraries(); len(skipped) > 0 {
+			logrus.WithField("count", len(skipped)).Warn("Safe mode: containerized libraries were never attached to")
+			for inode, path := range skipped {
+				logrus.WithFields(logrus.Fields{"inode": inode, "path": path}).Warn("Skipped containerized library")
+			}
+		}
+	}
+
 	return nil
 }
+
+// maxEventsGate cancels the capture once a fixed number of MCP messages
+// have been observed (--max-events), for bounded CI/cron captures.
+type maxEventsGate struct {
+	eventBus bus.EventBus
+	max      int64
+	seen     atomic.Int64
+	cancel   context.CancelFunc
+}
+
+// newMaxEventsGate creates a maxEventsGate that calls cancel once max MCP
+// messages have been observed.
+func newMaxEventsGate(eventBus bus.EventBus, max int, cancel context.CancelFunc) (*maxEventsGate, error) {
+	g := &maxEventsGate{
+		eventBus: eventBus,
+		max:      int64(max),
+		cancel:   cancel,
+	}
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, g.handleMCPMessage); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *maxEventsGate) handleMCPMessage(_ event.Event) {
+	if g.seen.Add(1) >= g.max {
+		g.cancel()
+	}
+}
+
+// Close unsubscribes from the event bus.
+func (g *maxEventsGate) Close() {
+	g.eventBus.Unsubscribe(event.EventTypeMCPMessage, g.handleMCPMessage)
+}
+
+// printActiveSessions writes a snapshot of in-flight MCP sessions to
+// stderr, for the SIGUSR2 live session listing. Printed to stderr (and
+// plain text, not through the TUI/console display) so it doesn't get lost
+// in or corrupt whichever output mode is active.
+func printActiveSessions(sessions []session.ActiveSession) {
+	if len(sessions) == 0 {
+		fmt.Fprintln(os.Stderr, "No active MCP sessions")
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Active MCP sessions (%d):\n", len(sessions))
+	for _, s := range sessions {
+		fmt.Fprintf(os.Stderr, "  %s  age=%s  messages=%d  client=%v  server=%v\n",
+			s.SessionID, s.Age.Round(time.Second), s.MessageCount, s.ClientInfo["name"], s.ServerInfo["name"])
+	}
+}
+
+// printSessionSummary writes a per-session stats table to stderr when
+// mcpspy exits with --session-dir set. Only covers sessions still tracked
+// at shutdown; sessions that already idled out and were flushed to disk
+// earlier in the run aren't included here, but do have their own bundle
+// file.
+func printSessionSummary(sessions []session.ActiveSession) {
+	if len(sessions) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\nSession summary (%d session(s)):\n", len(sessions))
+	for _, s := range sessions {
+		fmt.Fprintf(os.Stderr, "  %s  requests=%d  responses=%d  notifications=%d  errors=%d\n",
+			s.SessionID, s.Stats.Requests, s.Stats.Responses, s.Stats.Notifications, s.Stats.Errors)
+		if s.Instructions != "" {
+			fmt.Fprintf(os.Stderr, "    instructions: %s\n", s.Instructions)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"testing"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestMaxEventsGate_CancelsAfterThreshold(t *testing.T) {
+	eventBus := bus.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	gate, err := newMaxEventsGate(eventBus, 2, cancel)
+	if err != nil {
+		t.Fatalf("newMaxEventsGate() error = %v", err)
+	}
+	defer gate.Close()
+
+	eventBus.Publish(&event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{Method: "tools/list"}})
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case <-ctx.Done():
+		t.Fatalf("context cancelled early, after only 1 event")
+	default:
+	}
+
+	eventBus.Publish(&event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{Method: "tools/call"}})
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case <-ctx.Done():
+	default:
+		t.Errorf("expected context to be cancelled after reaching max events")
+	}
+}
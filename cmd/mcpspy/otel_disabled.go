@@ -0,0 +1,18 @@
+//go:build !otel
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+)
+
+// setupOTLPExporter is a stub used when mcpspy is built without the `otel`
+// build tag, so the default build doesn't pull in the OpenTelemetry/gRPC
+// dependency tree.
+func setupOTLPExporter(ctx context.Context, endpoint string, insecure bool, eventBus bus.EventBus) (io.Closer, error) {
+	return nil, fmt.Errorf("mcpspy was built without OpenTelemetry support; rebuild with `-tags otel` to use --otlp-endpoint")
+}
@@ -0,0 +1,18 @@
+//go:build otel
+
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/tracing"
+)
+
+// setupOTLPExporter creates an OTLP/gRPC trace exporter publishing spans for
+// correlated MCP request/response pairs. Only available when built with
+// `-tags otel`.
+func setupOTLPExporter(ctx context.Context, endpoint string, insecure bool, eventBus bus.EventBus) (io.Closer, error) {
+	return tracing.New(ctx, eventBus, tracing.Config{Endpoint: endpoint, Insecure: insecure})
+}
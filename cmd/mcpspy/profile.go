@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	_ "net/http/pprof" // registers pprof HTTP handlers on DefaultServeMux
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/sirupsen/logrus"
+)
+
+// validProfileTypes are the profile kinds accepted by --profile
+var validProfileTypes = map[string]bool{
+	"cpu":   true,
+	"mem":   true,
+	"block": true,
+}
+
+// startProfile begins capturing the requested profile type and returns a
+// stop function that writes the result to "mcpspy.<profileType>.pprof" in
+// the current directory. Callers should defer the returned stop function.
+func startProfile(profileType string) (stop func() error, err error) {
+	if !validProfileTypes[profileType] {
+		return nil, fmt.Errorf("invalid --profile type %q (must be one of: cpu, mem, block)", profileType)
+	}
+
+	filename := fmt.Sprintf("mcpspy.%s.pprof", profileType)
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profile file %q: %w", filename, err)
+	}
+
+	switch profileType {
+	case "cpu":
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		return func() error {
+			pprof.StopCPUProfile()
+			return file.Close()
+		}, nil
+	case "block":
+		runtime.SetBlockProfileRate(1)
+		return func() error {
+			defer file.Close()
+			runtime.SetBlockProfileRate(0)
+			return pprof.Lookup("block").WriteTo(file, 0)
+		}, nil
+	default: // "mem"
+		return func() error {
+			defer file.Close()
+			runtime.GC()
+			return pprof.Lookup("heap").WriteTo(file, 0)
+		}, nil
+	}
+}
+
+// servePprof starts a blocking HTTP server exposing live pprof endpoints at
+// /debug/pprof/. Intended to be run in a goroutine.
+func servePprof(addr string) {
+	logrus.WithField("addr", addr).Info("Serving pprof endpoints")
+	if err := http.ListenAndServe(addr, nil); err != nil {
+		logrus.WithError(err).Error("pprof server stopped")
+	}
+}
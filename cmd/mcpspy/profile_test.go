@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStartProfile_WritesFile(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir() error = %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	for _, profileType := range []string{"cpu", "mem", "block"} {
+		t.Run(profileType, func(t *testing.T) {
+			stop, err := startProfile(profileType)
+			if err != nil {
+				t.Fatalf("startProfile(%q) error = %v", profileType, err)
+			}
+
+			if err := stop(); err != nil {
+				t.Fatalf("stop() error = %v", err)
+			}
+
+			path := filepath.Join(dir, "mcpspy."+profileType+".pprof")
+			if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+				t.Errorf("expected non-empty profile file at %s, err=%v", path, err)
+			}
+		})
+	}
+}
+
+func TestStartProfile_InvalidType(t *testing.T) {
+	if _, err := startProfile("bogus"); err == nil {
+		t.Error("expected error for invalid profile type, got nil")
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/alex-ilgayev/mcpspy/pkg/output"
+)
+
+// Replay command flags
+var (
+	replayPID        []uint
+	replayComm       []string
+	replaySpeed      float64
+	replayNoColor    bool
+	replayColorTheme string
+)
+
+func newReplayCmd() *cobra.Command {
+	replayCmd := &cobra.Command{
+		Use:   "replay <file.jsonl>",
+		Short: "Re-render a JSONL capture through the console display",
+		Long: `Replay reads a JSONL file produced by --output/-o, one event per line, and
+feeds the mcp_message lines back through ConsoleDisplay so a previously
+captured run can be re-rendered with colors and a final summary - useful for
+demos and for debugging display/formatting issues without eBPF.
+
+By default events are paced according to their recorded timestamps. Use
+--speed to scale that pacing (2 replays twice as fast, 0.5 half as fast) or
+--speed 0 to emit every line immediately with no pacing at all.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runReplay,
+	}
+
+	replayCmd.Flags().UintSliceVar(&replayPID, "pid", nil, "Restrict output to MCP messages attributable to these comma-separated PIDs (repeatable)")
+	replayCmd.Flags().StringSliceVar(&replayComm, "comm", nil, "Restrict output to MCP messages whose process name contains one of these comma-separated substrings")
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 1.0, "Playback speed multiplier applied to the gaps between recorded timestamps; 0 disables pacing")
+	replayCmd.Flags().BoolVar(&replayNoColor, "no-color", false, "Disable colored console output")
+	replayCmd.Flags().StringVar(&replayColorTheme, "color-theme", "dark", fmt.Sprintf("Console color theme: %s", strings.Join(output.ColorThemeNames, "|")))
+
+	return replayCmd
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", args[0], err)
+	}
+	defer f.Close()
+
+	if err := output.SetColorTheme(replayColorTheme); err != nil {
+		return err
+	}
+	if replayNoColor {
+		color.NoColor = true
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	eventBus := bus.New()
+	defer eventBus.Close()
+
+	consoleDisplay, err := output.NewConsoleDisplay(os.Stdout, false, eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create console display: %w", err)
+	}
+	consoleDisplay.SetPIDCommFilter(toUint32s(replayPID), replayComm)
+	consoleDisplay.PrintHeader()
+
+	methodCounts := make(map[string]int)
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, func(e event.Event) {
+		if msg, ok := e.(*event.MCPEvent); ok {
+			methodCounts[msg.Method]++
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to subscribe to MCP events: %w", err)
+	}
+
+	start := time.Now()
+	replayed, err := replayJSONL(ctx, f, eventBus, replaySpeed)
+	if err != nil {
+		return fmt.Errorf("failed to replay '%s': %w", args[0], err)
+	}
+
+	// The event bus dispatches to subscribers asynchronously, so give the
+	// last few published events time to reach ConsoleDisplay before we
+	// summarize.
+	time.Sleep(50 * time.Millisecond)
+
+	consoleDisplay.PrintStats(methodCounts)
+	consoleDisplay.PrintSummary(replayed, len(methodCounts), 0, time.Since(start), false)
+	return nil
+}
+
+// replayJSONL scans r for mcp_message lines, publishing each one on
+// eventBus. Lines for other event shapes (alerts, tool usage, etc.) are
+// skipped: ConsoleDisplay's richer renderers for those rely on correlation
+// state built up live (e.g. matching a tool_call_result back to its
+// request) that a standalone replay can't reconstruct from a single line.
+//
+// When speed is non-zero, playback sleeps between events proportionally to
+// the gap between their recorded timestamps, divided by speed. A speed of 0
+// disables pacing entirely.
+func replayJSONL(ctx context.Context, r io.Reader, eventBus bus.EventBus, speed float64) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	replayed := 0
+	var prevTimestamp time.Time
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var generic map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &generic); err != nil {
+			continue
+		}
+		if sniffEventTypeName(generic) != "mcp_message" {
+			continue
+		}
+
+		var msg event.MCPEvent
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			continue
+		}
+
+		if speed > 0 && !prevTimestamp.IsZero() {
+			if gap := msg.Timestamp.Sub(prevTimestamp); gap > 0 {
+				select {
+				case <-time.After(time.Duration(float64(gap) / speed)):
+				case <-ctx.Done():
+					return replayed, nil
+				}
+			}
+		}
+		prevTimestamp = msg.Timestamp
+
+		eventBus.Publish(&msg)
+		replayed++
+
+		if ctx.Err() != nil {
+			return replayed, nil
+		}
+	}
+
+	return replayed, scanner.Err()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestReplayJSONL_SkipsNonMCPMessageLines(t *testing.T) {
+	input := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z","transport_type":"stdio","stdio_transport":{"from_pid":1,"from_comm":"a","to_pid":2,"to_comm":"b"},"type":"request","id":1,"method":"tools/list","raw":"{}"}`,
+		`{"timestamp":"2025-01-01T00:00:00.001Z","risk_level":"high","risk_score":0.9,"category":"prompt_injection","analyzed_text":"..."}`,
+		`{"timestamp":"2025-01-01T00:00:00.002Z",`, // malformed JSON
+		`{"timestamp":"2025-01-01T00:00:00.003Z","transport_type":"stdio","stdio_transport":{"from_pid":2,"from_comm":"b","to_pid":1,"to_comm":"a"},"type":"response","id":1,"request":{"type":"request","id":1,"method":"tools/list"},"raw":"{}"}`,
+	}, "\n")
+
+	eventBus := tu.NewMockBus()
+	var methods []string
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, func(e event.Event) {
+		if msg, ok := e.(*event.MCPEvent); ok {
+			methods = append(methods, msg.Method)
+		}
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	replayed, err := replayJSONL(context.Background(), strings.NewReader(input), eventBus, 0)
+	if err != nil {
+		t.Fatalf("replayJSONL() error = %v", err)
+	}
+	if replayed != 2 {
+		t.Errorf("expected 2 mcp_message lines replayed, got %d", replayed)
+	}
+	if len(methods) != 2 || methods[0] != "tools/list" || methods[1] != "tools/list" {
+		t.Errorf("expected both mcp_message lines published to the bus, got %v", methods)
+	}
+}
+
+func TestReplayJSONL_PacesBySpeed(t *testing.T) {
+	input := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z","transport_type":"stdio","stdio_transport":{"from_pid":1,"from_comm":"a","to_pid":2,"to_comm":"b"},"type":"request","id":1,"method":"tools/list","raw":"{}"}`,
+		`{"timestamp":"2025-01-01T00:00:00.05Z","transport_type":"stdio","stdio_transport":{"from_pid":1,"from_comm":"a","to_pid":2,"to_comm":"b"},"type":"request","id":2,"method":"tools/call","raw":"{}"}`,
+	}, "\n")
+
+	eventBus := tu.NewMockBus()
+
+	start := time.Now()
+	if _, err := replayJSONL(context.Background(), strings.NewReader(input), eventBus, 1); err != nil {
+		t.Fatalf("replayJSONL() error = %v", err)
+	}
+	paced := time.Since(start)
+
+	start = time.Now()
+	if _, err := replayJSONL(context.Background(), strings.NewReader(input), eventBus, 0); err != nil {
+		t.Fatalf("replayJSONL() error = %v", err)
+	}
+	unpaced := time.Since(start)
+
+	if paced < 40*time.Millisecond {
+		t.Errorf("expected pacing to wait roughly the 50ms gap between timestamps, only took %s", paced)
+	}
+	if unpaced >= 40*time.Millisecond {
+		t.Errorf("expected speed=0 to skip pacing entirely, took %s", unpaced)
+	}
+}
+
+func TestReplayJSONL_StopsOnContextCancel(t *testing.T) {
+	input := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z","transport_type":"stdio","stdio_transport":{"from_pid":1,"from_comm":"a","to_pid":2,"to_comm":"b"},"type":"request","id":1,"method":"tools/list","raw":"{}"}`,
+		`{"timestamp":"2025-01-01T00:01:00Z","transport_type":"stdio","stdio_transport":{"from_pid":1,"from_comm":"a","to_pid":2,"to_comm":"b"},"type":"request","id":2,"method":"tools/call","raw":"{}"}`,
+	}, "\n")
+
+	eventBus := tu.NewMockBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	replayed, err := replayJSONL(ctx, strings.NewReader(input), eventBus, 1)
+	if err != nil {
+		t.Fatalf("replayJSONL() error = %v", err)
+	}
+	if replayed != 1 {
+		t.Errorf("expected replay to stop before the second event's minute-long gap once cancelled, got %d replayed", replayed)
+	}
+}
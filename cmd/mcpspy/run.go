@@ -0,0 +1,493 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/alert"
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/ebpf"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/alex-ilgayev/mcpspy/pkg/fs"
+	"github.com/alex-ilgayev/mcpspy/pkg/http"
+	"github.com/alex-ilgayev/mcpspy/pkg/latency"
+	"github.com/alex-ilgayev/mcpspy/pkg/mcp"
+	"github.com/alex-ilgayev/mcpspy/pkg/namespace"
+	"github.com/alex-ilgayev/mcpspy/pkg/output"
+	"github.com/alex-ilgayev/mcpspy/pkg/proctree"
+	"github.com/alex-ilgayev/mcpspy/pkg/tcp"
+)
+
+// Run command flags
+var (
+	runShowBuffers          bool
+	runBufferMethods        []string
+	runLenientJSONRPC       bool
+	runNoNamespaceSwitch    bool
+	runCaptureDurationHisto bool
+	runSuppressPings        bool
+	runAlertThreshold       float64
+	runAlertWindow          time.Duration
+	runRedactPatterns       []string
+	runSummaryOnly          bool
+	runExpectNoErrors       bool
+	runStrictSchema         bool
+	runCorrelatedOnly       bool
+	runShowDupes            bool
+)
+
+func newRunCmd() *cobra.Command {
+	runCmd := &cobra.Command{
+		Use:   "run -- <command> [args...]",
+		Short: "Launch a command and monitor only its process tree",
+		Long: `Run spawns <command> as a child process, forwards its stdio
+transparently, and monitors MCP communication within its process tree only.
+It exits automatically once the child process exits, printing a session
+summary.
+
+This is more convenient than launching the MCP client separately and
+guessing its PID.
+
+Example:
+  sudo mcpspy run -- npx @modelcontextprotocol/server-filesystem /tmp`,
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         runRun,
+		SilenceUsage: true,
+	}
+
+	runCmd.Flags().BoolVarP(&runShowBuffers, "buffers", "b", false, "Show raw message buffers")
+	runCmd.Flags().StringSliceVar(&runBufferMethods, "buffer-methods", nil, "Restrict raw buffer display (--buffers) to these comma-separated methods (e.g. 'tools/call,initialize'); other methods still show the compact line")
+	runCmd.Flags().BoolVar(&runLenientJSONRPC, "lenient-jsonrpc", false, "Accept JSON-RPC messages with a missing or mismatching \"jsonrpc\":\"2.0\" field (for debugging non-conformant servers)")
+	runCmd.Flags().BoolVar(&runStrictSchema, "strict", false, "Validate request params and response results against the MCP schema for initialize, tools/call, tools/list, and resources/read; violations are attached to the event and shown in red, the message is still emitted")
+	runCmd.Flags().BoolVar(&runCorrelatedOnly, "correlated-only", false, "Hold each request until its response (or timeout) arrives and emit a single combined record with the full exchange and latency, instead of emitting the request and response separately")
+	runCmd.Flags().BoolVar(&runShowDupes, "show-dupes", false, "Emit every duplicate hop of an already-seen message as its own event instead of folding it, so multi-hop timing through a relay (e.g. a Docker proxy) is visible")
+	runCmd.Flags().BoolVar(&runNoNamespaceSwitch, "no-namespace-switch", false, "Safe mode: never setns into other mount namespaces; skip containerized libssl instead of entering the container to attach to it")
+	runCmd.Flags().BoolVar(&runCaptureDurationHisto, "capture-duration-histogram", false, "Print a table of request→response latency percentiles (p50/p90/p99) and average params/result payload size, overall and per method, alongside the session summary")
+	runCmd.Flags().BoolVar(&runSuppressPings, "suppress-pings", false, "Hide successful ping/pong keepalives from output; pings that time out are still shown")
+	runCmd.Flags().Float64Var(&runAlertThreshold, "alert-error-rate", 0.10, "Error-response rate (0.0-1.0), per method and overall, that triggers an alert")
+	runCmd.Flags().DurationVar(&runAlertWindow, "alert-window", 60*time.Second, "Rolling time window the error rate is computed over")
+	runCmd.Flags().StringSliceVar(&runRedactPatterns, "redact", output.DefaultRedactPatterns, "Mask values whose JSON key matches any of these comma-separated patterns (case-insensitive substring) in raw buffers (--buffers); pass an empty string to disable")
+	runCmd.Flags().BoolVar(&runSummaryOnly, "summary-only", false, "Suppress per-message output; print just a final compact summary line (for CI logs)")
+	runCmd.Flags().BoolVar(&runExpectNoErrors, "expect-no-errors", false, "With --summary-only, fail (non-zero exit) if any error responses were observed during the run")
+
+	return runCmd
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	mountNS, err := namespace.GetCurrentMountNamespace()
+	if err != nil {
+		return fmt.Errorf("failed to get current mount namespace: %w", err)
+	}
+
+	eventBus := bus.New()
+	defer eventBus.Close()
+
+	consoleDisplay, err := output.NewConsoleDisplay(os.Stdout, runShowBuffers, eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create console display: %w", err)
+	}
+	consoleDisplay.SetBufferMethods(runBufferMethods)
+	consoleDisplay.SetSuppressSuccessfulPings(runSuppressPings)
+	consoleDisplay.SetRedactPatterns(runRedactPatterns)
+	consoleDisplay.SetQuiet(runSummaryOnly)
+	consoleDisplay.PrintHeader()
+
+	runStart := time.Now()
+
+	loader, err := ebpf.New(uint32(os.Getpid()), 0, eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create eBPF loader: %w", err)
+	}
+	defer loader.Close()
+
+	libManager, err := ebpf.NewLibraryManager(eventBus, loader, mountNS, runNoNamespaceSwitch)
+	if err != nil {
+		return fmt.Errorf("failed to create library manager: %w", err)
+	}
+	defer libManager.Close()
+
+	httpManager, err := http.NewSessionManager(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP session manager: %w", err)
+	}
+	defer httpManager.Close()
+
+	fsManager, err := fs.NewSessionManager(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create FS session manager: %w", err)
+	}
+	defer fsManager.Close()
+
+	tcpManager, err := tcp.NewSessionManager(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to create TCP session manager: %w", err)
+	}
+	defer tcpManager.Close()
+
+	consoleDisplay.PrintInfo("Loading eBPF programs...")
+
+	if err := loader.Load(); err != nil {
+		return fmt.Errorf("failed to load eBPF programs: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	if err := loader.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start event processing: %w", err)
+	}
+
+	logrus.Debug("Doing initial enumeration of libraries for TLS inspection")
+	if err := loader.RunIterLibEnum(); err != nil {
+		return fmt.Errorf("failed to enumerate libraries: %w", err)
+	}
+
+	parser, err := mcp.NewParserWithConfig(eventBus, mcp.Config{
+		LenientJSONRPC: runLenientJSONRPC,
+		Strict:         runStrictSchema,
+		CorrelatedOnly: runCorrelatedOnly,
+		ShowDupes:      runShowDupes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP parser: %w", err)
+	}
+	defer parser.Close()
+
+	stats, err := newRunStats(eventBus)
+	if err != nil {
+		return fmt.Errorf("failed to set up session statistics: %w", err)
+	}
+	defer stats.Close()
+
+	alertMonitor, err := alert.New(eventBus, alert.Config{
+		Threshold: runAlertThreshold,
+		Window:    runAlertWindow,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set up error-rate alerting: %w", err)
+	}
+	defer alertMonitor.Close()
+
+	// Spawn the target command, forwarding its stdio transparently, and
+	// scope console output to its process tree so unrelated host traffic
+	// doesn't show up.
+	childCmd := exec.Command(args[0], args[1:]...)
+	childCmd.Stdin = os.Stdin
+	childCmd.Stdout = os.Stdout
+	childCmd.Stderr = os.Stderr
+
+	if err := childCmd.Start(); err != nil {
+		return fmt.Errorf("failed to start command '%s': %w", args[0], err)
+	}
+
+	tree := proctree.New(uint32(childCmd.Process.Pid))
+	consoleDisplay.SetPIDFilter(tree.Contains)
+
+	consoleDisplay.PrintInfo("Monitoring %s[%d]... it will auto-exit when the command exits", args[0], childCmd.Process.Pid)
+	consoleDisplay.PrintInfo("")
+
+	childDone := make(chan error, 1)
+	go func() {
+		childDone <- childCmd.Wait()
+	}()
+
+	var childErr error
+	select {
+	case childErr = <-childDone:
+		cancel()
+	case <-ctx.Done():
+		// Interrupted externally (Ctrl+C): pass it on to the child instead
+		// of leaving it orphaned.
+		_ = childCmd.Process.Signal(syscall.SIGTERM)
+		childErr = <-childDone
+	}
+
+	counts := stats.counts()
+	if runSummaryOnly {
+		consoleDisplay.PrintSummary(stats.total(), len(counts), stats.errors(), time.Since(runStart), runExpectNoErrors)
+	} else {
+		consoleDisplay.PrintStats(counts)
+		consoleDisplay.PrintDuplicates(stats.duplicates())
+		if runCaptureDurationHisto {
+			consoleDisplay.PrintLatencyHistogram(stats.overall.Summary(), stats.byMethodSummaries(), stats.dropped())
+			consoleDisplay.PrintPayloadSizes(stats.byMethodPayloadSizes())
+		}
+	}
+
+	if childErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(childErr, &exitErr) {
+			return fmt.Errorf("command '%s' exited with %s", args[0], exitErr.ProcessState)
+		}
+		return fmt.Errorf("failed to run command '%s': %w", args[0], childErr)
+	}
+
+	if runSummaryOnly && runExpectNoErrors && stats.errors() > 0 {
+		return fmt.Errorf("%d error response(s) observed during the run", stats.errors())
+	}
+
+	return nil
+}
+
+// runStats tallies MCP message counts by method, and request→response
+// latency distributions, for the session summary printed when `mcpspy run`
+// exits.
+type runStats struct {
+	eventBus bus.EventBus
+
+	// overall and byMethod track request→response latency regardless of
+	// --capture-duration-histogram; the flag only controls whether the
+	// summary is printed, keeping recording unconditional and cheap.
+	overall *latency.Histogram
+
+	mu             sync.Mutex
+	byKey          map[string]int
+	byMethod       map[string]*latency.Histogram
+	totalCount     int
+	errorCount     int
+	droppedCount   int
+	duplicateCount int
+
+	// byMethodParamsBytes/byMethodResultBytes track the average size of the
+	// raw "params"/"result" JSON value per method, keyed the same way as
+	// byMethod: by the request's method, so a response's result size is
+	// attributed to the method that produced it.
+	byMethodParamsBytes map[string]*byteAverage
+	byMethodResultBytes map[string]*byteAverage
+}
+
+// byteAverage is a running mean of byte sizes, kept as a sum/count pair
+// rather than retaining individual samples. Safe for concurrent use.
+type byteAverage struct {
+	mu    sync.Mutex
+	sum   int64
+	count int64
+}
+
+func (b *byteAverage) record(n int) {
+	b.mu.Lock()
+	b.sum += int64(n)
+	b.count++
+	b.mu.Unlock()
+}
+
+func (b *byteAverage) mean() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.count == 0 {
+		return 0
+	}
+	return float64(b.sum) / float64(b.count)
+}
+
+func (b *byteAverage) samples() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return int(b.count)
+}
+
+func newRunStats(eventBus bus.EventBus) (*runStats, error) {
+	s := &runStats{
+		eventBus:            eventBus,
+		byKey:               make(map[string]int),
+		overall:             latency.New(),
+		byMethod:            make(map[string]*latency.Histogram),
+		byMethodParamsBytes: make(map[string]*byteAverage),
+		byMethodResultBytes: make(map[string]*byteAverage),
+	}
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, s.record); err != nil {
+		return nil, err
+	}
+	if err := eventBus.Subscribe(event.EventTypeRequestTimeout, s.recordTimeout); err != nil {
+		return nil, err
+	}
+	if err := eventBus.Subscribe(event.EventTypeDuplicateMessage, s.recordDuplicate); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *runStats) record(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	key := msg.Method
+	if key == "" {
+		key = string(msg.MessageType)
+	}
+
+	s.mu.Lock()
+	s.byKey[key]++
+	s.totalCount++
+	if msg.MessageType == event.JSONRPCMessageTypeResponse && msg.Error.Message != "" {
+		s.errorCount++
+	}
+	s.mu.Unlock()
+
+	if msg.MessageType == event.JSONRPCMessageTypeResponse && msg.Request != nil {
+		s.overall.Record(msg.Latency)
+
+		s.mu.Lock()
+		h, exists := s.byMethod[msg.Request.Method]
+		if !exists {
+			h = latency.New()
+			s.byMethod[msg.Request.Method] = h
+		}
+		s.mu.Unlock()
+		h.Record(msg.Latency)
+
+		if msg.ResultBytes > 0 {
+			s.recordBytes(s.byMethodResultBytes, msg.Request.Method, msg.ResultBytes)
+		}
+	} else if msg.ParamsBytes > 0 {
+		s.recordBytes(s.byMethodParamsBytes, msg.Method, msg.ParamsBytes)
+	}
+}
+
+// recordBytes records n under key in byMethod, allocating its byteAverage
+// on first use.
+func (s *runStats) recordBytes(byMethod map[string]*byteAverage, key string, n int) {
+	s.mu.Lock()
+	avg, exists := byMethod[key]
+	if !exists {
+		avg = &byteAverage{}
+		byMethod[key] = avg
+	}
+	s.mu.Unlock()
+	avg.record(n)
+}
+
+// recordTimeout tallies a request that was evicted from the correlation
+// cache without ever receiving a matching response, so it's reported as
+// dropped rather than silently missing from the latency histograms.
+func (s *runStats) recordTimeout(e event.Event) {
+	if _, ok := e.(*event.RequestTimeoutEvent); !ok {
+		return
+	}
+	s.mu.Lock()
+	s.droppedCount++
+	s.mu.Unlock()
+}
+
+// recordDuplicate tallies a message hop folded because its hash had
+// already been seen, so the session summary can report how much a relay
+// (e.g. a Docker proxy) is duplicating instead of that being invisible.
+func (s *runStats) recordDuplicate(e event.Event) {
+	if _, ok := e.(*event.DuplicateMessageEvent); !ok {
+		return
+	}
+	s.mu.Lock()
+	s.duplicateCount++
+	s.mu.Unlock()
+}
+
+// byMethodSummaries returns the current per-method latency percentiles,
+// keyed by method name.
+func (s *runStats) byMethodSummaries() map[string]latency.Percentiles {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]latency.Percentiles, len(s.byMethod))
+	for method, h := range s.byMethod {
+		out[method] = h.Summary()
+	}
+	return out
+}
+
+// byMethodPayloadSizes returns the current average params/result payload
+// size per method, keyed by method name. A method only appears if at least
+// one request/response for it carried a non-empty params or result value.
+func (s *runStats) byMethodPayloadSizes() map[string]output.PayloadSizeStats {
+	s.mu.Lock()
+	methods := make(map[string]bool, len(s.byMethodParamsBytes)+len(s.byMethodResultBytes))
+	for method := range s.byMethodParamsBytes {
+		methods[method] = true
+	}
+	for method := range s.byMethodResultBytes {
+		methods[method] = true
+	}
+	params := s.byMethodParamsBytes
+	results := s.byMethodResultBytes
+	s.mu.Unlock()
+
+	out := make(map[string]output.PayloadSizeStats, len(methods))
+	for method := range methods {
+		var stats output.PayloadSizeStats
+		if avg, ok := params[method]; ok {
+			stats.AvgParamsBytes = avg.mean()
+			stats.ParamsCount = avg.samples()
+		}
+		if avg, ok := results[method]; ok {
+			stats.AvgResultBytes = avg.mean()
+			stats.ResultCount = avg.samples()
+		}
+		out[method] = stats
+	}
+	return out
+}
+
+func (s *runStats) counts() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]int, len(s.byKey))
+	for k, v := range s.byKey {
+		out[k] = v
+	}
+	return out
+}
+
+// total returns the number of MCP messages observed so far.
+func (s *runStats) total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.totalCount
+}
+
+// errors returns the number of error responses observed so far.
+func (s *runStats) errors() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.errorCount
+}
+
+// dropped returns the number of requests evicted from the correlation
+// cache without ever receiving a response.
+func (s *runStats) dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.droppedCount
+}
+
+// duplicates returns the number of message hops folded so far because
+// their hash had already been seen.
+func (s *runStats) duplicates() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.duplicateCount
+}
+
+func (s *runStats) Close() {
+	s.eventBus.Unsubscribe(event.EventTypeMCPMessage, s.record)
+	s.eventBus.Unsubscribe(event.EventTypeRequestTimeout, s.recordTimeout)
+	s.eventBus.Unsubscribe(event.EventTypeDuplicateMessage, s.recordDuplicate)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestRunStats_LatencyAndDropped(t *testing.T) {
+	eventBus := bus.New()
+	stats, err := newRunStats(eventBus)
+	if err != nil {
+		t.Fatalf("newRunStats() error = %v", err)
+	}
+	defer stats.Close()
+
+	req := &event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+			ID:          int64(1),
+		},
+	}
+	eventBus.Publish(req)
+
+	resp := &event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+			Request:     &req.JSONRPCMessage,
+			Latency:     10 * time.Millisecond,
+		},
+	}
+	eventBus.Publish(resp)
+
+	eventBus.Publish(&event.RequestTimeoutEvent{Method: "tools/list", ID: int64(2)})
+
+	time.Sleep(50 * time.Millisecond)
+
+	if got := stats.overall.Summary().Count; got != 1 {
+		t.Errorf("overall latency sample count = %d, want 1", got)
+	}
+	if got := stats.dropped(); got != 1 {
+		t.Errorf("dropped() = %d, want 1", got)
+	}
+}
+
+func TestRunStats_PayloadSizesAveragedPerMethod(t *testing.T) {
+	eventBus := bus.New()
+	stats, err := newRunStats(eventBus)
+	if err != nil {
+		t.Fatalf("newRunStats() error = %v", err)
+	}
+	defer stats.Close()
+
+	req1 := &event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+			ID:          int64(1),
+			ParamsBytes: 100,
+		},
+	}
+	eventBus.Publish(req1)
+
+	req2 := &event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+			ID:          int64(2),
+			ParamsBytes: 200,
+		},
+	}
+	eventBus.Publish(req2)
+
+	resp := &event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+			Request:     &req1.JSONRPCMessage,
+			ResultBytes: 300,
+		},
+	}
+	eventBus.Publish(resp)
+
+	time.Sleep(50 * time.Millisecond)
+
+	sizes := stats.byMethodPayloadSizes()
+	got, ok := sizes["tools/call"]
+	if !ok {
+		t.Fatalf("expected a payload size entry for tools/call, got %+v", sizes)
+	}
+	if got.AvgParamsBytes != 150 || got.ParamsCount != 2 {
+		t.Errorf("AvgParamsBytes/ParamsCount = %v/%d, want 150/2", got.AvgParamsBytes, got.ParamsCount)
+	}
+	if got.AvgResultBytes != 300 || got.ResultCount != 1 {
+		t.Errorf("AvgResultBytes/ResultCount = %v/%d, want 300/1", got.AvgResultBytes, got.ResultCount)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func newValidateCmd() *cobra.Command {
+	validateCmd := &cobra.Command{
+		Use:   "validate <file.jsonl>",
+		Short: "Check a JSONL capture file for malformed lines and inconsistencies",
+		Long: `Validate reads a JSONL file produced by --output/-o, one event per line, and
+reports: lines that aren't valid JSON, lines that don't match any known
+MCPSpy event shape, and MCP response messages whose id doesn't match their
+correlated request's id. Exits non-zero if any problem is found.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runValidate,
+	}
+
+	return validateCmd
+}
+
+// lineIssue records a single problem found on a line of the input file.
+type lineIssue struct {
+	Line   int
+	Reason string
+}
+
+// validationReport summarizes the problems found across a JSONL file.
+type validationReport struct {
+	Lines  int
+	Issues []lineIssue
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", args[0], err)
+	}
+	defer f.Close()
+
+	report, err := validateJSONL(f)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Lines checked: %d\n", report.Lines)
+	fmt.Printf("Issues found:  %d\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		fmt.Printf("  line %d: %s\n", issue.Line, issue.Reason)
+	}
+
+	if len(report.Issues) > 0 {
+		return fmt.Errorf("%d issue(s) found in '%s'", len(report.Issues), args[0])
+	}
+	return nil
+}
+
+// validateJSONL checks every non-empty line of r against the known MCPSpy
+// event shapes, reporting malformed JSON, event objects that don't match any
+// known shape, and MCP response messages whose id doesn't match their
+// correlated request's id.
+func validateJSONL(r io.Reader) (validationReport, error) {
+	var report validationReport
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		report.Lines++
+
+		var generic map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &generic); err != nil {
+			report.Issues = append(report.Issues, lineIssue{Line: lineNum, Reason: fmt.Sprintf("malformed JSON: %v", err)})
+			continue
+		}
+
+		eventTypeName := sniffEventTypeName(generic)
+		if eventTypeName == "" {
+			report.Issues = append(report.Issues, lineIssue{Line: lineNum, Reason: "unknown event type"})
+			continue
+		}
+
+		if eventTypeName != "mcp_message" {
+			continue
+		}
+
+		var msg event.MCPEvent
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			report.Issues = append(report.Issues, lineIssue{Line: lineNum, Reason: fmt.Sprintf("malformed mcp_message: %v", err)})
+			continue
+		}
+		if msg.MessageType == event.JSONRPCMessageTypeResponse && msg.Request != nil && msg.ID != msg.Request.ID {
+			report.Issues = append(report.Issues, lineIssue{
+				Line:   lineNum,
+				Reason: fmt.Sprintf("response id %v does not match correlated request id %v", msg.ID, msg.Request.ID),
+			})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// sniffEventTypeName identifies which MCPSpy event a decoded JSONL line
+// represents, by the presence of fields unique to that event's JSON
+// encoding (the JSONL output has no explicit type discriminator). Returns
+// "" if the line doesn't match any known event shape. Names match
+// output.AllEventTypeNames().
+func sniffEventTypeName(obj map[string]interface{}) string {
+	switch {
+	case hasKeys(obj, "error_rate", "threshold"):
+		return "alert"
+	case hasKeys(obj, "tool_name", "duration_ms"):
+		return "tool_call_result"
+	case hasKeys(obj, "size_bytes", "threshold"):
+		return "large_tool_args"
+	case hasKeys(obj, "violations"):
+		return "tool_result_schema_warning"
+	case hasKeys(obj, "ttl"):
+		return "request_timeout"
+	case hasKeys(obj, "usage_type"):
+		return "tool_usage"
+	case hasKeys(obj, "llm_session_id"):
+		return "tool_call_correlation"
+	case hasKeys(obj, "bridge_pid"):
+		return "bridge_correlation"
+	case hasKeys(obj, "aggregator_pid", "backend_pid"):
+		return "aggregator_edge"
+	case hasKeys(obj, "risk_level", "risk_score"):
+		return "security_alert"
+	case hasKeys(obj, "message_type", "session_id"):
+		return "llm_message"
+	case hasKeys(obj, "raw", "type", "transport_type"):
+		return "mcp_message"
+	default:
+		return ""
+	}
+}
+
+func hasKeys(obj map[string]interface{}, keys ...string) bool {
+	for _, k := range keys {
+		if _, ok := obj[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
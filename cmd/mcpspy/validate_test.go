@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateJSONL_ValidFile(t *testing.T) {
+	input := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z","transport_type":"stdio","stdio_transport":{"from_pid":1,"from_comm":"a","to_pid":2,"to_comm":"b"},"type":"request","id":1,"method":"tools/list","raw":"{}"}`,
+		`{"timestamp":"2025-01-01T00:00:01Z","transport_type":"stdio","stdio_transport":{"from_pid":2,"from_comm":"b","to_pid":1,"to_comm":"a"},"type":"response","id":1,"request":{"type":"request","id":1,"method":"tools/list"},"raw":"{}"}`,
+		`{"timestamp":"2025-01-01T00:00:02Z","risk_level":"high","risk_score":0.9,"category":"prompt_injection","analyzed_text":"..."}`,
+	}, "\n")
+
+	report, err := validateJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("validateJSONL() error = %v", err)
+	}
+	if report.Lines != 3 {
+		t.Errorf("expected 3 lines checked, got %d", report.Lines)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("expected no issues for a valid file, got %v", report.Issues)
+	}
+}
+
+func TestValidateJSONL_CorruptedLines(t *testing.T) {
+	input := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z",`, // truncated/malformed JSON
+		`{"some":"object","with":"no recognizable event fields"}`,
+		// Response whose id doesn't match its correlated request's id.
+		`{"timestamp":"2025-01-01T00:00:01Z","transport_type":"stdio","stdio_transport":{"from_pid":2,"from_comm":"b","to_pid":1,"to_comm":"a"},"type":"response","id":2,"request":{"type":"request","id":1,"method":"tools/list"},"raw":"{}"}`,
+	}, "\n")
+
+	report, err := validateJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("validateJSONL() error = %v", err)
+	}
+	if report.Lines != 3 {
+		t.Errorf("expected 3 lines checked, got %d", report.Lines)
+	}
+	if len(report.Issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d: %v", len(report.Issues), report.Issues)
+	}
+	if !strings.Contains(report.Issues[0].Reason, "malformed JSON") {
+		t.Errorf("expected line 1 to be reported as malformed JSON, got %q", report.Issues[0].Reason)
+	}
+	if !strings.Contains(report.Issues[1].Reason, "unknown event type") {
+		t.Errorf("expected line 2 to be reported as an unknown event type, got %q", report.Issues[1].Reason)
+	}
+	if !strings.Contains(report.Issues[2].Reason, "does not match correlated request id") {
+		t.Errorf("expected line 3 to be reported as a correlation mismatch, got %q", report.Issues[2].Reason)
+	}
+}
+
+func TestSniffEventTypeName(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  map[string]interface{}
+		want string
+	}{
+		{"mcp message", map[string]interface{}{"type": "request", "raw": "{}", "transport_type": "stdio"}, "mcp_message"},
+		{"security alert", map[string]interface{}{"risk_level": "high", "risk_score": 0.9}, "security_alert"},
+		{"llm message", map[string]interface{}{"message_type": "request", "session_id": 1.0}, "llm_message"},
+		{"tool usage", map[string]interface{}{"usage_type": "invocation"}, "tool_usage"},
+		{"tool call correlation", map[string]interface{}{"llm_session_id": 1.0}, "tool_call_correlation"},
+		{"bridge correlation", map[string]interface{}{"bridge_pid": 1.0}, "bridge_correlation"},
+		{"request timeout", map[string]interface{}{"ttl": "5s"}, "request_timeout"},
+		{"tool result schema warning", map[string]interface{}{"violations": []interface{}{"x"}}, "tool_result_schema_warning"},
+		{"unrecognized", map[string]interface{}{"foo": "bar"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sniffEventTypeName(tt.obj); got != tt.want {
+				t.Errorf("sniffEventTypeName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
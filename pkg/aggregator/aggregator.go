@@ -0,0 +1,164 @@
+// Package aggregator detects MCP gateway/aggregator topologies: a process
+// such as mcp-hub that receives a request from a client and relays it (or
+// several rewritten copies of it) to one or more backend MCP servers,
+// typically stripping or adding a backend-specific prefix on the tool name
+// along the way. It links the client->aggregator hop with the
+// aggregator->backend hop(s) it triggers, so a fan-out to multiple backends
+// shows up as a single labeled flow instead of unrelated calls.
+package aggregator
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+var (
+	pendingCacheSize = 4096
+	pendingCacheTTL  = 5 * time.Second
+
+	// toolNamePrefixSeparators lists the separators aggregators commonly
+	// use when rewriting a backend's tool name with a server-specific
+	// prefix (e.g. mcp-hub's "<server>__<tool>").
+	toolNamePrefixSeparators = []string{"__", ":", "."}
+)
+
+// pendingUpstream is a request seen arriving at a process, kept around in
+// case that process turns out to be an aggregator that relays it onward.
+type pendingUpstream struct {
+	mu sync.Mutex
+
+	ClientPID  uint32
+	ClientComm string
+	Method     string
+	ToolKey    string
+	Timestamp  time.Time
+
+	// backends accumulates the distinct backend PIDs this request has been
+	// relayed to so far, so a second distinct backend is recognized as a
+	// fan-out rather than a plain one-hop relay.
+	backends map[uint32]bool
+}
+
+// Detector correlates a request relayed by an aggregator process with the
+// request that caused it.
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+//
+// Emits the following events:
+// - EventTypeAggregatorEdge
+type Detector struct {
+	// pending is keyed by the PID of the process a request most recently
+	// arrived at, holding that request in case the process relays it
+	// onward. Only the latest request into a given process is tracked;
+	// concurrent distinct requests into the same aggregator within the TTL
+	// window are not disambiguated from one another.
+	pending *expirable.LRU[uint32, *pendingUpstream]
+
+	eventBus bus.EventBus
+}
+
+// New creates a new Detector.
+func New(eventBus bus.EventBus) (*Detector, error) {
+	d := &Detector{
+		pending:  expirable.NewLRU[uint32, *pendingUpstream](pendingCacheSize, nil, pendingCacheTTL),
+		eventBus: eventBus,
+	}
+
+	if err := d.eventBus.Subscribe(event.EventTypeMCPMessage, d.handleMessage); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// handleMessage checks whether msg is a relay of a request that previously
+// arrived at its sending process, and records msg itself as a new pending
+// upstream request in case it is in turn relayed onward.
+func (d *Detector) handleMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok || msg.MessageType != event.JSONRPCMessageTypeRequest || msg.StdioTransport == nil {
+		return
+	}
+	st := msg.StdioTransport
+	toolKey := toolKeyOf(msg)
+
+	if upstream, found := d.pending.Get(st.FromPID); found {
+		upstream.mu.Lock()
+		if relatedToolKey(upstream.ToolKey, toolKey) {
+			if upstream.backends == nil {
+				upstream.backends = make(map[uint32]bool)
+			}
+			upstream.backends[st.ToPID] = true
+			fanOutSize := len(upstream.backends)
+			clientPID, clientComm, method := upstream.ClientPID, upstream.ClientComm, upstream.Method
+			upstream.mu.Unlock()
+
+			d.eventBus.Publish(&event.AggregatorEdgeEvent{
+				Timestamp:      msg.Timestamp,
+				Method:         method,
+				ClientPID:      clientPID,
+				ClientComm:     clientComm,
+				AggregatorPID:  st.FromPID,
+				AggregatorComm: st.FromComm,
+				BackendPID:     st.ToPID,
+				BackendComm:    st.ToComm,
+				FanOutSize:     fanOutSize,
+			})
+		} else {
+			upstream.mu.Unlock()
+		}
+	}
+
+	d.pending.Add(st.ToPID, &pendingUpstream{
+		ClientPID:  st.FromPID,
+		ClientComm: st.FromComm,
+		Method:     msg.Method,
+		ToolKey:    toolKey,
+		Timestamp:  msg.Timestamp,
+	})
+}
+
+// toolKeyOf returns the identifier a relayed copy of msg is expected to
+// share with the original: the tool name for tools/call requests (which an
+// aggregator may rewrite with a prefix), or the method itself for anything
+// else (e.g. tools/list, which aggregators typically fan out unchanged).
+func toolKeyOf(msg *event.MCPEvent) string {
+	if name := msg.ExtractToolName(); name != "" {
+		return name
+	}
+	return msg.Method
+}
+
+// relatedToolKey reports whether b looks like a's tool name with a
+// backend-specific prefix stripped, or vice versa, or whether they're
+// simply equal (e.g. two fanned-out tools/list calls).
+func relatedToolKey(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	return hasPrefixedSuffix(a, b) || hasPrefixedSuffix(b, a)
+}
+
+// hasPrefixedSuffix reports whether prefixed is of the form
+// "<prefix><separator><suffix>" for one of the known separators.
+func hasPrefixedSuffix(prefixed, suffix string) bool {
+	for _, sep := range toolNamePrefixSeparators {
+		if idx := strings.LastIndex(prefixed, sep); idx > 0 && prefixed[idx+len(sep):] == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// Close releases the detector's event bus subscription.
+func (d *Detector) Close() {
+	d.eventBus.Unsubscribe(event.EventTypeMCPMessage, d.handleMessage)
+}
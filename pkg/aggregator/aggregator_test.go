@@ -0,0 +1,136 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func request(fromPID, toPID uint32, fromComm, toComm, method string, params map[string]interface{}) *event.MCPEvent {
+	return &event.MCPEvent{
+		Timestamp:     time.Now(),
+		TransportType: event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{
+			FromPID: fromPID, FromComm: fromComm, ToPID: toPID, ToComm: toComm,
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      method,
+			Params:      params,
+		},
+	}
+}
+
+// drainEdges reads exactly n events off the bus (the events published by the
+// test plus whatever the detector emits in response) and returns the
+// AggregatorEdgeEvents among them, in publish order.
+func drainEdges(mockBus interface {
+	Events() <-chan event.Event
+}, n int) []*event.AggregatorEdgeEvent {
+	var edges []*event.AggregatorEdgeEvent
+	for i := 0; i < n; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			if edge, ok := evt.(*event.AggregatorEdgeEvent); ok {
+				edges = append(edges, edge)
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	return edges
+}
+
+// TestDetector_LabelsFanOutAcrossTwoBackends covers the client->aggregator
+// ->two-backends flow: an aggregator fans a single tools/list request out
+// to two distinct backend servers to merge their tool lists.
+func TestDetector_LabelsFanOutAcrossTwoBackends(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	d, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+	defer d.Close()
+	defer mockBus.Close()
+
+	mockBus.Publish(request(100, 200, "client", "aggregator", "tools/list", nil))
+	mockBus.Publish(request(200, 300, "aggregator", "backend-a", "tools/list", nil))
+	mockBus.Publish(request(200, 400, "aggregator", "backend-b", "tools/list", nil))
+
+	// 3 published requests + 2 resulting aggregator edges.
+	edges := drainEdges(mockBus, 5)
+
+	if len(edges) != 2 {
+		t.Fatalf("expected 2 aggregator edges, got %d: %+v", len(edges), edges)
+	}
+
+	backends := map[uint32]string{}
+	for _, edge := range edges {
+		if edge.ClientPID != 100 || edge.ClientComm != "client" {
+			t.Errorf("expected client 100/client on every edge, got %d/%s", edge.ClientPID, edge.ClientComm)
+		}
+		if edge.AggregatorPID != 200 || edge.AggregatorComm != "aggregator" {
+			t.Errorf("expected aggregator 200/aggregator on every edge, got %d/%s", edge.AggregatorPID, edge.AggregatorComm)
+		}
+		backends[edge.BackendPID] = edge.BackendComm
+	}
+	if len(backends) != 2 || backends[300] != "backend-a" || backends[400] != "backend-b" {
+		t.Errorf("expected edges to distinct backends 300/backend-a and 400/backend-b, got %+v", backends)
+	}
+
+	if edges[1].FanOutSize != 2 {
+		t.Errorf("expected the second backend's edge to report fan_out_size=2, got %d", edges[1].FanOutSize)
+	}
+}
+
+// TestDetector_MatchesRewrittenToolCallName covers the more targeted case
+// of a single tools/call relayed to one backend, with the aggregator
+// stripping a backend-specific prefix off the tool name.
+func TestDetector_MatchesRewrittenToolCallName(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	d, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+	defer d.Close()
+	defer mockBus.Close()
+
+	mockBus.Publish(request(100, 200, "client", "aggregator", "tools/call", map[string]interface{}{"name": "weather__get_forecast"}))
+	mockBus.Publish(request(200, 300, "aggregator", "weather-server", "tools/call", map[string]interface{}{"name": "get_forecast"}))
+
+	// 2 published requests + 1 resulting aggregator edge.
+	edges := drainEdges(mockBus, 3)
+	if len(edges) != 1 {
+		t.Fatalf("expected 1 aggregator edge, got %d: %+v", len(edges), edges)
+	}
+
+	edge := edges[0]
+	if edge.BackendPID != 300 || edge.BackendComm != "weather-server" {
+		t.Errorf("expected backend 300/weather-server, got %d/%s", edge.BackendPID, edge.BackendComm)
+	}
+	if edge.FanOutSize != 1 {
+		t.Errorf("expected fan_out_size=1 for a single backend, got %d", edge.FanOutSize)
+	}
+}
+
+// TestDetector_UnrelatedCallsDontMatch ensures a process simply making an
+// unrelated outbound call (not a relay of what it just received) isn't
+// mislabeled as an aggregator edge.
+func TestDetector_UnrelatedCallsDontMatch(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	d, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+	defer d.Close()
+	defer mockBus.Close()
+
+	mockBus.Publish(request(100, 200, "client", "server", "tools/call", map[string]interface{}{"name": "search"}))
+	mockBus.Publish(request(200, 300, "server", "other", "tools/call", map[string]interface{}{"name": "unrelated_tool"}))
+
+	if edges := drainEdges(mockBus, 2); len(edges) != 0 {
+		t.Fatalf("expected no aggregator edge for an unrelated call, got %+v", edges)
+	}
+}
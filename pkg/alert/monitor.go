@@ -0,0 +1,170 @@
+// Package alert watches MCP response traffic for a degrading server: when
+// the error-response rate for a method, or overall, crosses a configured
+// threshold over a rolling time window, it publishes an AlertEvent.
+package alert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// overallKey is the series map key tracking the error rate across all
+// methods combined.
+const overallKey = ""
+
+const (
+	defaultWindow     = 60 * time.Second
+	defaultThreshold  = 0.10
+	defaultHysteresis = 0.05
+)
+
+// Config controls the Monitor's alerting thresholds.
+type Config struct {
+	// Window is how far back responses are considered when computing an
+	// error rate. Defaults to 60s.
+	Window time.Duration
+	// Threshold is the error rate, 0-1, that triggers an alert. Defaults
+	// to 0.10 (10%).
+	Threshold float64
+	// Hysteresis is how far the error rate must drop below Threshold
+	// before an alert can fire again for the same method, so a rate
+	// hovering around Threshold doesn't alert on every sample. Defaults
+	// to 0.05.
+	Hysteresis float64
+
+	// Clock supplies the current time used to decide which samples fall
+	// within Window. Defaults to the wall clock; tests substitute a
+	// clock.Fake for deterministic windows.
+	Clock clock.Clock
+}
+
+// sample is a single response outcome, timestamped for rolling-window
+// eviction.
+type sample struct {
+	at      time.Time
+	isError bool
+}
+
+// series tracks a rolling window of samples for one method (or overall)
+// and whether an alert is currently latched for it.
+type series struct {
+	samples []sample
+	// firing is true once an alert has fired for this series and not yet
+	// cleared by the rate dropping back below Threshold-Hysteresis.
+	firing bool
+}
+
+// Monitor watches EventTypeMCPMessage responses and publishes an
+// AlertEvent when the error rate for a method, or overall, crosses
+// Config.Threshold over Config.Window.
+//
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+//
+// Emits the following events:
+// - EventTypeAlert
+type Monitor struct {
+	eventBus bus.EventBus
+	config   Config
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// New creates a Monitor with custom config.
+func New(eventBus bus.EventBus, config Config) (*Monitor, error) {
+	if config.Window <= 0 {
+		config.Window = defaultWindow
+	}
+	if config.Threshold <= 0 {
+		config.Threshold = defaultThreshold
+	}
+	if config.Hysteresis <= 0 {
+		config.Hysteresis = defaultHysteresis
+	}
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+
+	m := &Monitor{
+		eventBus: eventBus,
+		config:   config,
+		series:   make(map[string]*series),
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, m.handleMCPMessage); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// handleMCPMessage records the outcome of a request/response pair against
+// both the overall series and the series for its method.
+func (m *Monitor) handleMCPMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok || msg.MessageType != event.JSONRPCMessageTypeResponse || msg.Request == nil {
+		return
+	}
+
+	isError := msg.Error.Message != ""
+	now := m.config.Clock.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record(overallKey, now, isError)
+	m.record(msg.Request.Method, now, isError)
+}
+
+// record appends a sample to key's series, evicts samples that have aged
+// out of the rolling window, and publishes an AlertEvent if the resulting
+// error rate crosses Threshold. Callers must hold m.mu.
+func (m *Monitor) record(key string, now time.Time, isError bool) {
+	s, ok := m.series[key]
+	if !ok {
+		s = &series{}
+		m.series[key] = s
+	}
+
+	s.samples = append(s.samples, sample{at: now, isError: isError})
+
+	cutoff := now.Add(-m.config.Window)
+	i := 0
+	for i < len(s.samples) && s.samples[i].at.Before(cutoff) {
+		i++
+	}
+	s.samples = s.samples[i:]
+
+	var errors int
+	for _, smp := range s.samples {
+		if smp.isError {
+			errors++
+		}
+	}
+	total := len(s.samples)
+	rate := float64(errors) / float64(total)
+
+	switch {
+	case !s.firing && rate > m.config.Threshold:
+		s.firing = true
+		m.eventBus.Publish(&event.AlertEvent{
+			Timestamp: now,
+			Method:    key,
+			ErrorRate: rate,
+			Threshold: m.config.Threshold,
+			Window:    m.config.Window,
+			Errors:    errors,
+			Total:     total,
+		})
+	case s.firing && rate <= m.config.Threshold-m.config.Hysteresis:
+		s.firing = false
+	}
+}
+
+func (m *Monitor) Close() {
+	m.eventBus.Unsubscribe(event.EventTypeMCPMessage, m.handleMCPMessage)
+}
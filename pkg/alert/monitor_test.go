@@ -0,0 +1,200 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func response(method string, isError bool) *event.MCPEvent {
+	msg := &event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+			Request:     &event.JSONRPCMessage{Method: method},
+		},
+	}
+	if isError {
+		msg.Error = event.JSONRPCError{Code: -32000, Message: "boom"}
+	}
+	return msg
+}
+
+func collectAlerts(events <-chan event.Event) []*event.AlertEvent {
+	var alerts []*event.AlertEvent
+	for {
+		select {
+		case e := <-events:
+			if a, ok := e.(*event.AlertEvent); ok {
+				alerts = append(alerts, a)
+			}
+		default:
+			return alerts
+		}
+	}
+}
+
+// forMethod filters alerts down to the ones scoped to method, ignoring the
+// overall ("") series that every per-method sample also feeds.
+func forMethod(alerts []*event.AlertEvent, method string) []*event.AlertEvent {
+	var out []*event.AlertEvent
+	for _, a := range alerts {
+		if a.Method == method {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func TestMonitor_FiresOnceWhenThresholdCrossedThenRearmsAfterHysteresis(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	fake := clock.NewFake(time.Unix(0, 0))
+
+	m, err := New(mockBus, Config{
+		Window:     time.Minute,
+		Threshold:  0.5,
+		Hysteresis: 0.2,
+		Clock:      fake,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	// 2 successes: rate 0%, below threshold.
+	mockBus.Publish(response("tools/call", false))
+	mockBus.Publish(response("tools/call", false))
+
+	// 2 errors: rate jumps to 50%, not yet *above* 50%, so no alert.
+	mockBus.Publish(response("tools/call", true))
+	mockBus.Publish(response("tools/call", true))
+
+	if alerts := forMethod(collectAlerts(mockBus.Events()), "tools/call"); len(alerts) != 0 {
+		t.Fatalf("expected no alert at exactly the threshold, got %d", len(alerts))
+	}
+
+	// A third error pushes the rate to 60%, above the 50% threshold.
+	mockBus.Publish(response("tools/call", true))
+
+	alerts := forMethod(collectAlerts(mockBus.Events()), "tools/call")
+	if len(alerts) != 1 {
+		t.Fatalf("expected exactly 1 alert when crossing the threshold, got %d: %+v", len(alerts), alerts)
+	}
+	if alerts[0].Method != "tools/call" {
+		t.Errorf("expected alert for method %q, got %q", "tools/call", alerts[0].Method)
+	}
+
+	// More errors while already firing must not re-fire (latched).
+	mockBus.Publish(response("tools/call", true))
+	mockBus.Publish(response("tools/call", true))
+	if alerts := forMethod(collectAlerts(mockBus.Events()), "tools/call"); len(alerts) != 0 {
+		t.Fatalf("expected no additional alert while already firing, got %d", len(alerts))
+	}
+
+	// Flood with successes until the rate drops below Threshold-Hysteresis
+	// (0.3), re-arming the latch.
+	for i := 0; i < 20; i++ {
+		mockBus.Publish(response("tools/call", false))
+	}
+	if alerts := forMethod(collectAlerts(mockBus.Events()), "tools/call"); len(alerts) != 0 {
+		t.Fatalf("expected re-arming to not itself fire an alert, got %d", len(alerts))
+	}
+
+	// Errors dominating again should cross the threshold and fire once more.
+	for i := 0; i < 25; i++ {
+		mockBus.Publish(response("tools/call", true))
+	}
+	if alerts := forMethod(collectAlerts(mockBus.Events()), "tools/call"); len(alerts) != 1 {
+		t.Fatalf("expected exactly 1 alert after re-arming and crossing the threshold again, got %d", len(alerts))
+	}
+}
+
+func TestMonitor_SamplesOutsideWindowAreEvicted(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	fake := clock.NewFake(time.Unix(0, 0))
+
+	m, err := New(mockBus, Config{
+		Window:    time.Minute,
+		Threshold: 0.5,
+		Clock:     fake,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	// A burst of errors, all inside the window.
+	for i := 0; i < 5; i++ {
+		mockBus.Publish(response("tools/call", true))
+	}
+	if alerts := forMethod(collectAlerts(mockBus.Events()), "tools/call"); len(alerts) != 1 {
+		t.Fatalf("expected 1 alert from the error burst, got %d", len(alerts))
+	}
+
+	// Advance well past the window: the old errors should no longer count.
+	fake.Advance(2 * time.Minute)
+	mockBus.Publish(response("tools/call", false))
+
+	if alerts := forMethod(collectAlerts(mockBus.Events()), "tools/call"); len(alerts) != 0 {
+		t.Fatalf("expected the stale error burst to be evicted from the window, got %d alerts", len(alerts))
+	}
+}
+
+func TestMonitor_OverallAndPerMethodAreTrackedIndependently(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	fake := clock.NewFake(time.Unix(0, 0))
+
+	m, err := New(mockBus, Config{
+		Window:    time.Minute,
+		Threshold: 0.5,
+		Clock:     fake,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	// tools/list stays healthy...
+	for i := 0; i < 10; i++ {
+		mockBus.Publish(response("tools/list", false))
+	}
+	// ...while tools/call degrades. Overall rate (2/12) stays under 50%, but
+	// tools/call's own rate (2/2) is 100%.
+	mockBus.Publish(response("tools/call", true))
+	mockBus.Publish(response("tools/call", true))
+
+	alerts := collectAlerts(mockBus.Events())
+	if len(alerts) != 1 {
+		t.Fatalf("expected exactly 1 alert (for tools/call only), got %d: %+v", len(alerts), alerts)
+	}
+	if alerts[0].Method != "tools/call" {
+		t.Errorf("expected the alert to scope to %q, got %q", "tools/call", alerts[0].Method)
+	}
+}
+
+func TestMonitor_IgnoresNonResponseAndUncorrelatedMessages(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	m, err := New(mockBus, Config{Threshold: 0.01})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(&event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{
+		MessageType: event.JSONRPCMessageTypeRequest,
+		Method:      "tools/call",
+	}})
+	mockBus.Publish(&event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{
+		MessageType: event.JSONRPCMessageTypeResponse,
+		Error:       event.JSONRPCError{Code: -32000, Message: "boom"},
+		// No Request: an uncorrelated response, should be ignored.
+	}})
+
+	if alerts := collectAlerts(mockBus.Events()); len(alerts) != 0 {
+		t.Fatalf("expected no alerts from non-response or uncorrelated messages, got %d", len(alerts))
+	}
+}
@@ -0,0 +1,90 @@
+package baseline
+
+import (
+	"sync"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// Monitor watches EventTypeMCPMessage traffic against a recorded Profile
+// and publishes a BaselineAnomalyEvent the first time a method, tool, or
+// host is seen that isn't in the baseline.
+//
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+//
+// Emits the following events:
+// - EventTypeBaselineAnomaly
+type Monitor struct {
+	eventBus bus.EventBus
+	profile  *Profile
+	clock    clock.Clock
+
+	mu sync.Mutex
+	// flagged dedupes by "<kind>:<value>" so a repeat offender (e.g. the
+	// same unexpected tool called many times) only alerts once per run
+	// instead of on every message.
+	flagged map[string]bool
+}
+
+// New creates a Monitor that flags live traffic deviating from profile.
+func New(eventBus bus.EventBus, profile *Profile) (*Monitor, error) {
+	m := &Monitor{
+		eventBus: eventBus,
+		profile:  profile,
+		clock:    clock.New(),
+		flagged:  make(map[string]bool),
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, m.handleMCPMessage); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Monitor) handleMCPMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if msg.Method != "" && !m.profile.Methods[msg.Method] {
+		m.flag(event.BaselineAnomalyMethod, msg.Method, msg)
+	}
+	if tool := msg.ExtractToolName(); tool != "" && !m.profile.Tools[tool] {
+		m.flag(event.BaselineAnomalyTool, tool, msg)
+	}
+	if msg.HttpTransport != nil && msg.HttpTransport.Host != "" && !m.profile.Hosts[msg.HttpTransport.Host] {
+		m.flag(event.BaselineAnomalyHost, msg.HttpTransport.Host, msg)
+	}
+}
+
+// flag publishes a BaselineAnomalyEvent for kind/value, unless one was
+// already published for this combination. Callers must hold m.mu.
+func (m *Monitor) flag(kind event.BaselineAnomalyKind, value string, msg *event.MCPEvent) {
+	key := string(kind) + ":" + value
+	if m.flagged[key] {
+		return
+	}
+	m.flagged[key] = true
+
+	m.eventBus.Publish(&event.BaselineAnomalyEvent{
+		Timestamp:      m.clock.Now(),
+		Kind:           kind,
+		Value:          value,
+		Method:         msg.Method,
+		TransportType:  msg.TransportType,
+		StdioTransport: msg.StdioTransport,
+		HttpTransport:  msg.HttpTransport,
+	})
+}
+
+// Close unsubscribes from the event bus.
+func (m *Monitor) Close() {
+	m.eventBus.Unsubscribe(event.EventTypeMCPMessage, m.handleMCPMessage)
+}
@@ -0,0 +1,109 @@
+package baseline
+
+import (
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func collectAnomalies(events <-chan event.Event) []*event.BaselineAnomalyEvent {
+	var anomalies []*event.BaselineAnomalyEvent
+	for {
+		select {
+		case e := <-events:
+			if a, ok := e.(*event.BaselineAnomalyEvent); ok {
+				anomalies = append(anomalies, a)
+			}
+		default:
+			return anomalies
+		}
+	}
+}
+
+func TestMonitor_FlagsToolNotInBaseline(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	profile := NewProfile()
+	profile.Methods["tools/call"] = true
+	profile.Tools["read_file"] = true
+
+	m, err := New(mockBus, profile)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(&event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			Method: "tools/call",
+			Params: map[string]interface{}{"name": "delete_all_files"},
+		},
+	})
+
+	anomalies := collectAnomalies(mockBus.Events())
+	if len(anomalies) != 1 {
+		t.Fatalf("expected 1 anomaly, got %d: %+v", len(anomalies), anomalies)
+	}
+	if anomalies[0].Kind != event.BaselineAnomalyTool || anomalies[0].Value != "delete_all_files" {
+		t.Errorf("got kind=%s value=%s, want tool/delete_all_files", anomalies[0].Kind, anomalies[0].Value)
+	}
+}
+
+func TestMonitor_NoAnomalyForKnownTraffic(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	profile := NewProfile()
+	profile.Methods["tools/call"] = true
+	profile.Tools["read_file"] = true
+	profile.Hosts["api.example.com"] = true
+
+	m, err := New(mockBus, profile)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(&event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			Method: "tools/call",
+			Params: map[string]interface{}{"name": "read_file"},
+		},
+		HttpTransport: &event.HttpTransport{Host: "api.example.com"},
+	})
+
+	if anomalies := collectAnomalies(mockBus.Events()); len(anomalies) != 0 {
+		t.Errorf("expected no anomalies for known traffic, got %+v", anomalies)
+	}
+}
+
+func TestMonitor_FlagsUnexpectedHostOnlyOnce(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	profile := NewProfile()
+	profile.Hosts["api.example.com"] = true
+
+	m, err := New(mockBus, profile)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	for i := 0; i < 3; i++ {
+		mockBus.Publish(&event.MCPEvent{
+			JSONRPCMessage: event.JSONRPCMessage{Method: "tools/list"},
+			HttpTransport:  &event.HttpTransport{Host: "evil.example.com"},
+		})
+	}
+
+	anomalies := collectAnomalies(mockBus.Events())
+	var hostAnomalies int
+	for _, a := range anomalies {
+		if a.Kind == event.BaselineAnomalyHost {
+			hostAnomalies++
+		}
+	}
+	if hostAnomalies != 1 {
+		t.Errorf("expected the unexpected host to be flagged exactly once, got %d", hostAnomalies)
+	}
+}
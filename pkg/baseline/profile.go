@@ -0,0 +1,68 @@
+// Package baseline records the set of JSON-RPC methods, tool names, and
+// hosts a known-good MCP session uses, then watches live traffic for
+// anything outside that set - a new tool, a new host, an unexpected method
+// - as a sign of drift or compromise.
+package baseline
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// Profile is a recorded baseline: the set of methods, tool names, and hosts
+// observed in a known-good capture, written by `mcpspy baseline record` and
+// read back via --baseline.
+type Profile struct {
+	Methods map[string]bool `json:"methods"`
+	Tools   map[string]bool `json:"tools"`
+	Hosts   map[string]bool `json:"hosts"`
+}
+
+// NewProfile returns an empty Profile ready to have observations recorded
+// into it.
+func NewProfile() *Profile {
+	return &Profile{
+		Methods: make(map[string]bool),
+		Tools:   make(map[string]bool),
+		Hosts:   make(map[string]bool),
+	}
+}
+
+// Observe records msg's method, tool name (if it's a tools/call request),
+// and host (if it arrived over HTTP) into the profile.
+func (p *Profile) Observe(msg *event.MCPEvent) {
+	if msg.Method != "" {
+		p.Methods[msg.Method] = true
+	}
+	if tool := msg.ExtractToolName(); tool != "" {
+		p.Tools[tool] = true
+	}
+	if msg.HttpTransport != nil && msg.HttpTransport.Host != "" {
+		p.Hosts[msg.HttpTransport.Host] = true
+	}
+}
+
+// Load reads a Profile previously written by Save.
+func Load(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := NewProfile()
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Save writes the profile to path as indented JSON.
+func (p *Profile) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
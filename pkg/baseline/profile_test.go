@@ -0,0 +1,52 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestProfile_ObserveRecordsMethodToolAndHost(t *testing.T) {
+	p := NewProfile()
+
+	p.Observe(&event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			Method: "tools/call",
+			Params: map[string]interface{}{"name": "read_file"},
+		},
+		HttpTransport: &event.HttpTransport{Host: "api.example.com"},
+	})
+
+	if !p.Methods["tools/call"] {
+		t.Errorf("expected method tools/call to be recorded")
+	}
+	if !p.Tools["read_file"] {
+		t.Errorf("expected tool read_file to be recorded")
+	}
+	if !p.Hosts["api.example.com"] {
+		t.Errorf("expected host api.example.com to be recorded")
+	}
+}
+
+func TestProfile_SaveAndLoadRoundTrip(t *testing.T) {
+	p := NewProfile()
+	p.Observe(&event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			Method: "tools/list",
+		},
+	})
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := p.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.Methods["tools/list"] {
+		t.Errorf("expected loaded profile to contain method tools/list, got %+v", loaded.Methods)
+	}
+}
@@ -0,0 +1,117 @@
+// Package bridge detects MCP messages that cross a stdio-to-HTTP bridge
+// process, such as mcp-proxy or supergateway, which relay a message
+// unchanged from one transport to the other. The same message therefore
+// shows up twice on the bus, once per transport; this package recognizes
+// the pair by content hash and reports the bridging process.
+package bridge
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+var (
+	pendingCacheSize = 4096
+	pendingCacheTTL  = 2 * time.Second
+)
+
+// Detector pairs MCP messages observed on one transport with the same
+// message observed on a different transport shortly after, and reports the
+// bridging process.
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+//
+// Emits the following events:
+// - EventTypeBridgeCorrelation
+type Detector struct {
+	// Messages seen on one transport, keyed by content hash, waiting for a
+	// matching sighting on a different transport. Thread-safe.
+	pending *expirable.LRU[string, *event.MCPEvent]
+
+	eventBus bus.EventBus
+}
+
+// New creates a new Detector.
+func New(eventBus bus.EventBus) (*Detector, error) {
+	d := &Detector{
+		pending:  expirable.NewLRU[string, *event.MCPEvent](pendingCacheSize, nil, pendingCacheTTL),
+		eventBus: eventBus,
+	}
+
+	if err := d.eventBus.Subscribe(event.EventTypeMCPMessage, d.handleMessage); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// handleMessage looks for the same message content having already been seen
+// on a different transport and, if found, emits a bridge correlation event.
+func (d *Detector) handleMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	hash := contentHash(msg.Raw)
+
+	other, found := d.pending.Get(hash)
+	if !found {
+		d.pending.Add(hash, msg)
+		return
+	}
+	if other.TransportType == msg.TransportType {
+		// Seen again on the same transport (e.g. a retry) - keep waiting
+		// for a sighting on the other transport.
+		return
+	}
+	d.pending.Remove(hash)
+
+	stdioMsg, httpMsg := msg, other
+	if msg.TransportType == event.TransportTypeHTTP {
+		stdioMsg, httpMsg = other, msg
+	}
+	if stdioMsg.StdioTransport == nil || httpMsg.HttpTransport == nil {
+		return
+	}
+
+	d.eventBus.Publish(&event.BridgeCorrelationEvent{
+		Timestamp:      msg.Timestamp,
+		Method:         effectiveMethod(msg),
+		ID:             msg.ID,
+		StdioTransport: stdioMsg.StdioTransport,
+		HttpTransport:  httpMsg.HttpTransport,
+		BridgePID:      httpMsg.HttpTransport.PID,
+		BridgeComm:     httpMsg.HttpTransport.Comm,
+		Latency:        msg.Timestamp.Sub(other.Timestamp).Abs(),
+	})
+}
+
+// effectiveMethod returns the message's method, falling back to the
+// original request's method for responses, which don't carry their own.
+func effectiveMethod(msg *event.MCPEvent) string {
+	if msg.Method != "" {
+		return msg.Method
+	}
+	if msg.Request != nil {
+		return msg.Request.Method
+	}
+	return ""
+}
+
+// contentHash hashes the raw JSON-RPC payload so identical messages relayed
+// unchanged across transports can be recognized as the same logical flow.
+func contentHash(raw string) string {
+	sum := sha1.Sum([]byte(strings.TrimSpace(raw)))
+	return fmt.Sprintf("%x", sum)
+}
+
+func (d *Detector) Close() {
+	d.eventBus.Unsubscribe(event.EventTypeMCPMessage, d.handleMessage)
+}
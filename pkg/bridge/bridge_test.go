@@ -0,0 +1,176 @@
+package bridge
+
+import (
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestDetector_CorrelatesMessageAcrossTransports(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	d, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+	defer d.Close()
+	defer mockBus.Close()
+
+	raw := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Now(),
+		Raw:           raw,
+		TransportType: event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{
+			FromPID: 100, FromComm: "server", ToPID: 200, ToComm: "proxy",
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Now().Add(5 * time.Millisecond),
+		Raw:           raw,
+		TransportType: event.TransportTypeHTTP,
+		HttpTransport: &event.HttpTransport{
+			PID: 200, Comm: "proxy", Host: "localhost:8080", IsRequest: true,
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+
+	var got *event.BridgeCorrelationEvent
+	for i := 0; i < 3; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			if c, ok := evt.(*event.BridgeCorrelationEvent); ok {
+				got = c
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if got == nil {
+		t.Fatal("Expected a BridgeCorrelationEvent, got none")
+	}
+	if got.Method != "tools/call" {
+		t.Errorf("Expected method tools/call, got %q", got.Method)
+	}
+	if got.BridgePID != 200 || got.BridgeComm != "proxy" {
+		t.Errorf("Expected bridge proxy[200], got %s[%d]", got.BridgeComm, got.BridgePID)
+	}
+	if got.StdioTransport == nil || got.HttpTransport == nil {
+		t.Fatalf("Expected both transport legs set, got %+v", got)
+	}
+}
+
+// TestDetector_CorrelatesRegardlessOfArrivalOrder guards against the two
+// legs being mislabeled when the HTTP leg happens to reach the parser
+// before the stdio leg - there's no kernel timestamp to order them by, so
+// Detector must identify each leg by its TransportType, not by which one
+// was seen first.
+func TestDetector_CorrelatesRegardlessOfArrivalOrder(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	d, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+	defer d.Close()
+	defer mockBus.Close()
+
+	raw := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Now(),
+		Raw:           raw,
+		TransportType: event.TransportTypeHTTP,
+		HttpTransport: &event.HttpTransport{
+			PID: 200, Comm: "proxy", Host: "localhost:8080", IsRequest: true,
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Now().Add(5 * time.Millisecond),
+		Raw:           raw,
+		TransportType: event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{
+			FromPID: 100, FromComm: "server", ToPID: 200, ToComm: "proxy",
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+
+	var got *event.BridgeCorrelationEvent
+	for i := 0; i < 3; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			if c, ok := evt.(*event.BridgeCorrelationEvent); ok {
+				got = c
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if got == nil {
+		t.Fatal("Expected a BridgeCorrelationEvent, got none")
+	}
+	if got.StdioTransport == nil || got.StdioTransport.FromPID != 100 {
+		t.Errorf("Expected stdio leg from PID 100, got %+v", got.StdioTransport)
+	}
+	if got.HttpTransport == nil || got.HttpTransport.PID != 200 {
+		t.Errorf("Expected http leg PID 200, got %+v", got.HttpTransport)
+	}
+	if got.Latency < 0 {
+		t.Errorf("Expected non-negative latency regardless of arrival order, got %v", got.Latency)
+	}
+}
+
+func TestDetector_NoCorrelationForSingleTransport(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	d, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+	defer d.Close()
+	defer mockBus.Close()
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Now(),
+		Raw:           `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		TransportType: event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{
+			FromPID: 100, FromComm: "server", ToPID: 200, ToComm: "client",
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/list",
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() == event.EventTypeBridgeCorrelation {
+				t.Fatalf("Expected no bridge correlation event, got one")
+			}
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
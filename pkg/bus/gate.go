@@ -0,0 +1,61 @@
+package bus
+
+import (
+	"sync/atomic"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// PausableBus wraps an EventBus and lets Publish be toggled on and off at
+// runtime without unsubscribing anyone or tearing down the underlying bus.
+// While paused, published events are dropped before reaching any
+// subscriber; Subscribe/Unsubscribe/Close pass straight through. This is
+// how --pause-signal lets a long-running capture go quiet during known-noisy
+// periods without detaching eBPF hooks.
+type PausableBus struct {
+	inner  EventBus
+	paused atomic.Bool
+}
+
+// NewPausable wraps inner in a PausableBus, initially unpaused.
+func NewPausable(inner EventBus) *PausableBus {
+	return &PausableBus{inner: inner}
+}
+
+// Publish forwards e to the underlying bus, unless paused.
+func (b *PausableBus) Publish(e event.Event) {
+	if b.paused.Load() {
+		return
+	}
+	b.inner.Publish(e)
+}
+
+// Subscribe registers fn on the underlying bus.
+func (b *PausableBus) Subscribe(eventType event.EventType, fn EventProcessor) error {
+	return b.inner.Subscribe(eventType, fn)
+}
+
+// Unsubscribe removes fn from the underlying bus.
+func (b *PausableBus) Unsubscribe(eventType event.EventType, fn EventProcessor) error {
+	return b.inner.Unsubscribe(eventType, fn)
+}
+
+// Close closes the underlying bus.
+func (b *PausableBus) Close() {
+	b.inner.Close()
+}
+
+// Pause stops events from reaching subscribers until Resume is called.
+func (b *PausableBus) Pause() {
+	b.paused.Store(true)
+}
+
+// Resume lets events reach subscribers again.
+func (b *PausableBus) Resume() {
+	b.paused.Store(false)
+}
+
+// Paused reports whether the bus is currently dropping published events.
+func (b *PausableBus) Paused() bool {
+	return b.paused.Load()
+}
@@ -0,0 +1,64 @@
+package bus
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestPausableBus_DropsEventsWhilePaused(t *testing.T) {
+	inner := New()
+	defer inner.Close()
+	gated := NewPausable(inner)
+
+	var mu sync.Mutex
+	var received []string
+
+	err := gated.Subscribe(event.EventTypeFSRead, func(e event.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, e.(*testEvent).payload)
+	})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	gated.Pause()
+	if !gated.Paused() {
+		t.Fatal("expected Paused() to be true after Pause()")
+	}
+
+	gated.Publish(&testEvent{eventType: event.EventTypeFSRead, payload: "dropped"})
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	if len(received) != 0 {
+		t.Errorf("expected no events while paused, got %v", received)
+	}
+	mu.Unlock()
+
+	gated.Resume()
+	if gated.Paused() {
+		t.Fatal("expected Paused() to be false after Resume()")
+	}
+
+	gated.Publish(&testEvent{eventType: event.EventTypeFSRead, payload: "delivered"})
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || received[0] != "delivered" {
+		t.Errorf("expected exactly one delivered event after resume, got %v", received)
+	}
+}
+
+func TestPausableBus_UnpausedByDefault(t *testing.T) {
+	gated := NewPausable(New())
+	defer gated.Close()
+
+	if gated.Paused() {
+		t.Error("expected a new PausableBus to start unpaused")
+	}
+}
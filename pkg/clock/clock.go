@@ -0,0 +1,57 @@
+// Package clock provides an injectable source of the current time so
+// components that stamp events (the MCP parser, LLM providers) can be
+// driven by a fixed time in tests instead of the wall clock, making their
+// output byte-for-byte reproducible.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock returns the current time. The zero value of Real satisfies it and
+// should be used in production; tests substitute a Fake.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is a Clock backed by time.Now.
+type Real struct{}
+
+// New returns the real, wall-clock Clock.
+func New() Clock {
+	return Real{}
+}
+
+func (Real) Now() time.Time { return time.Now() }
+
+// Fake is a Clock that only advances when told to, for deterministic tests.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake returns a Fake clock fixed at now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set pins the clock to t.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// Advance moves the clock forward by d.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
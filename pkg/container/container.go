@@ -0,0 +1,66 @@
+// Package container resolves the container ID a process belongs to, for
+// attributing stdio hops to the Docker/containerd container that produced
+// them.
+package container
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// containerIDPattern matches the 64-character hex container ID Docker and
+// containerd both embed in a containerized process's cgroup path, whether
+// it's cgroup v1 (e.g. "1:name=systemd:/docker/<id>") or cgroup v2 (e.g.
+// "0::/system.slice/docker-<id>.scope").
+var containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// ResolveID returns the container ID for pid, extracted from its
+// /proc/<pid>/cgroup entry. Returns "" if pid isn't running inside a
+// container, or its cgroup file can't be read (e.g. the process has since
+// exited).
+func ResolveID(pid uint32) string {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if id := containerIDPattern.FindString(scanner.Text()); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// Resolver caches ResolveID lookups per PID. A process's cgroup membership
+// doesn't change over its lifetime, so repeatedly re-reading /proc/<pid>/cgroup
+// for every message on a hot stdio pipe would be wasted work.
+type Resolver struct {
+	mu    sync.Mutex
+	cache map[uint32]string
+}
+
+// NewResolver returns an empty Resolver.
+func NewResolver() *Resolver {
+	return &Resolver{cache: make(map[uint32]string)}
+}
+
+// Resolve returns the container ID for pid, consulting the cache before
+// falling back to ResolveID. A pid resolving to "" (not containerized) is
+// cached too, so non-containerized processes aren't re-checked either.
+func (r *Resolver) Resolve(pid uint32) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id, ok := r.cache[pid]; ok {
+		return id
+	}
+	id := ResolveID(pid)
+	r.cache[pid] = id
+	return id
+}
@@ -0,0 +1,36 @@
+package container
+
+import (
+	"os"
+	"testing"
+)
+
+func TestResolveID_NonContainerizedProcess(t *testing.T) {
+	// The test process itself isn't running inside a container, so its
+	// cgroup shouldn't contain a container ID.
+	if id := ResolveID(uint32(os.Getpid())); id != "" {
+		t.Errorf("ResolveID(self) = %q, want \"\" (test runner isn't containerized)", id)
+	}
+}
+
+func TestResolveID_UnknownPID(t *testing.T) {
+	if id := ResolveID(999999); id != "" {
+		t.Errorf("ResolveID(999999) = %q, want \"\" for a nonexistent PID", id)
+	}
+}
+
+func TestResolver_CachesLookups(t *testing.T) {
+	r := NewResolver()
+	pid := uint32(os.Getpid())
+
+	first := r.Resolve(pid)
+	r.cache[pid] = "forced-for-test"
+	second := r.Resolve(pid)
+
+	if first != "" {
+		t.Errorf("Resolve(self) = %q, want \"\"", first)
+	}
+	if second != "forced-for-test" {
+		t.Errorf("Resolve() on a cached pid = %q, want the cached value to be returned instead of re-resolved", second)
+	}
+}
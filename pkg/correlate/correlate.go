@@ -0,0 +1,98 @@
+// Package correlate links LLM tool_use invocations with the MCP tools/call
+// requests they trigger, surfacing the full causal chain from a model's
+// decision to call a tool to the app actually issuing that call over MCP.
+package correlate
+
+import (
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+var (
+	pendingToolUseCacheSize = 4096
+	pendingToolUseCacheTTL  = 30 * time.Second
+)
+
+// Correlator pairs LLM tool_use invocations with the MCP tools/call request
+// that follows them.
+// Subscribes to the following events:
+// - EventTypeToolUsage
+// - EventTypeMCPMessage
+//
+// Emits the following events:
+// - EventTypeToolCallCorrelation
+type Correlator struct {
+	// Pending LLM tool_use invocations, keyed by tool name, waiting for a
+	// matching MCP tools/call request. Thread-safe.
+	pending *expirable.LRU[string, *event.ToolUsageEvent]
+
+	eventBus bus.EventBus
+}
+
+// New creates a new Correlator.
+func New(eventBus bus.EventBus) (*Correlator, error) {
+	c := &Correlator{
+		pending:  expirable.NewLRU[string, *event.ToolUsageEvent](pendingToolUseCacheSize, nil, pendingToolUseCacheTTL),
+		eventBus: eventBus,
+	}
+
+	if err := c.eventBus.Subscribe(event.EventTypeToolUsage, c.handleToolUsage); err != nil {
+		return nil, err
+	}
+	if err := c.eventBus.Subscribe(event.EventTypeMCPMessage, c.handleMCPMessage); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// handleToolUsage records LLM tool_use invocations so a later matching
+// MCP tools/call request can be paired with them. Tool results are ignored.
+func (c *Correlator) handleToolUsage(e event.Event) {
+	usage, ok := e.(*event.ToolUsageEvent)
+	if !ok || usage.UsageType != event.ToolUsageTypeInvocation {
+		return
+	}
+
+	c.pending.Add(usage.ToolName, usage)
+}
+
+// handleMCPMessage looks for a tools/call request matching a pending
+// tool_use invocation by name and, if found, emits a correlation event.
+func (c *Correlator) handleMCPMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok || msg.MessageType != event.JSONRPCMessageTypeRequest || msg.Method != "tools/call" {
+		return
+	}
+
+	toolName := msg.ExtractToolName()
+	if toolName == "" {
+		return
+	}
+
+	usage, found := c.pending.Get(toolName)
+	if !found {
+		return
+	}
+	c.pending.Remove(toolName)
+
+	c.eventBus.Publish(&event.ToolCallCorrelationEvent{
+		Timestamp:    msg.Timestamp,
+		ToolName:     toolName,
+		LLMSessionID: usage.SessionID,
+		LLMToolID:    usage.ToolID,
+		LLMInput:     usage.Input,
+		MCPRequestID: msg.ID,
+		MCPParams:    msg.Params,
+		Latency:      msg.Timestamp.Sub(usage.Timestamp),
+	})
+}
+
+func (c *Correlator) Close() {
+	c.eventBus.Unsubscribe(event.EventTypeToolUsage, c.handleToolUsage)
+	c.eventBus.Unsubscribe(event.EventTypeMCPMessage, c.handleMCPMessage)
+}
@@ -0,0 +1,94 @@
+package correlate
+
+import (
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestCorrelator_PairsToolUseWithMCPCall(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	c, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create correlator: %v", err)
+	}
+	defer c.Close()
+	defer mockBus.Close()
+
+	mockBus.Publish(&event.ToolUsageEvent{
+		SessionID: 1,
+		Timestamp: time.Now(),
+		UsageType: event.ToolUsageTypeInvocation,
+		ToolID:    "toolu_1",
+		ToolName:  "get_weather",
+		Input:     `{"city":"nyc"}`,
+	})
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp: time.Now(),
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+			Params: map[string]interface{}{
+				"name": "get_weather",
+			},
+		},
+	})
+
+	var got *event.ToolCallCorrelationEvent
+	for i := 0; i < 3; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			if c, ok := evt.(*event.ToolCallCorrelationEvent); ok {
+				got = c
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if got == nil {
+		t.Fatal("expected a ToolCallCorrelationEvent, got none")
+	}
+	if got.ToolName != "get_weather" {
+		t.Errorf("expected tool name get_weather, got %s", got.ToolName)
+	}
+	if got.LLMToolID != "toolu_1" {
+		t.Errorf("expected llm tool id toolu_1, got %s", got.LLMToolID)
+	}
+	if got.MCPRequestID != int64(1) {
+		t.Errorf("expected mcp request id 1, got %v", got.MCPRequestID)
+	}
+}
+
+func TestCorrelator_NoMatchWithoutPendingToolUse(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	c, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create correlator: %v", err)
+	}
+	defer c.Close()
+	defer mockBus.Close()
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp: time.Now(),
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+			Params: map[string]interface{}{
+				"name": "get_weather",
+			},
+		},
+	})
+
+	select {
+	case evt := <-mockBus.Events():
+		if _, ok := evt.(*event.ToolCallCorrelationEvent); ok {
+			t.Fatal("expected no correlation event without a matching tool_use")
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+}
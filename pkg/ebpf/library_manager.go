@@ -1,6 +1,7 @@
 package ebpf
 
 import (
+	"path/filepath"
 	"strings"
 	"sync"
 
@@ -10,30 +11,62 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// SSLProbeAttacher is an interface for attaching SSL probes to libraries
+// SSLProbeAttacher is an interface for attaching TLS probes to libraries.
+// Different backends need different symbol sets, hence the separate
+// per-backend methods rather than a single AttachProbes.
 type SSLProbeAttacher interface {
 	AttachSSLProbes(libraryPath string) error
+	AttachGnuTLSProbes(libraryPath string) error
+}
+
+// sslBackend identifies which TLS library implementation a loaded library
+// is, so ProcessLibraryEvent can attach the matching probe set.
+type sslBackend int
+
+const (
+	// sslBackendOpenSSL also covers BoringSSL (e.g. statically linked into
+	// Node.js/Chromium), which exposes the same SSL_* symbol names.
+	sslBackendOpenSSL sslBackend = iota
+	sslBackendGnuTLS
+)
+
+// detectSSLBackend classifies a library by filename, mirroring the
+// filename-based filtering already done on the kernel side
+// (is_filename_relevant in bpf/helpers.h).
+func detectSSLBackend(path string) sslBackend {
+	if strings.HasPrefix(filepath.Base(path), "libgnutls.so") {
+		return sslBackendGnuTLS
+	}
+	return sslBackendOpenSSL
 }
 
 // LibraryManager manages uprobe hooks for dynamically loaded libraries.
 // It prevents duplicate hooks and caches failed attempts.
 type LibraryManager struct {
-	attacher   SSLProbeAttacher
-	mountNS    uint32            // mount namespace ID
-	hookedLibs map[uint64]string // inode -> path (successfully hooked)
-	failedLibs map[uint64]error  // inode -> error (failed to hook)
-	eventBus   bus.EventBus
-	mu         sync.Mutex
+	attacher          SSLProbeAttacher
+	mountNS           uint32            // mount namespace ID
+	noNamespaceSwitch bool              // safe mode: never setns into other mount namespaces
+	hookedLibs        map[uint64]string // inode -> path (successfully hooked)
+	failedLibs        map[uint64]error  // inode -> error (failed to hook)
+	skippedLibs       map[uint64]string // inode -> path (skipped because of safe mode)
+	eventBus          bus.EventBus
+	mu                sync.Mutex
 }
 
-// NewLibraryManager creates a new library manager
-func NewLibraryManager(eventBus bus.EventBus, attacher SSLProbeAttacher, mountNS uint32) (*LibraryManager, error) {
+// NewLibraryManager creates a new library manager.
+// When noNamespaceSwitch is true, libraries loaded in a different mount
+// namespace than mcpspy's own are never entered via setns; they are
+// reported as skipped instead. This gives a lower-privilege operating
+// posture for security-conscious deployments.
+func NewLibraryManager(eventBus bus.EventBus, attacher SSLProbeAttacher, mountNS uint32, noNamespaceSwitch bool) (*LibraryManager, error) {
 	lm := &LibraryManager{
-		attacher:   attacher,
-		mountNS:    mountNS,
-		hookedLibs: make(map[uint64]string),
-		failedLibs: make(map[uint64]error),
-		eventBus:   eventBus,
+		attacher:          attacher,
+		mountNS:           mountNS,
+		noNamespaceSwitch: noNamespaceSwitch,
+		hookedLibs:        make(map[uint64]string),
+		failedLibs:        make(map[uint64]error),
+		skippedLibs:       make(map[uint64]string),
+		eventBus:          eventBus,
 	}
 
 	// Subscribe to library events
@@ -102,6 +135,15 @@ func (lm *LibraryManager) ProcessLibraryEvent(e event.Event) {
 
 	// Check if we need to fetch path in a different mount namespace
 	if targetMountNS != lm.mountNS {
+		if lm.noNamespaceSwitch {
+			// Safe mode: never setns into another mount namespace.
+			lm.skippedLibs[inode] = path
+			logrus.WithFields(e.LogFields()).
+				WithField("mount_ns", targetMountNS).
+				Warn("Skipping containerized library: namespace switching disabled (--no-namespace-switch)")
+			return
+		}
+
 		// Different namespace - need to modify path
 		modifiedPath, err = namespace.GetPathInMountNamespace(path, targetMountNS)
 		if err != nil {
@@ -114,8 +156,15 @@ func (lm *LibraryManager) ProcessLibraryEvent(e event.Event) {
 		modifiedPath = path
 	}
 
-	if err := lm.attacher.AttachSSLProbes(modifiedPath); err != nil {
-		lm.failedLibs[inode] = err
+	var attachErr error
+	switch detectSSLBackend(modifiedPath) {
+	case sslBackendGnuTLS:
+		attachErr = lm.attacher.AttachGnuTLSProbes(modifiedPath)
+	default:
+		attachErr = lm.attacher.AttachSSLProbes(modifiedPath)
+	}
+	if attachErr != nil {
+		lm.failedLibs[inode] = attachErr
 		logrus.WithFields(e.LogFields()).Warn("Failed to attach SSL probes")
 		return
 	}
@@ -157,6 +206,19 @@ func (lm *LibraryManager) FailedLibraries() map[uint64]error {
 	return result
 }
 
+// SkippedLibraries returns a copy of the libraries skipped because of safe mode
+// (--no-namespace-switch)
+func (lm *LibraryManager) SkippedLibraries() map[uint64]string {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	result := make(map[uint64]string, len(lm.skippedLibs))
+	for k, v := range lm.skippedLibs {
+		result[k] = v
+	}
+	return result
+}
+
 // Clean clears all tracked libraries (useful for testing)
 func (lm *LibraryManager) Clean() {
 	lm.mu.Lock()
@@ -164,6 +226,7 @@ func (lm *LibraryManager) Clean() {
 
 	lm.hookedLibs = make(map[uint64]string)
 	lm.failedLibs = make(map[uint64]error)
+	lm.skippedLibs = make(map[uint64]string)
 }
 
 // Close closes the library manager and cleans up resources
@@ -10,14 +10,16 @@ import (
 
 // testLoader is a test implementation that tracks attach calls
 type testLoader struct {
-	attachCalls   []string
-	attachResults map[string]error // path -> error
+	attachCalls       []string
+	attachResults     map[string]error // path -> error
+	gnutlsAttachCalls []string
 }
 
 func newTestLoader() *testLoader {
 	return &testLoader{
-		attachCalls:   []string{},
-		attachResults: make(map[string]error),
+		attachCalls:       []string{},
+		attachResults:     make(map[string]error),
+		gnutlsAttachCalls: []string{},
 	}
 }
 
@@ -29,12 +31,20 @@ func (t *testLoader) AttachSSLProbes(libraryPath string) error {
 	return nil
 }
 
+func (t *testLoader) AttachGnuTLSProbes(libraryPath string) error {
+	t.gnutlsAttachCalls = append(t.gnutlsAttachCalls, libraryPath)
+	if err, ok := t.attachResults[libraryPath]; ok {
+		return err
+	}
+	return nil
+}
+
 func TestLibraryManager_ProcessLibraryEvent(t *testing.T) {
 	// Create a test loader
 	tl := newTestLoader()
 
 	// Create library manager with the test loader
-	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221) // Use a test mount namespace
+	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221, false) // Use a test mount namespace
 	if err != nil {
 		t.Fatalf("Failed to create LibraryManager: %v", err)
 	}
@@ -177,7 +187,7 @@ func TestLibraryManager_ProcessLibraryEvent(t *testing.T) {
 }
 
 func TestLibraryManager_GetHookedLibraries(t *testing.T) {
-	lm, err := NewLibraryManager(tu.NewMockBus(), nil, 4026532221)
+	lm, err := NewLibraryManager(tu.NewMockBus(), nil, 4026532221, false)
 	if err != nil {
 		t.Fatalf("Failed to create LibraryManager: %v", err)
 	}
@@ -203,7 +213,7 @@ func TestLibraryManager_GetHookedLibraries(t *testing.T) {
 }
 
 func TestLibraryManager_GetFailedLibraries(t *testing.T) {
-	lm, err := NewLibraryManager(tu.NewMockBus(), nil, 4026532221)
+	lm, err := NewLibraryManager(tu.NewMockBus(), nil, 4026532221, false)
 	if err != nil {
 		t.Fatalf("Failed to create LibraryManager: %v", err)
 	}
@@ -231,7 +241,7 @@ func TestLibraryManager_GetFailedLibraries(t *testing.T) {
 }
 
 func TestLibraryManager_Reset(t *testing.T) {
-	lm, err := NewLibraryManager(tu.NewMockBus(), nil, 4026532221)
+	lm, err := NewLibraryManager(tu.NewMockBus(), nil, 4026532221, false)
 	if err != nil {
 		t.Fatalf("Failed to create LibraryManager: %v", err)
 	}
@@ -266,7 +276,7 @@ func TestLibraryManager_Reset(t *testing.T) {
 }
 
 func TestLibraryManager_Close(t *testing.T) {
-	lm, err := NewLibraryManager(tu.NewMockBus(), nil, 4026532221)
+	lm, err := NewLibraryManager(tu.NewMockBus(), nil, 4026532221, false)
 	if err != nil {
 		t.Fatalf("Failed to create LibraryManager: %v", err)
 	}
@@ -288,7 +298,7 @@ func TestLibraryManager_Close(t *testing.T) {
 // Test retry behavior with non-retryable errors
 func TestLibraryManager_NonRetryableError(t *testing.T) {
 	tl := newTestLoader()
-	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221)
+	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221, false)
 	if err != nil {
 		t.Fatalf("Failed to create LibraryManager: %v", err)
 	}
@@ -330,7 +340,7 @@ func TestLibraryManager_NonRetryableError(t *testing.T) {
 // Test retry behavior with retryable errors (like "no such file or directory")
 func TestLibraryManager_RetryableError(t *testing.T) {
 	tl := newTestLoader()
-	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221)
+	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221, false)
 	if err != nil {
 		t.Fatalf("Failed to create LibraryManager: %v", err)
 	}
@@ -372,7 +382,7 @@ func TestLibraryManager_RetryableError(t *testing.T) {
 // Test error state removal when load succeeds
 func TestLibraryManager_ErrorStateRemoval(t *testing.T) {
 	tl := newTestLoader()
-	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221)
+	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221, false)
 	if err != nil {
 		t.Fatalf("Failed to create LibraryManager: %v", err)
 	}
@@ -429,6 +439,149 @@ func TestLibraryManager_ErrorStateRemoval(t *testing.T) {
 	}
 }
 
+// Test safe mode: cross-namespace libraries are skipped, never attached to
+func TestLibraryManager_SafeModeSkipsNamespaceSwitch(t *testing.T) {
+	tl := newTestLoader()
+	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221, true) // safe mode enabled
+	if err != nil {
+		t.Fatalf("Failed to create LibraryManager: %v", err)
+	}
+	defer lm.Close()
+
+	event := &event.LibraryEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeLibrary,
+			PID:       1234,
+			CommBytes: [16]uint8{'s', 'a', 'f', 'e'},
+		},
+		Inode:     55555,
+		MntNSID:   1234, // Different namespace from library manager
+		PathBytes: makePathBytes("/usr/lib/libssl.so.container"),
+	}
+
+	lm.ProcessLibraryEvent(event)
+
+	if len(tl.attachCalls) != 0 {
+		t.Errorf("Expected namespace switching to never be attempted in safe mode, got %d attach calls", len(tl.attachCalls))
+	}
+
+	skipped := lm.SkippedLibraries()
+	if skipped[55555] != "/usr/lib/libssl.so.container" {
+		t.Errorf("Expected library to be reported as skipped, got %v", skipped)
+	}
+
+	_, failed := lm.Stats()
+	if failed != 0 {
+		t.Errorf("Expected skipped library to not be counted as failed, got %d failed", failed)
+	}
+
+	// Same-namespace libraries are unaffected by safe mode
+	localEvent := &event.LibraryEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeLibrary,
+			PID:       1234,
+			CommBytes: [16]uint8{'s', 'a', 'f', 'e'},
+		},
+		Inode:     66666,
+		MntNSID:   4026532221, // Same namespace as library manager
+		PathBytes: makePathBytes("/usr/lib/libssl.so.local"),
+	}
+
+	lm.ProcessLibraryEvent(localEvent)
+
+	if len(tl.attachCalls) != 1 || tl.attachCalls[0] != "/usr/lib/libssl.so.local" {
+		t.Errorf("Expected local library to still be attached in safe mode, got %v", tl.attachCalls)
+	}
+}
+
+func TestLibraryManager_DispatchesGnuTLSLibrariesToGnuTLSAttacher(t *testing.T) {
+	tl := newTestLoader()
+
+	lm, err := NewLibraryManager(tu.NewMockBus(), tl, 4026532221, false)
+	if err != nil {
+		t.Fatalf("Failed to create LibraryManager: %v", err)
+	}
+	defer lm.Close()
+
+	gnutlsEvent := &event.LibraryEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeLibrary,
+			PID:       1234,
+			CommBytes: [16]uint8{'t', 'e', 's', 't'},
+		},
+		Inode:     77777,
+		MntNSID:   4026532221,
+		PathBytes: makePathBytes("/usr/lib/x86_64-linux-gnu/libgnutls.so.30"),
+	}
+
+	lm.ProcessLibraryEvent(gnutlsEvent)
+
+	if len(tl.gnutlsAttachCalls) != 1 || tl.gnutlsAttachCalls[0] != "/usr/lib/x86_64-linux-gnu/libgnutls.so.30" {
+		t.Errorf("Expected GnuTLS library to be attached via AttachGnuTLSProbes, got %v", tl.gnutlsAttachCalls)
+	}
+	if len(tl.attachCalls) != 0 {
+		t.Errorf("Expected GnuTLS library to not be attached via AttachSSLProbes, got %v", tl.attachCalls)
+	}
+
+	hooked, _ := lm.Stats()
+	if hooked != 1 {
+		t.Errorf("Expected GnuTLS library to be marked as hooked, got %d", hooked)
+	}
+
+	// An OpenSSL library on the same manager still goes through AttachSSLProbes.
+	sslEvent := &event.LibraryEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeLibrary,
+			PID:       1234,
+			CommBytes: [16]uint8{'t', 'e', 's', 't'},
+		},
+		Inode:     88888,
+		MntNSID:   4026532221,
+		PathBytes: makePathBytes("/usr/lib/libssl.so.3"),
+	}
+
+	lm.ProcessLibraryEvent(sslEvent)
+
+	if len(tl.attachCalls) != 1 || tl.attachCalls[0] != "/usr/lib/libssl.so.3" {
+		t.Errorf("Expected OpenSSL library to be attached via AttachSSLProbes, got %v", tl.attachCalls)
+	}
+}
+
+// TestLibraryManager_WiredToEventBus verifies that NewLibraryManager subscribes
+// itself to EventTypeLibrary, so a library dlopen'd after startup (e.g. a
+// process lazily importing its TLS library) gets probes attached without the
+// caller having to forward events to ProcessLibraryEvent manually.
+func TestLibraryManager_WiredToEventBus(t *testing.T) {
+	tl := newTestLoader()
+	eventBus := tu.NewMockBus()
+
+	lm, err := NewLibraryManager(eventBus, tl, 4026532221, false)
+	if err != nil {
+		t.Fatalf("Failed to create LibraryManager: %v", err)
+	}
+	defer lm.Close()
+
+	eventBus.Publish(&event.LibraryEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeLibrary,
+			PID:       1234,
+			CommBytes: [16]uint8{'p', 'y', 't', 'h', 'o', 'n'},
+		},
+		Inode:     99999,
+		MntNSID:   4026532221,
+		PathBytes: makePathBytes("/usr/lib/libssl.so.3"),
+	})
+
+	if len(tl.attachCalls) != 1 || tl.attachCalls[0] != "/usr/lib/libssl.so.3" {
+		t.Errorf("Expected library event published on the bus to reach the attacher, got %v", tl.attachCalls)
+	}
+
+	hooked, _ := lm.Stats()
+	if hooked != 1 {
+		t.Errorf("Expected 1 hooked library after publishing to the bus, got %d", hooked)
+	}
+}
+
 // Helper function to create PathBytes array from string
 func makePathBytes(path string) [512]uint8 {
 	var result [512]uint8
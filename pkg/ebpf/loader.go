@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/cilium/ebpf"
@@ -31,23 +32,35 @@ type Loader struct {
 	reader    *ringbuf.Reader
 	mcpspyPID uint32
 
+	// captureBytes is the kernel-side capture length limit (--capture-bytes):
+	// the number of bytes copied from the start of each read/write payload
+	// into a data_event's buf. 0 means no limit (capture up to MAX_BUF_SIZE
+	// and drop anything larger, as before).
+	captureBytes uint32
+
 	// Iterator link for library enumeration
 	// Will be != nil if enumeration is ongoing
 	iterLink link.Link
 
 	eventBus bus.EventBus
+
+	// readErrors counts ring buffer Read() failures (other than the reader
+	// being closed on shutdown), for metrics reporting.
+	readErrors uint64
 }
 
-// New creates a new eBPF loader
-func New(mcpspyPID uint32, eventBus bus.EventBus) (*Loader, error) {
+// New creates a new eBPF loader. captureBytes configures the kernel-side
+// capture length limit (--capture-bytes); pass 0 for no limit.
+func New(mcpspyPID uint32, captureBytes uint32, eventBus bus.EventBus) (*Loader, error) {
 	// Remove the memory limit for eBPF
 	if err := rlimit.RemoveMemlock(); err != nil {
 		return nil, fmt.Errorf("failed to remove memlock: %w", err)
 	}
 
 	return &Loader{
-		mcpspyPID: mcpspyPID,
-		eventBus:  eventBus,
+		mcpspyPID:    mcpspyPID,
+		captureBytes: captureBytes,
+		eventBus:     eventBus,
 	}, nil
 }
 
@@ -103,6 +116,15 @@ func (l *Loader) Load() error {
 	}
 	logrus.WithField("mcpspy_pid", l.mcpspyPID).Debug("Set mcpspy PID in map")
 
+	// Set the configured capture length limit in the map, if any
+	if l.captureBytes > 0 {
+		captureKey := uint32(0)
+		if err := l.objs.CaptureBytesMap.Put(&captureKey, &l.captureBytes); err != nil {
+			return fmt.Errorf("failed to set capture-bytes limit in map: %w", err)
+		}
+		logrus.WithField("capture_bytes", l.captureBytes).Debug("Set capture-bytes limit in map")
+	}
+
 	// Attaching exit_vfs_read with Fexit
 	readEnterLink, err := link.AttachTracing(link.TracingOptions{
 		Program:    l.objs.ExitVfsRead,
@@ -123,6 +145,16 @@ func (l *Loader) Load() error {
 	}
 	l.links = append(l.links, readExitLink)
 
+	// Attaching exit_tcp_sendmsg with Fexit to capture bare JSON-RPC-over-TCP
+	tcpSendmsgLink, err := link.AttachTracing(link.TracingOptions{
+		Program:    l.objs.ExitTcpSendmsg,
+		AttachType: ebpf.AttachTraceFExit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach %s fexit: %w", l.objs.ExitTcpSendmsg.String(), err)
+	}
+	l.links = append(l.links, tcpSendmsgLink)
+
 	// Attaching trace_security_file_open with Fentry to track dynamic library loading
 	securityFileOpenLink, err := link.AttachTracing(link.TracingOptions{
 		Program:    l.objs.TraceSecurityFileOpen,
@@ -184,6 +216,7 @@ func (l *Loader) Start(ctx context.Context) error {
 					}
 
 					logrus.WithError(err).Error("Failed to read from ring buffer")
+					atomic.AddUint64(&l.readErrors, 1)
 					continue
 				}
 
@@ -282,6 +315,69 @@ func (l *Loader) Start(ctx context.Context) error {
 					}).Trace(fmt.Sprintf("event#%s", tlsFreeEvent.Type().String()))
 
 					event = tlsFreeEvent
+				case mcpevents.EventTypeTCPData:
+					if len(record.RawSample) < int(unsafe.Sizeof(mcpevents.TCPDataEvent{})) {
+						logrus.Warn("Received incomplete TCP data event")
+						continue
+					}
+
+					tcpEvent := new(mcpevents.TCPDataEvent)
+					if err := binary.Read(reader, binary.LittleEndian, tcpEvent); err != nil {
+						logrus.WithError(err).Error("Failed to parse TCP data event")
+						continue
+					}
+
+					logrus.WithFields(logrus.Fields{
+						"pid":      tcpEvent.PID,
+						"comm":     tcpEvent.Comm(),
+						"src":      fmt.Sprintf("%s:%d", tcpEvent.SrcAddrStr(), tcpEvent.SrcPort),
+						"dst":      fmt.Sprintf("%s:%d", tcpEvent.DstAddrStr(), tcpEvent.DstPortNum()),
+						"size":     tcpEvent.Size,
+						"buf_size": tcpEvent.BufSize,
+					}).Trace(fmt.Sprintf("event#%s", tcpEvent.Type().String()))
+
+					event = tcpEvent
+				case mcpevents.EventTypeSocketAddr:
+					if len(record.RawSample) < int(unsafe.Sizeof(mcpevents.SocketAddrEvent{})) {
+						logrus.Warn("Received incomplete socket address event")
+						continue
+					}
+
+					socketAddrEvent := new(mcpevents.SocketAddrEvent)
+					if err := binary.Read(reader, binary.LittleEndian, socketAddrEvent); err != nil {
+						logrus.WithError(err).Error("Failed to parse socket address event")
+						continue
+					}
+
+					logrus.WithFields(logrus.Fields{
+						"pid":     socketAddrEvent.PID,
+						"comm":    socketAddrEvent.Comm(),
+						"ssl_ctx": socketAddrEvent.SSLContext,
+						"local":   fmt.Sprintf("%s:%d", socketAddrEvent.LocalAddrStr(), socketAddrEvent.LocalPort),
+						"remote":  fmt.Sprintf("%s:%d", socketAddrEvent.RemoteAddrStr(), socketAddrEvent.RemotePort),
+					}).Trace(fmt.Sprintf("event#%s", socketAddrEvent.Type().String()))
+
+					event = socketAddrEvent
+				case mcpevents.EventTypeTlsHandshake:
+					if len(record.RawSample) < int(unsafe.Sizeof(mcpevents.TlsHandshakeEvent{})) {
+						logrus.Warn("Received incomplete TLS handshake event")
+						continue
+					}
+
+					handshakeEvent := new(mcpevents.TlsHandshakeEvent)
+					if err := binary.Read(reader, binary.LittleEndian, handshakeEvent); err != nil {
+						logrus.WithError(err).Error("Failed to parse TLS handshake event")
+						continue
+					}
+
+					logrus.WithFields(logrus.Fields{
+						"pid":     handshakeEvent.PID,
+						"comm":    handshakeEvent.Comm(),
+						"ssl_ctx": handshakeEvent.SSLContext,
+						"value":   handshakeEvent.Value(),
+					}).Trace(fmt.Sprintf("event#%s", handshakeEvent.Type().String()))
+
+					event = handshakeEvent
 				default:
 					logrus.WithField("type", eventType).Warn("Unknown event type")
 					continue
@@ -295,6 +391,12 @@ func (l *Loader) Start(ctx context.Context) error {
 	return nil
 }
 
+// ReadErrors returns the number of ring buffer Read() failures observed so
+// far (excluding the reader being closed on shutdown).
+func (l *Loader) ReadErrors() uint64 {
+	return atomic.LoadUint64(&l.readErrors)
+}
+
 // Close cleans up resources
 func (l *Loader) Close() error {
 	var errs []error
@@ -417,6 +519,116 @@ func (l *Loader) AttachSSLProbes(libraryPath string) error {
 	}
 	l.links = append(l.links, sslHandshakeExitLink)
 
+	// Attach SSL_get_version entry uprobe
+	sslGetVersionEntryLink, err := ex.Uprobe("SSL_get_version", l.objs.SslGetVersionEntry, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach SSL_get_version entry uprobe: %w", err)
+	}
+	l.links = append(l.links, sslGetVersionEntryLink)
+
+	// Attach SSL_get_version exit uretprobe
+	sslGetVersionExitLink, err := ex.Uretprobe("SSL_get_version", l.objs.SslGetVersionExit, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach SSL_get_version exit uretprobe: %w", err)
+	}
+	l.links = append(l.links, sslGetVersionExitLink)
+
+	// Attach SSL_CIPHER_get_name exit uretprobe. Only the return value
+	// (the cipher name string) is needed, so there's no entry probe.
+	sslCipherGetNameExitLink, err := ex.Uretprobe("SSL_CIPHER_get_name", l.objs.SslCipherGetNameExit, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach SSL_CIPHER_get_name exit uretprobe: %w", err)
+	}
+	l.links = append(l.links, sslCipherGetNameExitLink)
+
+	// Attach SSL_get_fd entry uprobe
+	sslGetFdEntryLink, err := ex.Uprobe("SSL_get_fd", l.objs.SslGetFdEntry, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach SSL_get_fd entry uprobe: %w", err)
+	}
+	l.links = append(l.links, sslGetFdEntryLink)
+
+	// Attach SSL_get_fd exit uretprobe
+	sslGetFdExitLink, err := ex.Uretprobe("SSL_get_fd", l.objs.SslGetFdExit, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach SSL_get_fd exit uretprobe: %w", err)
+	}
+	l.links = append(l.links, sslGetFdExitLink)
+
+	return nil
+}
+
+// AttachGnuTLSProbes attaches GnuTLS record send/recv and session lifecycle
+// probes to a specific library. GnuTLS exposes different symbol names and a
+// different session type than OpenSSL, so it needs its own attach sequence
+// rather than reusing AttachSSLProbes.
+func (l *Loader) AttachGnuTLSProbes(libraryPath string) error {
+	if l.objs == nil {
+		return fmt.Errorf("loader not loaded")
+	}
+
+	// Open the executable/library
+	ex, err := link.OpenExecutable(libraryPath)
+	if err != nil {
+		return fmt.Errorf("failed to open executable %s: %w", libraryPath, err)
+	}
+
+	// Attach gnutls_record_recv entry uprobe
+	recvEntryLink, err := ex.Uprobe("gnutls_record_recv", l.objs.GnutlsRecordRecvEntry, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach gnutls_record_recv entry uprobe: %w", err)
+	}
+	l.links = append(l.links, recvEntryLink)
+
+	// Attach gnutls_record_recv exit uretprobe
+	recvExitLink, err := ex.Uretprobe("gnutls_record_recv", l.objs.GnutlsRecordRecvExit, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach gnutls_record_recv exit uretprobe: %w", err)
+	}
+	l.links = append(l.links, recvExitLink)
+
+	// Attach gnutls_record_send uprobe
+	sendLink, err := ex.Uprobe("gnutls_record_send", l.objs.GnutlsRecordSendEntry, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach gnutls_record_send uprobe: %w", err)
+	}
+	l.links = append(l.links, sendLink)
+
+	// Attach gnutls_init entry uprobe for session creation
+	initEntryLink, err := ex.Uprobe("gnutls_init", l.objs.GnutlsInitEntry, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach gnutls_init entry uprobe: %w", err)
+	}
+	l.links = append(l.links, initEntryLink)
+
+	// Attach gnutls_init exit uretprobe for session creation
+	initExitLink, err := ex.Uretprobe("gnutls_init", l.objs.GnutlsInitExit, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach gnutls_init exit uretprobe: %w", err)
+	}
+	l.links = append(l.links, initExitLink)
+
+	// Attach gnutls_deinit uprobe for session destruction
+	deinitLink, err := ex.Uprobe("gnutls_deinit", l.objs.GnutlsDeinitEntry, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach gnutls_deinit uprobe: %w", err)
+	}
+	l.links = append(l.links, deinitLink)
+
+	// Attach gnutls_handshake entry uprobe
+	handshakeEntryLink, err := ex.Uprobe("gnutls_handshake", l.objs.GnutlsHandshakeEntry, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach gnutls_handshake entry uprobe: %w", err)
+	}
+	l.links = append(l.links, handshakeEntryLink)
+
+	// Attach gnutls_handshake exit uretprobe
+	handshakeExitLink, err := ex.Uretprobe("gnutls_handshake", l.objs.GnutlsHandshakeExit, nil)
+	if err != nil {
+		return fmt.Errorf("failed to attach gnutls_handshake exit uretprobe: %w", err)
+	}
+	l.links = append(l.links, handshakeExitLink)
+
 	return nil
 }
 
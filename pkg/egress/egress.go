@@ -0,0 +1,98 @@
+// Package egress flags MCP server processes that make outbound HTTP/TLS
+// requests beyond their stdio conversation with their client, a pattern
+// consistent with a tool quietly calling home or exfiltrating data.
+package egress
+
+import (
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+var (
+	serverPIDCacheSize = 4096
+	serverPIDCacheTTL  = 10 * time.Minute
+)
+
+// Detector infers which PIDs are acting as MCP servers from the stdio
+// conversations it observes, then reports any outbound HTTP/TLS request
+// made by one of those PIDs.
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+// - EventTypeHttpRequest
+//
+// Emits the following events:
+// - EventTypeUnexpectedEgress
+type Detector struct {
+	// serverPIDs maps a PID inferred to be an MCP server to the process
+	// name it was last seen under. Thread-safe.
+	serverPIDs *expirable.LRU[uint32, string]
+
+	eventBus bus.EventBus
+}
+
+// New creates a new Detector.
+func New(eventBus bus.EventBus) (*Detector, error) {
+	d := &Detector{
+		serverPIDs: expirable.NewLRU[uint32, string](serverPIDCacheSize, nil, serverPIDCacheTTL),
+		eventBus:   eventBus,
+	}
+
+	if err := d.eventBus.Subscribe(event.EventTypeMCPMessage, d.handleMCPMessage); err != nil {
+		return nil, err
+	}
+	if err := d.eventBus.Subscribe(event.EventTypeHttpRequest, d.handleHTTPRequest); err != nil {
+		d.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// handleMCPMessage records the server side of a stdio MCP conversation: the
+// process that reads a request (ToPID) or writes a response (FromPID) is
+// the server.
+func (d *Detector) handleMCPMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok || msg.StdioTransport == nil {
+		return
+	}
+
+	switch msg.MessageType {
+	case event.JSONRPCMessageTypeRequest:
+		d.serverPIDs.Add(msg.StdioTransport.ToPID, msg.StdioTransport.ToComm)
+	case event.JSONRPCMessageTypeResponse:
+		d.serverPIDs.Add(msg.StdioTransport.FromPID, msg.StdioTransport.FromComm)
+	}
+}
+
+// handleHTTPRequest reports any outbound HTTP/TLS request made by a PID
+// already inferred to be an MCP server.
+func (d *Detector) handleHTTPRequest(e event.Event) {
+	req, ok := e.(*event.HttpRequestEvent)
+	if !ok {
+		return
+	}
+
+	comm, found := d.serverPIDs.Get(req.PID)
+	if !found {
+		return
+	}
+
+	d.eventBus.Publish(&event.UnexpectedEgressEvent{
+		Timestamp:  time.Now(),
+		ServerPID:  req.PID,
+		ServerComm: comm,
+		Host:       req.Host,
+		Method:     req.Method,
+		Path:       req.Path,
+	})
+}
+
+// Close releases the detector's event bus subscriptions.
+func (d *Detector) Close() {
+	d.eventBus.Unsubscribe(event.EventTypeMCPMessage, d.handleMCPMessage)
+	d.eventBus.Unsubscribe(event.EventTypeHttpRequest, d.handleHTTPRequest)
+}
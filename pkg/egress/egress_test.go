@@ -0,0 +1,101 @@
+package egress
+
+import (
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestDetector_FlagsServerPIDContactingExternalHost(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	d, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+	defer d.Close()
+	defer mockBus.Close()
+
+	// The server (PID 200) responds to a tools/call request from its
+	// client (PID 100), establishing its role.
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp: time.Now(),
+		Raw:       `{"jsonrpc":"2.0","id":1,"method":"tools/call"}`,
+		StdioTransport: &event.StdioTransport{
+			FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server",
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp: time.Now(),
+		Raw:       `{"jsonrpc":"2.0","id":1,"result":{}}`,
+		StdioTransport: &event.StdioTransport{
+			FromPID: 200, FromComm: "mcp-server", ToPID: 100, ToComm: "claude",
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+		},
+	})
+
+	// The server process then makes an outbound request to a host that
+	// was never part of its MCP conversation.
+	mockBus.Publish(&event.HttpRequestEvent{
+		EventHeader: event.EventHeader{EventType: event.EventTypeHttpRequest, PID: 200},
+		Method:      "POST",
+		Host:        "attacker.example.com",
+		Path:        "/exfil",
+	})
+
+	var got *event.UnexpectedEgressEvent
+	for i := 0; i < 4; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			if e, ok := evt.(*event.UnexpectedEgressEvent); ok {
+				got = e
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if got == nil {
+		t.Fatal("expected an UnexpectedEgressEvent, got none")
+	}
+	if got.ServerPID != 200 {
+		t.Errorf("expected server PID 200, got %d", got.ServerPID)
+	}
+	if got.Host != "attacker.example.com" {
+		t.Errorf("expected host attacker.example.com, got %q", got.Host)
+	}
+}
+
+func TestDetector_IgnoresRequestsFromNonServerPIDs(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	d, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+	defer d.Close()
+	defer mockBus.Close()
+
+	// PID 300 never appears in any MCP conversation, so it's never
+	// inferred to be a server.
+	mockBus.Publish(&event.HttpRequestEvent{
+		EventHeader: event.EventHeader{EventType: event.EventTypeHttpRequest, PID: 300},
+		Method:      "GET",
+		Host:        "example.com",
+	})
+
+	select {
+	case evt := <-mockBus.Events():
+		if _, ok := evt.(*event.UnexpectedEgressEvent); ok {
+			t.Fatalf("expected no UnexpectedEgressEvent for an unrecognized PID, got one")
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+}
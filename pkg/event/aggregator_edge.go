@@ -0,0 +1,47 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AggregatorEdgeEvent reports one call relayed by an MCP
+// gateway/aggregator process (e.g. mcp-hub) that fans a single client
+// request out to one or more backend MCP servers, typically rewriting tool
+// names with a backend-specific prefix along the way. It links the
+// client->aggregator hop with the aggregator->backend hop it triggered, so
+// the two can be read as one logical flow instead of two unrelated calls.
+type AggregatorEdgeEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Method    string    `json:"method"`
+
+	ClientPID  uint32 `json:"client_pid"`
+	ClientComm string `json:"client_comm"`
+
+	AggregatorPID  uint32 `json:"aggregator_pid"`
+	AggregatorComm string `json:"aggregator_comm"`
+
+	BackendPID  uint32 `json:"backend_pid"`
+	BackendComm string `json:"backend_comm"`
+
+	// FanOutSize is the number of distinct backends seen handling this
+	// same client request so far, including this one. 1 means no fan-out
+	// has been observed yet; 2+ means this is part of a fan-out.
+	FanOutSize int `json:"fan_out_size"`
+}
+
+func (e *AggregatorEdgeEvent) Type() EventType { return EventTypeAggregatorEdge }
+
+func (e *AggregatorEdgeEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"method":          e.Method,
+		"client_pid":      e.ClientPID,
+		"client_comm":     e.ClientComm,
+		"aggregator_pid":  e.AggregatorPID,
+		"aggregator_comm": e.AggregatorComm,
+		"backend_pid":     e.BackendPID,
+		"backend_comm":    e.BackendComm,
+		"fan_out_size":    e.FanOutSize,
+	}
+}
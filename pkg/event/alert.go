@@ -0,0 +1,38 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AlertEvent is published when the error-response rate for a method (or
+// overall, when Method is empty) crosses a configured threshold over a
+// rolling time window, surfacing a degrading MCP server in real time
+// instead of only at shutdown.
+type AlertEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Method is the JSON-RPC method the rate applies to, or "" for the
+	// rate across all methods combined.
+	Method string `json:"method"`
+
+	ErrorRate float64       `json:"error_rate"`
+	Threshold float64       `json:"threshold"`
+	Window    time.Duration `json:"window"`
+
+	Errors int `json:"errors"`
+	Total  int `json:"total"`
+}
+
+func (e *AlertEvent) Type() EventType { return EventTypeAlert }
+
+func (e *AlertEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"method":     e.Method,
+		"error_rate": e.ErrorRate,
+		"threshold":  e.Threshold,
+		"window":     e.Window,
+		"errors":     e.Errors,
+		"total":      e.Total,
+	}
+}
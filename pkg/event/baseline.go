@@ -0,0 +1,61 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// BaselineAnomalyKind identifies which aspect of an MCP message wasn't
+// present in the recorded baseline profile.
+type BaselineAnomalyKind string
+
+const (
+	BaselineAnomalyMethod BaselineAnomalyKind = "method"
+	BaselineAnomalyTool   BaselineAnomalyKind = "tool"
+	BaselineAnomalyHost   BaselineAnomalyKind = "host"
+)
+
+// BaselineAnomalyEvent is published the first time a live MCP message uses
+// a method, tool, or host that wasn't observed in the recorded --baseline
+// profile, flagging possible drift or compromise of the monitored server.
+type BaselineAnomalyEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+
+	// Kind and Value identify what deviated, e.g. Kind "tool", Value
+	// "delete_all_files".
+	Kind  BaselineAnomalyKind `json:"kind"`
+	Value string              `json:"value"`
+
+	// Method is the JSON-RPC method of the message that triggered the
+	// anomaly, for context.
+	Method string `json:"method"`
+
+	// Process chain the message arrived on, identical in shape to
+	// MCPEvent's transport fields.
+	TransportType   TransportType `json:"transport_type"`
+	*StdioTransport `json:"stdio_transport,omitempty"`
+	*HttpTransport  `json:"http_transport,omitempty"`
+}
+
+func (e *BaselineAnomalyEvent) Type() EventType { return EventTypeBaselineAnomaly }
+
+func (e *BaselineAnomalyEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"kind":   e.Kind,
+		"value":  e.Value,
+		"method": e.Method,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["pid"] = e.HttpTransport.PID
+		fields["comm"] = e.HttpTransport.Comm
+		fields["host"] = e.HttpTransport.Host
+	}
+	return fields
+}
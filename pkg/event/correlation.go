@@ -0,0 +1,91 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ToolCallCorrelationEvent links an LLM tool_use invocation (the model
+// deciding to call a tool) with the MCP tools/call request that the
+// MCP-enabled app subsequently issued for it, showing the full chain:
+// prompt -> model decides to call tool X -> app invokes MCP tools/call X.
+type ToolCallCorrelationEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	ToolName  string    `json:"tool_name"`
+
+	// LLM side (from the tool_use invocation)
+	LLMSessionID uint64 `json:"llm_session_id"`
+	LLMToolID    string `json:"llm_tool_id,omitempty"`
+	LLMInput     string `json:"llm_input,omitempty"`
+
+	// MCP side (from the tools/call request)
+	MCPRequestID interface{}            `json:"mcp_request_id,omitempty"`
+	MCPParams    map[string]interface{} `json:"mcp_params,omitempty"`
+
+	// Time elapsed between the model deciding to call the tool and the
+	// app issuing the corresponding MCP request.
+	Latency time.Duration `json:"latency"`
+}
+
+func (e *ToolCallCorrelationEvent) Type() EventType { return EventTypeToolCallCorrelation }
+
+func (e *ToolCallCorrelationEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"tool_name":      e.ToolName,
+		"llm_session_id": e.LLMSessionID,
+		"llm_tool_id":    e.LLMToolID,
+		"mcp_request_id": e.MCPRequestID,
+		"latency":        e.Latency,
+	}
+}
+
+// BridgeCorrelationEvent links the two sightings of the same MCP message
+// crossing a stdio-to-HTTP bridge (e.g. mcp-proxy, supergateway), which
+// relay a message unchanged from one transport to the other. It identifies
+// the bridging process so the two legs can be read as a single logical
+// flow instead of two unrelated messages.
+type BridgeCorrelationEvent struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Method    string      `json:"method"`
+	ID        interface{} `json:"id,omitempty"`
+
+	// The two transport legs the message was observed on.
+	StdioTransport *StdioTransport `json:"stdio_transport"`
+	HttpTransport  *HttpTransport  `json:"http_transport"`
+
+	// The process identified as doing the bridging, i.e. the HTTP-side
+	// endpoint of the flow.
+	BridgePID  uint32 `json:"bridge_pid"`
+	BridgeComm string `json:"bridge_comm"`
+
+	// Time elapsed between the message first being observed on one
+	// transport and being observed on the other. Derived from each leg's
+	// parse-time Timestamp (see MCPEvent.Timestamp), so it reflects when
+	// mcpspy saw each hop rather than a true kernel-level measurement;
+	// Detector takes the absolute difference so a slower-to-parse stdio
+	// leg arriving "after" its HTTP counterpart doesn't read as negative.
+	Latency time.Duration `json:"latency"`
+}
+
+func (e *BridgeCorrelationEvent) Type() EventType { return EventTypeBridgeCorrelation }
+
+func (e *BridgeCorrelationEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"method":      e.Method,
+		"id":          e.ID,
+		"bridge_pid":  e.BridgePID,
+		"bridge_comm": e.BridgeComm,
+		"latency":     e.Latency,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["host"] = e.HttpTransport.Host
+	}
+	return fields
+}
@@ -0,0 +1,51 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DuplicateMessageEvent is published whenever a message hash already seen
+// on an earlier hop is folded instead of being emitted as its own MCPEvent
+// (see mcp.Config.ShowDupes to emit every hop instead), so metrics and
+// JSONL subscribers can measure how many hops a Docker proxy or similar
+// relay is collapsing instead of the fold happening silently inside the
+// parser.
+type DuplicateMessageEvent struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Method    string      `json:"method"`
+	ID        interface{} `json:"id,omitempty"`
+
+	// Process chain the duplicate hop arrived on, identical in shape to
+	// MCPEvent's transport fields.
+	TransportType   TransportType `json:"transport_type"`
+	*StdioTransport `json:"stdio_transport,omitempty"`
+	*HttpTransport  `json:"http_transport,omitempty"`
+	*TCPTransport   `json:"tcp_transport,omitempty"`
+}
+
+func (e *DuplicateMessageEvent) Type() EventType { return EventTypeDuplicateMessage }
+
+func (e *DuplicateMessageEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"method": e.Method,
+		"id":     e.ID,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["pid"] = e.HttpTransport.PID
+		fields["comm"] = e.HttpTransport.Comm
+		fields["host"] = e.HttpTransport.Host
+	}
+	if e.TCPTransport != nil {
+		fields["pid"] = e.TCPTransport.PID
+		fields["comm"] = e.TCPTransport.Comm
+	}
+	return fields
+}
@@ -0,0 +1,38 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// UnexpectedEgressEvent is published when a PID identified as an MCP
+// server's process (via its role in a StdioTransport conversation) is also
+// seen making an outbound HTTP/TLS request, reported separately via
+// HttpRequestEvent. A server reaching out beyond its client conversation is
+// worth flagging: it could be legitimate (an API-backed tool), or it could
+// be exfiltration/call-home behavior.
+type UnexpectedEgressEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+
+	ServerPID  uint32 `json:"server_pid"`
+	ServerComm string `json:"server_comm"`
+
+	// Host is the destination of the outbound request, as captured from
+	// the HttpRequestEvent.
+	Host   string `json:"host"`
+	Method string `json:"method"`
+	Path   string `json:"path,omitempty"`
+}
+
+func (e *UnexpectedEgressEvent) Type() EventType { return EventTypeUnexpectedEgress }
+
+func (e *UnexpectedEgressEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"server_pid":  e.ServerPID,
+		"server_comm": e.ServerComm,
+		"host":        e.Host,
+		"method":      e.Method,
+		"path":        e.Path,
+	}
+}
@@ -1,6 +1,10 @@
 package event
 
 import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
 	"github.com/alex-ilgayev/mcpspy/pkg/encoder"
 	"github.com/sirupsen/logrus"
 )
@@ -14,6 +18,15 @@ const (
 	EventTypeTlsPayloadSend EventType = 4
 	EventTypeTlsPayloadRecv EventType = 5
 	EventTypeTlsFree        EventType = 6
+	// A negotiated TLS version or cipher suite name was observed, via
+	// SSL_get_version/SSL_CIPHER_get_name calls made by the monitored process.
+	EventTypeTlsHandshake EventType = 7
+	// Bare JSON-RPC captured directly off a TCP socket via tcp_sendmsg,
+	// for MCP deployments that skip HTTP framing entirely.
+	EventTypeTCPData EventType = 8
+	// The local/remote address of a TLS connection, resolved from the fd
+	// returned by the monitored process's own SSL_get_fd call.
+	EventTypeSocketAddr EventType = 9
 
 	// Events that are not originated from eBPF
 
@@ -36,6 +49,63 @@ const (
 	EventTypeLLMMessage EventType = 107
 	// Detected a tool usage event (invocation or result)
 	EventTypeToolUsage EventType = 108
+	// An LLM tool_use invocation was linked to the MCP tools/call request it triggered
+	EventTypeToolCallCorrelation EventType = 109
+	// A request was evicted from the correlation cache without ever receiving a response
+	EventTypeRequestTimeout EventType = 110
+	// The same MCP message was observed on both a stdio hop and an HTTP hop, identifying a bridge process
+	EventTypeBridgeCorrelation EventType = 111
+	// A tools/call result violated the MCP content schema (e.g. a content
+	// block missing "type"), independent of JSON-RPC/method-level validation
+	EventTypeToolResultSchemaWarning EventType = 112
+	// The error-response rate for a method (or overall) crossed a
+	// configured threshold over a rolling window
+	EventTypeAlert EventType = 113
+	// A tools/call request was paired with its response, summarizing the
+	// tool name, how long it took, and whether it errored
+	EventTypeToolCallResult EventType = 114
+	// A tools/call request's serialized arguments exceeded the configured
+	// size threshold
+	EventTypeLargeToolArgs EventType = 115
+	// A stdio or HTTP payload could not be parsed as JSON-RPC
+	EventTypeParseError EventType = 116
+	// A filesystem session's reassembly buffer exceeded its size cap before
+	// a complete JSON message could be decoded, and the partial data was
+	// discarded
+	EventTypeFSBufferDropped EventType = 117
+	// Complete JSON message aggregated from raw TCP data events
+	EventTypeTCPAggregated EventType = 118
+	// A complete application message was reassembled from one or more
+	// RFC6455 WebSocket frames on an upgraded HTTP connection
+	EventTypeHttpWebSocket EventType = 119
+	// A pipe/socket inode belonging to an established MCP channel was read
+	// or written by a PID that isn't one of the channel's known endpoints
+	EventTypeFSUnexpectedPID EventType = 120
+	// A call relayed by an MCP gateway/aggregator to one of its backends
+	EventTypeAggregatorEdge EventType = 121
+	// A request, response, or notification was observed out of order
+	// relative to the initialize/notifications-initialized handshake
+	EventTypeProtocolViolation EventType = 122
+	// gRPC traffic (application/grpc content-type over HTTP/2) was
+	// observed on a connection mcpspy otherwise can't parse
+	EventTypeGRPCDetected EventType = 123
+	// A live MCP message used a method, tool, or host that wasn't present
+	// in a recorded --baseline profile
+	EventTypeBaselineAnomaly EventType = 124
+	// A PID identified as an MCP server (via stdio role inference) made an
+	// outbound HTTP/TLS request to a host outside its MCP conversation
+	EventTypeUnexpectedEgress EventType = 125
+	// An MCPEvent matched a user-defined rule (see pkg/rules) whose action
+	// calls for a highlighted alert rather than a plain log line
+	EventTypeRuleMatch EventType = 126
+
+	// A session's initialize handshake negotiated mismatched or deprecated
+	// MCP protocol versions between client and server (see pkg/handshake)
+	EventTypeProtocolVersionMismatch EventType = 127
+	// A message was dropped as a duplicate of one already seen on an
+	// earlier hop (e.g. relayed unchanged through a Docker proxy) instead
+	// of being emitted as its own MCPEvent
+	EventTypeDuplicateMessage EventType = 128
 )
 
 type HttpVersion uint8
@@ -71,6 +141,12 @@ func (e EventType) String() string {
 		return "tls_recv"
 	case EventTypeTlsFree:
 		return "tls_free"
+	case EventTypeTlsHandshake:
+		return "tls_handshake"
+	case EventTypeTCPData:
+		return "tcp_data"
+	case EventTypeSocketAddr:
+		return "socket_addr"
 	case EventTypeHttpRequest:
 		return "http_request"
 	case EventTypeHttpResponse:
@@ -89,6 +165,46 @@ func (e EventType) String() string {
 		return "llm_message"
 	case EventTypeToolUsage:
 		return "tool_usage"
+	case EventTypeToolCallCorrelation:
+		return "tool_call_correlation"
+	case EventTypeRequestTimeout:
+		return "request_timeout"
+	case EventTypeBridgeCorrelation:
+		return "bridge_correlation"
+	case EventTypeToolResultSchemaWarning:
+		return "tool_result_schema_warning"
+	case EventTypeAlert:
+		return "alert"
+	case EventTypeToolCallResult:
+		return "tool_call_result"
+	case EventTypeLargeToolArgs:
+		return "large_tool_args"
+	case EventTypeParseError:
+		return "parse_error"
+	case EventTypeFSBufferDropped:
+		return "fs_buffer_dropped"
+	case EventTypeTCPAggregated:
+		return "tcp_aggregated"
+	case EventTypeHttpWebSocket:
+		return "http_websocket"
+	case EventTypeFSUnexpectedPID:
+		return "fs_unexpected_pid"
+	case EventTypeAggregatorEdge:
+		return "aggregator_edge"
+	case EventTypeProtocolViolation:
+		return "protocol_violation"
+	case EventTypeGRPCDetected:
+		return "grpc_detected"
+	case EventTypeBaselineAnomaly:
+		return "baseline_anomaly"
+	case EventTypeUnexpectedEgress:
+		return "unexpected_egress"
+	case EventTypeRuleMatch:
+		return "rule_match"
+	case EventTypeProtocolVersionMismatch:
+		return "protocol_version_mismatch"
+	case EventTypeDuplicateMessage:
+		return "duplicate_message"
 	default:
 		return "unknown"
 	}
@@ -102,10 +218,67 @@ type Event interface {
 	LogFields() logrus.Fields
 }
 
+// HookID identifies the specific eBPF attachment (kprobe/uprobe) an event
+// originated from. Several hooks can produce the same EventType (e.g.
+// SSL_write and SSL_write_ex both emit EventTypeTlsPayloadSend), so this is
+// tracked separately for per-hook capture statistics.
+type HookID uint8
+
+const (
+	HookUnknown          HookID = 0
+	HookVFSRead          HookID = 1
+	HookVFSWrite         HookID = 2
+	HookLibraryIter      HookID = 3
+	HookSecurityFileOpen HookID = 4
+	HookSSLRead          HookID = 5
+	HookSSLReadEx        HookID = 6
+	HookSSLWrite         HookID = 7
+	HookSSLWriteEx       HookID = 8
+	HookSSLFree          HookID = 9
+	HookSSLGetVersion    HookID = 10
+	HookSSLCipherGetName HookID = 11
+	HookTCPSendmsg       HookID = 12
+	HookSSLGetFd         HookID = 18
+)
+
+func (h HookID) String() string {
+	switch h {
+	case HookVFSRead:
+		return "vfs_read"
+	case HookVFSWrite:
+		return "vfs_write"
+	case HookLibraryIter:
+		return "library_iter"
+	case HookSecurityFileOpen:
+		return "security_file_open"
+	case HookSSLRead:
+		return "ssl_read"
+	case HookSSLReadEx:
+		return "ssl_read_ex"
+	case HookSSLWrite:
+		return "ssl_write"
+	case HookSSLWriteEx:
+		return "ssl_write_ex"
+	case HookSSLFree:
+		return "ssl_free"
+	case HookSSLGetVersion:
+		return "ssl_get_version"
+	case HookSSLCipherGetName:
+		return "ssl_cipher_get_name"
+	case HookTCPSendmsg:
+		return "tcp_sendmsg"
+	case HookSSLGetFd:
+		return "ssl_get_fd"
+	default:
+		return "unknown"
+	}
+}
+
 // EventHeader represents the common header for all events
 type EventHeader struct {
 	EventType EventType
-	_         [3]uint8 // padding
+	HookID    HookID
+	_         [2]uint8 // padding
 	PID       uint32
 	CommBytes [16]uint8
 }
@@ -118,13 +291,14 @@ func (h *EventHeader) Comm() string {
 type FSEventBase struct {
 	EventHeader
 
-	Inode    uint32    // Inode number for correlation
-	FromPID  uint32    // Sender (writer) PID
-	FromComm [16]uint8 // Sender comm
-	ToPID    uint32    // Receiver (reader) PID
-	ToComm   [16]uint8 // Receiver comm
-	_        [4]uint8  // Explicit padding for 8-byte alignment of FilePtr
-	FilePtr  uint64    // File pointer (struct file*) for session tracking
+	Inode        uint32    // Inode number for correlation
+	FromPID      uint32    // Sender (writer) PID
+	FromComm     [16]uint8 // Sender comm
+	ToPID        uint32    // Receiver (reader) PID
+	ToComm       [16]uint8 // Receiver comm
+	IsUnixSocket uint8     // 1 if the fd is a Unix domain socket, 0 if a pipe
+	_            [3]uint8  // Explicit padding for 8-byte alignment of FilePtr
+	FilePtr      uint64    // File pointer (struct file*) for session tracking
 }
 
 func (e *FSEventBase) FromCommStr() string {
@@ -135,6 +309,12 @@ func (e *FSEventBase) ToCommStr() string {
 	return encoder.BytesToStr(e.ToComm[:])
 }
 
+// IsUnixSocketFd reports whether this event came from a Unix domain socket
+// fd rather than a pipe.
+func (e *FSEventBase) IsUnixSocketFd() bool {
+	return e.IsUnixSocket != 0
+}
+
 // FSDataEvent represents raw r/w payload events from eBPF
 type FSDataEvent struct {
 	FSEventBase
@@ -157,6 +337,107 @@ func (e *FSDataEvent) LogFields() logrus.Fields {
 	}
 }
 
+// TCPDataEvent represents a raw payload captured directly off a TCP socket
+// via tcp_sendmsg, for MCP deployments that send newline-delimited
+// JSON-RPC without HTTP framing. Only the common single-buffer send path is
+// captured; see HookTCPSendmsg.
+//
+// SrcAddr/DstAddr/DstPort are kept as raw network-byte-order bytes rather
+// than Go integers, matching how the kernel's struct sock_common stores
+// them, so this struct can be populated directly by binary.Read without
+// mixing byte orders within a single field read.
+type TCPDataEvent struct {
+	EventHeader
+
+	SrcAddr [4]uint8 // Local IPv4 address
+	DstAddr [4]uint8 // Remote IPv4 address
+	SrcPort uint16   // Local port (host byte order, kernel skc_num)
+	DstPort [2]uint8 // Remote port, network byte order (kernel skc_dport)
+
+	Size    uint32            // Actual data size
+	BufSize uint32            // Size of data in buf (may be truncated)
+	Buf     [128 * 1024]uint8 // Data buffer
+}
+
+func (e *TCPDataEvent) Type() EventType { return e.EventType }
+func (e *TCPDataEvent) Buffer() []byte {
+	return e.Buf[:e.BufSize]
+}
+
+// SrcAddrStr formats SrcAddr as a dotted-quad IPv4 address.
+func (e *TCPDataEvent) SrcAddrStr() string {
+	return net.IP(e.SrcAddr[:]).String()
+}
+
+// DstAddrStr formats DstAddr as a dotted-quad IPv4 address.
+func (e *TCPDataEvent) DstAddrStr() string {
+	return net.IP(e.DstAddr[:]).String()
+}
+
+// DstPortNum decodes the network-byte-order DstPort into a host integer.
+func (e *TCPDataEvent) DstPortNum() uint16 {
+	return binary.BigEndian.Uint16(e.DstPort[:])
+}
+
+func (e *TCPDataEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"pid":      e.PID,
+		"comm":     e.Comm(),
+		"src":      fmt.Sprintf("%s:%d", e.SrcAddrStr(), e.SrcPort),
+		"dst":      fmt.Sprintf("%s:%d", e.DstAddrStr(), e.DstPortNum()),
+		"size":     e.Size,
+		"buf_size": e.BufSize,
+	}
+}
+
+// SocketAddrEvent carries the local/remote address of a TLS connection,
+// resolved by the eBPF program from the fd the monitored process's own
+// SSL_get_fd call returned. LocalAddr/RemoteAddr hold either a 4-byte IPv4
+// or a 16-byte IPv6 address depending on Family; SSLContext attributes this
+// to the same SSL session TlsPayloadEvent/TlsHandshakeEvent use.
+type SocketAddrEvent struct {
+	EventHeader
+
+	SSLContext uint64
+	Family     uint16
+	LocalAddr  [16]uint8
+	LocalPort  uint16
+	RemoteAddr [16]uint8
+	RemotePort uint16
+}
+
+func (e *SocketAddrEvent) Type() EventType { return e.EventType }
+
+// LocalAddrStr formats LocalAddr as an IPv4 or IPv6 literal depending on Family.
+func (e *SocketAddrEvent) LocalAddrStr() string {
+	return e.formatAddr(e.LocalAddr)
+}
+
+// RemoteAddrStr formats RemoteAddr as an IPv4 or IPv6 literal depending on Family.
+func (e *SocketAddrEvent) RemoteAddrStr() string {
+	return e.formatAddr(e.RemoteAddr)
+}
+
+// socketAddrFamilyINet is the kernel's AF_INET, matching bpf/types.h.
+const socketAddrFamilyINet = 2
+
+func (e *SocketAddrEvent) formatAddr(addr [16]uint8) string {
+	if e.Family == socketAddrFamilyINet {
+		return net.IP(addr[:4]).String()
+	}
+	return net.IP(addr[:]).String()
+}
+
+func (e *SocketAddrEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"pid":     e.PID,
+		"comm":    e.Comm(),
+		"ssl_ctx": e.SSLContext,
+		"local":   fmt.Sprintf("%s:%d", e.LocalAddrStr(), e.LocalPort),
+		"remote":  fmt.Sprintf("%s:%d", e.RemoteAddrStr(), e.RemotePort),
+	}
+}
+
 // FSAggregatedEvent represents a complete JSON message aggregated from
 // multiple raw FS events in userspace
 type FSAggregatedEvent struct {
@@ -185,8 +466,14 @@ func NewFSAggregatedEvent(
 	toPID uint32,
 	toComm [16]uint8,
 	filePtr uint64,
+	isUnixSocket bool,
 	payload []byte,
 ) *FSAggregatedEvent {
+	var isUnixSocketByte uint8
+	if isUnixSocket {
+		isUnixSocketByte = 1
+	}
+
 	return &FSAggregatedEvent{
 		FSEventBase: FSEventBase{
 			EventHeader: EventHeader{
@@ -194,13 +481,79 @@ func NewFSAggregatedEvent(
 				PID:       pid,
 				CommBytes: comm,
 			},
-			Inode:    inode,
-			FromPID:  fromPID,
-			FromComm: fromComm,
-			ToPID:    toPID,
-			ToComm:   toComm,
-			FilePtr:  filePtr,
+			Inode:        inode,
+			FromPID:      fromPID,
+			FromComm:     fromComm,
+			ToPID:        toPID,
+			ToComm:       toComm,
+			IsUnixSocket: isUnixSocketByte,
+			FilePtr:      filePtr,
+		},
+		Payload: payload,
+	}
+}
+
+// TCPAggregatedEvent represents a complete JSON message aggregated from
+// multiple raw TCPDataEvents in userspace, keyed by socket 4-tuple.
+type TCPAggregatedEvent struct {
+	EventHeader
+
+	SrcAddr [4]uint8
+	DstAddr [4]uint8
+	SrcPort uint16
+	DstPort [2]uint8
+
+	Payload []byte // Complete JSON message
+}
+
+func (e *TCPAggregatedEvent) Type() EventType { return e.EventType }
+
+// SrcAddrStr formats SrcAddr as a dotted-quad IPv4 address.
+func (e *TCPAggregatedEvent) SrcAddrStr() string {
+	return net.IP(e.SrcAddr[:]).String()
+}
+
+// DstAddrStr formats DstAddr as a dotted-quad IPv4 address.
+func (e *TCPAggregatedEvent) DstAddrStr() string {
+	return net.IP(e.DstAddr[:]).String()
+}
+
+// DstPortNum decodes the network-byte-order DstPort into a host integer.
+func (e *TCPAggregatedEvent) DstPortNum() uint16 {
+	return binary.BigEndian.Uint16(e.DstPort[:])
+}
+
+func (e *TCPAggregatedEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"pid":  e.PID,
+		"comm": e.Comm(),
+		"src":  fmt.Sprintf("%s:%d", e.SrcAddrStr(), e.SrcPort),
+		"dst":  fmt.Sprintf("%s:%d", e.DstAddrStr(), e.DstPortNum()),
+		"size": len(e.Payload),
+	}
+}
+
+// NewTCPAggregatedEvent creates a new TCPAggregatedEvent for
+// usermode-aggregated JSON.
+func NewTCPAggregatedEvent(
+	pid uint32,
+	comm [16]uint8,
+	srcAddr [4]uint8,
+	dstAddr [4]uint8,
+	srcPort uint16,
+	dstPort [2]uint8,
+	payload []byte,
+) *TCPAggregatedEvent {
+	return &TCPAggregatedEvent{
+		EventHeader: EventHeader{
+			EventType: EventTypeTCPAggregated,
+			PID:       pid,
+			CommBytes: comm,
 		},
+		SrcAddr: srcAddr,
+		DstAddr: dstAddr,
+		SrcPort: srcPort,
+		DstPort: dstPort,
 		Payload: payload,
 	}
 }
@@ -274,6 +627,31 @@ func (e *TlsFreeEvent) LogFields() logrus.Fields {
 	}
 }
 
+// TlsHandshakeEvent carries a TLS version or cipher suite name string,
+// observed from the monitored process's own SSL_get_version or
+// SSL_CIPHER_get_name calls. HookID distinguishes which. SSLContext is only
+// meaningful for HookSSLGetVersion; SSL_CIPHER_get_name only receives a
+// cipher pointer, not the SSL context, so its SSLContext is zero.
+type TlsHandshakeEvent struct {
+	EventHeader
+
+	SSLContext uint64
+	ValueBytes [64]uint8 // TLS version or cipher suite name, e.g. "TLSv1.3"
+}
+
+func (e *TlsHandshakeEvent) Type() EventType { return e.EventType }
+func (e *TlsHandshakeEvent) Value() string {
+	return encoder.BytesToStr(e.ValueBytes[:])
+}
+func (e *TlsHandshakeEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"pid":     e.PID,
+		"comm":    e.Comm(),
+		"ssl_ctx": e.SSLContext,
+		"value":   e.Value(),
+	}
+}
+
 // HttpRequestEvent is generated after aggregating TLS events for a request.
 // (not generated from eBPF program)
 type HttpRequestEvent struct {
@@ -286,17 +664,34 @@ type HttpRequestEvent struct {
 	Path           string
 	RequestHeaders map[string]string
 	RequestPayload []byte
+
+	// AuthScheme is the scheme from the request's Authorization header
+	// (e.g. "Bearer", "Basic"), or "" if the header was absent. The
+	// credential itself is never captured.
+	AuthScheme string
+	// HasCredentials reports whether an Authorization header was present.
+	HasCredentials bool
+
+	// RemoteAddr/LocalAddr are the "ip:port" endpoints of the underlying
+	// TLS connection, attributed by SSL context from a SocketAddrEvent.
+	// Empty for plaintext connections or if no SocketAddrEvent was seen
+	// for this SSLContext yet.
+	RemoteAddr string
+	LocalAddr  string
 }
 
 func (e *HttpRequestEvent) Type() EventType { return e.EventType }
 func (e *HttpRequestEvent) LogFields() logrus.Fields {
 	return logrus.Fields{
-		"pid":     e.PID,
-		"comm":    e.Comm(),
-		"ssl_ctx": e.SSLContext,
-		"method":  e.Method,
-		"host":    e.Host,
-		"path":    e.Path,
+		"pid":             e.PID,
+		"comm":            e.Comm(),
+		"ssl_ctx":         e.SSLContext,
+		"method":          e.Method,
+		"host":            e.Host,
+		"path":            e.Path,
+		"auth_scheme":     e.AuthScheme,
+		"has_credentials": e.HasCredentials,
+		"remote_addr":     e.RemoteAddr,
 	}
 }
 
@@ -313,6 +708,12 @@ type HttpResponseEvent struct {
 	Code            int
 	IsChunked       bool
 	ResponsePayload []byte
+	// Truncated is set when the response was finalized because the
+	// underlying connection closed before a clean terminator was observed
+	// (e.g. an SSE stream or chunked response cut short), rather than
+	// because the response was fully received. ResponsePayload holds
+	// whatever was captured up to that point.
+	Truncated bool
 }
 
 func (e *HttpResponseEvent) Type() EventType { return e.EventType }
@@ -326,6 +727,7 @@ func (e *HttpResponseEvent) LogFields() logrus.Fields {
 		"path":       e.Path,
 		"code":       e.Code,
 		"is_chunked": e.IsChunked,
+		"truncated":  e.Truncated,
 	}
 }
 
@@ -341,17 +743,77 @@ type SSEEvent struct {
 	SSEEventType string
 	// SSE data
 	Data []byte
+	// LastEventID is the event's "id:" field, if present. Clients echo this
+	// back as the Last-Event-ID header when reconnecting after a dropped SSE
+	// stream, so it identifies frames that may be replayed on resumption.
+	LastEventID string
 }
 
 func (e *SSEEvent) Type() EventType { return e.EventType }
 func (e *SSEEvent) LogFields() logrus.Fields {
 	return logrus.Fields{
-		"pid":       e.PID,
-		"comm":      e.Comm(),
-		"ssl_ctx":   e.SSLContext,
-		"method":    e.Method,
-		"host":      e.Host,
-		"path":      e.Path,
-		"sse_event": e.SSEEventType,
+		"pid":           e.PID,
+		"comm":          e.Comm(),
+		"ssl_ctx":       e.SSLContext,
+		"method":        e.Method,
+		"host":          e.Host,
+		"path":          e.Path,
+		"sse_event":     e.SSEEventType,
+		"last_event_id": e.LastEventID,
+	}
+}
+
+// WebSocketMessageEvent represents a complete application message
+// reassembled from one or more RFC6455 frames on a connection that upgraded
+// from HTTP, carrying the handshake's request context for correlation.
+// Will create EventTypeHttpWebSocket.
+type WebSocketMessageEvent struct {
+	EventHeader
+	HttpRequestEvent
+
+	SSLContext uint64 // SSL context pointer (session identifier)
+
+	// IsRequest is true for a client->server message, false for
+	// server->client.
+	IsRequest bool
+	// Data is the reassembled message payload (concatenated across
+	// continuation frames), for a text (opcode 0x1) message.
+	Data []byte
+}
+
+func (e *WebSocketMessageEvent) Type() EventType { return e.EventType }
+func (e *WebSocketMessageEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"pid":        e.PID,
+		"comm":       e.Comm(),
+		"ssl_ctx":    e.SSLContext,
+		"host":       e.Host,
+		"path":       e.Path,
+		"is_request": e.IsRequest,
+	}
+}
+
+// GRPCDetectedEvent reports that a connection is carrying gRPC traffic
+// (HTTP/2 framing with an "application/grpc" content-type), a transport
+// mcpspy otherwise has no visibility into. The protobuf body is never
+// decoded; Method is best-effort and only populated when the ":path"
+// pseudo-header could be read without Huffman decoding.
+type GRPCDetectedEvent struct {
+	EventHeader
+
+	SSLContext uint64 // SSL context pointer (session identifier)
+
+	// Method is the RPC path from the ":path" pseudo-header, e.g.
+	// "/mcp.ToolService/CallTool", or "" if it couldn't be read.
+	Method string
+}
+
+func (e *GRPCDetectedEvent) Type() EventType { return e.EventType }
+func (e *GRPCDetectedEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"pid":     e.PID,
+		"comm":    e.Comm(),
+		"ssl_ctx": e.SSLContext,
+		"method":  e.Method,
 	}
 }
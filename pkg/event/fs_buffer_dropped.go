@@ -0,0 +1,42 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FSBufferDroppedEvent is published when a filesystem session's reassembly
+// buffer grows past its size cap before a complete JSON document could be
+// decoded from it (e.g. a message far larger than any realistic MCP
+// payload, or a stream that never produces valid JSON). The accumulated
+// bytes are discarded and the session starts over, rather than letting an
+// unbounded buffer sit in memory indefinitely.
+type FSBufferDroppedEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+
+	PID      uint32 `json:"pid"`
+	Comm     string `json:"comm"`
+	FromPID  uint32 `json:"from_pid"`
+	FromComm string `json:"from_comm"`
+	ToPID    uint32 `json:"to_pid"`
+	ToComm   string `json:"to_comm"`
+
+	SizeBytes int `json:"size_bytes"`
+	Threshold int `json:"threshold"`
+}
+
+func (e *FSBufferDroppedEvent) Type() EventType { return EventTypeFSBufferDropped }
+
+func (e *FSBufferDroppedEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"pid":        e.PID,
+		"comm":       e.Comm,
+		"from_pid":   e.FromPID,
+		"from_comm":  e.FromComm,
+		"to_pid":     e.ToPID,
+		"to_comm":    e.ToComm,
+		"size_bytes": e.SizeBytes,
+		"threshold":  e.Threshold,
+	}
+}
@@ -0,0 +1,45 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FSUnexpectedPIDEvent is published when a pipe/socket inode that's already
+// part of an established MCP channel is read or written by a PID that isn't
+// one of the two endpoints recorded when the channel was first observed.
+// This can indicate a debugger or another process attaching to an existing
+// stdio channel, or an injection attempt riding along an inode an attacker
+// doesn't otherwise have a handle to.
+type FSUnexpectedPIDEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+
+	Inode uint32 `json:"inode"`
+
+	// FromPID/ToPID/FromComm/ToComm are the channel's established endpoints,
+	// as first observed on this inode.
+	FromPID  uint32 `json:"from_pid"`
+	FromComm string `json:"from_comm"`
+	ToPID    uint32 `json:"to_pid"`
+	ToComm   string `json:"to_comm"`
+
+	// UnexpectedPID/UnexpectedComm is the PID that showed up mid-session and
+	// doesn't match either established endpoint.
+	UnexpectedPID  uint32 `json:"unexpected_pid"`
+	UnexpectedComm string `json:"unexpected_comm"`
+}
+
+func (e *FSUnexpectedPIDEvent) Type() EventType { return EventTypeFSUnexpectedPID }
+
+func (e *FSUnexpectedPIDEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"inode":           e.Inode,
+		"from_pid":        e.FromPID,
+		"from_comm":       e.FromComm,
+		"to_pid":          e.ToPID,
+		"to_comm":         e.ToComm,
+		"unexpected_pid":  e.UnexpectedPID,
+		"unexpected_comm": e.UnexpectedComm,
+	}
+}
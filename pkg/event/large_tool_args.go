@@ -0,0 +1,50 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LargeToolArgsEvent is published when a tools/call request's serialized
+// arguments exceed the configured size threshold. Large arguments are
+// unusual and worth flagging (e.g. an accidental data dump, or a client bug)
+// even without the entropy/encoding signals a dedicated exfiltration
+// detector would look for.
+type LargeToolArgsEvent struct {
+	Timestamp time.Time   `json:"timestamp"`
+	ID        interface{} `json:"id"`
+
+	ToolName  string `json:"tool_name"`
+	SizeBytes int    `json:"size_bytes"`
+	Threshold int    `json:"threshold"`
+
+	// Process chain the request arrived on, identical in shape to MCPEvent's
+	// transport fields.
+	TransportType   TransportType `json:"transport_type"`
+	*StdioTransport `json:"stdio_transport,omitempty"`
+	*HttpTransport  `json:"http_transport,omitempty"`
+}
+
+func (e *LargeToolArgsEvent) Type() EventType { return EventTypeLargeToolArgs }
+
+func (e *LargeToolArgsEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"id":         e.ID,
+		"tool_name":  e.ToolName,
+		"size_bytes": e.SizeBytes,
+		"threshold":  e.Threshold,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["pid"] = e.HttpTransport.PID
+		fields["comm"] = e.HttpTransport.Comm
+		fields["host"] = e.HttpTransport.Host
+	}
+	return fields
+}
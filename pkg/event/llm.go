@@ -17,7 +17,7 @@ const (
 
 // LLMEvent represents a parsed LLM API message
 type LLMEvent struct {
-	SessionID   uint64         `json:"session_id"`          // Correlates all events in the same HTTP session
+	SessionID   uint64         `json:"session_id"` // Correlates all events in the same HTTP session
 	Timestamp   time.Time      `json:"timestamp"`
 	MessageType LLMMessageType `json:"message_type"`
 	PID         uint32         `json:"pid"`
@@ -28,6 +28,12 @@ type LLMEvent struct {
 	Content     string         `json:"content,omitempty"` // Request: user prompt, StreamChunk: delta, Response: full content
 	Error       string         `json:"error,omitempty"`
 	RawJSON     string         `json:"raw_json,omitempty"` // Original HTTP payload JSON (for requests/responses, not stream chunks)
+
+	// Attachments records a reference for each image/file block found in a
+	// multimodal request: the URL for a remote image, or an "inline:<media
+	// type>" marker for an inline base64 block. The base64 data itself is
+	// never stored here.
+	Attachments []string `json:"attachments,omitempty"`
 }
 
 func (e *LLMEvent) Type() EventType { return EventTypeLLMMessage }
@@ -1,6 +1,9 @@
 package event
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -19,9 +22,12 @@ const (
 type TransportType string
 
 const (
-	TransportTypeStdio TransportType = "stdio"
-	TransportTypeSSE   TransportType = "sse"
-	TransportTypeHTTP  TransportType = "http"
+	TransportTypeStdio      TransportType = "stdio"
+	TransportTypeSSE        TransportType = "sse"
+	TransportTypeHTTP       TransportType = "http"
+	TransportTypeUnixSocket TransportType = "unix_socket"
+	TransportTypeTCP        TransportType = "tcp"
+	TransportTypeWebSocket  TransportType = "websocket"
 )
 
 // StdioTransport represents the info relevant for the stdio transport.
@@ -30,13 +36,41 @@ type StdioTransport struct {
 	FromComm string `json:"from_comm"`
 	ToPID    uint32 `json:"to_pid"`
 	ToComm   string `json:"to_comm"`
+
+	// FromContainerID/ToContainerID are the Docker/containerd container ID
+	// each side of the hop belongs to (see pkg/container), or "" if that
+	// side isn't running in a container.
+	FromContainerID string `json:"from_container_id,omitempty"`
+	ToContainerID   string `json:"to_container_id,omitempty"`
 }
 
 type HttpTransport struct {
-	PID       uint32 `json:"pid,omitempty"`
-	Comm      string `json:"comm,omitempty"`
-	Host      string `json:"host,omitempty"`
-	IsRequest bool   `json:"is_request,omitempty"`
+	PID        uint32 `json:"pid,omitempty"`
+	Comm       string `json:"comm,omitempty"`
+	Host       string `json:"host,omitempty"`
+	HTTPMethod string `json:"method,omitempty"`
+	Path       string `json:"path,omitempty"`
+	IsRequest  bool   `json:"is_request,omitempty"`
+
+	// RemoteAddr/LocalAddr are the "ip:port" endpoints of the underlying TLS
+	// connection, resolved via the SSL_get_fd uprobe (see
+	// event.SocketAddrEvent) and attributed to this request/response by SSL
+	// context in pkg/http.SessionManager. Empty when the connection is
+	// plaintext or the address couldn't be resolved (e.g. the capture
+	// started mid-connection, after SSL_get_fd had already been called).
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	LocalAddr  string `json:"local_addr,omitempty"`
+}
+
+// TCPTransport represents the info relevant for bare JSON-RPC captured
+// directly off a TCP socket (no HTTP framing), identified by its 4-tuple.
+type TCPTransport struct {
+	PID     uint32 `json:"pid,omitempty"`
+	Comm    string `json:"comm,omitempty"`
+	SrcAddr string `json:"src_addr,omitempty"`
+	SrcPort uint16 `json:"src_port,omitempty"`
+	DstAddr string `json:"dst_addr,omitempty"`
+	DstPort uint16 `json:"dst_port,omitempty"`
 }
 
 // JSONRPCMessage represents a parsed JSON-RPC 2.0 message.
@@ -52,6 +86,62 @@ type JSONRPCMessage struct {
 	// This field is nil for request and notification messages.
 	// For response messages, it contains the corresponding request that triggered this response.
 	Request *JSONRPCMessage `json:"request,omitempty"`
+
+	// Latency is the time elapsed between the request being observed and
+	// this response being observed. Set alongside Request, so it's only
+	// populated on response messages.
+	Latency time.Duration `json:"latency,omitempty"`
+
+	// CorrelationID is a stable identifier shared by a request and its
+	// response, set on both when the parser runs in CorrelationIDMode
+	// instead of embedding Request. Empty otherwise.
+	CorrelationID string `json:"correlation_id,omitempty"`
+
+	// NonConformant is true when the message was accepted despite missing or
+	// mismatching "jsonrpc":"2.0" (only possible in lenient mode).
+	NonConformant bool `json:"non_conformant,omitempty"`
+
+	// Truncated is true when the message was recovered from a prefix cut
+	// short by a kernel-side capture length limit (--capture-bytes): only
+	// Method/MessageType/ID were recoverable, Params/Result/Error are zero
+	// values rather than "absent".
+	Truncated bool `json:"truncated,omitempty"`
+
+	// ParamsBytes is the size, in bytes, of the raw "params" value as it
+	// appeared in the source JSON, measured before Params is decoded. 0 when
+	// params was absent or the message is Truncated.
+	ParamsBytes int `json:"params_bytes,omitempty"`
+
+	// ResultBytes is the size, in bytes, of the raw "result" value as it
+	// appeared in the source JSON, measured before Result is decoded. 0 when
+	// result was absent or the message is Truncated.
+	ResultBytes int `json:"result_bytes,omitempty"`
+
+	// Meta holds the MCP "_meta" extension object, when present in
+	// params (requests/notifications) or result (responses). It carries
+	// spec-defined fields such as progressToken as well as vendor keys.
+	Meta map[string]interface{} `json:"meta,omitempty"`
+
+	// Page holds cursor-pagination bookkeeping for responses to
+	// tools/list, resources/list, and prompts/list, linking this page to
+	// the others in the same paginated sequence. Nil for any other message.
+	Page *PageInfo `json:"page,omitempty"`
+}
+
+// PageInfo reports where a tools/list, resources/list, or prompts/list
+// response falls within a cursor-paginated sequence, accumulated across the
+// session's earlier pages with the same method.
+type PageInfo struct {
+	// Page is this response's 1-based position in the sequence.
+	Page int `json:"page"`
+	// ItemCount is the number of items returned on this page alone.
+	ItemCount int `json:"item_count"`
+	// TotalItemCount is the running total across this page and every
+	// earlier page of the same sequence.
+	TotalItemCount int `json:"total_item_count"`
+	// HasMore is true when the response carried a nextCursor, meaning the
+	// client would need another request to see the rest.
+	HasMore bool `json:"has_more"`
 }
 
 func (m *JSONRPCMessage) LogFields() logrus.Fields {
@@ -79,14 +169,29 @@ type JSONRPCError struct {
 
 // MCPEvent represents a parsed MCP message
 type MCPEvent struct {
+	// Timestamp is stamped when mcpspy parses the message, not when the
+	// underlying syscall/packet actually occurred - there is no kernel
+	// timestamp on the raw eBPF events to fall back on. For a single
+	// process this is a fine proxy for ordering, but across a multi-hop
+	// flow (e.g. a stdio-to-HTTP bridge, see pkg/bridge) two hops of the
+	// same logical message can be stamped slightly out of true order if
+	// one leg takes longer to reach userspace parsing than the other.
 	Timestamp       time.Time     `json:"timestamp"`
 	TransportType   TransportType `json:"transport_type"`
 	*StdioTransport `json:"stdio_transport,omitempty"`
 	*HttpTransport  `json:"http_transport,omitempty"`
+	*TCPTransport   `json:"tcp_transport,omitempty"`
 
 	JSONRPCMessage
 
 	Raw string `json:"raw"`
+
+	// ValidationErrors lists the MCP schema violations found in this
+	// message's params/result when strict validation is enabled (see
+	// mcp.Config.Strict). Empty when strict validation is off, the method
+	// isn't covered, or no violation was found; the message is still
+	// emitted regardless, this only flags it.
+	ValidationErrors []string `json:"validation_errors,omitempty"`
 }
 
 func (e *MCPEvent) Type() EventType { return EventTypeMCPMessage }
@@ -104,7 +209,18 @@ func (e *MCPEvent) LogFields() logrus.Fields {
 		fields["pid"] = e.HttpTransport.PID
 		fields["comm"] = e.HttpTransport.Comm
 		fields["host"] = e.HttpTransport.Host
+		fields["method"] = e.HttpTransport.HTTPMethod
+		fields["path"] = e.HttpTransport.Path
 		fields["is_request"] = e.HttpTransport.IsRequest
+		if e.HttpTransport.RemoteAddr != "" {
+			fields["remote_addr"] = e.HttpTransport.RemoteAddr
+		}
+	}
+	if e.TCPTransport != nil {
+		fields["pid"] = e.TCPTransport.PID
+		fields["comm"] = e.TCPTransport.Comm
+		fields["src"] = fmt.Sprintf("%s:%d", e.TCPTransport.SrcAddr, e.TCPTransport.SrcPort)
+		fields["dst"] = fmt.Sprintf("%s:%d", e.TCPTransport.DstAddr, e.TCPTransport.DstPort)
 	}
 
 	return fields
@@ -123,6 +239,117 @@ func (msg *MCPEvent) ExtractToolName() string {
 	return ""
 }
 
+// maxToolArgValueLen caps how much of a single argument value
+// ExtractToolArgsSummary renders before truncating it with "...".
+const maxToolArgValueLen = 40
+
+// ExtractToolArgsSummary renders a compact, single-line summary of a
+// tools/call request's "arguments", e.g. `cmd="ls", cwd="/tmp"`, suitable
+// for inline display (see --show-args). Arguments are rendered in
+// alphabetical key order for stable output; long string values are
+// truncated, and nested objects/arrays are elided to their size rather than
+// expanded, since this is meant to be a glance-able summary, not a full
+// dump. Returns "" for any other method or a tools/call with no arguments.
+func (msg *MCPEvent) ExtractToolArgsSummary() string {
+	if msg.Method != "tools/call" || msg.Params == nil {
+		return ""
+	}
+
+	args, ok := msg.Params["arguments"].(map[string]interface{})
+	if !ok || len(args) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, summarizeToolArgValue(args[k])))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// summarizeToolArgValue renders a single tools/call argument value for
+// ExtractToolArgsSummary.
+func summarizeToolArgValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		if len(val) > maxToolArgValueLen {
+			val = val[:maxToolArgValueLen] + "..."
+		}
+		return fmt.Sprintf("%q", val)
+	case map[string]interface{}:
+		return fmt.Sprintf("{...%d keys}", len(val))
+	case []interface{}:
+		return fmt.Sprintf("[...%d items]", len(val))
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// ExtractNotificationSummary returns a short human-readable summary of the
+// params for well-known MCP notifications (progress, log messages,
+// list-changed, cancellation), suitable for inline display. Returns "" for
+// non-notification messages or notifications without a recognized summary.
+func (msg *MCPEvent) ExtractNotificationSummary() string {
+	if msg.MessageType != JSONRPCMessageTypeNotification || msg.Params == nil {
+		return ""
+	}
+
+	switch msg.Method {
+	case "notifications/progress":
+		progress, _ := msg.Params["progress"].(float64)
+		if total, ok := msg.Params["total"].(float64); ok && total > 0 {
+			return fmt.Sprintf("%.0f%%", progress/total*100)
+		}
+		if message, ok := msg.Params["message"].(string); ok && message != "" {
+			return message
+		}
+		return fmt.Sprintf("progress=%v", msg.Params["progress"])
+	case "notifications/message":
+		if level, ok := msg.Params["level"].(string); ok {
+			return level
+		}
+	case "notifications/tools/list_changed":
+		return "tools changed"
+	case "notifications/resources/list_changed":
+		return "resources changed"
+	case "notifications/prompts/list_changed":
+		return "prompts changed"
+	case "notifications/cancelled":
+		if reason, ok := msg.Params["reason"].(string); ok && reason != "" {
+			return reason
+		}
+		return fmt.Sprintf("requestId=%v", msg.Params["requestId"])
+	}
+
+	return ""
+}
+
+// ExtractProgressToken returns the MCP "_meta.progressToken" value, if present,
+// formatted as a string. Returns "" when no progress token was set.
+func (msg *MCPEvent) ExtractProgressToken() string {
+	if msg.Meta == nil {
+		return ""
+	}
+
+	switch token := msg.Meta["progressToken"].(type) {
+	case string:
+		return token
+	case float64:
+		return fmt.Sprintf("%v", token)
+	default:
+		return ""
+	}
+}
+
 // Copy creates a deep copy of the MCPEvent to avoid data races in async processing
 func (e *MCPEvent) Copy() *MCPEvent {
 	cp := &MCPEvent{
@@ -135,6 +362,8 @@ func (e *MCPEvent) Copy() *MCPEvent {
 			Method:      e.Method,
 			Result:      e.Result,
 			Error:       e.Error,
+			ParamsBytes: e.ParamsBytes,
+			ResultBytes: e.ResultBytes,
 		},
 	}
 
@@ -146,6 +375,14 @@ func (e *MCPEvent) Copy() *MCPEvent {
 		}
 	}
 
+	// Deep copy Meta map
+	if e.Meta != nil {
+		cp.Meta = make(map[string]interface{}, len(e.Meta))
+		for k, v := range e.Meta {
+			cp.Meta[k] = v
+		}
+	}
+
 	// Deep copy Request if present
 	if e.Request != nil {
 		cp.Request = &JSONRPCMessage{
@@ -161,29 +398,165 @@ func (e *MCPEvent) Copy() *MCPEvent {
 				cp.Request.Params[k] = v
 			}
 		}
+		if e.Request.Meta != nil {
+			cp.Request.Meta = make(map[string]interface{}, len(e.Request.Meta))
+			for k, v := range e.Request.Meta {
+				cp.Request.Meta[k] = v
+			}
+		}
 	}
 
 	// Deep copy transport info
 	if e.StdioTransport != nil {
 		cp.StdioTransport = &StdioTransport{
-			FromPID:  e.StdioTransport.FromPID,
-			FromComm: e.StdioTransport.FromComm,
-			ToPID:    e.StdioTransport.ToPID,
-			ToComm:   e.StdioTransport.ToComm,
+			FromPID:         e.StdioTransport.FromPID,
+			FromComm:        e.StdioTransport.FromComm,
+			ToPID:           e.StdioTransport.ToPID,
+			ToComm:          e.StdioTransport.ToComm,
+			FromContainerID: e.StdioTransport.FromContainerID,
+			ToContainerID:   e.StdioTransport.ToContainerID,
 		}
 	}
 	if e.HttpTransport != nil {
 		cp.HttpTransport = &HttpTransport{
-			PID:       e.HttpTransport.PID,
-			Comm:      e.HttpTransport.Comm,
-			Host:      e.HttpTransport.Host,
-			IsRequest: e.HttpTransport.IsRequest,
+			PID:        e.HttpTransport.PID,
+			Comm:       e.HttpTransport.Comm,
+			Host:       e.HttpTransport.Host,
+			HTTPMethod: e.HttpTransport.HTTPMethod,
+			Path:       e.HttpTransport.Path,
+			IsRequest:  e.HttpTransport.IsRequest,
+			RemoteAddr: e.HttpTransport.RemoteAddr,
+			LocalAddr:  e.HttpTransport.LocalAddr,
 		}
 	}
 
 	return cp
 }
 
+// SamplingRequest holds the fields extracted from a sampling/createMessage
+// request: the mechanism by which an MCP server asks the client to run its
+// own LLM on the server's behalf, potentially steering what it generates.
+type SamplingRequest struct {
+	// ModelPreferences is the raw modelPreferences object (hints, cost/speed/
+	// intelligence priorities), if present.
+	ModelPreferences map[string]interface{} `json:"model_preferences,omitempty"`
+	MaxTokens        int                    `json:"max_tokens,omitempty"`
+	SystemPrompt     string                 `json:"system_prompt,omitempty"`
+	// Messages is the raw messages array the server wants the client's LLM
+	// to process.
+	Messages []interface{} `json:"messages,omitempty"`
+}
+
+// ExtractSamplingRequest extracts the modelPreferences, maxTokens,
+// systemPrompt, and messages fields from a sampling/createMessage request.
+// Returns false for any other method.
+func (msg *MCPEvent) ExtractSamplingRequest() (SamplingRequest, bool) {
+	if msg.Method != "sampling/createMessage" || msg.Params == nil {
+		return SamplingRequest{}, false
+	}
+
+	var sr SamplingRequest
+	if prefs, ok := msg.Params["modelPreferences"].(map[string]interface{}); ok {
+		sr.ModelPreferences = prefs
+	}
+	if maxTokens, ok := msg.Params["maxTokens"].(float64); ok {
+		sr.MaxTokens = int(maxTokens)
+	}
+	if systemPrompt, ok := msg.Params["systemPrompt"].(string); ok {
+		sr.SystemPrompt = systemPrompt
+	}
+	if messages, ok := msg.Params["messages"].([]interface{}); ok {
+		sr.Messages = messages
+	}
+
+	return sr, true
+}
+
+// ExtractSamplingModelHint returns the first requested model name from a
+// sampling/createMessage request's modelPreferences.hints, if any. Returns
+// "" when absent.
+func (msg *MCPEvent) ExtractSamplingModelHint() string {
+	sr, ok := msg.ExtractSamplingRequest()
+	if !ok || sr.ModelPreferences == nil {
+		return ""
+	}
+
+	hints, ok := sr.ModelPreferences["hints"].([]interface{})
+	if !ok || len(hints) == 0 {
+		return ""
+	}
+
+	hint, ok := hints[0].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	name, _ := hint["name"].(string)
+	return name
+}
+
+// ElicitationRequest holds the fields extracted from an elicitation/create
+// request: the mechanism by which an MCP server asks the client to collect
+// structured input from the user.
+type ElicitationRequest struct {
+	Message         string                 `json:"message,omitempty"`
+	RequestedSchema map[string]interface{} `json:"requested_schema,omitempty"`
+}
+
+// ExtractElicitationRequest extracts the message and requestedSchema fields
+// from an elicitation/create request. Returns false for any other method.
+func (msg *MCPEvent) ExtractElicitationRequest() (ElicitationRequest, bool) {
+	if msg.Method != "elicitation/create" || msg.Params == nil {
+		return ElicitationRequest{}, false
+	}
+
+	var er ElicitationRequest
+	if message, ok := msg.Params["message"].(string); ok {
+		er.Message = message
+	}
+	if schema, ok := msg.Params["requestedSchema"].(map[string]interface{}); ok {
+		er.RequestedSchema = schema
+	}
+
+	return er, true
+}
+
+// ElicitationResponse holds the fields extracted from the response to an
+// elicitation/create request: whether the user accepted, declined, or
+// cancelled, and the data they provided, if any. Privacy-relevant since
+// it's where the user's own input flows back to the server.
+type ElicitationResponse struct {
+	// Action is one of "accept", "decline", or "cancel".
+	Action string `json:"action,omitempty"`
+	// Content holds the user-provided data, present only when Action is
+	// "accept".
+	Content map[string]interface{} `json:"content,omitempty"`
+}
+
+// ExtractElicitationResponse extracts the action and content fields from a
+// response to an elicitation/create request, identified via the response's
+// correlated Request. Returns false for responses to any other method.
+func (msg *MCPEvent) ExtractElicitationResponse() (ElicitationResponse, bool) {
+	if msg.MessageType != JSONRPCMessageTypeResponse || msg.Request == nil || msg.Request.Method != "elicitation/create" {
+		return ElicitationResponse{}, false
+	}
+
+	result, ok := msg.Result.(map[string]interface{})
+	if !ok {
+		return ElicitationResponse{}, false
+	}
+
+	var er ElicitationResponse
+	if action, ok := result["action"].(string); ok {
+		er.Action = action
+	}
+	if content, ok := result["content"].(map[string]interface{}); ok {
+		er.Content = content
+	}
+
+	return er, true
+}
+
 // ExtractResourceURI attempts to extract resource URI from resource-related requests
 func (msg *MCPEvent) ExtractResourceURI() string {
 	// Check if this is a resource method that has a URI parameter
@@ -0,0 +1,365 @@
+package event
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractNotificationSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  MCPEvent
+		want string
+	}{
+		{
+			name: "progress with total",
+			msg: MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					MessageType: JSONRPCMessageTypeNotification,
+					Method:      "notifications/progress",
+					Params:      map[string]interface{}{"progress": 3.0, "total": 10.0},
+				},
+			},
+			want: "30%",
+		},
+		{
+			name: "progress without total falls back to message",
+			msg: MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					MessageType: JSONRPCMessageTypeNotification,
+					Method:      "notifications/progress",
+					Params:      map[string]interface{}{"progress": 3.0, "message": "indexing files"},
+				},
+			},
+			want: "indexing files",
+		},
+		{
+			name: "log message level",
+			msg: MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					MessageType: JSONRPCMessageTypeNotification,
+					Method:      "notifications/message",
+					Params:      map[string]interface{}{"level": "warning", "data": "disk almost full"},
+				},
+			},
+			want: "warning",
+		},
+		{
+			name: "tools list changed",
+			msg: MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					MessageType: JSONRPCMessageTypeNotification,
+					Method:      "notifications/tools/list_changed",
+					Params:      map[string]interface{}{},
+				},
+			},
+			want: "tools changed",
+		},
+		{
+			name: "cancelled with reason",
+			msg: MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					MessageType: JSONRPCMessageTypeNotification,
+					Method:      "notifications/cancelled",
+					Params:      map[string]interface{}{"requestId": "42", "reason": "user aborted"},
+				},
+			},
+			want: "user aborted",
+		},
+		{
+			name: "not a notification",
+			msg: MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					MessageType: JSONRPCMessageTypeRequest,
+					Method:      "notifications/progress",
+					Params:      map[string]interface{}{"progress": 3.0, "total": 10.0},
+				},
+			},
+			want: "",
+		},
+		{
+			name: "unrecognized notification method",
+			msg: MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					MessageType: JSONRPCMessageTypeNotification,
+					Method:      "notifications/initialized",
+					Params:      map[string]interface{}{},
+				},
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.ExtractNotificationSummary(); got != tt.want {
+				t.Errorf("ExtractNotificationSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractSamplingRequest(t *testing.T) {
+	msg := MCPEvent{
+		JSONRPCMessage: JSONRPCMessage{
+			MessageType: JSONRPCMessageTypeRequest,
+			Method:      "sampling/createMessage",
+			Params: map[string]interface{}{
+				"maxTokens":    512.0,
+				"systemPrompt": "You are a helpful assistant.",
+				"messages": []interface{}{
+					map[string]interface{}{"role": "user", "content": map[string]interface{}{"type": "text", "text": "summarize this"}},
+				},
+				"modelPreferences": map[string]interface{}{
+					"hints":                []interface{}{map[string]interface{}{"name": "claude-3-sonnet"}},
+					"intelligencePriority": 0.8,
+					"speedPriority":        0.2,
+				},
+			},
+		},
+	}
+
+	sr, ok := msg.ExtractSamplingRequest()
+	if !ok {
+		t.Fatal("ExtractSamplingRequest() ok = false, want true")
+	}
+	if sr.MaxTokens != 512 {
+		t.Errorf("MaxTokens = %d, want 512", sr.MaxTokens)
+	}
+	if sr.SystemPrompt != "You are a helpful assistant." {
+		t.Errorf("SystemPrompt = %q, want %q", sr.SystemPrompt, "You are a helpful assistant.")
+	}
+	if len(sr.Messages) != 1 {
+		t.Errorf("len(Messages) = %d, want 1", len(sr.Messages))
+	}
+	if sr.ModelPreferences == nil {
+		t.Fatal("ModelPreferences = nil, want set")
+	}
+
+	if hint := msg.ExtractSamplingModelHint(); hint != "claude-3-sonnet" {
+		t.Errorf("ExtractSamplingModelHint() = %q, want %q", hint, "claude-3-sonnet")
+	}
+}
+
+func TestExtractSamplingRequest_NotSamplingMethod(t *testing.T) {
+	msg := MCPEvent{
+		JSONRPCMessage: JSONRPCMessage{
+			MessageType: JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+			Params:      map[string]interface{}{"name": "test"},
+		},
+	}
+
+	if _, ok := msg.ExtractSamplingRequest(); ok {
+		t.Error("ExtractSamplingRequest() ok = true, want false for a non-sampling method")
+	}
+	if hint := msg.ExtractSamplingModelHint(); hint != "" {
+		t.Errorf("ExtractSamplingModelHint() = %q, want empty for a non-sampling method", hint)
+	}
+}
+
+func TestExtractSamplingRequest_NoModelHint(t *testing.T) {
+	msg := MCPEvent{
+		JSONRPCMessage: JSONRPCMessage{
+			MessageType: JSONRPCMessageTypeRequest,
+			Method:      "sampling/createMessage",
+			Params:      map[string]interface{}{"maxTokens": 100.0},
+		},
+	}
+
+	sr, ok := msg.ExtractSamplingRequest()
+	if !ok {
+		t.Fatal("ExtractSamplingRequest() ok = false, want true")
+	}
+	if sr.ModelPreferences != nil {
+		t.Errorf("ModelPreferences = %v, want nil", sr.ModelPreferences)
+	}
+	if hint := msg.ExtractSamplingModelHint(); hint != "" {
+		t.Errorf("ExtractSamplingModelHint() = %q, want empty when modelPreferences is absent", hint)
+	}
+}
+
+func TestExtractElicitationRequest(t *testing.T) {
+	msg := MCPEvent{
+		JSONRPCMessage: JSONRPCMessage{
+			MessageType: JSONRPCMessageTypeRequest,
+			Method:      "elicitation/create",
+			Params: map[string]interface{}{
+				"message": "Please provide your GitHub username",
+				"requestedSchema": map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"username": map[string]interface{}{"type": "string"}},
+				},
+			},
+		},
+	}
+
+	er, ok := msg.ExtractElicitationRequest()
+	if !ok {
+		t.Fatal("ExtractElicitationRequest() ok = false, want true")
+	}
+	if er.Message != "Please provide your GitHub username" {
+		t.Errorf("Message = %q, want %q", er.Message, "Please provide your GitHub username")
+	}
+	if er.RequestedSchema == nil {
+		t.Error("RequestedSchema = nil, want set")
+	}
+}
+
+func TestExtractElicitationRequest_NotElicitationMethod(t *testing.T) {
+	msg := MCPEvent{
+		JSONRPCMessage: JSONRPCMessage{
+			MessageType: JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+			Params:      map[string]interface{}{"name": "test"},
+		},
+	}
+
+	if _, ok := msg.ExtractElicitationRequest(); ok {
+		t.Error("ExtractElicitationRequest() ok = true, want false for a non-elicitation method")
+	}
+}
+
+func TestExtractElicitationResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      interface{}
+		wantAction  string
+		wantContent bool
+	}{
+		{
+			name:        "accept with content",
+			result:      map[string]interface{}{"action": "accept", "content": map[string]interface{}{"username": "octocat"}},
+			wantAction:  "accept",
+			wantContent: true,
+		},
+		{
+			name:       "decline",
+			result:     map[string]interface{}{"action": "decline"},
+			wantAction: "decline",
+		},
+		{
+			name:       "cancel",
+			result:     map[string]interface{}{"action": "cancel"},
+			wantAction: "cancel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					MessageType: JSONRPCMessageTypeResponse,
+					Result:      tt.result,
+					Request: &JSONRPCMessage{
+						MessageType: JSONRPCMessageTypeRequest,
+						Method:      "elicitation/create",
+					},
+				},
+			}
+
+			er, ok := msg.ExtractElicitationResponse()
+			if !ok {
+				t.Fatal("ExtractElicitationResponse() ok = false, want true")
+			}
+			if er.Action != tt.wantAction {
+				t.Errorf("Action = %q, want %q", er.Action, tt.wantAction)
+			}
+			if tt.wantContent && er.Content == nil {
+				t.Error("Content = nil, want set")
+			}
+			if !tt.wantContent && er.Content != nil {
+				t.Errorf("Content = %v, want nil", er.Content)
+			}
+		})
+	}
+}
+
+func TestExtractElicitationResponse_NotElicitationRequest(t *testing.T) {
+	msg := MCPEvent{
+		JSONRPCMessage: JSONRPCMessage{
+			MessageType: JSONRPCMessageTypeResponse,
+			Result:      map[string]interface{}{"action": "accept"},
+			Request: &JSONRPCMessage{
+				MessageType: JSONRPCMessageTypeRequest,
+				Method:      "tools/call",
+			},
+		},
+	}
+
+	if _, ok := msg.ExtractElicitationResponse(); ok {
+		t.Error("ExtractElicitationResponse() ok = true, want false when the correlated request wasn't elicitation/create")
+	}
+}
+
+func TestExtractToolArgsSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want string
+	}{
+		{
+			name: "string value",
+			args: map[string]interface{}{"cmd": "ls"},
+			want: `cmd="ls"`,
+		},
+		{
+			name: "number value",
+			args: map[string]interface{}{"timeout": 30.0},
+			want: "timeout=30",
+		},
+		{
+			name: "object value is elided",
+			args: map[string]interface{}{"options": map[string]interface{}{"a": 1, "b": 2}},
+			want: "options={...2 keys}",
+		},
+		{
+			name: "array value is elided",
+			args: map[string]interface{}{"files": []interface{}{"a.txt", "b.txt", "c.txt"}},
+			want: "files=[...3 items]",
+		},
+		{
+			name: "multiple keys are sorted alphabetically",
+			args: map[string]interface{}{"cwd": "/tmp", "cmd": "ls"},
+			want: `cmd="ls", cwd="/tmp"`,
+		},
+		{
+			name: "long string value is truncated",
+			args: map[string]interface{}{"data": strings.Repeat("x", 50)},
+			want: `data="` + strings.Repeat("x", 40) + `..."`,
+		},
+		{
+			name: "no arguments",
+			args: map[string]interface{}{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := MCPEvent{
+				JSONRPCMessage: JSONRPCMessage{
+					Method: "tools/call",
+					Params: map[string]interface{}{"name": "run", "arguments": tt.args},
+				},
+			}
+
+			if got := msg.ExtractToolArgsSummary(); got != tt.want {
+				t.Errorf("ExtractToolArgsSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractToolArgsSummary_NotToolCall(t *testing.T) {
+	msg := MCPEvent{
+		JSONRPCMessage: JSONRPCMessage{
+			Method: "resources/read",
+			Params: map[string]interface{}{"uri": "file:///etc/hosts"},
+		},
+	}
+
+	if got := msg.ExtractToolArgsSummary(); got != "" {
+		t.Errorf("ExtractToolArgsSummary() = %q, want empty for a non-tools/call method", got)
+	}
+}
@@ -0,0 +1,26 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ParseErrorEvent is published when a stdio or HTTP payload could not be
+// decoded as JSON-RPC (and, for stdio, no truncated-prefix recovery was
+// possible either), so metrics subscribers can track a parse failure rate
+// instead of relying on a log line.
+type ParseErrorEvent struct {
+	Timestamp     time.Time     `json:"timestamp"`
+	TransportType TransportType `json:"transport_type"`
+	Reason        string        `json:"reason"`
+}
+
+func (e *ParseErrorEvent) Type() EventType { return EventTypeParseError }
+
+func (e *ParseErrorEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"transport_type": e.TransportType,
+		"reason":         e.Reason,
+	}
+}
@@ -0,0 +1,53 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProtocolVersionMismatchEvent is published when a session's initialize
+// handshake negotiates a server protocolVersion different from the one the
+// client requested, or either side is running a protocol revision older
+// than the one this parser targets (see pkg/mcp's 2025-06-18 schema). A
+// mismatch usually just means the server downgraded to the newest version
+// it supports, per spec, but it's also a common source of capability
+// negotiation failures worth surfacing.
+type ProtocolVersionMismatchEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+
+	ClientVersion string `json:"client_version"`
+	ServerVersion string `json:"server_version"`
+
+	// Reason is a short human-readable description, e.g. "client and
+	// server negotiated mismatched protocol versions".
+	Reason string `json:"reason"`
+
+	// Process chain the handshake was observed on, identical in shape to
+	// MCPEvent's transport fields.
+	TransportType   TransportType `json:"transport_type"`
+	*StdioTransport `json:"stdio_transport,omitempty"`
+	*HttpTransport  `json:"http_transport,omitempty"`
+}
+
+func (e *ProtocolVersionMismatchEvent) Type() EventType { return EventTypeProtocolVersionMismatch }
+
+func (e *ProtocolVersionMismatchEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"client_version": e.ClientVersion,
+		"server_version": e.ServerVersion,
+		"reason":         e.Reason,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["pid"] = e.HttpTransport.PID
+		fields["comm"] = e.HttpTransport.Comm
+		fields["host"] = e.HttpTransport.Host
+	}
+	return fields
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProtocolViolationEvent is published when a message is observed out of
+// order relative to the MCP initialization handshake: per spec, a client
+// must not send requests other than ping before the server has responded to
+// initialize and the client has sent notifications/initialized.
+type ProtocolViolationEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+
+	// Method and MessageType identify the offending message.
+	Method      string             `json:"method"`
+	MessageType JSONRPCMessageType `json:"message_type"`
+	ID          interface{}        `json:"id,omitempty"`
+
+	// Reason is a short human-readable description of the violation, e.g.
+	// "request sent before handshake completed".
+	Reason string `json:"reason"`
+
+	// Process chain the message arrived on, identical in shape to
+	// MCPEvent's transport fields.
+	TransportType   TransportType `json:"transport_type"`
+	*StdioTransport `json:"stdio_transport,omitempty"`
+	*HttpTransport  `json:"http_transport,omitempty"`
+}
+
+func (e *ProtocolViolationEvent) Type() EventType { return EventTypeProtocolViolation }
+
+func (e *ProtocolViolationEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"method":       e.Method,
+		"message_type": e.MessageType,
+		"reason":       e.Reason,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["pid"] = e.HttpTransport.PID
+		fields["comm"] = e.HttpTransport.Comm
+		fields["host"] = e.HttpTransport.Host
+	}
+	return fields
+}
@@ -0,0 +1,53 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RequestTimeoutEvent is published when a request is evicted from the
+// correlation cache without ever receiving a matching response, so metrics,
+// JSONL, and alerting subscribers can all observe timeouts uniformly
+// instead of relying on a log line.
+type RequestTimeoutEvent struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Method    string      `json:"method"`
+	ID        interface{} `json:"id"`
+	// TTL is the correlation cache timeout that was configured when this
+	// request went unanswered for that long.
+	TTL time.Duration `json:"ttl"`
+
+	// Process chain the request arrived on, identical in shape to MCPEvent's
+	// transport fields.
+	TransportType   TransportType `json:"transport_type"`
+	*StdioTransport `json:"stdio_transport,omitempty"`
+	*HttpTransport  `json:"http_transport,omitempty"`
+	*TCPTransport   `json:"tcp_transport,omitempty"`
+}
+
+func (e *RequestTimeoutEvent) Type() EventType { return EventTypeRequestTimeout }
+
+func (e *RequestTimeoutEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"method": e.Method,
+		"id":     e.ID,
+		"ttl":    e.TTL,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["pid"] = e.HttpTransport.PID
+		fields["comm"] = e.HttpTransport.Comm
+		fields["host"] = e.HttpTransport.Host
+	}
+	if e.TCPTransport != nil {
+		fields["pid"] = e.TCPTransport.PID
+		fields["comm"] = e.TCPTransport.Comm
+	}
+	return fields
+}
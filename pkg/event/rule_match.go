@@ -0,0 +1,29 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RuleMatchEvent is published when a live MCPEvent matches a user-defined
+// rule (see pkg/rules) whose action is to raise a highlighted alert.
+type RuleMatchEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Rule is the matching rule's Name.
+	Rule        string `json:"rule"`
+	Method      string `json:"method"`
+	Tool        string `json:"tool,omitempty"`
+	ResourceURI string `json:"resource_uri,omitempty"`
+}
+
+func (e *RuleMatchEvent) Type() EventType { return EventTypeRuleMatch }
+
+func (e *RuleMatchEvent) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"rule":         e.Rule,
+		"method":       e.Method,
+		"tool":         e.Tool,
+		"resource_uri": e.ResourceURI,
+	}
+}
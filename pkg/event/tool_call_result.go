@@ -0,0 +1,49 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ToolCallResultEvent is published when a tools/call request is paired with
+// its response, giving a single summary of how the call went without
+// requiring consumers to join the request and response MCPEvents themselves.
+type ToolCallResultEvent struct {
+	Timestamp time.Time   `json:"timestamp"`
+	ID        interface{} `json:"id"`
+
+	ToolName   string                 `json:"tool_name"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+	DurationMs int64                  `json:"duration_ms"`
+	IsError    bool                   `json:"is_error"`
+
+	// Process chain the result arrived on, identical in shape to MCPEvent's
+	// transport fields.
+	TransportType   TransportType `json:"transport_type"`
+	*StdioTransport `json:"stdio_transport,omitempty"`
+	*HttpTransport  `json:"http_transport,omitempty"`
+}
+
+func (e *ToolCallResultEvent) Type() EventType { return EventTypeToolCallResult }
+
+func (e *ToolCallResultEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"id":          e.ID,
+		"tool_name":   e.ToolName,
+		"duration_ms": e.DurationMs,
+		"is_error":    e.IsError,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["pid"] = e.HttpTransport.PID
+		fields["comm"] = e.HttpTransport.Comm
+		fields["host"] = e.HttpTransport.Host
+	}
+	return fields
+}
@@ -0,0 +1,48 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ToolResultSchemaWarningEvent is published when a tools/call response's
+// result violates the MCP content schema (e.g. a content block missing
+// "type", or isError set without any content). This is distinct from the
+// method-level JSON-RPC/MCP validation done while parsing the message: a
+// schema-invalid result is still a well-formed JSON-RPC response, but one
+// that can crash or confuse a client expecting a conformant content[].
+type ToolResultSchemaWarningEvent struct {
+	Timestamp time.Time   `json:"timestamp"`
+	ID        interface{} `json:"id"`
+	// Violations lists every schema check that failed, in human-readable
+	// form (e.g. "content[0] missing \"type\" field").
+	Violations []string `json:"violations"`
+
+	// Process chain the result arrived on, identical in shape to MCPEvent's
+	// transport fields.
+	TransportType   TransportType `json:"transport_type"`
+	*StdioTransport `json:"stdio_transport,omitempty"`
+	*HttpTransport  `json:"http_transport,omitempty"`
+}
+
+func (e *ToolResultSchemaWarningEvent) Type() EventType { return EventTypeToolResultSchemaWarning }
+
+func (e *ToolResultSchemaWarningEvent) LogFields() logrus.Fields {
+	fields := logrus.Fields{
+		"id":         e.ID,
+		"violations": e.Violations,
+	}
+	if e.StdioTransport != nil {
+		fields["from_pid"] = e.StdioTransport.FromPID
+		fields["from_comm"] = e.StdioTransport.FromComm
+		fields["to_pid"] = e.StdioTransport.ToPID
+		fields["to_comm"] = e.StdioTransport.ToComm
+	}
+	if e.HttpTransport != nil {
+		fields["pid"] = e.HttpTransport.PID
+		fields["comm"] = e.HttpTransport.Comm
+		fields["host"] = e.HttpTransport.Host
+	}
+	return fields
+}
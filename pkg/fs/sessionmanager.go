@@ -6,13 +6,60 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/encoder"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/sirupsen/logrus"
 )
 
-// sessionKey uniquely identifies a filesystem session
+const (
+	// sessionCacheSize bounds the number of concurrently tracked fd
+	// sessions, to protect against unbounded growth from a runaway number
+	// of open files.
+	sessionCacheSize = 4096
+	// sessionTTL evicts a session that hasn't seen a read/write in this
+	// long, so an fd that's gone quiet (e.g. the process exited without
+	// CleanupSession being called) doesn't hold its buffer forever.
+	sessionTTL = 30 * time.Second
+
+	// maxSessionBufferBytes caps how much unparsed data a session will
+	// accumulate while waiting for a complete JSON document, e.g. a
+	// multi-chunk tools/list result or a large base64 resource. Anything
+	// beyond this is never going to be a realistic single MCP message, so
+	// the buffer is dropped rather than growing without bound.
+	maxSessionBufferBytes = 8 * 1024 * 1024
+
+	// channelCacheSize bounds the number of inodes tracked for the
+	// established-endpoints anomaly check, mirroring sessionCacheSize.
+	channelCacheSize = 4096
+	// channelTTL expires an inode's established endpoints once the channel
+	// has been quiet this long, so a reused inode (e.g. after an fd closes
+	// and the number gets recycled) doesn't get flagged against a stale pair.
+	channelTTL = 30 * time.Second
+)
+
+// channel records the endpoints first observed on a given inode, so later
+// reads/writes on that same inode can be checked for an unexpected PID
+// joining mid-session.
+type channel struct {
+	fromPID  uint32
+	fromComm [16]uint8
+	toPID    uint32
+	toComm   [16]uint8
+}
+
+// sessionKey uniquely identifies a filesystem session.
+//
+// A single large write is often delivered to us as several FSDataEvents -
+// one per write() syscall the writer happened to issue - rather than one
+// record covering the whole message. No sequence number is needed to stitch
+// them back together: the BPF_MAP_TYPE_RINGBUF events ride a single shared
+// ring buffer drained by one reader goroutine, so records for the same
+// FilePtr always arrive in submission order, and ProcessFSEvent's
+// session.buf concatenation below reassembles them as they come.
 type sessionKey struct {
 	pid           uint32
 	filePtr       uint64
@@ -30,26 +77,36 @@ type session struct {
 	toComm   [16]uint8
 	filePtr  uint64
 
+	// isUnixSocket is true when this session's fd is a Unix domain socket
+	// rather than a pipe, distinguishing MCP-over-Unix-socket transports
+	// from the default stdio pipes.
+	isUnixSocket bool
+
 	// Buffer for accumulating data
 	buf *bytes.Buffer
 }
 
 // SessionManager manages filesystem sessions and aggregates JSON payloads
 // Subscribes to: EventTypeFSRead, EventTypeFSWrite
-// Publishes: EventTypeFSAggregatedRead, EventTypeFSAggregatedWrite
+// Publishes: EventTypeFSAggregatedRead, EventTypeFSAggregatedWrite, EventTypeFSBufferDropped, EventTypeFSUnexpectedPID
 type SessionManager struct {
 	mu sync.Mutex
 
-	sessions map[sessionKey]*session
+	sessions *expirable.LRU[sessionKey, *session]
+	// channels tracks each inode's established endpoints, independent of
+	// sessions (which are keyed per-fd/direction), so an unexpected PID
+	// showing up on either side of an existing channel can be detected.
+	channels *expirable.LRU[uint32, *channel]
 	eventBus bus.EventBus
 }
 
 // NewSessionManager creates a new filesystem session manager
 func NewSessionManager(eventBus bus.EventBus) (*SessionManager, error) {
 	sm := &SessionManager{
-		sessions: make(map[sessionKey]*session),
 		eventBus: eventBus,
 	}
+	sm.sessions = expirable.NewLRU[sessionKey, *session](sessionCacheSize, sm.handleSessionEvicted, sessionTTL)
+	sm.channels = expirable.NewLRU[uint32, *channel](channelCacheSize, nil, channelTTL)
 
 	// Subscribe to raw FS events
 	if err := sm.eventBus.Subscribe(event.EventTypeFSRead, sm.handleFSEvent); err != nil {
@@ -63,6 +120,70 @@ func NewSessionManager(eventBus bus.EventBus) (*SessionManager, error) {
 	return sm, nil
 }
 
+// handleSessionEvicted is the sessions cache's eviction callback. A session
+// falls out either because it timed out with a half-built message that will
+// never be completed, or because CleanupSession/capacity pressure removed
+// it outright; either way any buffered bytes left behind are gone for good,
+// so report it the same way an explicit cap overflow is reported.
+func (s *SessionManager) handleSessionEvicted(_ sessionKey, sess *session) {
+	if sess.buf.Len() == 0 {
+		return
+	}
+	s.reportBufferDropped(sess, sess.buf.Len())
+}
+
+// reportBufferDropped publishes a warning that a session's reassembly
+// buffer was discarded before producing a complete JSON message.
+func (s *SessionManager) reportBufferDropped(sess *session, sizeBytes int) {
+	evt := &event.FSBufferDroppedEvent{
+		Timestamp: time.Now(),
+		PID:       sess.pid,
+		Comm:      encoder.BytesToStr(sess.comm[:]),
+		FromPID:   sess.fromPID,
+		FromComm:  encoder.BytesToStr(sess.fromComm[:]),
+		ToPID:     sess.toPID,
+		ToComm:    encoder.BytesToStr(sess.toComm[:]),
+		SizeBytes: sizeBytes,
+		Threshold: maxSessionBufferBytes,
+	}
+	logrus.WithFields(evt.LogFields()).Warn("Discarding fs session buffer that never produced a complete JSON message")
+	s.eventBus.Publish(evt)
+}
+
+// checkUnexpectedPID records the first-seen endpoints for e.Inode, or, if a
+// channel is already established for it, flags a warning when this event's
+// PID isn't one of those endpoints - e.g. a debugger attaching to an
+// existing MCP stdio pipe, or an injection attempt riding along it.
+func (s *SessionManager) checkUnexpectedPID(e *event.FSDataEvent) {
+	ch, exists := s.channels.Get(e.Inode)
+	if !exists {
+		s.channels.Add(e.Inode, &channel{
+			fromPID:  e.FromPID,
+			fromComm: e.FromComm,
+			toPID:    e.ToPID,
+			toComm:   e.ToComm,
+		})
+		return
+	}
+
+	if e.PID == ch.fromPID || e.PID == ch.toPID {
+		return
+	}
+
+	evt := &event.FSUnexpectedPIDEvent{
+		Timestamp:      time.Now(),
+		Inode:          e.Inode,
+		FromPID:        ch.fromPID,
+		FromComm:       encoder.BytesToStr(ch.fromComm[:]),
+		ToPID:          ch.toPID,
+		ToComm:         encoder.BytesToStr(ch.toComm[:]),
+		UnexpectedPID:  e.PID,
+		UnexpectedComm: encoder.BytesToStr(e.CommBytes[:]),
+	}
+	logrus.WithFields(evt.LogFields()).Warn("Unexpected PID observed on an established fs channel's inode")
+	s.eventBus.Publish(evt)
+}
+
 // handleFSEvent is called by the event bus when raw FS events arrive
 func (s *SessionManager) handleFSEvent(e event.Event) {
 	fsEvent, ok := e.(*event.FSDataEvent)
@@ -88,37 +209,61 @@ func (s *SessionManager) ProcessFSEvent(e *event.FSDataEvent) error {
 	}
 
 	// Get or create session
-	sess, exists := s.sessions[key]
+	sess, exists := s.sessions.Get(key)
 	if !exists {
 		sess = &session{
-			pid:      e.PID,
-			comm:     e.CommBytes,
-			inode:    e.Inode,
-			fromPID:  e.FromPID,
-			fromComm: e.FromComm,
-			toPID:    e.ToPID,
-			toComm:   e.ToComm,
-			filePtr:  e.FilePtr,
-			buf:      &bytes.Buffer{},
+			pid:          e.PID,
+			comm:         e.CommBytes,
+			inode:        e.Inode,
+			fromPID:      e.FromPID,
+			fromComm:     e.FromComm,
+			toPID:        e.ToPID,
+			toComm:       e.ToComm,
+			filePtr:      e.FilePtr,
+			isUnixSocket: e.IsUnixSocketFd(),
+			buf:          &bytes.Buffer{},
 		}
-		s.sessions[key] = sess
 	}
+	s.sessions.Add(key, sess)
+
+	s.checkUnexpectedPID(e)
 
 	// Append data to buffer
 	if _, err := sess.buf.Write(e.Buffer()); err != nil {
 		return err
 	}
 
+	// The kernel only captured a prefix of this read/write (--capture-bytes):
+	// the bytes it dropped are gone for good, not merely deferred to a later
+	// event, so any remainder left after this pass must be flushed as a
+	// best-effort message rather than held in the buffer forever.
+	truncated := e.Size > e.BufSize
+
+	// A message that never completes (e.g. malformed framing, or a single
+	// JSON document far larger than any real MCP payload) would otherwise
+	// grow sess.buf forever between now and its TTL eviction. Cut it off
+	// and start fresh rather than waiting for the TTL.
+	if sess.buf.Len() > maxSessionBufferBytes {
+		size := sess.buf.Len()
+		sess.buf.Reset()
+		s.reportBufferDropped(sess, size)
+		return nil
+	}
+
 	// Try to parse JSON from the accumulated buffer
-	if err := s.tryEmitJsonEvent(sess, key); err != nil {
+	if err := s.tryEmitJsonEvent(sess, key, truncated); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// tryEmitJsonEvent attempts to parse and emit complete JSON messages
-func (s *SessionManager) tryEmitJsonEvent(sess *session, key sessionKey) error {
+// tryEmitJsonEvent attempts to parse and emit complete JSON messages. When
+// truncated is true, any bytes left over after decoding every complete
+// message are flushed as a final best-effort payload instead of being kept
+// for a continuation that will never arrive; the MCP parser recovers what it
+// can (typically method/id) from it.
+func (s *SessionManager) tryEmitJsonEvent(sess *session, key sessionKey, truncated bool) error {
 	bufData := bytes.TrimSpace(sess.buf.Bytes())
 	if len(bufData) == 0 {
 		sess.buf.Reset()
@@ -161,9 +306,18 @@ func (s *SessionManager) tryEmitJsonEvent(sess *session, key sessionKey) error {
 		lastGoodPosition = decoder.InputOffset()
 	}
 
+	remainingData := bufData[lastGoodPosition:]
+
+	if truncated && len(bytes.TrimSpace(remainingData)) > 0 {
+		if err := s.emitJsonEvent(sess, key, remainingData); err != nil {
+			return err
+		}
+		sess.buf.Reset()
+		return nil
+	}
+
 	// Update buffer: keep only unprocessed bytes
 	if lastGoodPosition > 0 {
-		remainingData := bufData[lastGoodPosition:]
 		sess.buf = bytes.NewBuffer(remainingData)
 	}
 
@@ -187,6 +341,7 @@ func (s *SessionManager) emitJsonEvent(sess *session, key sessionKey, payload []
 		sess.toPID,
 		sess.toComm,
 		sess.filePtr,
+		sess.isUnixSocket,
 		payload,
 	)
 
@@ -205,7 +360,8 @@ func (s *SessionManager) Close() {
 	defer s.mu.Unlock()
 
 	// Clear all sessions
-	s.sessions = make(map[sessionKey]*session)
+	s.sessions.Purge()
+	s.channels.Purge()
 }
 
 // CleanupSession removes a specific session (e.g., when file is closed)
@@ -214,9 +370,9 @@ func (s *SessionManager) CleanupSession(pid uint32, filePtr uint64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Delete both read and write sessions for this PID+FilePtr
+	// Remove both read and write sessions for this PID+FilePtr
 	keyRead := sessionKey{pid: pid, filePtr: filePtr, origEventType: event.EventTypeFSRead}
 	keyWrite := sessionKey{pid: pid, filePtr: filePtr, origEventType: event.EventTypeFSWrite}
-	delete(s.sessions, keyRead)
-	delete(s.sessions, keyWrite)
+	s.sessions.Remove(keyRead)
+	s.sessions.Remove(keyWrite)
 }
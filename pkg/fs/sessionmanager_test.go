@@ -1,6 +1,8 @@
 package fs
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -285,6 +287,71 @@ func TestSessionManager_MultipleJsonAcrossFragments(t *testing.T) {
 	}
 }
 
+// TestSessionManager_LargeWriteSpanningMultipleRecords reassembles a single
+// large JSON message (e.g. a tools/call result carrying a big base64
+// resource) that a client split across several separate write() syscalls
+// because it exceeded one write's worth of pipe buffer capacity - each
+// syscall arrives as its own FSDataEvent sharing the session's FilePtr, in
+// order, and must be stitched back into the one message before parsing.
+func TestSessionManager_LargeWriteSpanningMultipleRecords(t *testing.T) {
+	mockBus := testutil.NewMockBus()
+	defer mockBus.Close()
+
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	defer sm.Close()
+
+	pid := uint32(9000)
+	filePtr := uint64(0xdeadbeef)
+
+	// Build a JSON-RPC message with a payload large enough that no single
+	// write() call would carry it in one go, then split it into several
+	// fragments close to a realistic per-syscall chunk size.
+	blob := strings.Repeat("A", 300*1024)
+	fullMessage := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":{"data":"%s"}}`, blob)
+
+	const chunkSize = 64 * 1024
+	var lastEvt event.Event
+	for offset := 0; offset < len(fullMessage); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(fullMessage) {
+			end = len(fullMessage)
+		}
+		chunk := []byte(fullMessage[offset:end])
+
+		evt := &event.FSDataEvent{
+			FSEventBase: event.FSEventBase{
+				EventHeader: event.EventHeader{
+					EventType: event.EventTypeFSWrite,
+					PID:       pid,
+				},
+				FilePtr: filePtr,
+			},
+			Size:    uint32(len(chunk)),
+			BufSize: uint32(len(chunk)),
+		}
+		copy(evt.Buf[:], chunk)
+		mockBus.Publish(evt)
+
+		if received, ok := receiveAggregatedEvent(mockBus.Events(), 50*time.Millisecond); ok {
+			lastEvt = received
+		}
+	}
+
+	if lastEvt == nil {
+		t.Fatal("Did not receive an aggregated event for the reassembled large write")
+	}
+	aggEvt, ok := lastEvt.(*event.FSAggregatedEvent)
+	if !ok {
+		t.Fatalf("Expected FSAggregatedEvent, got %T", lastEvt)
+	}
+	if string(aggEvt.Payload) != fullMessage {
+		t.Errorf("Reassembled payload did not match the original message (len %d vs %d)", len(aggEvt.Payload), len(fullMessage))
+	}
+}
+
 func TestSessionManager_MultipleSessions(t *testing.T) {
 	mockBus := testutil.NewMockBus()
 	defer mockBus.Close()
@@ -512,7 +579,7 @@ func TestSessionManager_CleanupSession(t *testing.T) {
 	// Verify session exists
 	sm.mu.Lock()
 	key := sessionKey{pid: pid, filePtr: filePtr, origEventType: event.EventTypeFSRead}
-	_, exists := sm.sessions[key]
+	_, exists := sm.sessions.Get(key)
 	sm.mu.Unlock()
 	if !exists {
 		t.Fatal("Session should exist after processing incomplete JSON")
@@ -523,8 +590,8 @@ func TestSessionManager_CleanupSession(t *testing.T) {
 
 	// Verify sessions are deleted
 	sm.mu.Lock()
-	_, exists1 := sm.sessions[sessionKey{pid: pid, filePtr: filePtr, origEventType: event.EventTypeFSRead}]
-	_, exists2 := sm.sessions[sessionKey{pid: pid, filePtr: filePtr, origEventType: event.EventTypeFSWrite}]
+	_, exists1 := sm.sessions.Get(sessionKey{pid: pid, filePtr: filePtr, origEventType: event.EventTypeFSRead})
+	_, exists2 := sm.sessions.Get(sessionKey{pid: pid, filePtr: filePtr, origEventType: event.EventTypeFSWrite})
 	sm.mu.Unlock()
 	if exists1 || exists2 {
 		t.Fatal("Sessions should be deleted after CleanupSession")
@@ -598,6 +665,99 @@ func TestSessionManager_JsonArray(t *testing.T) {
 	}
 }
 
+func TestSessionManager_KernelTruncatedFlushesRemainder(t *testing.T) {
+	mockBus := testutil.NewMockBus()
+	defer mockBus.Close()
+
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	defer sm.Close()
+
+	pid := uint32(2468)
+	filePtr := uint64(0x9abc)
+
+	// Kernel-side --capture-bytes truncated this read to BufSize bytes, well
+	// short of Size: the rest of the message was never copied and will never
+	// arrive, so the session must flush what it has instead of waiting.
+	partial := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"big_tool","argum`)
+	fsEvent := &event.FSDataEvent{
+		FSEventBase: event.FSEventBase{
+			EventHeader: event.EventHeader{
+				EventType: event.EventTypeFSRead,
+				PID:       pid,
+			},
+			FilePtr: filePtr,
+		},
+		Size:    uint32(len(partial)) + 200,
+		BufSize: uint32(len(partial)),
+	}
+	copy(fsEvent.Buf[:], partial)
+	mockBus.Publish(fsEvent)
+
+	evt, ok := receiveAggregatedEvent(mockBus.Events(), 100*time.Millisecond)
+	if !ok {
+		t.Fatal("No FSAggregatedEvent received for truncated read")
+	}
+	aggEvt := evt.(*event.FSAggregatedEvent)
+	if string(aggEvt.Payload) != string(partial) {
+		t.Errorf("Expected flushed partial payload %q, got %q", partial, aggEvt.Payload)
+	}
+
+	// The session buffer must have been reset, not left holding the
+	// unparseable remainder to corrupt whatever comes next on this fd.
+	sm.mu.Lock()
+	key := sessionKey{pid: pid, filePtr: filePtr, origEventType: event.EventTypeFSRead}
+	sess, exists := sm.sessions.Get(key)
+	var bufLen int
+	if exists {
+		bufLen = sess.buf.Len()
+	}
+	sm.mu.Unlock()
+	if exists && bufLen != 0 {
+		t.Errorf("Expected session buffer to be reset after truncated flush, got %d bytes", bufLen)
+	}
+}
+
+func TestSessionManager_UnixSocketFlagPropagates(t *testing.T) {
+	mockBus := testutil.NewMockBus()
+	defer mockBus.Close()
+
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	defer sm.Close()
+
+	jsonData := []byte(`{"jsonrpc":"2.0","method":"test","id":1}`)
+
+	fsEvent := &event.FSDataEvent{
+		FSEventBase: event.FSEventBase{
+			EventHeader: event.EventHeader{
+				EventType: event.EventTypeFSRead,
+				PID:       1234,
+			},
+			FilePtr:      0x7fff12345678,
+			IsUnixSocket: 1,
+		},
+		Size:    uint32(len(jsonData)),
+		BufSize: uint32(len(jsonData)),
+	}
+	copy(fsEvent.Buf[:], jsonData)
+
+	mockBus.Publish(fsEvent)
+
+	evt, ok := receiveAggregatedEvent(mockBus.Events(), 100*time.Millisecond)
+	if !ok {
+		t.Fatal("No FSAggregatedEvent received")
+	}
+	aggEvt := evt.(*event.FSAggregatedEvent)
+	if !aggEvt.IsUnixSocketFd() {
+		t.Error("Expected IsUnixSocketFd() to be true for a Unix socket fd")
+	}
+}
+
 func TestSessionManager_ReadWriteEventTypes(t *testing.T) {
 	mockBus := testutil.NewMockBus()
 	defer mockBus.Close()
@@ -659,3 +819,165 @@ func TestSessionManager_ReadWriteEventTypes(t *testing.T) {
 		t.Errorf("Expected EventTypeFSAggregatedWrite, got %v", evt.Type())
 	}
 }
+
+func TestSessionManager_OversizedBufferIsDroppedWithWarning(t *testing.T) {
+	mockBus := testutil.NewMockBus()
+	defer mockBus.Close()
+
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	defer sm.Close()
+
+	pid := uint32(9999)
+	filePtr := uint64(0xbeef)
+
+	// A chunk that never completes a JSON document: it starts like a real
+	// request, but the object never closes, so each read just grows the
+	// session's buffer instead of ever flushing.
+	chunk := append([]byte(`{"unterminated":"`), make([]byte, 128*1024-18)...)
+	for i := range chunk[18:] {
+		chunk[18+i] = 'x'
+	}
+
+	var dropped *event.FSBufferDroppedEvent
+	for i := 0; i < 65 && dropped == nil; i++ {
+		fsEvent := &event.FSDataEvent{
+			FSEventBase: event.FSEventBase{
+				EventHeader: event.EventHeader{
+					EventType: event.EventTypeFSRead,
+					PID:       pid,
+				},
+				FilePtr: filePtr,
+			},
+			BufSize: uint32(len(chunk)),
+		}
+		copy(fsEvent.Buf[:], chunk)
+		mockBus.Publish(fsEvent)
+
+		for {
+			select {
+			case evt := <-mockBus.Events():
+				if e, ok := evt.(*event.FSBufferDroppedEvent); ok {
+					dropped = e
+				}
+			case <-time.After(10 * time.Millisecond):
+				goto nextChunk
+			}
+		}
+	nextChunk:
+	}
+
+	if dropped == nil {
+		t.Fatal("Expected an FSBufferDroppedEvent once the session buffer exceeded its cap")
+	}
+	if dropped.PID != pid {
+		t.Errorf("Expected dropped event for PID %d, got %d", pid, dropped.PID)
+	}
+	if dropped.Threshold != maxSessionBufferBytes {
+		t.Errorf("Expected threshold %d, got %d", maxSessionBufferBytes, dropped.Threshold)
+	}
+	if dropped.SizeBytes <= maxSessionBufferBytes {
+		t.Errorf("Expected dropped size to exceed the cap, got %d", dropped.SizeBytes)
+	}
+
+	// The session must start clean afterwards: a fresh, complete message on
+	// the same fd should parse normally, not be prefixed by the discarded
+	// garbage.
+	jsonData := []byte(`{"jsonrpc":"2.0","method":"ping","id":1}`)
+	fsEvent := &event.FSDataEvent{
+		FSEventBase: event.FSEventBase{
+			EventHeader: event.EventHeader{
+				EventType: event.EventTypeFSRead,
+				PID:       pid,
+			},
+			FilePtr: filePtr,
+		},
+		BufSize: uint32(len(jsonData)),
+	}
+	copy(fsEvent.Buf[:], jsonData)
+	mockBus.Publish(fsEvent)
+
+	evt, ok := receiveAggregatedEvent(mockBus.Events(), 100*time.Millisecond)
+	if !ok {
+		t.Fatal("Did not receive event for message after buffer reset")
+	}
+	aggEvt := evt.(*event.FSAggregatedEvent)
+	if string(aggEvt.Payload) != string(jsonData) {
+		t.Errorf("Expected clean payload %q after reset, got %q", jsonData, aggEvt.Payload)
+	}
+}
+
+func TestSessionManager_UnexpectedPIDOnEstablishedChannel(t *testing.T) {
+	mockBus := testutil.NewMockBus()
+	defer mockBus.Close()
+
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	defer sm.Close()
+
+	const inode = uint32(555)
+
+	newEvent := func(pid uint32, filePtr uint64, fromPID, toPID uint32) *event.FSDataEvent {
+		jsonData := []byte(`{"jsonrpc":"2.0","method":"ping","id":1}`)
+		fsEvent := &event.FSDataEvent{
+			FSEventBase: event.FSEventBase{
+				EventHeader: event.EventHeader{
+					EventType: event.EventTypeFSRead,
+					PID:       pid,
+				},
+				Inode:   inode,
+				FromPID: fromPID,
+				ToPID:   toPID,
+				FilePtr: filePtr,
+			},
+			Size:    uint32(len(jsonData)),
+			BufSize: uint32(len(jsonData)),
+		}
+		copy(fsEvent.Buf[:], jsonData)
+		return fsEvent
+	}
+
+	// First sighting of this inode establishes its channel (server PID 100
+	// reading from client PID 200).
+	mockBus.Publish(newEvent(100, 0xaaaa, 200, 100))
+	if _, ok := receiveAggregatedEvent(mockBus.Events(), 100*time.Millisecond); !ok {
+		t.Fatal("Expected initial aggregated event")
+	}
+
+	// A legitimate continuation from one of the established endpoints must
+	// not trigger a warning.
+	mockBus.Publish(newEvent(100, 0xaaaa, 200, 100))
+	if _, ok := receiveAggregatedEvent(mockBus.Events(), 100*time.Millisecond); !ok {
+		t.Fatal("Expected second aggregated event")
+	}
+
+	// A third, unrelated PID now reads/writes the same inode, under a
+	// different fd - an unexpected process joining an established channel.
+	mockBus.Publish(newEvent(300, 0xbbbb, 300, 300))
+
+	var warning *event.FSUnexpectedPIDEvent
+	for warning == nil {
+		select {
+		case evt := <-mockBus.Events():
+			if e, ok := evt.(*event.FSUnexpectedPIDEvent); ok {
+				warning = e
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Expected an FSUnexpectedPIDEvent for the third PID")
+		}
+	}
+
+	if warning.Inode != inode {
+		t.Errorf("Expected inode %d, got %d", inode, warning.Inode)
+	}
+	if warning.FromPID != 200 || warning.ToPID != 100 {
+		t.Errorf("Expected established endpoints 200/100, got %d/%d", warning.FromPID, warning.ToPID)
+	}
+	if warning.UnexpectedPID != 300 {
+		t.Errorf("Expected unexpected PID 300, got %d", warning.UnexpectedPID)
+	}
+}
@@ -0,0 +1,89 @@
+package handshake
+
+import "strings"
+
+// Capabilities is a minimal decode of the "capabilities" object negotiated
+// during initialize:
+// https://modelcontextprotocol.io/specification/2025-06-18/schema#clientcapabilities
+// https://modelcontextprotocol.io/specification/2025-06-18/schema#servercapabilities
+//
+// Only presence of each top-level category is tracked, plus the one nested
+// flag (resources.subscribe) this package has a use for. The full schema
+// carries more nested options (e.g. listChanged on tools/resources/prompts)
+// this doesn't decode.
+type Capabilities struct {
+	Tools              bool
+	Resources          bool
+	ResourcesSubscribe bool
+	Prompts            bool
+	Sampling           bool
+	Logging            bool
+	Roots              bool
+	Elicitation        bool
+}
+
+// decodeCapabilities extracts the categories Capabilities tracks from raw,
+// the "capabilities" value of an initialize request's params or response
+// result. Unrecognized or absent categories are left false; a non-object
+// raw (including nil, for a handshake that omitted capabilities entirely)
+// decodes to the zero value.
+func decodeCapabilities(raw interface{}) Capabilities {
+	m, _ := raw.(map[string]interface{})
+
+	var c Capabilities
+	if m == nil {
+		return c
+	}
+
+	_, c.Tools = m["tools"]
+	_, c.Prompts = m["prompts"]
+	_, c.Sampling = m["sampling"]
+	_, c.Logging = m["logging"]
+	_, c.Roots = m["roots"]
+	_, c.Elicitation = m["elicitation"]
+
+	if resources, ok := m["resources"]; ok {
+		c.Resources = true
+		if obj, ok := resources.(map[string]interface{}); ok {
+			c.ResourcesSubscribe, _ = obj["subscribe"].(bool)
+		}
+	}
+
+	return c
+}
+
+// String renders the capabilities present in c as a comma-separated,
+// human-readable list, e.g. "tools, resources (subscribe), prompts". It
+// returns "none" if no category is set.
+func (c Capabilities) String() string {
+	var parts []string
+	if c.Tools {
+		parts = append(parts, "tools")
+	}
+	if c.Resources {
+		if c.ResourcesSubscribe {
+			parts = append(parts, "resources (subscribe)")
+		} else {
+			parts = append(parts, "resources")
+		}
+	}
+	if c.Prompts {
+		parts = append(parts, "prompts")
+	}
+	if c.Sampling {
+		parts = append(parts, "sampling")
+	}
+	if c.Logging {
+		parts = append(parts, "logging")
+	}
+	if c.Roots {
+		parts = append(parts, "roots")
+	}
+	if c.Elicitation {
+		parts = append(parts, "elicitation")
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
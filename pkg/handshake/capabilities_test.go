@@ -0,0 +1,100 @@
+package handshake
+
+import "testing"
+
+func TestDecodeCapabilities(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  interface{}
+		want Capabilities
+	}{
+		{
+			name: "nil capabilities",
+			raw:  nil,
+			want: Capabilities{},
+		},
+		{
+			name: "non-object capabilities",
+			raw:  "oops",
+			want: Capabilities{},
+		},
+		{
+			name: "empty object",
+			raw:  map[string]interface{}{},
+			want: Capabilities{},
+		},
+		{
+			name: "client capabilities with sampling and roots",
+			raw: map[string]interface{}{
+				"sampling": map[string]interface{}{},
+				"roots":    map[string]interface{}{"listChanged": true},
+			},
+			want: Capabilities{Sampling: true, Roots: true},
+		},
+		{
+			name: "server capabilities with resources subscribe",
+			raw: map[string]interface{}{
+				"tools":     map[string]interface{}{"listChanged": true},
+				"resources": map[string]interface{}{"subscribe": true, "listChanged": false},
+				"logging":   map[string]interface{}{},
+			},
+			want: Capabilities{Tools: true, Resources: true, ResourcesSubscribe: true, Logging: true},
+		},
+		{
+			name: "resources without subscribe",
+			raw: map[string]interface{}{
+				"resources": map[string]interface{}{"listChanged": true},
+			},
+			want: Capabilities{Resources: true},
+		},
+		{
+			name: "resources as an empty object (no nested flags)",
+			raw: map[string]interface{}{
+				"resources": map[string]interface{}{},
+			},
+			want: Capabilities{Resources: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeCapabilities(tt.raw); got != tt.want {
+				t.Errorf("decodeCapabilities() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapabilities_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Capabilities
+		want string
+	}{
+		{name: "none", c: Capabilities{}, want: "none"},
+		{name: "tools only", c: Capabilities{Tools: true}, want: "tools"},
+		{
+			name: "resources with subscribe",
+			c:    Capabilities{Resources: true, ResourcesSubscribe: true},
+			want: "resources (subscribe)",
+		},
+		{
+			name: "resources without subscribe",
+			c:    Capabilities{Resources: true},
+			want: "resources",
+		},
+		{
+			name: "multiple categories preserve declaration order",
+			c:    Capabilities{Tools: true, Prompts: true, Sampling: true},
+			want: "tools, prompts, sampling",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// Package handshake watches MCP traffic for violations of the
+// initialize/notifications-initialized handshake: per spec, a client must
+// not send requests other than ping before the server has responded to
+// initialize and the client has sent notifications/initialized. This is an
+// optional conformance check for server/client developers, not something
+// mcpspy enforces or blocks on.
+package handshake
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+const (
+	methodInitialize            = "initialize"
+	methodNotificationsInitDone = "notifications/initialized"
+	methodPing                  = "ping"
+)
+
+// deprecatedProtocolVersions are MCP spec revisions older than the one this
+// repo's parser targets (see pkg/mcp's 2025-06-18 schema). Negotiating one
+// isn't an error, but it means the session is missing newer capabilities.
+var deprecatedProtocolVersions = map[string]bool{
+	"2024-11-05": true,
+	"2025-03-26": true,
+}
+
+// Monitor watches EventTypeMCPMessage and publishes a
+// ProtocolViolationEvent for any request, response, or notification
+// observed before its session's handshake has completed. It also watches
+// each session's initialize exchange for the negotiated protocolVersion and
+// capabilities, publishing a ProtocolVersionMismatchEvent when the server
+// didn't agree to what the client asked for, and logging a one-line
+// summary of what each side supports once per session.
+//
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+//
+// Emits the following events:
+// - EventTypeProtocolViolation
+// - EventTypeProtocolVersionMismatch
+type Monitor struct {
+	eventBus bus.EventBus
+
+	mu sync.Mutex
+	// initialized tracks, per session, whether notifications/initialized
+	// has been observed yet. Absent keys count as not initialized.
+	initialized map[string]bool
+	// clientProtocolVersion tracks, per session, the protocolVersion the
+	// initialize request asked for, so it can be compared against the
+	// server's answer once the response arrives.
+	clientProtocolVersion map[string]string
+	// clientCapabilities tracks, per session, the capabilities object the
+	// initialize request declared, so it can be logged alongside the
+	// server's once the response arrives.
+	clientCapabilities map[string]Capabilities
+	// versionLogged tracks, per session, whether the negotiated
+	// protocolVersion has already been logged, so a long-lived session
+	// doesn't spam the log on every message.
+	versionLogged map[string]bool
+}
+
+// New creates a Monitor.
+func New(eventBus bus.EventBus) (*Monitor, error) {
+	m := &Monitor{
+		eventBus:              eventBus,
+		initialized:           make(map[string]bool),
+		clientProtocolVersion: make(map[string]string),
+		clientCapabilities:    make(map[string]Capabilities),
+		versionLogged:         make(map[string]bool),
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, m.handleMCPMessage); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// sessionKey identifies the hop a message's handshake state is tracked
+// against, scoped the same way as the other per-session detectors in this
+// repo since mcpspy has no higher-level session identity yet.
+func sessionKey(msg *event.MCPEvent) string {
+	switch {
+	case msg.StdioTransport != nil:
+		return fmt.Sprintf("stdio:%d:%d", msg.StdioTransport.FromPID, msg.StdioTransport.ToPID)
+	case msg.HttpTransport != nil:
+		return fmt.Sprintf("http:%d:%s", msg.HttpTransport.PID, msg.HttpTransport.Host)
+	case msg.TCPTransport != nil:
+		return fmt.Sprintf("tcp:%s:%d-%s:%d", msg.TCPTransport.SrcAddr, msg.TCPTransport.SrcPort, msg.TCPTransport.DstAddr, msg.TCPTransport.DstPort)
+	default:
+		return "unknown"
+	}
+}
+
+// handleMCPMessage flags msg as a violation if its session's handshake
+// hasn't completed yet, then advances the session to initialized if msg is
+// what completes it.
+func (m *Monitor) handleMCPMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	key := sessionKey(msg)
+
+	m.mu.Lock()
+	wasInitialized := m.initialized[key]
+	if msg.MessageType == event.JSONRPCMessageTypeNotification && msg.Method == methodNotificationsInitDone {
+		m.initialized[key] = true
+	}
+	if msg.MessageType == event.JSONRPCMessageTypeRequest && msg.Method == methodInitialize {
+		if v, ok := msg.Params["protocolVersion"].(string); ok {
+			m.clientProtocolVersion[key] = v
+		}
+		m.clientCapabilities[key] = decodeCapabilities(msg.Params["capabilities"])
+	}
+	m.mu.Unlock()
+
+	if violated, reason := violation(wasInitialized, msg); violated {
+		m.eventBus.Publish(&event.ProtocolViolationEvent{
+			Timestamp:      msg.Timestamp,
+			Method:         msg.Method,
+			MessageType:    msg.MessageType,
+			ID:             msg.ID,
+			Reason:         reason,
+			TransportType:  msg.TransportType,
+			StdioTransport: msg.StdioTransport,
+			HttpTransport:  msg.HttpTransport,
+		})
+	}
+
+	if msg.MessageType == event.JSONRPCMessageTypeResponse && msg.Request != nil && msg.Request.Method == methodInitialize {
+		m.checkProtocolVersion(key, msg)
+	}
+}
+
+// checkProtocolVersion logs the protocol version and capabilities
+// negotiated by an initialize exchange and, the first time the session's
+// response is seen, publishes a ProtocolVersionMismatchEvent if the server
+// answered with a different or deprecated version than the client
+// requested.
+func (m *Monitor) checkProtocolVersion(key string, msg *event.MCPEvent) {
+	result, ok := msg.Result.(map[string]interface{})
+	if !ok {
+		return
+	}
+	serverVersion, ok := result["protocolVersion"].(string)
+	if !ok {
+		return
+	}
+	serverCapabilities := decodeCapabilities(result["capabilities"])
+
+	m.mu.Lock()
+	clientVersion := m.clientProtocolVersion[key]
+	clientCapabilities := m.clientCapabilities[key]
+	alreadyLogged := m.versionLogged[key]
+	m.versionLogged[key] = true
+	m.mu.Unlock()
+
+	if alreadyLogged {
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"client_version":      clientVersion,
+		"server_version":      serverVersion,
+		"client_capabilities": clientCapabilities.String(),
+		"server_capabilities": serverCapabilities.String(),
+	}).Debug("MCP protocol version and capabilities negotiated")
+
+	reason := ""
+	switch {
+	case clientVersion != "" && clientVersion != serverVersion:
+		reason = fmt.Sprintf("client requested protocol version %q but server negotiated %q", clientVersion, serverVersion)
+	case deprecatedProtocolVersions[serverVersion]:
+		reason = fmt.Sprintf("server negotiated deprecated protocol version %q", serverVersion)
+	default:
+		return
+	}
+
+	m.eventBus.Publish(&event.ProtocolVersionMismatchEvent{
+		Timestamp:      msg.Timestamp,
+		ClientVersion:  clientVersion,
+		ServerVersion:  serverVersion,
+		Reason:         reason,
+		TransportType:  msg.TransportType,
+		StdioTransport: msg.StdioTransport,
+		HttpTransport:  msg.HttpTransport,
+	})
+}
+
+// violation reports whether msg should have waited for the handshake to
+// complete, given wasInitialized (the session's state just before msg).
+func violation(wasInitialized bool, msg *event.MCPEvent) (bool, string) {
+	if wasInitialized {
+		return false, ""
+	}
+
+	switch msg.MessageType {
+	case event.JSONRPCMessageTypeRequest:
+		if msg.Method == methodInitialize || msg.Method == methodPing {
+			return false, ""
+		}
+		return true, fmt.Sprintf("%q request sent before the initialize handshake completed", msg.Method)
+	case event.JSONRPCMessageTypeResponse:
+		if msg.Request != nil && msg.Request.Method == methodInitialize {
+			return false, ""
+		}
+		return true, fmt.Sprintf("response to %q observed before the initialize handshake completed", requestMethod(msg))
+	case event.JSONRPCMessageTypeNotification:
+		if msg.Method == methodNotificationsInitDone {
+			return false, ""
+		}
+		return true, fmt.Sprintf("%q notification sent before the initialize handshake completed", msg.Method)
+	default:
+		return false, ""
+	}
+}
+
+// requestMethod returns the method of the request a response correlates to,
+// or "" if it wasn't correlated.
+func requestMethod(msg *event.MCPEvent) string {
+	if msg.Request == nil {
+		return ""
+	}
+	return msg.Request.Method
+}
+
+// Close unsubscribes from the event bus.
+func (m *Monitor) Close() {
+	m.eventBus.Unsubscribe(event.EventTypeMCPMessage, m.handleMCPMessage)
+}
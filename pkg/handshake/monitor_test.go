@@ -0,0 +1,189 @@
+package handshake
+
+import (
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func request(method string) *event.MCPEvent {
+	return &event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{
+		MessageType: event.JSONRPCMessageTypeRequest,
+		ID:          int64(1),
+		Method:      method,
+	}}
+}
+
+func response(requestMethod string) *event.MCPEvent {
+	return &event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{
+		MessageType: event.JSONRPCMessageTypeResponse,
+		ID:          int64(1),
+		Request:     &event.JSONRPCMessage{Method: requestMethod},
+	}}
+}
+
+func notification(method string) *event.MCPEvent {
+	return &event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{
+		MessageType: event.JSONRPCMessageTypeNotification,
+		Method:      method,
+	}}
+}
+
+func collectViolations(events <-chan event.Event) []*event.ProtocolViolationEvent {
+	var violations []*event.ProtocolViolationEvent
+	for {
+		select {
+		case e := <-events:
+			if v, ok := e.(*event.ProtocolViolationEvent); ok {
+				violations = append(violations, v)
+			}
+		default:
+			return violations
+		}
+	}
+}
+
+func TestMonitor_CorrectHandshakeProducesNoViolations(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	m, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(request(methodInitialize))
+	mockBus.Publish(response(methodInitialize))
+	mockBus.Publish(notification(methodNotificationsInitDone))
+	mockBus.Publish(request("tools/call"))
+	mockBus.Publish(response("tools/call"))
+
+	if violations := collectViolations(mockBus.Events()); len(violations) != 0 {
+		t.Fatalf("expected no violations for a correct handshake, got %d: %+v", len(violations), violations)
+	}
+}
+
+func TestMonitor_OutOfOrderRequestTriggersViolation(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	m, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(request("tools/call"))
+
+	violations := collectViolations(mockBus.Events())
+	if len(violations) != 1 {
+		t.Fatalf("expected exactly 1 violation for a request sent before the handshake, got %d: %+v", len(violations), violations)
+	}
+	if violations[0].Method != "tools/call" {
+		t.Errorf("expected violation for method %q, got %q", "tools/call", violations[0].Method)
+	}
+}
+
+func initRequest(protocolVersion string) *event.MCPEvent {
+	return &event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{
+		MessageType: event.JSONRPCMessageTypeRequest,
+		ID:          int64(1),
+		Method:      methodInitialize,
+		Params:      map[string]interface{}{"protocolVersion": protocolVersion},
+	}}
+}
+
+func initResponse(protocolVersion string) *event.MCPEvent {
+	return &event.MCPEvent{JSONRPCMessage: event.JSONRPCMessage{
+		MessageType: event.JSONRPCMessageTypeResponse,
+		ID:          int64(1),
+		Result:      map[string]interface{}{"protocolVersion": protocolVersion},
+		Request:     &event.JSONRPCMessage{Method: methodInitialize},
+	}}
+}
+
+func collectVersionMismatches(events <-chan event.Event) []*event.ProtocolVersionMismatchEvent {
+	var mismatches []*event.ProtocolVersionMismatchEvent
+	for {
+		select {
+		case e := <-events:
+			if v, ok := e.(*event.ProtocolVersionMismatchEvent); ok {
+				mismatches = append(mismatches, v)
+			}
+		default:
+			return mismatches
+		}
+	}
+}
+
+func TestMonitor_MatchingProtocolVersionProducesNoMismatch(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	m, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(initRequest("2025-06-18"))
+	mockBus.Publish(initResponse("2025-06-18"))
+
+	if mismatches := collectVersionMismatches(mockBus.Events()); len(mismatches) != 0 {
+		t.Fatalf("expected no mismatch when client and server agree, got %d: %+v", len(mismatches), mismatches)
+	}
+}
+
+func TestMonitor_DifferingProtocolVersionProducesMismatch(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	m, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(initRequest("2025-06-18"))
+	mockBus.Publish(initResponse("2024-11-05"))
+
+	mismatches := collectVersionMismatches(mockBus.Events())
+	if len(mismatches) != 1 {
+		t.Fatalf("expected exactly 1 mismatch, got %d: %+v", len(mismatches), mismatches)
+	}
+	if mismatches[0].ClientVersion != "2025-06-18" || mismatches[0].ServerVersion != "2024-11-05" {
+		t.Errorf("got client=%q server=%q, want client=%q server=%q",
+			mismatches[0].ClientVersion, mismatches[0].ServerVersion, "2025-06-18", "2024-11-05")
+	}
+}
+
+func TestMonitor_DeprecatedProtocolVersionProducesMismatchEvenWhenAgreed(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	m, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(initRequest("2024-11-05"))
+	mockBus.Publish(initResponse("2024-11-05"))
+
+	if mismatches := collectVersionMismatches(mockBus.Events()); len(mismatches) != 1 {
+		t.Fatalf("expected 1 mismatch for a deprecated version, got %d", len(mismatches))
+	}
+}
+
+func TestMonitor_PingAllowedBeforeHandshake(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	m, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer m.Close()
+
+	mockBus.Publish(request(methodPing))
+
+	if violations := collectViolations(mockBus.Events()); len(violations) != 0 {
+		t.Fatalf("expected ping to be allowed before the handshake, got %d violations", len(violations))
+	}
+}
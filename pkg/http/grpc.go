@@ -0,0 +1,47 @@
+package http
+
+import "bytes"
+
+// grpcContentType is the Content-Type value gRPC uses on the wire. Its
+// appearance anywhere in an HTTP/2 frame's header block is strong evidence
+// of gRPC traffic, even without decoding the surrounding HPACK framing.
+var grpcContentType = []byte("application/grpc")
+
+// grpcPathHeaderName is the literal (non-Huffman-coded) HPACK header name
+// for the ":path" pseudo-header, which carries the RPC method for a gRPC
+// call (e.g. "/mcp.ToolService/CallTool").
+var grpcPathHeaderName = []byte(":path")
+
+// detectGRPC performs a best-effort, non-HPACK scan of a raw HTTP/2 payload
+// for signs of gRPC traffic. Full HTTP/2 framing and HPACK decoding
+// (including Huffman-coded header fields) are out of scope - this only
+// catches the "application/grpc" content-type and a literal ":path" header
+// value, which is enough to flag gRPC traffic as present and report the
+// called method without attempting to decode the protobuf body.
+func detectGRPC(data []byte) (isGRPC bool, method string) {
+	isGRPC = bytes.Contains(data, grpcContentType)
+
+	if idx := bytes.Index(data, grpcPathHeaderName); idx != -1 {
+		method = literalHPACKString(data[idx+len(grpcPathHeaderName):])
+	}
+
+	return isGRPC, method
+}
+
+// literalHPACKString reads a single HPACK string value immediately
+// following a literal header name: a length-prefixed byte (the high bit is
+// the Huffman flag, the low 7 bits are the length) followed by that many
+// literal bytes. Huffman-coded values (the high bit set) aren't decoded and
+// return "".
+func literalHPACKString(data []byte) string {
+	if len(data) == 0 || data[0]&0x80 != 0 {
+		return ""
+	}
+
+	length := int(data[0] & 0x7F)
+	if length == 0 || len(data) < 1+length {
+		return ""
+	}
+
+	return string(data[1 : 1+length])
+}
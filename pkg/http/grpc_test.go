@@ -0,0 +1,48 @@
+package http
+
+import "testing"
+
+func TestDetectGRPC_ContentType(t *testing.T) {
+	data := []byte("\x00\x00\x20\x01\x04\x00\x00\x00\x01content-type: application/grpc+proto")
+
+	isGRPC, _ := detectGRPC(data)
+	if !isGRPC {
+		t.Fatalf("expected application/grpc content-type to be detected")
+	}
+}
+
+func TestDetectGRPC_NoContentType(t *testing.T) {
+	isGRPC, _ := detectGRPC([]byte("plain http/2 headers, nothing grpc-flavored here"))
+	if isGRPC {
+		t.Fatalf("expected no gRPC detection for unrelated HTTP/2 data")
+	}
+}
+
+func TestDetectGRPC_ExtractsMethodFromLiteralPath(t *testing.T) {
+	path := "/mcp.ToolService/CallTool"
+	var data []byte
+	data = append(data, []byte("application/grpc")...)
+	data = append(data, []byte(":path")...)
+	data = append(data, byte(len(path))) // literal string, no Huffman flag
+	data = append(data, []byte(path)...)
+
+	isGRPC, method := detectGRPC(data)
+	if !isGRPC {
+		t.Fatalf("expected application/grpc content-type to be detected")
+	}
+	if method != path {
+		t.Errorf("method = %q, want %q", method, path)
+	}
+}
+
+func TestDetectGRPC_HuffmanEncodedPathNotDecoded(t *testing.T) {
+	var data []byte
+	data = append(data, []byte("application/grpc")...)
+	data = append(data, []byte(":path")...)
+	data = append(data, byte(0x80|10)) // Huffman flag set; we don't decode it
+
+	_, method := detectGRPC(data)
+	if method != "" {
+		t.Errorf("method = %q, want empty for Huffman-coded value", method)
+	}
+}
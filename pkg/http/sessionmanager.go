@@ -22,6 +22,12 @@ type httpRequest struct {
 	host       string
 	headers    map[string]string
 	body       []byte
+
+	// authScheme and hasCredentials are derived from the Authorization
+	// header for security auditing. The credential itself is never
+	// retained, here or in headers.
+	authScheme     string
+	hasCredentials bool
 }
 
 // httpResponse represents a parsed HTTP response
@@ -33,7 +39,11 @@ type httpResponse struct {
 	body       []byte
 	isChunked  bool
 	isSSE      bool
-	isGzip     bool
+	// isNDJSON marks a newline-delimited JSON stream (e.g. Ollama's
+	// /api/chat, /api/generate): unlike SSE, each line is a bare JSON
+	// object with no "data:"/"event:" framing or blank-line terminator.
+	isNDJSON bool
+	isGzip   bool
 }
 
 // session tracks HTTP communication for a single SSL context
@@ -56,6 +66,17 @@ type session struct {
 	// SSE tracking
 	isSSE         bool
 	sseEventsSent int // Track how many SSE events we've already sent
+
+	// NDJSON tracking
+	isNDJSON        bool
+	ndjsonLinesSent int // Track how many NDJSON lines we've already sent
+
+	// WebSocket tracking: once the handshake response is seen, subsequent
+	// payloads on this session are RFC6455 frames rather than HTTP, so each
+	// direction gets its own reassembly buffer (the connection is full-duplex).
+	isWebSocket   bool
+	wsRequestBuf  *bytes.Buffer
+	wsResponseBuf *bytes.Buffer
 }
 
 func (s *session) logFields() logrus.Fields {
@@ -69,7 +90,8 @@ func (s *session) logFields() logrus.Fields {
 // SessionManager manages HTTP sessions over SSL contexts
 // Subscribes to the following events:
 // - TlsPayload (for both send and recv) - to capture HTTP data
-// - TlsFree - to clean up sessions
+// - TlsFree - to finalize and clean up sessions on connection close
+// - SocketAddr - to attribute a connection's local/remote address
 //
 // Emits the following events:
 // - HttpRequestEvent
@@ -79,12 +101,25 @@ type SessionManager struct {
 	mu       sync.Mutex
 	sessions map[uint64]*session // key is SSL context
 	eventBus bus.EventBus
+
+	// grpcSeen tracks SSL contexts a GRPCDetectedEvent has already been
+	// emitted for, keyed by SSL context, so a long-lived HTTP/2 connection
+	// only reports gRPC once instead of on every frame.
+	grpcSeen map[uint64]bool
+
+	// socketAddrs holds the most recent SocketAddrEvent per SSL context, so
+	// emitHttpRequestEvent/emitHttpResponseEvent can attribute an address
+	// regardless of whether it arrived before or after the HTTP data that
+	// triggered SSL_get_fd in the traced process.
+	socketAddrs map[uint64]*event.SocketAddrEvent
 }
 
 func NewSessionManager(eventBus bus.EventBus) (*SessionManager, error) {
 	sm := &SessionManager{
-		sessions: make(map[uint64]*session),
-		eventBus: eventBus,
+		sessions:    make(map[uint64]*session),
+		grpcSeen:    make(map[uint64]bool),
+		socketAddrs: make(map[uint64]*event.SocketAddrEvent),
+		eventBus:    eventBus,
 	}
 
 	if err := eventBus.Subscribe(event.EventTypeTlsPayloadRecv, sm.ProcessTlsEvent); err != nil {
@@ -98,10 +133,29 @@ func NewSessionManager(eventBus bus.EventBus) (*SessionManager, error) {
 		sm.Close()
 		return nil, err
 	}
+	if err := eventBus.Subscribe(event.EventTypeSocketAddr, sm.ProcessSocketAddrEvent); err != nil {
+		sm.Close()
+		return nil, err
+	}
 
 	return sm, nil
 }
 
+// ProcessSocketAddrEvent records the local/remote address resolved for an
+// SSL context, so it's available by the time the session's request/response
+// events are emitted.
+func (s *SessionManager) ProcessSocketAddrEvent(e event.Event) {
+	addrEvent, ok := e.(*event.SocketAddrEvent)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.socketAddrs[addrEvent.SSLContext] = addrEvent
+}
+
 func (s *SessionManager) ProcessTlsEvent(e event.Event) {
 	// We only handle TlsPayload events here
 	tlsEvent, ok := e.(*event.TlsPayloadEvent)
@@ -109,6 +163,14 @@ func (s *SessionManager) ProcessTlsEvent(e event.Event) {
 		return
 	}
 
+	// We don't parse HTTP/2 framing (used by both streamable-HTTP MCP
+	// over h2 and gRPC), but we can still sniff it for gRPC traffic so
+	// that's not entirely invisible.
+	if tlsEvent.HttpVersion == event.HttpVersion2 {
+		s.processGRPCEvent(tlsEvent)
+		return
+	}
+
 	// Only process HTTP/1.1 events for now.
 	if tlsEvent.HttpVersion != event.HttpVersion1 {
 		return
@@ -119,18 +181,27 @@ func (s *SessionManager) ProcessTlsEvent(e event.Event) {
 
 	logrus.WithFields(e.LogFields()).Trace("Processing TLS event")
 
+	// The eBPF side caps how much of a single SSL_read/SSL_write buffer it
+	// copies into the ring buffer event; anything past that is lost before
+	// it ever reaches us. Surface it instead of silently parsing a gap.
+	if tlsEvent.Size > tlsEvent.BufSize {
+		logrus.WithFields(e.LogFields()).Warn("TLS payload truncated before capture; some request/response data is missing")
+	}
+
 	// Get or create session
 	sess, exists := s.sessions[tlsEvent.SSLContext]
 	if !exists {
 		logrus.WithFields(e.LogFields()).Trace("Creating new session")
 		sess = &session{
-			pid:         tlsEvent.PID,
-			comm:        tlsEvent.CommBytes,
-			sslContext:  tlsEvent.SSLContext,
-			request:     &httpRequest{},
-			requestBuf:  &bytes.Buffer{},
-			response:    &httpResponse{},
-			responseBuf: &bytes.Buffer{},
+			pid:           tlsEvent.PID,
+			comm:          tlsEvent.CommBytes,
+			sslContext:    tlsEvent.SSLContext,
+			request:       &httpRequest{},
+			requestBuf:    &bytes.Buffer{},
+			response:      &httpResponse{},
+			responseBuf:   &bytes.Buffer{},
+			wsRequestBuf:  &bytes.Buffer{},
+			wsResponseBuf: &bytes.Buffer{},
 		}
 		s.sessions[tlsEvent.SSLContext] = sess
 	} else {
@@ -142,6 +213,12 @@ func (s *SessionManager) ProcessTlsEvent(e event.Event) {
 	switch tlsEvent.EventType {
 	case event.EventTypeTlsPayloadSend:
 		// Client -> Server (Request)
+		if sess.isWebSocket {
+			sess.wsRequestBuf.Write(data)
+			s.processWebSocketFrames(sess, sess.wsRequestBuf, true)
+			break
+		}
+
 		sess.requestBuf.Write(data)
 		sess.request = parseHTTPRequest(sess.requestBuf.Bytes())
 
@@ -152,6 +229,12 @@ func (s *SessionManager) ProcessTlsEvent(e event.Event) {
 		}
 	case event.EventTypeTlsPayloadRecv:
 		// Server -> Client (Response)
+		if sess.isWebSocket {
+			sess.wsResponseBuf.Write(data)
+			s.processWebSocketFrames(sess, sess.wsResponseBuf, false)
+			break
+		}
+
 		sess.responseBuf.Write(data)
 		sess.response = parseHTTPResponse(sess.responseBuf.Bytes())
 
@@ -161,25 +244,55 @@ func (s *SessionManager) ProcessTlsEvent(e event.Event) {
 			sess.isSSE = true
 		}
 
+		// Check if this is an NDJSON response
+		if sess.response != nil && sess.response.isNDJSON {
+			logrus.WithFields(sess.logFields()).Trace("NDJSON response detected")
+			sess.isNDJSON = true
+		}
+
 		// For SSE and chunked responses, process incrementally
 		if sess.isSSE && sess.response != nil && sess.response.isChunked {
 			// Process SSE events from the current response buffer
 			s.processHTTPSSEResponse(sess)
 		}
 
+		// For NDJSON and chunked responses, process incrementally
+		if sess.isNDJSON && sess.response != nil && sess.response.isChunked {
+			s.processHTTPNDJSONResponse(sess)
+		}
+
 		// Emit response event if complete and not yet emitted
 		if sess.response != nil && sess.response.isComplete && !sess.responseEventEmitted {
 			s.emitHttpResponseEvent(sess)
 			sess.responseEventEmitted = true
 		}
+
+		// A 101 response hands the connection off to the WebSocket
+		// protocol: anything captured past the handshake headers is
+		// already framed, not HTTP, so seed wsResponseBuf with it and
+		// process frames from here on.
+		if sess.responseEventEmitted && !sess.isWebSocket && isWebSocketUpgrade(sess.response) {
+			sess.isWebSocket = true
+			if headerEnd := bytes.Index(sess.responseBuf.Bytes(), []byte("\r\n\r\n")); headerEnd != -1 {
+				sess.wsResponseBuf.Write(sess.responseBuf.Bytes()[headerEnd+4:])
+				s.processWebSocketFrames(sess, sess.wsResponseBuf, false)
+			}
+		}
 	}
 
-	// Clean up session when both events have been emitted
-	if sess.requestEventEmitted && sess.responseEventEmitted {
+	// Clean up session when both events have been emitted. A WebSocket
+	// session stays open past that point - it lives until ProcessTlsFreeEvent
+	// tears down the connection - since both directions keep carrying frames.
+	if sess.requestEventEmitted && sess.responseEventEmitted && !sess.isWebSocket {
 		delete(s.sessions, tlsEvent.SSLContext)
 	}
 }
 
+// ProcessTlsFreeEvent handles the SSL_free call that tears down a
+// connection's SSL context. This is our connection-close signal: rather
+// than relying on an idle timeout to decide a long-lived session (e.g. SSE)
+// is over, we finalize it here, keyed by the same SSL context used to
+// track it throughout its life.
 func (s *SessionManager) ProcessTlsFreeEvent(e event.Event) {
 	// We only handle TlsFree events here
 	tlsFreeEvent, ok := e.(*event.TlsFreeEvent)
@@ -190,11 +303,117 @@ func (s *SessionManager) ProcessTlsFreeEvent(e event.Event) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if sess, exists := s.sessions[tlsFreeEvent.SSLContext]; exists {
+		s.finalizeSessionOnClose(sess)
+	}
+
 	// Clean up the session
 	delete(s.sessions, tlsFreeEvent.SSLContext)
+	delete(s.grpcSeen, tlsFreeEvent.SSLContext)
+	delete(s.socketAddrs, tlsFreeEvent.SSLContext)
+}
+
+// processGRPCEvent performs best-effort gRPC detection on an HTTP/2
+// connection's payload. Full HTTP/2 framing and HPACK decoding are out of
+// scope, so this emits at most one GRPCDetectedEvent per SSL context,
+// the first time a frame looks like gRPC traffic.
+func (s *SessionManager) processGRPCEvent(tlsEvent *event.TlsPayloadEvent) {
+	if s.grpcSeen[tlsEvent.SSLContext] {
+		return
+	}
+
+	isGRPC, method := detectGRPC(tlsEvent.Buffer())
+	if !isGRPC {
+		return
+	}
+	s.grpcSeen[tlsEvent.SSLContext] = true
+
+	grpcEvent := &event.GRPCDetectedEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeGRPCDetected,
+			PID:       tlsEvent.PID,
+			CommBytes: tlsEvent.CommBytes,
+		},
+		SSLContext: tlsEvent.SSLContext,
+		Method:     method,
+	}
+
+	logrus.WithFields(grpcEvent.LogFields()).Trace(fmt.Sprintf("event#%s", grpcEvent.Type().String()))
+
+	s.eventBus.Publish(grpcEvent)
+}
+
+// finalizeSessionOnClose emits any request/response events that hadn't yet
+// been emitted for sess when its connection closed. A response that was
+// still streaming (no clean terminator observed, e.g. a chunked response or
+// SSE stream cut short) is emitted with whatever was captured so far,
+// marked Truncated, instead of being silently dropped.
+func (s *SessionManager) finalizeSessionOnClose(sess *session) {
+	if sess.request == nil || !sess.request.isComplete {
+		// Never got a full request; nothing coherent to finalize.
+		return
+	}
+
+	if !sess.requestEventEmitted {
+		s.emitHttpRequestEvent(sess)
+		sess.requestEventEmitted = true
+	}
+
+	if sess.responseEventEmitted || sess.responseBuf.Len() == 0 {
+		// Either already emitted, or no response data ever arrived.
+		return
+	}
+
+	logrus.WithFields(sess.logFields()).Debug("Connection closed mid-response; finalizing HTTP session with captured data")
+
+	sess.response.body = partialResponseBody(sess)
+	s.emitHttpResponseEvent(sess)
+	sess.responseEventEmitted = true
+}
+
+// partialResponseBody extracts whatever response body bytes have arrived so
+// far from sess's raw response buffer, used to finalize a session whose
+// connection closed before the response was recognized as complete.
+func partialResponseBody(sess *session) []byte {
+	raw := sess.responseBuf.Bytes()
+	headerEnd := bytes.Index(raw, []byte("\r\n\r\n"))
+	if headerEnd == -1 {
+		return nil
+	}
+
+	bodyStart := headerEnd + 4
+	if bodyStart >= len(raw) {
+		return nil
+	}
+	bodyData := raw[bodyStart:]
+
+	if sess.response != nil && sess.response.isChunked {
+		body, _ := parseChunkedBody(bodyData)
+		bodyData = body
+	}
+
+	if sess.response != nil && sess.response.isGzip && len(bodyData) > 0 {
+		bodyData = decompressGzip(bodyData)
+	}
+
+	return bodyData
+}
+
+// socketAddrStrs returns the "ip:port" remote/local endpoints recorded for
+// sslContext, or "", "" if no SocketAddrEvent has been seen for it yet.
+// Callers must hold s.mu.
+func (s *SessionManager) socketAddrStrs(sslContext uint64) (remote, local string) {
+	addrEvent, ok := s.socketAddrs[sslContext]
+	if !ok {
+		return "", ""
+	}
+	return fmt.Sprintf("%s:%d", addrEvent.RemoteAddrStr(), addrEvent.RemotePort),
+		fmt.Sprintf("%s:%d", addrEvent.LocalAddrStr(), addrEvent.LocalPort)
 }
 
 func (s *SessionManager) emitHttpRequestEvent(sess *session) {
+	remoteAddr, localAddr := s.socketAddrStrs(sess.sslContext)
+
 	// Build request event
 	event := &event.HttpRequestEvent{
 		EventHeader: event.EventHeader{
@@ -208,6 +427,10 @@ func (s *SessionManager) emitHttpRequestEvent(sess *session) {
 		Path:           sess.request.path,
 		RequestHeaders: sess.request.headers,
 		RequestPayload: sess.request.body,
+		AuthScheme:     sess.request.authScheme,
+		HasCredentials: sess.request.hasCredentials,
+		RemoteAddr:     remoteAddr,
+		LocalAddr:      localAddr,
 	}
 
 	logrus.WithFields(event.LogFields()).Trace(fmt.Sprintf("event#%s", event.Type().String()))
@@ -220,6 +443,8 @@ func (s *SessionManager) emitHttpResponseEvent(sess *session) {
 		logrus.WithFields(sess.logFields()).Debug("HTTP request is not complete when HTTP response event is emitted. Expect missing data.")
 	}
 
+	remoteAddr, localAddr := s.socketAddrStrs(sess.sslContext)
+
 	// Build response event - includes request info for context
 	event := &event.HttpResponseEvent{
 		EventHeader: event.EventHeader{
@@ -240,11 +465,58 @@ func (s *SessionManager) emitHttpResponseEvent(sess *session) {
 			Path:           sess.request.path,
 			RequestHeaders: sess.request.headers,
 			RequestPayload: sess.request.body,
+			AuthScheme:     sess.request.authScheme,
+			HasCredentials: sess.request.hasCredentials,
+			RemoteAddr:     remoteAddr,
+			LocalAddr:      localAddr,
 		},
 		Code:            sess.response.statusCode,
 		IsChunked:       sess.response.isChunked,
 		ResponseHeaders: sess.response.headers,
 		ResponsePayload: sess.response.body,
+		Truncated:       !sess.response.isComplete,
+	}
+
+	logrus.WithFields(event.LogFields()).Trace(fmt.Sprintf("event#%s", event.Type().String()))
+
+	s.eventBus.Publish(event)
+}
+
+// processWebSocketFrames decodes as many complete messages as buf currently
+// holds, emits one WebSocketMessageEvent per message, and drops the consumed
+// bytes from buf so a frame split across TLS records is completed (and
+// parsed) on a later call instead of being re-parsed from the start.
+func (s *SessionManager) processWebSocketFrames(sess *session, buf *bytes.Buffer, isRequest bool) {
+	messages, consumed := parseWebSocketMessages(buf.Bytes())
+	if consumed > 0 {
+		buf.Next(consumed)
+	}
+	for _, msg := range messages {
+		s.emitWebSocketEvent(sess, msg, isRequest)
+	}
+}
+
+func (s *SessionManager) emitWebSocketEvent(sess *session, data []byte, isRequest bool) {
+	event := &event.WebSocketMessageEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeHttpWebSocket,
+			PID:       sess.pid,
+			CommBytes: sess.comm,
+		},
+		SSLContext: sess.sslContext,
+		HttpRequestEvent: event.HttpRequestEvent{
+			EventHeader: event.EventHeader{
+				EventType: event.EventTypeHttpRequest,
+				PID:       sess.pid,
+				CommBytes: sess.comm,
+			},
+			SSLContext: sess.sslContext,
+			Method:     sess.request.method,
+			Host:       sess.request.host,
+			Path:       sess.request.path,
+		},
+		IsRequest: isRequest,
+		Data:      data,
 	}
 
 	logrus.WithFields(event.LogFields()).Trace(fmt.Sprintf("event#%s", event.Type().String()))
@@ -252,7 +524,7 @@ func (s *SessionManager) emitHttpResponseEvent(sess *session) {
 	s.eventBus.Publish(event)
 }
 
-func (s *SessionManager) emitSSEEvent(sess *session, eventType string, data []byte) {
+func (s *SessionManager) emitSSEEvent(sess *session, eventType string, data []byte, lastEventID string) {
 	// Build SSE event - include request and response context
 	event := &event.SSEEvent{
 		EventHeader: event.EventHeader{
@@ -273,9 +545,12 @@ func (s *SessionManager) emitSSEEvent(sess *session, eventType string, data []by
 			Path:           sess.request.path,
 			RequestHeaders: sess.request.headers,
 			RequestPayload: sess.request.body,
+			AuthScheme:     sess.request.authScheme,
+			HasCredentials: sess.request.hasCredentials,
 		},
 		SSEEventType: eventType,
 		Data:         data,
+		LastEventID:  lastEventID,
 	}
 
 	logrus.WithFields(event.LogFields()).Trace(fmt.Sprintf("event#%s", event.Type().String()))
@@ -339,6 +614,11 @@ func parseHTTPRequest(data []byte) *httpRequest {
 				case "content-length":
 					hasContentLength = true
 					fmt.Sscanf(value, "%d", &contentLength)
+				case "authorization":
+					req.authScheme, _, _ = strings.Cut(value, " ")
+					req.hasCredentials = true
+					// Redact the credential from the retained headers too.
+					req.headers[key] = req.authScheme + " <redacted>"
 				}
 			}
 		}
@@ -419,6 +699,8 @@ func parseHTTPResponse(data []byte) *httpResponse {
 					resp.isChunked = true
 				} else if lowerKey == "content-type" && strings.Contains(strings.ToLower(value), "text/event-stream") {
 					resp.isSSE = true
+				} else if lowerKey == "content-type" && strings.Contains(strings.ToLower(value), "application/x-ndjson") {
+					resp.isNDJSON = true
 				} else if lowerKey == "content-length" {
 					hasContentLength = true
 					fmt.Sscanf(value, "%d", &contentLength)
@@ -583,11 +865,12 @@ func (s *SessionManager) processHTTPSSEResponse(sess *session) {
 		newEvents := allEvents[sess.sseEventsSent:]
 
 		for _, eventData := range newEvents {
-			// Extract event type and data content for the SSE event
-			eventType, dataContent := extractSSEEventData(eventData)
+			// Extract event type, data content, and id (for Last-Event-ID
+			// resumption) for the SSE event
+			eventType, dataContent, id := extractSSEEventData(eventData)
 			if dataContent != nil {
 				// Create SSE event with HTTP context
-				s.emitSSEEvent(sess, eventType, dataContent)
+				s.emitSSEEvent(sess, eventType, dataContent, id)
 			}
 
 			sess.sseEventsSent++
@@ -595,6 +878,63 @@ func (s *SessionManager) processHTTPSSEResponse(sess *session) {
 	}
 }
 
+// processHTTPNDJSONResponse processes NDJSON lines from chunked data
+// incrementally, the same way processHTTPSSEResponse does for SSE.
+func (s *SessionManager) processHTTPNDJSONResponse(sess *session) {
+	if !sess.request.isComplete {
+		logrus.WithFields(sess.logFields()).Debug("HTTP request is not complete when NDJSON chunks are processed. Expect missing data.")
+	}
+
+	rawData := sess.responseBuf.Bytes()
+
+	headerEnd := bytes.Index(rawData, []byte("\r\n\r\n"))
+	if headerEnd == -1 {
+		return // Headers not complete yet
+	}
+
+	bodyStart := headerEnd + 4
+	if bodyStart >= len(rawData) {
+		return // No body data yet
+	}
+
+	// We ignore the error here, as we want to extract lines as soon as
+	// they arrive.
+	chunkData, _ := parseChunkedBody(rawData[bodyStart:])
+	if len(chunkData) == 0 {
+		return
+	}
+
+	allLines := parseNDJSONLines(chunkData)
+
+	if len(allLines) > sess.ndjsonLinesSent {
+		newLines := allLines[sess.ndjsonLinesSent:]
+		for _, line := range newLines {
+			s.emitSSEEvent(sess, "", line, "")
+			sess.ndjsonLinesSent++
+		}
+	}
+}
+
+// parseNDJSONLines splits data on newlines and returns the complete lines
+// (i.e. every line but a possibly-unterminated final one, which may still
+// be waiting on more chunk data). Blank lines are skipped.
+func parseNDJSONLines(data []byte) [][]byte {
+	var lines [][]byte
+
+	rawLines := bytes.Split(data, []byte("\n"))
+	// The last element is either "" (data ended on a newline) or a
+	// not-yet-terminated line; either way it isn't a complete line yet.
+	for _, line := range rawLines[:len(rawLines)-1] {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) == 0 {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
 // parseSSEEvents receives raw response payload (after trimming the chunked parts)
 // and returns list of SSE events as raw data.
 // Each event contains all fields (data:, event:, id:, retry:, etc.) concatenated.
@@ -647,14 +987,19 @@ func parseSSEEvents(data []byte) [][]byte {
 	return events
 }
 
-// extractSSEEventData extracts both the event type and data content from a complete SSE event.
-// Returns the event type (defaulting to "message" if not specified) and the data content.
-func extractSSEEventData(event []byte) (eventType string, data []byte) {
+// extractSSEEventData extracts the event type, data content, and id from a
+// complete SSE event. Returns the event type (defaulting to "message" if not
+// specified), the data content, and the id (empty if the event carried no
+// "id:" field). The id is what a client echoes back as the Last-Event-ID
+// header when reconnecting, so mcpspy can recognize replayed events after a
+// dropped SSE connection (see pkg/mcp's use of SSEEvent.LastEventID).
+func extractSSEEventData(event []byte) (eventType string, data []byte, id string) {
 	lines := bytes.Split(event, []byte("\n"))
 	var dataLines [][]byte
 
 	dataPrefix := []byte("data:")
 	eventPrefix := []byte("event:")
+	idPrefix := []byte("id:")
 	eventType = "message" // Default per SSE spec
 
 	for _, line := range lines {
@@ -668,12 +1013,14 @@ func extractSSEEventData(event []byte) (eventType string, data []byte) {
 			if len(extractedType) > 0 {
 				eventType = string(extractedType)
 			}
+		} else if bytes.HasPrefix(line, idPrefix) {
+			id = string(bytes.TrimSpace(bytes.TrimPrefix(line, idPrefix)))
 		}
 	}
 
 	if len(dataLines) == 0 {
-		return eventType, nil
+		return eventType, nil, id
 	}
 
-	return eventType, bytes.Join(dataLines, []byte("\n"))
+	return eventType, bytes.Join(dataLines, []byte("\n")), id
 }
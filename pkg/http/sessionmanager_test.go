@@ -5,9 +5,13 @@ import (
 	"compress/gzip"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+
 	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
 )
@@ -134,6 +138,51 @@ func TestSessionManager_BasicRequestResponse(t *testing.T) {
 	}
 }
 
+func TestSessionManager_AttributesSocketAddr(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create SessionManager: %v", err)
+	}
+	defer sm.Close()
+
+	sslCtx := uint64(54321)
+
+	addrEvent := &event.SocketAddrEvent{
+		EventHeader: event.EventHeader{EventType: event.EventTypeSocketAddr},
+		SSLContext:  sslCtx,
+		Family:      2, // AF_INET
+		LocalPort:   51000,
+		RemotePort:  443,
+	}
+	copy(addrEvent.LocalAddr[:4], []byte{127, 0, 0, 1})
+	copy(addrEvent.RemoteAddr[:4], []byte{93, 184, 216, 34})
+	sm.ProcessSocketAddrEvent(addrEvent)
+
+	requestData := []byte("GET /api/test HTTP/1.1\r\nHost: example.com\r\nContent-Length: 0\r\n\r\n")
+	requestEvent := &event.TlsPayloadEvent{
+		EventHeader: event.EventHeader{EventType: event.EventTypeTlsPayloadSend},
+		SSLContext:  sslCtx,
+		HttpVersion: event.HttpVersion1,
+		BufSize:     uint32(len(requestData)),
+	}
+	copy(requestEvent.Buf[:], requestData)
+	sm.ProcessTlsEvent(requestEvent)
+
+	select {
+	case evt := <-mockBus.Events():
+		httpEvent := evt.(*event.HttpRequestEvent)
+		if httpEvent.RemoteAddr != "93.184.216.34:443" {
+			t.Errorf("Expected RemoteAddr 93.184.216.34:443, got %s", httpEvent.RemoteAddr)
+		}
+		if httpEvent.LocalAddr != "127.0.0.1:51000" {
+			t.Errorf("Expected LocalAddr 127.0.0.1:51000, got %s", httpEvent.LocalAddr)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No request event received")
+	}
+}
+
 func TestSessionManager_FragmentedPayload(t *testing.T) {
 	mockBus := tu.NewMockBus()
 	sm, err := NewSessionManager(mockBus)
@@ -373,6 +422,60 @@ func TestSessionManager_IgnoresNonHTTP11(t *testing.T) {
 	}
 }
 
+func TestSessionManager_DetectsGRPCOverHTTP2(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create SessionManager: %v", err)
+	}
+	defer sm.Close()
+
+	path := "/mcp.ToolService/CallTool"
+	var buf []byte
+	buf = append(buf, []byte("application/grpc")...)
+	buf = append(buf, []byte(":path")...)
+	buf = append(buf, byte(len(path)))
+	buf = append(buf, []byte(path)...)
+
+	tlsEvent := &event.TlsPayloadEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeTlsPayloadSend,
+			PID:       1234,
+		},
+		SSLContext:  uint64(777),
+		HttpVersion: event.HttpVersion2,
+		BufSize:     uint32(len(buf)),
+	}
+	copy(tlsEvent.Buf[:], buf)
+
+	sm.ProcessTlsEvent(tlsEvent)
+
+	select {
+	case e := <-mockBus.Events():
+		detected, ok := e.(*event.GRPCDetectedEvent)
+		if !ok {
+			t.Fatalf("expected GRPCDetectedEvent, got %T", e)
+		}
+		if detected.Method != path {
+			t.Errorf("Method = %q, want %q", detected.Method, path)
+		}
+		if detected.SSLContext != 777 {
+			t.Errorf("SSLContext = %d, want 777", detected.SSLContext)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected a GRPCDetectedEvent to be published")
+	}
+
+	// A second frame on the same connection shouldn't emit again.
+	sm.ProcessTlsEvent(tlsEvent)
+	select {
+	case e := <-mockBus.Events():
+		t.Fatalf("expected gRPC detection to fire only once per connection, got %T", e)
+	case <-time.After(50 * time.Millisecond):
+		// Expected: no second event
+	}
+}
+
 func TestParseHTTPMessage_Completeness(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -891,6 +994,64 @@ func TestSessionManager_RequestWithPayload(t *testing.T) {
 	}
 }
 
+func TestSessionManager_AuthorizationHeader(t *testing.T) {
+	tests := []struct {
+		name               string
+		authHeader         string
+		wantScheme         string
+		wantHasCredentials bool
+	}{
+		{name: "bearer token", authHeader: "Bearer eyJhbGciOiJIUzI1NiJ9.secret", wantScheme: "Bearer", wantHasCredentials: true},
+		{name: "basic auth", authHeader: "Basic dXNlcjpwYXNz", wantScheme: "Basic", wantHasCredentials: true},
+		{name: "no authorization header", authHeader: "", wantScheme: "", wantHasCredentials: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBus := tu.NewMockBus()
+			sm, err := NewSessionManager(mockBus)
+			if err != nil {
+				t.Fatalf("Failed to create SessionManager: %v", err)
+			}
+			defer sm.Close()
+
+			requestData := "GET /api/resource HTTP/1.1\r\nHost: api.example.com\r\n"
+			if tt.authHeader != "" {
+				requestData += "Authorization: " + tt.authHeader + "\r\n"
+			}
+			requestData += "\r\n"
+
+			requestEvent := &event.TlsPayloadEvent{
+				EventHeader: event.EventHeader{EventType: event.EventTypeTlsPayloadSend},
+				SSLContext:  uint64(1),
+				HttpVersion: event.HttpVersion1,
+				BufSize:     uint32(len(requestData)),
+			}
+			copy(requestEvent.Buf[:], requestData)
+			sm.ProcessTlsEvent(requestEvent)
+
+			select {
+			case evt := <-mockBus.Events():
+				httpEvent := evt.(*event.HttpRequestEvent)
+				if httpEvent.AuthScheme != tt.wantScheme {
+					t.Errorf("AuthScheme = %q, want %q", httpEvent.AuthScheme, tt.wantScheme)
+				}
+				if httpEvent.HasCredentials != tt.wantHasCredentials {
+					t.Errorf("HasCredentials = %v, want %v", httpEvent.HasCredentials, tt.wantHasCredentials)
+				}
+				if value, ok := httpEvent.RequestHeaders["Authorization"]; ok && strings.Contains(value, "secret") {
+					t.Errorf("RequestHeaders retained the credential: %q", value)
+				}
+				if value, ok := httpEvent.RequestHeaders["Authorization"]; ok && strings.Contains(value, "dXNlcjpwYXNz") {
+					t.Errorf("RequestHeaders retained the credential: %q", value)
+				}
+			case <-time.After(100 * time.Millisecond):
+				t.Fatal("No request event received")
+			}
+		})
+	}
+}
+
 func TestProcessTlsFreeEvent_DeletesSession(t *testing.T) {
 	mockBus := tu.NewMockBus()
 	sm, err := NewSessionManager(mockBus)
@@ -1012,7 +1173,8 @@ func TestProcessTlsFreeEvent_IncompleteChunkedResponse(t *testing.T) {
 		// Expected - no event
 	}
 
-	// Send TlsFreeEvent to force cleanup
+	// Send TlsFreeEvent: the connection closing mid-stream should finalize
+	// the session with whatever was captured, instead of dropping it.
 	freeEvent := &event.TlsFreeEvent{
 		EventHeader: event.EventHeader{
 			EventType: event.EventTypeTlsFree,
@@ -1022,12 +1184,28 @@ func TestProcessTlsFreeEvent_IncompleteChunkedResponse(t *testing.T) {
 	}
 	sm.ProcessTlsFreeEvent(freeEvent)
 
-	// Session should be deleted but no response event (incomplete response)
 	select {
-	case <-mockBus.Events():
-		t.Fatal("Should not receive response event for incomplete chunked response even after TlsFree")
+	case evt := <-mockBus.Events():
+		if evt.Type() != event.EventTypeHttpResponse {
+			t.Fatalf("Expected EventTypeHttpResponse, got %v", evt.Type())
+		}
+		respEvent := evt.(*event.HttpResponseEvent)
+		if !respEvent.Truncated {
+			t.Error("Expected Truncated=true for a response finalized by connection close")
+		}
+		if string(respEvent.ResponsePayload) != "Hello World" {
+			t.Errorf("Expected captured payload %q, got %q", "Hello World", respEvent.ResponsePayload)
+		}
 	case <-time.After(100 * time.Millisecond):
-		// Expected - no response event for incomplete response
+		t.Fatal("Should have received a finalized response event after connection close")
+	}
+
+	// Session should be deleted after finalizing.
+	sm.mu.Lock()
+	_, exists := sm.sessions[sslCtx]
+	sm.mu.Unlock()
+	if exists {
+		t.Fatal("Session should be deleted after TlsFreeEvent")
 	}
 }
 
@@ -1822,6 +2000,7 @@ func TestExtractSSEEventData(t *testing.T) {
 		input        string
 		expectedType string
 		expectedData string
+		expectedID   string
 	}{
 		{
 			name:         "simple data event",
@@ -1846,18 +2025,21 @@ func TestExtractSSEEventData(t *testing.T) {
 			input:        "event: notification\ndata: content\nid: 123",
 			expectedType: "notification",
 			expectedData: "content",
+			expectedID:   "123",
 		},
 		{
 			name:         "multiple data fields in mixed event",
 			input:        "id: 456\ndata: first\nretry: 1000\ndata: second\nevent: custom",
 			expectedType: "custom",
 			expectedData: "first\nsecond",
+			expectedID:   "456",
 		},
 		{
 			name:         "no data field",
 			input:        "event: test\nid: 789",
 			expectedType: "test",
 			expectedData: "",
+			expectedID:   "789",
 		},
 		{
 			name:         "empty data field",
@@ -1876,18 +2058,21 @@ func TestExtractSSEEventData(t *testing.T) {
 			input:        "event: test\r\ndata: content\r\nid: 123\r\n",
 			expectedType: "test",
 			expectedData: "content",
+			expectedID:   "123",
 		},
 		{
 			name:         "event type first in mixed fields",
 			input:        "event: custom\ndata: payload\nid: 789\nretry: 3000",
 			expectedType: "custom",
 			expectedData: "payload",
+			expectedID:   "789",
 		},
 		{
 			name:         "event type last in mixed fields",
 			input:        "id: 999\ndata: info\nevent: status",
 			expectedType: "status",
 			expectedData: "info",
+			expectedID:   "999",
 		},
 		{
 			name:         "only data field - defaults to message",
@@ -1912,12 +2097,13 @@ func TestExtractSSEEventData(t *testing.T) {
 			input:        "data: content\nid: 456",
 			expectedType: "message",
 			expectedData: "content",
+			expectedID:   "456",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			eventType, data := extractSSEEventData([]byte(tt.input))
+			eventType, data, id := extractSSEEventData([]byte(tt.input))
 
 			if eventType != tt.expectedType {
 				t.Errorf("extractSSEEventData() eventType = %q, want %q", eventType, tt.expectedType)
@@ -1927,6 +2113,10 @@ func TestExtractSSEEventData(t *testing.T) {
 			if dataStr != tt.expectedData {
 				t.Errorf("extractSSEEventData() data = %q, want %q", dataStr, tt.expectedData)
 			}
+
+			if id != tt.expectedID {
+				t.Errorf("extractSSEEventData() id = %q, want %q", id, tt.expectedID)
+			}
 		})
 	}
 }
@@ -2116,3 +2306,38 @@ func TestParseHTTPResponse_Gzip(t *testing.T) {
 		t.Errorf("Expected decompressed body %q, got %q (length %d)", originalBody, resp.body, len(resp.body))
 	}
 }
+
+func TestSessionManager_WarnsOnTruncatedTlsPayload(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create SessionManager: %v", err)
+	}
+	defer sm.Close()
+
+	hook := logrustest.NewLocal(logrus.StandardLogger())
+
+	requestData := []byte("GET /api/test HTTP/1.1\r\nHost: example.com\r\nContent-Length: 0\r\n\r\n")
+	requestEvent := &event.TlsPayloadEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeTlsPayloadSend,
+		},
+		SSLContext:  uint64(99999),
+		HttpVersion: event.HttpVersion1,
+		Size:        uint32(len(requestData)) + 1024,
+		BufSize:     uint32(len(requestData)),
+	}
+	copy(requestEvent.Buf[:], requestData)
+	sm.ProcessTlsEvent(requestEvent)
+
+	found := false
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel && strings.Contains(entry.Message, "truncated") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("Expected a warning log entry about a truncated TLS payload")
+	}
+}
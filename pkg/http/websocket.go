@@ -0,0 +1,137 @@
+package http
+
+import (
+	"strings"
+)
+
+// WebSocket opcodes we care about, per RFC6455 section 5.2.
+const (
+	wsOpcodeContinuation = 0x0
+	wsOpcodeText         = 0x1
+	wsOpcodeClose        = 0x8
+)
+
+// isWebSocketUpgrade reports whether resp is a successful WebSocket upgrade
+// response (HTTP 101 Switching Protocols with an Upgrade: websocket header),
+// per RFC6455 section 4.2.2.
+func isWebSocketUpgrade(resp *httpResponse) bool {
+	if resp == nil || resp.statusCode != 101 {
+		return false
+	}
+	for key, value := range resp.headers {
+		if strings.EqualFold(key, "Upgrade") && strings.EqualFold(strings.TrimSpace(value), "websocket") {
+			return true
+		}
+	}
+	return false
+}
+
+// wsFrame is a single decoded RFC6455 frame.
+type wsFrame struct {
+	fin     bool
+	opcode  uint8
+	payload []byte
+}
+
+// parseWebSocketFrame decodes a single RFC6455 frame (section 5.2) from the
+// start of data, unmasking the payload if the frame is masked (as client
+// frames always are). It reports how many bytes of data the frame consumed,
+// and consumed == 0 when data doesn't yet hold a complete frame.
+func parseWebSocketFrame(data []byte) (frame wsFrame, consumed int) {
+	if len(data) < 2 {
+		return wsFrame{}, 0
+	}
+
+	fin := data[0]&0x80 != 0
+	opcode := data[0] & 0x0f
+	masked := data[1]&0x80 != 0
+	payloadLen := uint64(data[1] & 0x7f)
+
+	offset := 2
+	switch payloadLen {
+	case 126:
+		if len(data) < offset+2 {
+			return wsFrame{}, 0
+		}
+		payloadLen = uint64(data[offset])<<8 | uint64(data[offset+1])
+		offset += 2
+	case 127:
+		if len(data) < offset+8 {
+			return wsFrame{}, 0
+		}
+		payloadLen = 0
+		for i := 0; i < 8; i++ {
+			payloadLen = payloadLen<<8 | uint64(data[offset+i])
+		}
+		offset += 8
+	}
+
+	var maskKey [4]byte
+	if masked {
+		if len(data) < offset+4 {
+			return wsFrame{}, 0
+		}
+		copy(maskKey[:], data[offset:offset+4])
+		offset += 4
+	}
+
+	if uint64(len(data)-offset) < payloadLen {
+		return wsFrame{}, 0
+	}
+
+	payload := make([]byte, payloadLen)
+	copy(payload, data[offset:uint64(offset)+payloadLen])
+	if masked {
+		for i := range payload {
+			payload[i] ^= maskKey[i%4]
+		}
+	}
+
+	return wsFrame{fin: fin, opcode: opcode, payload: payload}, offset + int(payloadLen)
+}
+
+// parseWebSocketMessages decodes as many complete frames as data holds,
+// reassembling fragmented messages (a non-fin text frame followed by one or
+// more continuation frames, per RFC6455 section 5.4) into whole text
+// messages. It returns the reassembled message payloads and the number of
+// leading bytes of data that were fully consumed; any trailing bytes belong
+// to a frame or message still in progress and should be retained for the
+// next call.
+func parseWebSocketMessages(data []byte) (messages [][]byte, consumed int) {
+	var fragment []byte
+	fragmenting := false
+
+	for {
+		frame, n := parseWebSocketFrame(data[consumed:])
+		if n == 0 {
+			break
+		}
+		consumed += n
+
+		switch frame.opcode {
+		case wsOpcodeText:
+			if frame.fin {
+				messages = append(messages, frame.payload)
+			} else {
+				fragmenting = true
+				fragment = append([]byte{}, frame.payload...)
+			}
+		case wsOpcodeContinuation:
+			if !fragmenting {
+				continue
+			}
+			fragment = append(fragment, frame.payload...)
+			if frame.fin {
+				messages = append(messages, fragment)
+				fragmenting = false
+				fragment = nil
+			}
+		case wsOpcodeClose:
+			return messages, consumed
+		default:
+			// Binary, ping, pong, etc. - not a JSON-RPC message, skip it.
+		}
+	}
+
+	return messages, consumed
+}
@@ -0,0 +1,285 @@
+package http
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// maskFrame builds a single masked RFC6455 frame (as a client would send)
+// carrying payload, with the given fin bit and opcode.
+func maskFrame(fin bool, opcode byte, payload []byte) []byte {
+	var buf bytes.Buffer
+
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+	buf.WriteByte(b0)
+
+	length := len(payload)
+	switch {
+	case length <= 125:
+		buf.WriteByte(0x80 | byte(length))
+	case length <= 0xffff:
+		buf.WriteByte(0x80 | 126)
+		buf.WriteByte(byte(length >> 8))
+		buf.WriteByte(byte(length))
+	default:
+		buf.WriteByte(0x80 | 127)
+		for i := 7; i >= 0; i-- {
+			buf.WriteByte(byte(length >> (8 * i)))
+		}
+	}
+
+	maskKey := [4]byte{0x12, 0x34, 0x56, 0x78}
+	buf.Write(maskKey[:])
+
+	masked := make([]byte, length)
+	for i, b := range payload {
+		masked[i] = b ^ maskKey[i%4]
+	}
+	buf.Write(masked)
+
+	return buf.Bytes()
+}
+
+func TestParseWebSocketFrame_MaskedClientFrame(t *testing.T) {
+	payload := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call"}`)
+	raw := maskFrame(true, wsOpcodeText, payload)
+
+	frame, consumed := parseWebSocketFrame(raw)
+	if consumed != len(raw) {
+		t.Fatalf("Expected to consume %d bytes, consumed %d", len(raw), consumed)
+	}
+	if !frame.fin {
+		t.Error("Expected fin bit set")
+	}
+	if frame.opcode != wsOpcodeText {
+		t.Errorf("Expected text opcode, got %#x", frame.opcode)
+	}
+	if string(frame.payload) != string(payload) {
+		t.Errorf("Expected unmasked payload %q, got %q", payload, frame.payload)
+	}
+}
+
+func TestParseWebSocketFrame_Incomplete(t *testing.T) {
+	raw := maskFrame(true, wsOpcodeText, []byte("hello world"))
+
+	// Feed only the header and mask key, no payload yet.
+	_, consumed := parseWebSocketFrame(raw[:6])
+	if consumed != 0 {
+		t.Errorf("Expected 0 (incomplete), consumed %d", consumed)
+	}
+}
+
+func TestParseWebSocketFrame_ExtendedLength16(t *testing.T) {
+	payload := bytes.Repeat([]byte("a"), 300) // forces the 16-bit length field
+	raw := maskFrame(true, wsOpcodeText, payload)
+
+	frame, consumed := parseWebSocketFrame(raw)
+	if consumed != len(raw) {
+		t.Fatalf("Expected to consume %d bytes, consumed %d", len(raw), consumed)
+	}
+	if len(frame.payload) != len(payload) {
+		t.Errorf("Expected payload length %d, got %d", len(payload), len(frame.payload))
+	}
+}
+
+func TestParseWebSocketMessages_Fragmented(t *testing.T) {
+	part1 := []byte(`{"jsonrpc":"2.0",`)
+	part2 := []byte(`"id":1,"method":"tools/call"}`)
+
+	var raw bytes.Buffer
+	raw.Write(maskFrame(false, wsOpcodeText, part1))
+	raw.Write(maskFrame(true, wsOpcodeContinuation, part2))
+
+	messages, consumed := parseWebSocketMessages(raw.Bytes())
+	if consumed != raw.Len() {
+		t.Fatalf("Expected to consume %d bytes, consumed %d", raw.Len(), consumed)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 reassembled message, got %d", len(messages))
+	}
+	want := string(part1) + string(part2)
+	if string(messages[0]) != want {
+		t.Errorf("Expected reassembled message %q, got %q", want, messages[0])
+	}
+}
+
+func TestParseWebSocketMessages_MultipleCompleteFrames(t *testing.T) {
+	msg1 := []byte(`{"jsonrpc":"2.0","id":1,"method":"a"}`)
+	msg2 := []byte(`{"jsonrpc":"2.0","id":2,"method":"b"}`)
+
+	var raw bytes.Buffer
+	raw.Write(maskFrame(true, wsOpcodeText, msg1))
+	raw.Write(maskFrame(true, wsOpcodeText, msg2))
+
+	messages, consumed := parseWebSocketMessages(raw.Bytes())
+	if consumed != raw.Len() {
+		t.Fatalf("Expected to consume %d bytes, consumed %d", raw.Len(), consumed)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(messages))
+	}
+	if string(messages[0]) != string(msg1) || string(messages[1]) != string(msg2) {
+		t.Errorf("Unexpected messages: %q, %q", messages[0], messages[1])
+	}
+}
+
+func TestParseWebSocketMessages_TrailingPartialFrameRetained(t *testing.T) {
+	complete := maskFrame(true, wsOpcodeText, []byte("done"))
+	partial := maskFrame(true, wsOpcodeText, []byte("not yet complete"))
+	// Truncate the partial frame so it can't be decoded yet.
+	partial = partial[:len(partial)-3]
+
+	raw := append(append([]byte{}, complete...), partial...)
+
+	messages, consumed := parseWebSocketMessages(raw)
+	if len(messages) != 1 || string(messages[0]) != "done" {
+		t.Fatalf("Expected only the complete message, got %q", messages)
+	}
+	if consumed != len(complete) {
+		t.Errorf("Expected to consume exactly the complete frame (%d bytes), consumed %d", len(complete), consumed)
+	}
+}
+
+func TestIsWebSocketUpgrade(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *httpResponse
+		want bool
+	}{
+		{
+			name: "valid upgrade",
+			resp: &httpResponse{statusCode: 101, headers: map[string]string{"Upgrade": "websocket"}},
+			want: true,
+		},
+		{
+			name: "wrong status code",
+			resp: &httpResponse{statusCode: 200, headers: map[string]string{"Upgrade": "websocket"}},
+			want: false,
+		},
+		{
+			name: "missing upgrade header",
+			resp: &httpResponse{statusCode: 101, headers: map[string]string{}},
+			want: false,
+		},
+		{
+			name: "nil response",
+			resp: nil,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isWebSocketUpgrade(tt.resp); got != tt.want {
+				t.Errorf("isWebSocketUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSessionManager_WebSocketHandshakeAndFrames covers the full path: an
+// HTTP upgrade handshake followed by masked client frames and unmasked
+// server frames, confirming each is decoded and emitted individually.
+func TestSessionManager_WebSocketHandshakeAndFrames(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create SessionManager: %v", err)
+	}
+	defer sm.Close()
+
+	sslCtx := uint64(999)
+
+	sendTLS := func(eventType event.EventType, data []byte) {
+		e := &event.TlsPayloadEvent{
+			EventHeader: event.EventHeader{EventType: eventType},
+			SSLContext:  sslCtx,
+			HttpVersion: event.HttpVersion1,
+			BufSize:     uint32(len(data)),
+		}
+		copy(e.Buf[:], data)
+		sm.ProcessTlsEvent(e)
+	}
+
+	// Handshake request and response.
+	reqData := []byte("GET /mcp HTTP/1.1\r\nHost: example.com\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+	sendTLS(event.EventTypeTlsPayloadSend, reqData)
+
+	respData := []byte("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+	sendTLS(event.EventTypeTlsPayloadRecv, respData)
+
+	// Drain the HTTP request/response events emitted for the handshake.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-mockBus.Events():
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Expected handshake request/response events")
+		}
+	}
+
+	// Client -> server: a single masked text frame.
+	clientMsg := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call"}`)
+	sendTLS(event.EventTypeTlsPayloadSend, maskFrame(true, wsOpcodeText, clientMsg))
+
+	select {
+	case evt := <-mockBus.Events():
+		wsEvt, ok := evt.(*event.WebSocketMessageEvent)
+		if !ok {
+			t.Fatalf("Expected WebSocketMessageEvent, got %T", evt)
+		}
+		if !wsEvt.IsRequest {
+			t.Error("Expected IsRequest=true for a client->server frame")
+		}
+		if string(wsEvt.Data) != string(clientMsg) {
+			t.Errorf("Expected data %q, got %q", clientMsg, wsEvt.Data)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected a WebSocketMessageEvent for the client frame")
+	}
+
+	// Server -> client: an unmasked (per spec, server frames aren't masked)
+	// fragmented text message.
+	serverMsg := []byte(`{"jsonrpc":"2.0","id":1,"result":{}}`)
+	var serverFrames bytes.Buffer
+	serverFrames.Write(unmaskedFrame(false, wsOpcodeText, serverMsg[:10]))
+	serverFrames.Write(unmaskedFrame(true, wsOpcodeContinuation, serverMsg[10:]))
+	sendTLS(event.EventTypeTlsPayloadRecv, serverFrames.Bytes())
+
+	select {
+	case evt := <-mockBus.Events():
+		wsEvt, ok := evt.(*event.WebSocketMessageEvent)
+		if !ok {
+			t.Fatalf("Expected WebSocketMessageEvent, got %T", evt)
+		}
+		if wsEvt.IsRequest {
+			t.Error("Expected IsRequest=false for a server->client frame")
+		}
+		if string(wsEvt.Data) != string(serverMsg) {
+			t.Errorf("Expected reassembled data %q, got %q", serverMsg, wsEvt.Data)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected a WebSocketMessageEvent for the reassembled server message")
+	}
+}
+
+// unmaskedFrame builds a single unmasked RFC6455 frame, as a server sends.
+func unmaskedFrame(fin bool, opcode byte, payload []byte) []byte {
+	var buf bytes.Buffer
+
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+	buf.WriteByte(b0)
+	buf.WriteByte(byte(len(payload)))
+	buf.Write(payload)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,108 @@
+// Package latency provides a fixed-memory histogram for tracking
+// request-response latency distributions and reporting percentiles.
+package latency
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// numBuckets bounds memory use regardless of how many samples are
+// recorded. Bucket i covers [2^i, 2^(i+1)) milliseconds, so numBuckets=32
+// covers latencies up to roughly 49 days, far beyond anything a single
+// MCP request should ever take.
+const numBuckets = 32
+
+// Histogram is a streaming latency histogram. Samples are bucketed by
+// power-of-two millisecond boundaries rather than retained individually,
+// so memory use is constant regardless of sample count. It is safe for
+// concurrent use.
+type Histogram struct {
+	mu      sync.Mutex
+	buckets [numBuckets]uint64
+	count   uint64
+}
+
+// New returns an empty Histogram.
+func New() *Histogram {
+	return &Histogram{}
+}
+
+// Record adds a latency sample to the histogram.
+func (h *Histogram) Record(d time.Duration) {
+	idx := bucketIndex(d)
+	h.mu.Lock()
+	h.buckets[idx]++
+	h.count++
+	h.mu.Unlock()
+}
+
+// bucketIndex returns the bucket holding d: bucket i covers
+// [2^i, 2^(i+1)) milliseconds. Negative or sub-millisecond durations fall
+// into bucket 0; anything beyond the last bucket's range is clamped into it.
+func bucketIndex(d time.Duration) int {
+	ms := d.Milliseconds()
+	idx := 0
+	for ms >= 2 && idx < numBuckets-1 {
+		ms >>= 1
+		idx++
+	}
+	return idx
+}
+
+// upperBoundMillis returns the upper edge, in milliseconds, of bucket i.
+func upperBoundMillis(i int) int64 {
+	return int64(1) << uint(i+1)
+}
+
+// Count returns the number of samples recorded.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Percentile estimates the duration at percentile p (0-100) as the upper
+// bound of the bucket containing that rank. Returns 0 if no samples have
+// been recorded.
+func (h *Histogram) Percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+
+	target := uint64(math.Ceil(p / 100 * float64(h.count)))
+	if target < 1 {
+		target = 1
+	}
+
+	var cumulative uint64
+	for i, c := range h.buckets {
+		cumulative += c
+		if cumulative >= target {
+			return time.Duration(upperBoundMillis(i)) * time.Millisecond
+		}
+	}
+	return time.Duration(upperBoundMillis(numBuckets-1)) * time.Millisecond
+}
+
+// Percentiles bundles the commonly reported tail-latency percentiles.
+type Percentiles struct {
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Count uint64
+}
+
+// Summary returns the p50/p90/p99 percentiles and sample count.
+func (h *Histogram) Summary() Percentiles {
+	return Percentiles{
+		P50:   h.Percentile(50),
+		P90:   h.Percentile(90),
+		P99:   h.Percentile(99),
+		Count: h.Count(),
+	}
+}
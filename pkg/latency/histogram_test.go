@@ -0,0 +1,71 @@
+package latency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogram_Percentiles(t *testing.T) {
+	h := New()
+
+	// 100 samples: 1ms, 2ms, ..., 100ms
+	for i := 1; i <= 100; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	summary := h.Summary()
+	if summary.Count != 100 {
+		t.Errorf("Count = %d, want 100", summary.Count)
+	}
+
+	// Bucket boundaries double, so percentiles are reported as the upper
+	// edge of the bucket containing that rank, not an exact sample value.
+	if summary.P50 < 50*time.Millisecond || summary.P50 > 64*time.Millisecond {
+		t.Errorf("P50 = %v, want between 50ms and 64ms", summary.P50)
+	}
+	if summary.P90 < 90*time.Millisecond || summary.P90 > 128*time.Millisecond {
+		t.Errorf("P90 = %v, want between 90ms and 128ms", summary.P90)
+	}
+	if summary.P99 < 99*time.Millisecond || summary.P99 > 128*time.Millisecond {
+		t.Errorf("P99 = %v, want between 99ms and 128ms", summary.P99)
+	}
+}
+
+func TestHistogram_Empty(t *testing.T) {
+	h := New()
+
+	summary := h.Summary()
+	if summary.Count != 0 {
+		t.Errorf("Count = %d, want 0", summary.Count)
+	}
+	if summary.P50 != 0 || summary.P90 != 0 || summary.P99 != 0 {
+		t.Errorf("percentiles of an empty histogram should be 0, got %+v", summary)
+	}
+}
+
+func TestHistogram_AllSameLatency(t *testing.T) {
+	h := New()
+
+	for i := 0; i < 10; i++ {
+		h.Record(10 * time.Millisecond)
+	}
+
+	summary := h.Summary()
+	if summary.Count != 10 {
+		t.Errorf("Count = %d, want 10", summary.Count)
+	}
+	if summary.P50 != summary.P99 {
+		t.Errorf("P50 (%v) and P99 (%v) should match when all samples are identical", summary.P50, summary.P99)
+	}
+}
+
+func TestBucketIndex_Monotonic(t *testing.T) {
+	prev := bucketIndex(0)
+	for ms := int64(1); ms < 1<<20; ms <<= 1 {
+		idx := bucketIndex(time.Duration(ms) * time.Millisecond)
+		if idx < prev {
+			t.Errorf("bucketIndex(%dms) = %d, want >= previous bucket %d", ms, idx, prev)
+		}
+		prev = idx
+	}
+}
@@ -9,8 +9,17 @@ const (
 	ProviderUnknown   Provider = ""
 	ProviderAnthropic Provider = "anthropic"
 	ProviderGemini    Provider = "gemini"
+	ProviderOpenAI    Provider = "openai"
+	ProviderOllama    Provider = "ollama"
 )
 
+// ollamaHosts are the hosts a local Ollama server listens on by default.
+// Host headers carry the port for non-default ports like 11434.
+var ollamaHosts = map[string]bool{
+	"localhost:11434": true,
+	"127.0.0.1:11434": true,
+}
+
 // DetectProvider detects the LLM provider from HTTP request parameters
 func DetectProvider(host, path string) Provider {
 	host = strings.ToLower(host)
@@ -40,5 +49,15 @@ func DetectProvider(host, path string) Provider {
 		return ProviderGemini
 	}
 
+	// OpenAI detection: Chat Completions and the newer Responses API
+	if host == "api.openai.com" && (path == "/v1/chat/completions" || path == "/v1/responses") {
+		return ProviderOpenAI
+	}
+
+	// Ollama detection: local server, chat and completion endpoints
+	if ollamaHosts[host] && (path == "/api/chat" || path == "/api/generate") {
+		return ProviderOllama
+	}
+
 	return ProviderUnknown
 }
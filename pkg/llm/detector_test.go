@@ -125,6 +125,56 @@ func TestDetectProvider(t *testing.T) {
 			path: "/v1internal/models",
 			want: ProviderUnknown,
 		},
+		// OpenAI test cases
+		{
+			name: "valid openai chat completions endpoint",
+			host: "api.openai.com",
+			path: "/v1/chat/completions",
+			want: ProviderOpenAI,
+		},
+		{
+			name: "valid openai responses endpoint",
+			host: "api.openai.com",
+			path: "/v1/responses",
+			want: ProviderOpenAI,
+		},
+		{
+			name: "openai wrong path",
+			host: "api.openai.com",
+			path: "/v1/embeddings",
+			want: ProviderUnknown,
+		},
+		// Ollama test cases
+		{
+			name: "valid ollama chat endpoint on localhost",
+			host: "localhost:11434",
+			path: "/api/chat",
+			want: ProviderOllama,
+		},
+		{
+			name: "valid ollama generate endpoint on 127.0.0.1",
+			host: "127.0.0.1:11434",
+			path: "/api/generate",
+			want: ProviderOllama,
+		},
+		{
+			name: "ollama case insensitive host",
+			host: "LOCALHOST:11434",
+			path: "/api/chat",
+			want: ProviderOllama,
+		},
+		{
+			name: "ollama wrong port",
+			host: "localhost:8080",
+			path: "/api/chat",
+			want: ProviderUnknown,
+		},
+		{
+			name: "ollama wrong path",
+			host: "localhost:11434",
+			path: "/api/pull",
+			want: ProviderUnknown,
+		},
 	}
 
 	for _, tt := range tests {
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
 	"github.com/alex-ilgayev/mcpspy/pkg/llm/providers"
 	"github.com/sirupsen/logrus"
@@ -13,13 +14,24 @@ import (
 type ParserConfig struct {
 	PublishLLMEvents  bool // Publish LLM request/response/stream events
 	PublishToolEvents bool // Publish tool usage events
+
+	// Clock supplies the current time for emitted events, propagated to
+	// every provider parser. Defaults to the wall clock when nil. Tests set
+	// this to a clock.Fake for deterministic, golden-file-comparable output.
+	Clock clock.Clock
+}
+
+// clockSetter is implemented by provider parsers that support overriding
+// their time source.
+type clockSetter interface {
+	SetClock(clock.Clock)
 }
 
 // Parser handles parsing of LLM API messages
 type Parser struct {
-	eventBus  bus.EventBus
-	providers map[Provider]ProviderParser
-	config    ParserConfig
+	eventBus bus.EventBus
+	registry *Registry
+	config   ParserConfig
 }
 
 // NewParser creates a new LLM parser with default config (publish all events)
@@ -32,15 +44,35 @@ func NewParser(eventBus bus.EventBus) (*Parser, error) {
 
 // NewParserWithConfig creates a new LLM parser with custom config
 func NewParserWithConfig(eventBus bus.EventBus, config ParserConfig) (*Parser, error) {
+	anthropicParser := providers.NewAnthropicParser()
+	geminiParser := providers.NewGeminiParser()
+	openaiParser := providers.NewOpenAIParser()
+	ollamaParser := providers.NewOllamaParser()
+
+	registry := NewRegistry()
+	registry.Register("api.anthropic.com", anthropicParser)
+	registry.Register("api.openai.com", openaiParser)
+	registry.Register("generativelanguage.googleapis.com", geminiParser)
+	// Gemini CLI (cloudcode) hosts are also *.googleapis.com; DetectProvider
+	// already restricts which hosts/paths reach this registry, so the
+	// broader wildcard here only ever matches traffic it already approved.
+	registry.Register("*.googleapis.com", geminiParser)
+	registry.Register("localhost:11434", ollamaParser)
+	registry.Register("127.0.0.1:11434", ollamaParser)
+
 	p := &Parser{
-		eventBus:  eventBus,
-		providers: make(map[Provider]ProviderParser),
-		config:    config,
+		eventBus: eventBus,
+		registry: registry,
+		config:   config,
 	}
 
-	// Register providers
-	p.providers[ProviderAnthropic] = providers.NewAnthropicParser()
-	p.providers[ProviderGemini] = providers.NewGeminiParser()
+	if config.Clock != nil {
+		for _, parser := range []ProviderParser{anthropicParser, geminiParser, openaiParser, ollamaParser} {
+			if cs, ok := parser.(clockSetter); ok {
+				cs.SetClock(config.Clock)
+			}
+		}
+	}
 
 	if err := p.eventBus.Subscribe(event.EventTypeHttpRequest, p.handleRequest); err != nil {
 		return nil, err
@@ -69,8 +101,9 @@ func (p *Parser) handleRequest(e event.Event) {
 		return
 	}
 
-	parser, ok := p.providers[provider]
+	parser, ok := p.registry.ParserForHost(httpEvent.Host)
 	if !ok {
+		logrus.WithField("host", httpEvent.Host).Debug("No provider parser registered for host")
 		return
 	}
 
@@ -110,13 +143,15 @@ func (p *Parser) handleResponse(e event.Event) {
 
 	// Skip streaming responses - they're handled by SSE events
 	if contentType, ok := httpEvent.ResponseHeaders["Content-Type"]; ok {
-		if strings.Contains(strings.ToLower(contentType), "text/event-stream") {
+		contentType = strings.ToLower(contentType)
+		if strings.Contains(contentType, "text/event-stream") || strings.Contains(contentType, "application/x-ndjson") {
 			return
 		}
 	}
 
-	parser, ok := p.providers[provider]
+	parser, ok := p.registry.ParserForHost(httpEvent.Host)
 	if !ok {
+		logrus.WithField("host", httpEvent.Host).Debug("No provider parser registered for host")
 		return
 	}
 
@@ -154,8 +189,9 @@ func (p *Parser) handleSSE(e event.Event) {
 		return
 	}
 
-	parser, ok := p.providers[provider]
+	parser, ok := p.registry.ParserForHost(sseEvent.Host)
 	if !ok {
+		logrus.WithField("host", sseEvent.Host).Debug("No provider parser registered for host")
 		return
 	}
 
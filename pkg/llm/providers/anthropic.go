@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
 )
 
@@ -97,10 +98,20 @@ type AnthropicParser struct {
 	// emittedResults maps "sessionID:tool_use_id" to emittedResultEntry for deduplication
 	// Entries are cleaned up after emittedResultsTTL
 	emittedResults sync.Map
+
+	// clock supplies the current time for emitted events. Defaults to the
+	// wall clock; tests substitute a clock.Fake via SetClock for
+	// reproducible timestamps.
+	clock clock.Clock
 }
 
 func NewAnthropicParser() *AnthropicParser {
-	return &AnthropicParser{}
+	return &AnthropicParser{clock: clock.New()}
+}
+
+// SetClock overrides the parser's time source, for deterministic tests.
+func (p *AnthropicParser) SetClock(c clock.Clock) {
+	p.clock = c
 }
 
 // Request structure (minimal)
@@ -157,7 +168,7 @@ func (p *AnthropicParser) ParseRequest(req *event.HttpRequestEvent) (*event.LLME
 
 	return &event.LLMEvent{
 		SessionID:   req.SSLContext,
-		Timestamp:   time.Now(),
+		Timestamp:   p.clock.Now(),
 		MessageType: event.LLMMessageTypeRequest,
 		PID:         req.PID,
 		Comm:        req.Comm(),
@@ -165,6 +176,7 @@ func (p *AnthropicParser) ParseRequest(req *event.HttpRequestEvent) (*event.LLME
 		Path:        req.Path,
 		Model:       anthropicReq.Model,
 		Content:     extractUserPrompt(anthropicReq.Messages),
+		Attachments: extractUserAttachments(anthropicReq.Messages),
 		RawJSON:     string(req.RequestPayload),
 	}, nil
 }
@@ -178,7 +190,7 @@ func (p *AnthropicParser) ParseResponse(resp *event.HttpResponseEvent) (*event.L
 
 	ev := &event.LLMEvent{
 		SessionID:   resp.SSLContext,
-		Timestamp:   time.Now(),
+		Timestamp:   p.clock.Now(),
 		MessageType: event.LLMMessageTypeResponse,
 		PID:         resp.PID,
 		Comm:        resp.Comm(),
@@ -217,7 +229,7 @@ func (p *AnthropicParser) ParseStreamEvent(sse *event.SSEEvent) (*event.LLMEvent
 	// Build event by extracting available fields
 	ev := &event.LLMEvent{
 		SessionID:   sse.SSLContext,
-		Timestamp:   time.Now(),
+		Timestamp:   p.clock.Now(),
 		MessageType: event.LLMMessageTypeStreamChunk,
 		PID:         sse.PID,
 		Comm:        sse.Comm(),
@@ -255,6 +267,17 @@ func extractUserPrompt(messages []anthropicMessage) string {
 	return ""
 }
 
+// extractUserAttachments returns the image/file references carried by the
+// last user message, if any.
+func extractUserAttachments(messages []anthropicMessage) []string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return extractAttachments(messages[i].Content)
+		}
+	}
+	return nil
+}
+
 func extractMessageContent(content interface{}) string {
 	if content == nil {
 		return ""
@@ -279,6 +302,72 @@ func extractMessageContent(content interface{}) string {
 	return ""
 }
 
+// extractAttachments pulls image/file references out of a multimodal
+// content blocks array, recording just a reference (the remote URL, or a
+// marker for inline base64 data) rather than the raw bytes. Shared by the
+// OpenAI and Anthropic parsers since their vision block shapes only differ
+// in where the URL/base64 data lives.
+func extractAttachments(content interface{}) []string {
+	blocks, ok := content.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var attachments []string
+	for _, block := range blocks {
+		m, ok := block.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch m["type"] {
+		case "image_url": // OpenAI Chat Completions vision block
+			imageURL, ok := m["image_url"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if url, ok := imageURL["url"].(string); ok {
+				attachments = append(attachments, attachmentRef(url))
+			}
+		case "image": // Anthropic vision block
+			source, ok := m["source"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if sourceType, _ := source["type"].(string); sourceType == "url" {
+				if url, ok := source["url"].(string); ok {
+					attachments = append(attachments, url)
+				}
+				continue
+			}
+			mediaType, _ := source["media_type"].(string)
+			attachments = append(attachments, inlineAttachmentMarker(mediaType))
+		}
+	}
+	return attachments
+}
+
+// attachmentRef turns an OpenAI image_url value into a recorded reference:
+// the URL itself, or an inline marker if it's a data: URI embedding base64.
+func attachmentRef(url string) string {
+	mediaType, isInline := strings.CutPrefix(url, "data:")
+	if !isInline {
+		return url
+	}
+	if idx := strings.Index(mediaType, ";"); idx != -1 {
+		mediaType = mediaType[:idx]
+	}
+	return inlineAttachmentMarker(mediaType)
+}
+
+// inlineAttachmentMarker records that an attachment was inline base64 data,
+// without storing the data itself.
+func inlineAttachmentMarker(mediaType string) string {
+	if mediaType == "" {
+		mediaType = "unknown"
+	}
+	return fmt.Sprintf("inline:%s", mediaType)
+}
+
 func extractResponseText(blocks []anthropicContentBlock) string {
 	var texts []string
 	for _, block := range blocks {
@@ -368,7 +457,7 @@ func (p *AnthropicParser) extractToolCalls(payload []byte, sessionID uint64) []*
 
 		events = append(events, &event.ToolUsageEvent{
 			SessionID: sessionID,
-			Timestamp: time.Now(),
+			Timestamp: p.clock.Now(),
 			UsageType: event.ToolUsageTypeInvocation,
 			ToolID:    block.ID,
 			ToolName:  block.Name,
@@ -419,7 +508,7 @@ func (p *AnthropicParser) extractToolResults(payload []byte, sessionID uint64) [
 
 			// Skip if we've already emitted this result (dedup with session-scoped key)
 			emittedKey := fmt.Sprintf("%d:%s", sessionID, block.ToolUseID)
-			entry := emittedResultEntry{timestamp: time.Now()}
+			entry := emittedResultEntry{timestamp: p.clock.Now()}
 			if _, alreadyEmitted := p.emittedResults.LoadOrStore(emittedKey, entry); alreadyEmitted {
 				continue
 			}
@@ -435,7 +524,7 @@ func (p *AnthropicParser) extractToolResults(payload []byte, sessionID uint64) [
 
 			events = append(events, &event.ToolUsageEvent{
 				SessionID: sessionID,
-				Timestamp: time.Now(),
+				Timestamp: p.clock.Now(),
 				UsageType: event.ToolUsageTypeResult,
 				ToolID:    block.ToolUseID,
 				ToolName:  toolName,
@@ -451,7 +540,7 @@ func (p *AnthropicParser) extractToolResults(payload []byte, sessionID uint64) [
 
 // cleanupExpiredResults removes entries from emittedResults that are older than emittedResultsTTL
 func (p *AnthropicParser) cleanupExpiredResults() {
-	now := time.Now()
+	now := p.clock.Now()
 	p.emittedResults.Range(func(key, value interface{}) bool {
 		if entry, ok := value.(emittedResultEntry); ok {
 			if now.Sub(entry.timestamp) > emittedResultsTTL {
@@ -600,7 +689,7 @@ func (p *AnthropicParser) handleContentBlockStop(data string, sessionID uint64)
 	// Emit the completed tool invocation event
 	return []*event.ToolUsageEvent{{
 		SessionID: block.sessionID,
-		Timestamp: time.Now(),
+		Timestamp: p.clock.Now(),
 		UsageType: event.ToolUsageTypeInvocation,
 		ToolID:    block.id,
 		ToolName:  block.name,
@@ -137,6 +137,68 @@ func TestAnthropicParser_ParseRequest(t *testing.T) {
 	}
 }
 
+func TestAnthropicParser_ParseRequest_Attachments(t *testing.T) {
+	parser := NewAnthropicParser()
+
+	tests := []struct {
+		name                string
+		payload             string
+		expectedAttachments []string
+	}{
+		{
+			name: "inline base64 image records a marker, not the data",
+			payload: `{
+				"model": "claude-sonnet-4-20250514",
+				"messages": [{
+					"role": "user",
+					"content": [
+						{"type": "image", "source": {"type": "base64", "media_type": "image/png", "data": "iVBORw0KGgo="}},
+						{"type": "text", "text": "What is in this image?"}
+					]
+				}]
+			}`,
+			expectedAttachments: []string{"inline:image/png"},
+		},
+		{
+			name: "remote image URL source",
+			payload: `{
+				"model": "claude-sonnet-4-20250514",
+				"messages": [{
+					"role": "user",
+					"content": [
+						{"type": "image", "source": {"type": "url", "url": "https://example.com/cat.png"}}
+					]
+				}]
+			}`,
+			expectedAttachments: []string{"https://example.com/cat.png"},
+		},
+		{
+			name: "text-only content",
+			payload: `{
+				"model": "claude-sonnet-4-20250514",
+				"messages": [{"role": "user", "content": "Hello, world!"}]
+			}`,
+			expectedAttachments: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &event.HttpRequestEvent{
+				EventHeader:    makeEventHeader(1234, "python"),
+				SSLContext:     99999,
+				Host:           "api.anthropic.com",
+				Path:           "/v1/messages",
+				RequestPayload: []byte(tt.payload),
+			}
+
+			result, err := parser.ParseRequest(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttachments, result.Attachments)
+		})
+	}
+}
+
 func TestAnthropicParser_ParseResponse(t *testing.T) {
 	parser := NewAnthropicParser()
 
@@ -187,6 +249,14 @@ func TestAnthropicParser_ParseResponse(t *testing.T) {
 			}`,
 			expectedError: "Overloaded",
 		},
+		{
+			name: "rate limit error",
+			payload: `{
+				"type": "error",
+				"error": {"type": "rate_limit_error", "message": "Number of request tokens has exceeded your per-minute rate limit"}
+			}`,
+			expectedError: "Number of request tokens has exceeded your per-minute rate limit",
+		},
 		{
 			name: "response with tool use block",
 			payload: `{
@@ -309,6 +379,12 @@ func TestAnthropicParser_ParseStreamEvent(t *testing.T) {
 			expectedError: "Server overloaded",
 			expectedDone:  true,
 		},
+		{
+			name:          "rate limit error event",
+			data:          `{"type":"error","error":{"type":"rate_limit_error","message":"Rate limit exceeded"}}`,
+			expectedError: "Rate limit exceeded",
+			expectedDone:  true,
+		},
 		{
 			name:         "empty data",
 			data:         "",
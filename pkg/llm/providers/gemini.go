@@ -2,30 +2,42 @@ package providers
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
-	"time"
 
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
 )
 
 // GeminiParser parses Google Gemini API requests and responses
 type GeminiParser struct {
 	modelPattern *regexp.Regexp
+
+	// clock supplies the current time for emitted events. Defaults to the
+	// wall clock; tests substitute a clock.Fake via SetClock for
+	// reproducible timestamps.
+	clock clock.Clock
 }
 
 func NewGeminiParser() *GeminiParser {
 	return &GeminiParser{
 		// Match model name from path like /v1beta/models/gemini-2.0-flash:generateContent
 		modelPattern: regexp.MustCompile(`/models/([^/:]+)`),
+		clock:        clock.New(),
 	}
 }
 
+// SetClock overrides the parser's time source, for deterministic tests.
+func (p *GeminiParser) SetClock(c clock.Clock) {
+	p.clock = c
+}
+
 // Request structures
 type geminiRequest struct {
-	Contents          []geminiContent    `json:"contents"`
-	GenerationConfig  *geminiGenConfig   `json:"generationConfig,omitempty"`
-	SystemInstruction *geminiContent     `json:"systemInstruction,omitempty"`
+	Contents          []geminiContent  `json:"contents"`
+	GenerationConfig  *geminiGenConfig `json:"generationConfig,omitempty"`
+	SystemInstruction *geminiContent   `json:"systemInstruction,omitempty"`
 }
 
 type geminiContent struct {
@@ -35,10 +47,18 @@ type geminiContent struct {
 
 type geminiPart struct {
 	Text             string                  `json:"text,omitempty"`
+	InlineData       *geminiInlineData       `json:"inlineData,omitempty"`
 	FunctionCall     *geminiFunctionCall     `json:"functionCall,omitempty"`
 	FunctionResponse *geminiFunctionResponse `json:"functionResponse,omitempty"`
 }
 
+// geminiInlineData is a vision block carrying base64-encoded media directly
+// in the request rather than as a fileData URI reference.
+type geminiInlineData struct {
+	MimeType string `json:"mimeType,omitempty"`
+	Data     string `json:"data,omitempty"`
+}
+
 type geminiFunctionCall struct {
 	Name string          `json:"name"`
 	Args json.RawMessage `json:"args,omitempty"`
@@ -73,10 +93,18 @@ type cloudcodeResponse struct {
 
 // Response structures
 type geminiResponse struct {
-	Candidates    []geminiCandidate `json:"candidates,omitempty"`
-	UsageMetadata *geminiUsage      `json:"usageMetadata,omitempty"`
-	ModelVersion  string            `json:"modelVersion,omitempty"`
-	Error         *geminiError      `json:"error,omitempty"`
+	Candidates     []geminiCandidate     `json:"candidates,omitempty"`
+	UsageMetadata  *geminiUsage          `json:"usageMetadata,omitempty"`
+	ModelVersion   string                `json:"modelVersion,omitempty"`
+	PromptFeedback *geminiPromptFeedback `json:"promptFeedback,omitempty"`
+	Error          *geminiError          `json:"error,omitempty"`
+}
+
+// geminiPromptFeedback carries safety-filter decisions made before any
+// candidate is generated. A non-empty BlockReason means the prompt itself
+// was rejected, so there's no content to extract.
+type geminiPromptFeedback struct {
+	BlockReason string `json:"blockReason,omitempty"`
 }
 
 type geminiCandidate struct {
@@ -119,7 +147,7 @@ func (p *GeminiParser) ParseRequest(req *event.HttpRequestEvent) (*event.LLMEven
 
 	return &event.LLMEvent{
 		SessionID:   req.SSLContext,
-		Timestamp:   time.Now(),
+		Timestamp:   p.clock.Now(),
 		MessageType: event.LLMMessageTypeRequest,
 		PID:         req.PID,
 		Comm:        req.Comm(),
@@ -127,6 +155,7 @@ func (p *GeminiParser) ParseRequest(req *event.HttpRequestEvent) (*event.LLMEven
 		Path:        req.Path,
 		Model:       model,
 		Content:     extractGeminiUserPrompt(geminiReq.Contents),
+		Attachments: extractGeminiUserAttachments(geminiReq.Contents),
 		RawJSON:     string(req.RequestPayload),
 	}, nil
 }
@@ -156,7 +185,7 @@ func (p *GeminiParser) ParseResponse(resp *event.HttpResponseEvent) (*event.LLME
 
 	ev := &event.LLMEvent{
 		SessionID:   resp.SSLContext,
-		Timestamp:   time.Now(),
+		Timestamp:   p.clock.Now(),
 		MessageType: event.LLMMessageTypeResponse,
 		PID:         resp.PID,
 		Comm:        resp.Comm(),
@@ -172,6 +201,13 @@ func (p *GeminiParser) ParseResponse(resp *event.HttpResponseEvent) (*event.LLME
 		return ev, nil
 	}
 
+	// A blocked prompt produces no candidates, so surface the block reason
+	// as the error instead of leaving Content empty with no explanation.
+	if geminiResp.PromptFeedback != nil && geminiResp.PromptFeedback.BlockReason != "" {
+		ev.Error = fmt.Sprintf("prompt blocked: %s", geminiResp.PromptFeedback.BlockReason)
+		return ev, nil
+	}
+
 	ev.Content = extractGeminiResponseText(geminiResp.Candidates)
 	return ev, nil
 }
@@ -208,7 +244,7 @@ func (p *GeminiParser) ParseStreamEvent(sse *event.SSEEvent) (*event.LLMEvent, b
 
 	ev := &event.LLMEvent{
 		SessionID:   sse.SSLContext,
-		Timestamp:   time.Now(),
+		Timestamp:   p.clock.Now(),
 		MessageType: event.LLMMessageTypeStreamChunk,
 		PID:         sse.PID,
 		Comm:        sse.Comm(),
@@ -224,6 +260,11 @@ func (p *GeminiParser) ParseStreamEvent(sse *event.SSEEvent) (*event.LLMEvent, b
 		return ev, true, nil
 	}
 
+	if streamResp.PromptFeedback != nil && streamResp.PromptFeedback.BlockReason != "" {
+		ev.Error = fmt.Sprintf("prompt blocked: %s", streamResp.PromptFeedback.BlockReason)
+		return ev, true, nil
+	}
+
 	// Extract text from candidates
 	ev.Content = extractGeminiResponseText(streamResp.Candidates)
 
@@ -277,6 +318,31 @@ func extractGeminiPartsText(parts []geminiPart) string {
 	return strings.Join(texts, "\n")
 }
 
+// extractGeminiUserAttachments returns the inline vision blocks carried by
+// the last user message, if any.
+func extractGeminiUserAttachments(contents []geminiContent) []string {
+	for i := len(contents) - 1; i >= 0; i-- {
+		if contents[i].Role == "user" || contents[i].Role == "" {
+			return extractGeminiPartsAttachments(contents[i].Parts)
+		}
+	}
+	return nil
+}
+
+// extractGeminiPartsAttachments records a reference for each inlineData
+// (base64 image/file) part, without storing the data itself. Gemini has no
+// equivalent of a remote image URL block, so unlike OpenAI/Anthropic this
+// only ever produces inline markers.
+func extractGeminiPartsAttachments(parts []geminiPart) []string {
+	var attachments []string
+	for _, part := range parts {
+		if part.InlineData != nil {
+			attachments = append(attachments, inlineAttachmentMarker(part.InlineData.MimeType))
+		}
+	}
+	return attachments
+}
+
 // extractGeminiResponseText extracts text from candidates array
 func extractGeminiResponseText(candidates []geminiCandidate) string {
 	if len(candidates) == 0 {
@@ -336,7 +402,7 @@ func (p *GeminiParser) extractFunctionCalls(payload []byte, sessionID uint64) []
 			rawJSON, _ := json.Marshal(part)
 			events = append(events, &event.ToolUsageEvent{
 				SessionID: sessionID,
-				Timestamp: time.Now(),
+				Timestamp: p.clock.Now(),
 				UsageType: event.ToolUsageTypeInvocation,
 				ToolName:  part.FunctionCall.Name,
 				Input:     string(part.FunctionCall.Args),
@@ -375,7 +441,7 @@ func (p *GeminiParser) extractFunctionResponses(payload []byte, sessionID uint64
 			rawJSON, _ := json.Marshal(part)
 			events = append(events, &event.ToolUsageEvent{
 				SessionID: sessionID,
-				Timestamp: time.Now(),
+				Timestamp: p.clock.Now(),
 				UsageType: event.ToolUsageTypeResult,
 				ToolName:  part.FunctionResponse.Name,
 				Output:    string(part.FunctionResponse.Response),
@@ -424,7 +490,7 @@ func (p *GeminiParser) extractToolUsageFromSSE(sse *event.SSEEvent) []*event.Too
 			rawJSON, _ := json.Marshal(part)
 			events = append(events, &event.ToolUsageEvent{
 				SessionID: sse.SSLContext,
-				Timestamp: time.Now(),
+				Timestamp: p.clock.Now(),
 				UsageType: event.ToolUsageTypeInvocation,
 				ToolName:  part.FunctionCall.Name,
 				Input:     string(part.FunctionCall.Args),
@@ -138,6 +138,31 @@ func TestGeminiParser_ParseRequest(t *testing.T) {
 	}
 }
 
+func TestGeminiParser_ParseRequest_InlineDataAttachment(t *testing.T) {
+	parser := NewGeminiParser()
+
+	req := &event.HttpRequestEvent{
+		EventHeader: makeEventHeader(1234, "test"),
+		Path:        "/v1beta/models/gemini-2.0-flash:generateContent",
+		Host:        "generativelanguage.googleapis.com",
+	}
+	req.RequestPayload = []byte(`{
+		"contents": [{
+			"role": "user",
+			"parts": [
+				{"text": "What's in this image?"},
+				{"inlineData": {"mimeType": "image/png", "data": "aGVsbG8="}}
+			]
+		}]
+	}`)
+
+	result, err := parser.ParseRequest(req)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, "What's in this image?", result.Content)
+	assert.Equal(t, []string{"inline:image/png"}, result.Attachments)
+}
+
 func TestGeminiParser_ParseResponse(t *testing.T) {
 	parser := NewGeminiParser()
 
@@ -242,6 +267,15 @@ func TestGeminiParser_ParseResponse(t *testing.T) {
 			payload: `{invalid`,
 			wantErr: true,
 		},
+		{
+			name: "prompt blocked by safety filter",
+			path: "/v1beta/models/gemini-2.0-flash:generateContent",
+			payload: `{
+				"promptFeedback": {"blockReason": "SAFETY"}
+			}`,
+			expectedModel: "gemini-2.0-flash",
+			expectedError: "prompt blocked: SAFETY",
+		},
 	}
 
 	for _, tt := range tests {
@@ -374,6 +408,15 @@ func TestGeminiParser_ParseStreamEvent(t *testing.T) {
 			expectedError: "Rate limit exceeded",
 			expectedDone:  true,
 		},
+		{
+			name: "prompt blocked mid-stream",
+			path: "/v1beta/models/gemini-2.0-flash:streamGenerateContent",
+			data: `{
+				"promptFeedback": {"blockReason": "PROHIBITED_CONTENT"}
+			}`,
+			expectedError: "prompt blocked: PROHIBITED_CONTENT",
+			expectedDone:  true,
+		},
 		{
 			name:         "empty data",
 			path:         "/v1beta/models/gemini-2.0-flash:streamGenerateContent",
@@ -0,0 +1,268 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// generatePath is the Ollama completion endpoint. It has a different
+// request/response shape than the chat endpoint (a plain "prompt" instead
+// of "messages", and a "response" string instead of "message.content" in
+// each streamed line), so OllamaParser branches on the request path to
+// pick the right decoder.
+const generatePath = "/api/generate"
+
+// OllamaParser parses Ollama API requests and responses. It supports both
+// the chat endpoint (/api/chat) and the completion endpoint
+// (/api/generate). Unlike the other providers, Ollama streams
+// newline-delimited JSON rather than SSE: each line is a complete, bare
+// JSON object with no "data:"/"event:" framing, and the stream ends with a
+// line carrying "done":true instead of a sentinel event.
+type OllamaParser struct {
+	// clock supplies the current time for emitted events. Defaults to the
+	// wall clock; tests substitute a clock.Fake via SetClock for
+	// reproducible timestamps.
+	clock clock.Clock
+}
+
+func NewOllamaParser() *OllamaParser {
+	return &OllamaParser{clock: clock.New()}
+}
+
+// SetClock overrides the parser's time source, for deterministic tests.
+func (p *OllamaParser) SetClock(c clock.Clock) {
+	p.clock = c
+}
+
+// isGeneratePath reports whether path (ignoring any query string) is the
+// completion endpoint rather than the chat endpoint.
+func isGeneratePath(path string) bool {
+	if idx := strings.Index(path, "?"); idx != -1 {
+		path = path[:idx]
+	}
+	return path == generatePath
+}
+
+type ollamaChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaChatRequest struct {
+	Model    string              `json:"model"`
+	Messages []ollamaChatMessage `json:"messages"`
+}
+
+// ollamaChatLine is both a streamed chat line and a non-streaming chat
+// response - they're the same shape, just with stream:false collapsing the
+// whole reply into the single "done":true line.
+type ollamaChatLine struct {
+	Model   string            `json:"model,omitempty"`
+	Message ollamaChatMessage `json:"message"`
+	Done    bool              `json:"done"`
+	Error   string            `json:"error,omitempty"`
+}
+
+type ollamaGenerateRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+// ollamaGenerateLine is both a streamed completion line and a non-streaming
+// response, for the same reason as ollamaChatLine.
+type ollamaGenerateLine struct {
+	Model    string `json:"model,omitempty"`
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ParseRequest parses an Ollama API request.
+func (p *OllamaParser) ParseRequest(req *event.HttpRequestEvent) (*event.LLMEvent, error) {
+	if isGeneratePath(req.Path) {
+		return p.parseGenerateRequest(req)
+	}
+	return p.parseChatRequest(req)
+}
+
+func (p *OllamaParser) parseChatRequest(req *event.HttpRequestEvent) (*event.LLMEvent, error) {
+	var chatReq ollamaChatRequest
+	if err := json.Unmarshal(req.RequestPayload, &chatReq); err != nil {
+		return nil, err
+	}
+
+	return &event.LLMEvent{
+		SessionID:   req.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeRequest,
+		PID:         req.PID,
+		Comm:        req.Comm(),
+		Host:        req.Host,
+		Path:        req.Path,
+		Model:       chatReq.Model,
+		Content:     extractOllamaUserPrompt(chatReq.Messages),
+		RawJSON:     string(req.RequestPayload),
+	}, nil
+}
+
+func (p *OllamaParser) parseGenerateRequest(req *event.HttpRequestEvent) (*event.LLMEvent, error) {
+	var genReq ollamaGenerateRequest
+	if err := json.Unmarshal(req.RequestPayload, &genReq); err != nil {
+		return nil, err
+	}
+
+	return &event.LLMEvent{
+		SessionID:   req.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeRequest,
+		PID:         req.PID,
+		Comm:        req.Comm(),
+		Host:        req.Host,
+		Path:        req.Path,
+		Model:       genReq.Model,
+		Content:     genReq.Prompt,
+		RawJSON:     string(req.RequestPayload),
+	}, nil
+}
+
+// ParseResponse parses a non-streaming Ollama API response (stream:false
+// collapses the whole reply into a single "done":true JSON object, the same
+// shape as one line of the streaming response).
+func (p *OllamaParser) ParseResponse(resp *event.HttpResponseEvent) (*event.LLMEvent, error) {
+	if isGeneratePath(resp.Path) {
+		return p.parseGenerateResponse(resp)
+	}
+	return p.parseChatResponse(resp)
+}
+
+func (p *OllamaParser) parseChatResponse(resp *event.HttpResponseEvent) (*event.LLMEvent, error) {
+	var line ollamaChatLine
+	if err := json.Unmarshal(resp.ResponsePayload, &line); err != nil {
+		return nil, err
+	}
+
+	ev := &event.LLMEvent{
+		SessionID:   resp.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeResponse,
+		PID:         resp.PID,
+		Comm:        resp.Comm(),
+		Host:        resp.Host,
+		Path:        resp.Path,
+		Model:       line.Model,
+		Content:     line.Message.Content,
+		Error:       line.Error,
+		RawJSON:     string(resp.ResponsePayload),
+	}
+	return ev, nil
+}
+
+func (p *OllamaParser) parseGenerateResponse(resp *event.HttpResponseEvent) (*event.LLMEvent, error) {
+	var line ollamaGenerateLine
+	if err := json.Unmarshal(resp.ResponsePayload, &line); err != nil {
+		return nil, err
+	}
+
+	ev := &event.LLMEvent{
+		SessionID:   resp.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeResponse,
+		PID:         resp.PID,
+		Comm:        resp.Comm(),
+		Host:        resp.Host,
+		Path:        resp.Path,
+		Model:       line.Model,
+		Content:     line.Response,
+		Error:       line.Error,
+		RawJSON:     string(resp.ResponsePayload),
+	}
+	return ev, nil
+}
+
+// ParseStreamEvent parses a single line of an Ollama NDJSON stream. sse.Data
+// holds the raw line with no SSE framing to strip, unlike the other
+// providers' ParseStreamEvent.
+func (p *OllamaParser) ParseStreamEvent(sse *event.SSEEvent) (*event.LLMEvent, bool, error) {
+	data := bytes.TrimSpace(sse.Data)
+	if len(data) == 0 {
+		return nil, false, nil
+	}
+
+	if isGeneratePath(sse.Path) {
+		return p.parseGenerateStreamLine(sse, data)
+	}
+	return p.parseChatStreamLine(sse, data)
+}
+
+func (p *OllamaParser) parseChatStreamLine(sse *event.SSEEvent, data []byte) (*event.LLMEvent, bool, error) {
+	var line ollamaChatLine
+	if err := json.Unmarshal(data, &line); err != nil {
+		return nil, false, err
+	}
+
+	ev := &event.LLMEvent{
+		SessionID:   sse.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeStreamChunk,
+		PID:         sse.PID,
+		Comm:        sse.Comm(),
+		Host:        sse.Host,
+		Path:        sse.Path,
+		Model:       line.Model,
+		Content:     line.Message.Content,
+		RawJSON:     string(data),
+	}
+
+	if line.Error != "" {
+		ev.Error = line.Error
+		return ev, true, nil
+	}
+
+	return ev, line.Done, nil
+}
+
+func (p *OllamaParser) parseGenerateStreamLine(sse *event.SSEEvent, data []byte) (*event.LLMEvent, bool, error) {
+	var line ollamaGenerateLine
+	if err := json.Unmarshal(data, &line); err != nil {
+		return nil, false, err
+	}
+
+	ev := &event.LLMEvent{
+		SessionID:   sse.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeStreamChunk,
+		PID:         sse.PID,
+		Comm:        sse.Comm(),
+		Host:        sse.Host,
+		Path:        sse.Path,
+		Model:       line.Model,
+		Content:     line.Response,
+		RawJSON:     string(data),
+	}
+
+	if line.Error != "" {
+		ev.Error = line.Error
+		return ev, true, nil
+	}
+
+	return ev, line.Done, nil
+}
+
+func extractOllamaUserPrompt(messages []ollamaChatMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// ExtractToolUsage is a no-op for Ollama: tool calling isn't modeled here
+// yet.
+func (p *OllamaParser) ExtractToolUsage(e event.Event) []*event.ToolUsageEvent {
+	return nil
+}
@@ -0,0 +1,153 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOllamaParser_ParseRequest_Chat(t *testing.T) {
+	parser := NewOllamaParser()
+
+	req := &event.HttpRequestEvent{
+		EventHeader: makeEventHeader(1234, "python"),
+		SSLContext:  99999,
+		Host:        "localhost:11434",
+		Path:        "/api/chat",
+		RequestPayload: []byte(`{
+			"model": "llama3",
+			"messages": [
+				{"role": "user", "content": "First question"},
+				{"role": "assistant", "content": "First answer"},
+				{"role": "user", "content": "Follow-up question"}
+			]
+		}`),
+	}
+
+	result, err := parser.ParseRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, event.LLMMessageTypeRequest, result.MessageType)
+	assert.Equal(t, "llama3", result.Model)
+	assert.Equal(t, "Follow-up question", result.Content)
+	assert.Equal(t, "/api/chat", result.Path)
+}
+
+func TestOllamaParser_ParseRequest_Generate(t *testing.T) {
+	parser := NewOllamaParser()
+
+	req := &event.HttpRequestEvent{
+		EventHeader:    makeEventHeader(1234, "python"),
+		SSLContext:     99999,
+		Host:           "127.0.0.1:11434",
+		Path:           "/api/generate",
+		RequestPayload: []byte(`{"model": "llama3", "prompt": "Why is the sky blue?"}`),
+	}
+
+	result, err := parser.ParseRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, "llama3", result.Model)
+	assert.Equal(t, "Why is the sky blue?", result.Content)
+	assert.Equal(t, "/api/generate", result.Path)
+}
+
+func TestOllamaParser_ParseResponse_Chat(t *testing.T) {
+	parser := NewOllamaParser()
+
+	resp := &event.HttpResponseEvent{
+		HttpRequestEvent: event.HttpRequestEvent{Path: "/api/chat"},
+		ResponsePayload: []byte(`{
+			"model": "llama3",
+			"message": {"role": "assistant", "content": "The sky is blue due to Rayleigh scattering."},
+			"done": true
+		}`),
+	}
+
+	result, err := parser.ParseResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, "llama3", result.Model)
+	assert.Equal(t, "The sky is blue due to Rayleigh scattering.", result.Content)
+}
+
+// TestOllamaParser_ParseStreamEvent_Chat covers an /api/chat NDJSON stream:
+// unlike SSE, each line is a bare JSON object with no "data:" prefix, and
+// the final line carries "done":true instead of a sentinel.
+func TestOllamaParser_ParseStreamEvent_Chat(t *testing.T) {
+	parser := NewOllamaParser()
+	path := event.HttpRequestEvent{Path: "/api/chat"}
+
+	ev, done, err := parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: path,
+		Data:             []byte(`{"model":"llama3","message":{"role":"assistant","content":"The"},"done":false}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, "The", ev.Content)
+	assert.Equal(t, "llama3", ev.Model)
+	assert.False(t, done)
+
+	ev, done, err = parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: path,
+		Data:             []byte(`{"model":"llama3","message":{"role":"assistant","content":" sky"},"done":false}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, " sky", ev.Content)
+	assert.False(t, done)
+
+	ev, done, err = parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: path,
+		Data:             []byte(`{"model":"llama3","message":{"role":"assistant","content":""},"done":true,"total_duration":12345}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.True(t, done)
+}
+
+func TestOllamaParser_ParseStreamEvent_Generate(t *testing.T) {
+	parser := NewOllamaParser()
+	path := event.HttpRequestEvent{Path: "/api/generate"}
+
+	ev, done, err := parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: path,
+		Data:             []byte(`{"model":"llama3","response":"Once","done":false}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, "Once", ev.Content)
+	assert.False(t, done)
+
+	ev, done, err = parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: path,
+		Data:             []byte(`{"model":"llama3","response":"","done":true}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.True(t, done)
+}
+
+func TestOllamaParser_ParseStreamEvent_EmptyLineIsSkipped(t *testing.T) {
+	parser := NewOllamaParser()
+
+	ev, done, err := parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: event.HttpRequestEvent{Path: "/api/chat"},
+		Data:             []byte("   "),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+	assert.False(t, done)
+}
+
+func TestOllamaParser_ParseStreamEvent_Error(t *testing.T) {
+	parser := NewOllamaParser()
+
+	ev, done, err := parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: event.HttpRequestEvent{Path: "/api/chat"},
+		Data:             []byte(`{"error":"model \"llama3\" not found"}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, `model "llama3" not found`, ev.Error)
+	assert.True(t, done)
+}
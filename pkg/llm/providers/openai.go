@@ -0,0 +1,637 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// responsesPath is the OpenAI Responses API endpoint. It has a different
+// request/response/streaming shape than Chat Completions, so OpenAIParser
+// branches on the request path to pick the right decoder.
+const responsesPath = "/v1/responses"
+
+// streamingToolCall accumulates a Chat Completions tool_calls delta across
+// SSE chunks. OpenAI streams the function name up front and the arguments
+// as a series of string fragments, so only the arguments need a builder.
+type streamingToolCall struct {
+	id        string
+	name      string
+	arguments strings.Builder
+}
+
+// OpenAIParser parses OpenAI API requests and responses. It supports both
+// the Chat Completions endpoint (/v1/chat/completions) and the newer
+// Responses endpoint (/v1/responses).
+type OpenAIParser struct {
+	// streamingToolCalls maps "sessionID:index" to in-progress tool_calls
+	// being accumulated across Chat Completions SSE chunks, until the
+	// chunk carrying finish_reason flushes them.
+	streamingToolCalls sync.Map
+
+	// clock supplies the current time for emitted events. Defaults to the
+	// wall clock; tests substitute a clock.Fake via SetClock for
+	// reproducible timestamps.
+	clock clock.Clock
+}
+
+func NewOpenAIParser() *OpenAIParser {
+	return &OpenAIParser{clock: clock.New()}
+}
+
+// SetClock overrides the parser's time source, for deterministic tests.
+func (p *OpenAIParser) SetClock(c clock.Clock) {
+	p.clock = c
+}
+
+// isResponsesPath reports whether path (ignoring any query string) is the
+// Responses API endpoint rather than Chat Completions.
+func isResponsesPath(path string) bool {
+	if idx := strings.Index(path, "?"); idx != -1 {
+		path = path[:idx]
+	}
+	return path == responsesPath
+}
+
+// --- Chat Completions structures (minimal) ---
+
+type openaiChatRequest struct {
+	Model    string              `json:"model"`
+	Messages []openaiChatMessage `json:"messages"`
+}
+
+type openaiChatMessage struct {
+	Role       string           `json:"role"`
+	Content    interface{}      `json:"content"`
+	ToolCalls  []openaiToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type openaiToolCall struct {
+	ID       string `json:"id"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openaiChatResponse struct {
+	Model   string             `json:"model,omitempty"`
+	Choices []openaiChatChoice `json:"choices,omitempty"`
+	Error   *openaiError       `json:"error,omitempty"`
+}
+
+type openaiChatChoice struct {
+	Message      openaiChatMessage `json:"message"`
+	Delta        *openaiChatDelta  `json:"delta,omitempty"`
+	FinishReason string            `json:"finish_reason,omitempty"`
+}
+
+type openaiChatDelta struct {
+	Content   string                `json:"content,omitempty"`
+	ToolCalls []openaiToolCallDelta `json:"tool_calls,omitempty"`
+}
+
+// openaiToolCallDelta is one fragment of a streamed tool call. Index
+// identifies which tool call it belongs to (a single chunk's delta can
+// carry fragments for multiple concurrent tool calls); ID and Function.Name
+// normally only appear in the first fragment, with Function.Arguments
+// trickling in across subsequent chunks.
+type openaiToolCallDelta struct {
+	Index    int                   `json:"index"`
+	ID       string                `json:"id,omitempty"`
+	Function openaiToolCallDeltaFn `json:"function,omitempty"`
+}
+
+type openaiToolCallDeltaFn struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+type openaiError struct {
+	Message string `json:"message"`
+}
+
+// --- Responses API structures (minimal) ---
+
+// openaiResponsesRequest models a /v1/responses request. Input is either a
+// plain string prompt or an array of typed input items, so it's decoded
+// loosely and picked apart by extractResponsesInputText.
+type openaiResponsesRequest struct {
+	Model string      `json:"model"`
+	Input interface{} `json:"input"`
+}
+
+// openaiResponsesRequestForTools re-decodes the request body to pull out
+// function_call_output items, which extractResponsesInputText ignores.
+type openaiResponsesRequestForTools struct {
+	Input []openaiResponsesInputItem `json:"input"`
+}
+
+type openaiResponsesInputItem struct {
+	Type   string `json:"type"`
+	CallID string `json:"call_id,omitempty"`
+	Output string `json:"output,omitempty"`
+}
+
+type openaiResponsesResponse struct {
+	Model  string                     `json:"model,omitempty"`
+	Output []openaiResponseOutputItem `json:"output,omitempty"`
+	Error  *openaiError               `json:"error,omitempty"`
+}
+
+type openaiResponseOutputItem struct {
+	Type      string                      `json:"type"` // "message" or "function_call"
+	Content   []openaiResponseContentPart `json:"content,omitempty"`
+	CallID    string                      `json:"call_id,omitempty"`
+	Name      string                      `json:"name,omitempty"`
+	Arguments string                      `json:"arguments,omitempty"`
+}
+
+type openaiResponseContentPart struct {
+	Type string `json:"type"` // "output_text" for assistant text
+	Text string `json:"text,omitempty"`
+}
+
+// openaiResponsesStreamEvent models an SSE event from the Responses API.
+// Unlike Chat Completions, each event carries its own "type" (e.g.
+// "response.output_text.delta", "response.completed") instead of always
+// being a partial response object.
+type openaiResponsesStreamEvent struct {
+	Type     string                   `json:"type"`
+	Delta    string                   `json:"delta,omitempty"`
+	Response *openaiResponsesResponse `json:"response,omitempty"`
+	Error    *openaiError             `json:"error,omitempty"`
+}
+
+// ParseRequest parses an OpenAI API request
+func (p *OpenAIParser) ParseRequest(req *event.HttpRequestEvent) (*event.LLMEvent, error) {
+	if isResponsesPath(req.Path) {
+		return p.parseResponsesRequest(req)
+	}
+	return p.parseChatRequest(req)
+}
+
+func (p *OpenAIParser) parseChatRequest(req *event.HttpRequestEvent) (*event.LLMEvent, error) {
+	var chatReq openaiChatRequest
+	if err := json.Unmarshal(req.RequestPayload, &chatReq); err != nil {
+		return nil, err
+	}
+
+	return &event.LLMEvent{
+		SessionID:   req.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeRequest,
+		PID:         req.PID,
+		Comm:        req.Comm(),
+		Host:        req.Host,
+		Path:        req.Path,
+		Model:       chatReq.Model,
+		Content:     extractChatUserPrompt(chatReq.Messages),
+		Attachments: extractChatUserAttachments(chatReq.Messages),
+		RawJSON:     string(req.RequestPayload),
+	}, nil
+}
+
+func (p *OpenAIParser) parseResponsesRequest(req *event.HttpRequestEvent) (*event.LLMEvent, error) {
+	var respReq openaiResponsesRequest
+	if err := json.Unmarshal(req.RequestPayload, &respReq); err != nil {
+		return nil, err
+	}
+
+	return &event.LLMEvent{
+		SessionID:   req.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeRequest,
+		PID:         req.PID,
+		Comm:        req.Comm(),
+		Host:        req.Host,
+		Path:        req.Path,
+		Model:       respReq.Model,
+		Content:     extractResponsesInputText(respReq.Input),
+		RawJSON:     string(req.RequestPayload),
+	}, nil
+}
+
+// ParseResponse parses an OpenAI API response (non-streaming)
+func (p *OpenAIParser) ParseResponse(resp *event.HttpResponseEvent) (*event.LLMEvent, error) {
+	if isResponsesPath(resp.Path) {
+		return p.parseResponsesResponse(resp)
+	}
+	return p.parseChatResponse(resp)
+}
+
+func (p *OpenAIParser) parseChatResponse(resp *event.HttpResponseEvent) (*event.LLMEvent, error) {
+	var chatResp openaiChatResponse
+	if err := json.Unmarshal(resp.ResponsePayload, &chatResp); err != nil {
+		return nil, err
+	}
+
+	ev := &event.LLMEvent{
+		SessionID:   resp.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeResponse,
+		PID:         resp.PID,
+		Comm:        resp.Comm(),
+		Host:        resp.Host,
+		Path:        resp.Path,
+		Model:       chatResp.Model,
+		RawJSON:     string(resp.ResponsePayload),
+	}
+
+	if chatResp.Error != nil {
+		ev.Error = chatResp.Error.Message
+		return ev, nil
+	}
+
+	if len(chatResp.Choices) > 0 {
+		ev.Content = extractMessageContent(chatResp.Choices[0].Message.Content)
+	}
+	return ev, nil
+}
+
+func (p *OpenAIParser) parseResponsesResponse(resp *event.HttpResponseEvent) (*event.LLMEvent, error) {
+	var respResp openaiResponsesResponse
+	if err := json.Unmarshal(resp.ResponsePayload, &respResp); err != nil {
+		return nil, err
+	}
+
+	ev := &event.LLMEvent{
+		SessionID:   resp.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeResponse,
+		PID:         resp.PID,
+		Comm:        resp.Comm(),
+		Host:        resp.Host,
+		Path:        resp.Path,
+		Model:       respResp.Model,
+		RawJSON:     string(resp.ResponsePayload),
+	}
+
+	if respResp.Error != nil {
+		ev.Error = respResp.Error.Message
+		return ev, nil
+	}
+
+	ev.Content = extractResponsesOutputText(respResp.Output)
+	return ev, nil
+}
+
+// ParseStreamEvent parses a single OpenAI streaming SSE event.
+// Returns: event (may be nil for skip), done flag, error
+func (p *OpenAIParser) ParseStreamEvent(sse *event.SSEEvent) (*event.LLMEvent, bool, error) {
+	if isResponsesPath(sse.Path) {
+		return p.parseResponsesStreamEvent(sse)
+	}
+	return p.parseChatStreamEvent(sse)
+}
+
+// parseChatStreamEvent parses a Chat Completions SSE chunk. The stream ends
+// with a literal "[DONE]" payload rather than a typed terminal event.
+func (p *OpenAIParser) parseChatStreamEvent(sse *event.SSEEvent) (*event.LLMEvent, bool, error) {
+	data := strings.TrimSpace(string(sse.Data))
+	if data == "" {
+		return nil, false, nil
+	}
+	if data == "[DONE]" {
+		return nil, true, nil
+	}
+
+	var chunk openaiChatResponse
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return nil, false, err
+	}
+
+	ev := &event.LLMEvent{
+		SessionID:   sse.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeStreamChunk,
+		PID:         sse.PID,
+		Comm:        sse.Comm(),
+		Host:        sse.Host,
+		Path:        sse.Path,
+		Model:       chunk.Model,
+		RawJSON:     data,
+	}
+
+	if chunk.Error != nil && chunk.Error.Message != "" {
+		ev.Error = chunk.Error.Message
+		return ev, true, nil
+	}
+
+	var done bool
+	if len(chunk.Choices) > 0 {
+		if chunk.Choices[0].Delta != nil {
+			ev.Content = chunk.Choices[0].Delta.Content
+		}
+		done = chunk.Choices[0].FinishReason != ""
+	}
+
+	return ev, done, nil
+}
+
+// parseResponsesStreamEvent parses a Responses API SSE event, e.g.
+// {"type":"response.output_text.delta","delta":"Hi"} or the terminal
+// {"type":"response.completed","response":{...}}.
+func (p *OpenAIParser) parseResponsesStreamEvent(sse *event.SSEEvent) (*event.LLMEvent, bool, error) {
+	data := strings.TrimSpace(string(sse.Data))
+	if data == "" {
+		return nil, false, nil
+	}
+
+	var streamEvent openaiResponsesStreamEvent
+	if err := json.Unmarshal([]byte(data), &streamEvent); err != nil {
+		return nil, false, err
+	}
+
+	done := streamEvent.Type == "response.completed" || streamEvent.Type == "response.failed"
+
+	ev := &event.LLMEvent{
+		SessionID:   sse.SSLContext,
+		Timestamp:   p.clock.Now(),
+		MessageType: event.LLMMessageTypeStreamChunk,
+		PID:         sse.PID,
+		Comm:        sse.Comm(),
+		Host:        sse.Host,
+		Path:        sse.Path,
+		RawJSON:     data,
+	}
+
+	if streamEvent.Response != nil && streamEvent.Response.Model != "" {
+		ev.Model = streamEvent.Response.Model
+	}
+
+	if streamEvent.Type == "response.output_text.delta" && streamEvent.Delta != "" {
+		ev.Content = streamEvent.Delta
+	}
+
+	if streamEvent.Error != nil && streamEvent.Error.Message != "" {
+		ev.Error = streamEvent.Error.Message
+		return ev, true, nil
+	}
+
+	return ev, done, nil
+}
+
+func extractChatUserPrompt(messages []openaiChatMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return extractMessageContent(messages[i].Content)
+		}
+	}
+	return ""
+}
+
+// extractChatUserAttachments returns the image/file references carried by
+// the last user message, if any.
+func extractChatUserAttachments(messages []openaiChatMessage) []string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return extractAttachments(messages[i].Content)
+		}
+	}
+	return nil
+}
+
+// extractResponsesInputText pulls the last user message out of a Responses
+// API "input", which is either a plain string prompt or an array of typed
+// input items (each with a role and content parts).
+func extractResponsesInputText(input interface{}) string {
+	if s, ok := input.(string); ok {
+		return s
+	}
+
+	items, ok := input.([]interface{})
+	if !ok {
+		return ""
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		m, ok := items[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if role, _ := m["role"].(string); role != "user" {
+			continue
+		}
+		return extractResponsesContentText(m["content"])
+	}
+	return ""
+}
+
+func extractResponsesContentText(content interface{}) string {
+	if s, ok := content.(string); ok {
+		return s
+	}
+
+	parts, ok := content.([]interface{})
+	if !ok {
+		return ""
+	}
+	var texts []string
+	for _, part := range parts {
+		m, ok := part.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if m["type"] == "input_text" {
+			if text, ok := m["text"].(string); ok {
+				texts = append(texts, text)
+			}
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func extractResponsesOutputText(output []openaiResponseOutputItem) string {
+	var texts []string
+	for _, item := range output {
+		if item.Type != "message" {
+			continue
+		}
+		for _, part := range item.Content {
+			if part.Type == "output_text" && part.Text != "" {
+				texts = append(texts, part.Text)
+			}
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// ExtractToolUsage extracts tool usage events from HTTP events.
+// Accepts *event.HttpRequestEvent (for tool results), *event.HttpResponseEvent
+// (for tool invocations), and *event.SSEEvent (for streamed tool invocations
+// on the Chat Completions endpoint; the Responses API streams function
+// calls as complete objects rather than accumulated deltas and isn't
+// handled here yet).
+func (p *OpenAIParser) ExtractToolUsage(e event.Event) []*event.ToolUsageEvent {
+	switch ev := e.(type) {
+	case *event.HttpRequestEvent:
+		if isResponsesPath(ev.Path) {
+			return p.extractResponsesToolResults(ev.RequestPayload, ev.SSLContext)
+		}
+		return p.extractChatToolResults(ev.RequestPayload, ev.SSLContext)
+	case *event.HttpResponseEvent:
+		if isResponsesPath(ev.Path) {
+			return p.extractResponsesToolCalls(ev.ResponsePayload, ev.SSLContext)
+		}
+		return p.extractChatToolCalls(ev.ResponsePayload, ev.SSLContext)
+	case *event.SSEEvent:
+		if isResponsesPath(ev.Path) {
+			return nil
+		}
+		return p.extractChatToolCallsFromSSE(ev)
+	default:
+		return nil
+	}
+}
+
+// extractChatToolCallsFromSSE accumulates tool_calls deltas from Chat
+// Completions SSE chunks, keyed by session and call index, and flushes them
+// into ToolUsageEvents once a chunk carries a finish_reason.
+func (p *OpenAIParser) extractChatToolCallsFromSSE(sse *event.SSEEvent) []*event.ToolUsageEvent {
+	data := strings.TrimSpace(string(sse.Data))
+	if data == "" || data == "[DONE]" {
+		return nil
+	}
+
+	var chunk openaiChatResponse
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil || len(chunk.Choices) == 0 {
+		return nil
+	}
+	choice := chunk.Choices[0]
+
+	if choice.Delta != nil {
+		for _, tc := range choice.Delta.ToolCalls {
+			key := fmt.Sprintf("%d:%d", sse.SSLContext, tc.Index)
+			callI, _ := p.streamingToolCalls.LoadOrStore(key, &streamingToolCall{})
+			call := callI.(*streamingToolCall)
+			if tc.ID != "" {
+				call.id = tc.ID
+			}
+			if tc.Function.Name != "" {
+				call.name = tc.Function.Name
+			}
+			if tc.Function.Arguments != "" {
+				call.arguments.WriteString(tc.Function.Arguments)
+			}
+		}
+	}
+
+	if choice.FinishReason == "" {
+		return nil
+	}
+
+	// The chunk carrying finish_reason marks every tool call in this
+	// session's stream as complete; flush them all.
+	prefix := fmt.Sprintf("%d:", sse.SSLContext)
+	var events []*event.ToolUsageEvent
+	p.streamingToolCalls.Range(func(k, v interface{}) bool {
+		key := k.(string)
+		if !strings.HasPrefix(key, prefix) {
+			return true
+		}
+		call := v.(*streamingToolCall)
+		events = append(events, &event.ToolUsageEvent{
+			SessionID: sse.SSLContext,
+			Timestamp: p.clock.Now(),
+			UsageType: event.ToolUsageTypeInvocation,
+			ToolID:    call.id,
+			ToolName:  call.name,
+			Input:     call.arguments.String(),
+		})
+		p.streamingToolCalls.Delete(key)
+		return true
+	})
+	return events
+}
+
+func (p *OpenAIParser) extractChatToolCalls(payload []byte, sessionID uint64) []*event.ToolUsageEvent {
+	var resp openaiChatResponse
+	if err := json.Unmarshal(payload, &resp); err != nil || len(resp.Choices) == 0 {
+		return nil
+	}
+
+	var events []*event.ToolUsageEvent
+	for _, tc := range resp.Choices[0].Message.ToolCalls {
+		events = append(events, &event.ToolUsageEvent{
+			SessionID: sessionID,
+			Timestamp: p.clock.Now(),
+			UsageType: event.ToolUsageTypeInvocation,
+			ToolID:    tc.ID,
+			ToolName:  tc.Function.Name,
+			Input:     tc.Function.Arguments,
+		})
+	}
+	return events
+}
+
+func (p *OpenAIParser) extractChatToolResults(payload []byte, sessionID uint64) []*event.ToolUsageEvent {
+	var req openaiChatRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil
+	}
+
+	var events []*event.ToolUsageEvent
+	for _, msg := range req.Messages {
+		if msg.Role != "tool" {
+			continue
+		}
+		events = append(events, &event.ToolUsageEvent{
+			SessionID: sessionID,
+			Timestamp: p.clock.Now(),
+			UsageType: event.ToolUsageTypeResult,
+			ToolID:    msg.ToolCallID,
+			Output:    extractMessageContent(msg.Content),
+		})
+	}
+	return events
+}
+
+func (p *OpenAIParser) extractResponsesToolCalls(payload []byte, sessionID uint64) []*event.ToolUsageEvent {
+	var resp openaiResponsesResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return nil
+	}
+
+	var events []*event.ToolUsageEvent
+	for _, item := range resp.Output {
+		if item.Type != "function_call" {
+			continue
+		}
+		events = append(events, &event.ToolUsageEvent{
+			SessionID: sessionID,
+			Timestamp: p.clock.Now(),
+			UsageType: event.ToolUsageTypeInvocation,
+			ToolID:    item.CallID,
+			ToolName:  item.Name,
+			Input:     item.Arguments,
+		})
+	}
+	return events
+}
+
+func (p *OpenAIParser) extractResponsesToolResults(payload []byte, sessionID uint64) []*event.ToolUsageEvent {
+	var req openaiResponsesRequestForTools
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil
+	}
+
+	var events []*event.ToolUsageEvent
+	for _, item := range req.Input {
+		if item.Type != "function_call_output" {
+			continue
+		}
+		events = append(events, &event.ToolUsageEvent{
+			SessionID: sessionID,
+			Timestamp: p.clock.Now(),
+			UsageType: event.ToolUsageTypeResult,
+			ToolID:    item.CallID,
+			Output:    item.Output,
+		})
+	}
+	return events
+}
@@ -0,0 +1,435 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIParser_ParseRequest_ChatCompletions(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	tests := []struct {
+		name            string
+		payload         string
+		expectedModel   string
+		expectedContent string
+	}{
+		{
+			name: "simple string content",
+			payload: `{
+				"model": "gpt-4o",
+				"messages": [{"role": "user", "content": "Hello, world!"}]
+			}`,
+			expectedModel:   "gpt-4o",
+			expectedContent: "Hello, world!",
+		},
+		{
+			name: "multiple messages extracts last user message",
+			payload: `{
+				"model": "gpt-4o",
+				"messages": [
+					{"role": "user", "content": "First question"},
+					{"role": "assistant", "content": "First answer"},
+					{"role": "user", "content": "Follow-up question"}
+				]
+			}`,
+			expectedModel:   "gpt-4o",
+			expectedContent: "Follow-up question",
+		},
+		{
+			name: "array content blocks",
+			payload: `{
+				"model": "gpt-4o",
+				"messages": [{
+					"role": "user",
+					"content": [
+						{"type": "text", "text": "What is in this image?"}
+					]
+				}]
+			}`,
+			expectedModel:   "gpt-4o",
+			expectedContent: "What is in this image?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &event.HttpRequestEvent{
+				EventHeader:    makeEventHeader(1234, "python"),
+				SSLContext:     99999,
+				Host:           "api.openai.com",
+				Path:           "/v1/chat/completions",
+				RequestPayload: []byte(tt.payload),
+			}
+
+			result, err := parser.ParseRequest(req)
+			require.NoError(t, err)
+			assert.Equal(t, event.LLMMessageTypeRequest, result.MessageType)
+			assert.Equal(t, tt.expectedModel, result.Model)
+			assert.Equal(t, tt.expectedContent, result.Content)
+			assert.Equal(t, "/v1/chat/completions", result.Path)
+		})
+	}
+}
+
+func TestOpenAIParser_ParseRequest_Attachments(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	tests := []struct {
+		name                string
+		payload             string
+		expectedAttachments []string
+	}{
+		{
+			name: "remote image_url",
+			payload: `{
+				"model": "gpt-4o",
+				"messages": [{
+					"role": "user",
+					"content": [
+						{"type": "text", "text": "What is in this image?"},
+						{"type": "image_url", "image_url": {"url": "https://example.com/cat.png"}}
+					]
+				}]
+			}`,
+			expectedAttachments: []string{"https://example.com/cat.png"},
+		},
+		{
+			name: "inline base64 image_url records a marker, not the data",
+			payload: `{
+				"model": "gpt-4o",
+				"messages": [{
+					"role": "user",
+					"content": [
+						{"type": "image_url", "image_url": {"url": "data:image/jpeg;base64,/9j/4AAQSkZJRg=="}}
+					]
+				}]
+			}`,
+			expectedAttachments: []string{"inline:image/jpeg"},
+		},
+		{
+			name: "no image blocks",
+			payload: `{
+				"model": "gpt-4o",
+				"messages": [{"role": "user", "content": "Hello, world!"}]
+			}`,
+			expectedAttachments: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &event.HttpRequestEvent{
+				EventHeader:    makeEventHeader(1234, "python"),
+				SSLContext:     99999,
+				Host:           "api.openai.com",
+				Path:           "/v1/chat/completions",
+				RequestPayload: []byte(tt.payload),
+			}
+
+			result, err := parser.ParseRequest(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttachments, result.Attachments)
+		})
+	}
+}
+
+func TestOpenAIParser_ParseRequest_Responses(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	tests := []struct {
+		name            string
+		payload         string
+		expectedContent string
+	}{
+		{
+			name:            "plain string input",
+			payload:         `{"model": "gpt-5", "input": "Summarize this file"}`,
+			expectedContent: "Summarize this file",
+		},
+		{
+			name: "array of input items extracts last user message",
+			payload: `{
+				"model": "gpt-5",
+				"input": [
+					{"role": "user", "content": [{"type": "input_text", "text": "First"}]},
+					{"role": "assistant", "content": [{"type": "output_text", "text": "Ack"}]},
+					{"role": "user", "content": [{"type": "input_text", "text": "Second"}]}
+				]
+			}`,
+			expectedContent: "Second",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &event.HttpRequestEvent{
+				EventHeader:    makeEventHeader(1234, "python"),
+				SSLContext:     99999,
+				Host:           "api.openai.com",
+				Path:           "/v1/responses",
+				RequestPayload: []byte(tt.payload),
+			}
+
+			result, err := parser.ParseRequest(req)
+			require.NoError(t, err)
+			assert.Equal(t, "gpt-5", result.Model)
+			assert.Equal(t, tt.expectedContent, result.Content)
+			assert.Equal(t, "/v1/responses", result.Path)
+		})
+	}
+}
+
+func TestOpenAIParser_ParseResponse_ChatCompletions(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	req := &event.HttpRequestEvent{
+		EventHeader: makeEventHeader(1234, "python"),
+		SSLContext:  99999,
+		Host:        "api.openai.com",
+		Path:        "/v1/chat/completions",
+	}
+
+	resp := &event.HttpResponseEvent{
+		HttpRequestEvent: *req,
+		ResponsePayload: []byte(`{
+			"model": "gpt-4o",
+			"choices": [{"message": {"role": "assistant", "content": "Hi there!"}, "finish_reason": "stop"}]
+		}`),
+	}
+
+	result, err := parser.ParseResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, event.LLMMessageTypeResponse, result.MessageType)
+	assert.Equal(t, "gpt-4o", result.Model)
+	assert.Equal(t, "Hi there!", result.Content)
+	assert.Empty(t, result.Error)
+}
+
+func TestOpenAIParser_ParseResponse_ChatCompletions_Error(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	resp := &event.HttpResponseEvent{
+		HttpRequestEvent: event.HttpRequestEvent{Path: "/v1/chat/completions"},
+		ResponsePayload:  []byte(`{"error": {"message": "rate limit exceeded"}}`),
+	}
+
+	result, err := parser.ParseResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, "rate limit exceeded", result.Error)
+}
+
+func TestOpenAIParser_ParseResponse_Responses(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	resp := &event.HttpResponseEvent{
+		HttpRequestEvent: event.HttpRequestEvent{
+			EventHeader: makeEventHeader(1234, "python"),
+			SSLContext:  99999,
+			Host:        "api.openai.com",
+			Path:        "/v1/responses",
+		},
+		ResponsePayload: []byte(`{
+			"model": "gpt-5",
+			"output": [
+				{"type": "message", "content": [{"type": "output_text", "text": "Here is the summary."}]}
+			]
+		}`),
+	}
+
+	result, err := parser.ParseResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-5", result.Model)
+	assert.Equal(t, "Here is the summary.", result.Content)
+}
+
+func TestOpenAIParser_ParseResponse_Responses_Error(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	resp := &event.HttpResponseEvent{
+		HttpRequestEvent: event.HttpRequestEvent{Path: "/v1/responses"},
+		ResponsePayload:  []byte(`{"error": {"message": "invalid request"}}`),
+	}
+
+	result, err := parser.ParseResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, "invalid request", result.Error)
+}
+
+func TestOpenAIParser_ParseStreamEvent_ChatCompletions(t *testing.T) {
+	parser := NewOpenAIParser()
+	sse := &event.SSEEvent{
+		HttpRequestEvent: event.HttpRequestEvent{Path: "/v1/chat/completions"},
+	}
+
+	ev, done, err := parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: sse.HttpRequestEvent,
+		Data:             []byte(`{"model":"gpt-4o","choices":[{"delta":{"content":"Hel"}}]}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, "Hel", ev.Content)
+	assert.Equal(t, "gpt-4o", ev.Model)
+	assert.False(t, done)
+
+	ev, done, err = parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: sse.HttpRequestEvent,
+		Data:             []byte(`{"model":"gpt-4o","choices":[{"delta":{},"finish_reason":"stop"}]}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.True(t, done)
+
+	ev, done, err = parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: sse.HttpRequestEvent,
+		Data:             []byte(`[DONE]`),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+	assert.True(t, done)
+}
+
+func TestOpenAIParser_ParseStreamEvent_Responses(t *testing.T) {
+	parser := NewOpenAIParser()
+	path := event.HttpRequestEvent{Path: "/v1/responses"}
+
+	ev, done, err := parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: path,
+		Data:             []byte(`{"type":"response.output_text.delta","delta":"Hel"}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, "Hel", ev.Content)
+	assert.False(t, done)
+
+	ev, done, err = parser.ParseStreamEvent(&event.SSEEvent{
+		HttpRequestEvent: path,
+		Data:             []byte(`{"type":"response.completed","response":{"model":"gpt-5"}}`),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, "gpt-5", ev.Model)
+	assert.True(t, done)
+}
+
+func TestOpenAIParser_ExtractToolUsage_ChatCompletions(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	resp := &event.HttpResponseEvent{
+		HttpRequestEvent: event.HttpRequestEvent{SSLContext: 1, Path: "/v1/chat/completions"},
+		ResponsePayload: []byte(`{
+			"model": "gpt-4o",
+			"choices": [{"message": {"role": "assistant", "tool_calls": [
+				{"id": "call_1", "function": {"name": "get_weather", "arguments": "{\"city\":\"SF\"}"}}
+			]}}]
+		}`),
+	}
+
+	events := parser.ExtractToolUsage(resp)
+	require.Len(t, events, 1)
+	assert.Equal(t, event.ToolUsageTypeInvocation, events[0].UsageType)
+	assert.Equal(t, "call_1", events[0].ToolID)
+	assert.Equal(t, "get_weather", events[0].ToolName)
+
+	req := &event.HttpRequestEvent{
+		SSLContext: 1,
+		Path:       "/v1/chat/completions",
+		RequestPayload: []byte(`{
+			"messages": [{"role": "tool", "tool_call_id": "call_1", "content": "72F and sunny"}]
+		}`),
+	}
+
+	results := parser.ExtractToolUsage(req)
+	require.Len(t, results, 1)
+	assert.Equal(t, event.ToolUsageTypeResult, results[0].UsageType)
+	assert.Equal(t, "call_1", results[0].ToolID)
+	assert.Equal(t, "72F and sunny", results[0].Output)
+}
+
+func TestOpenAIParser_ExtractToolUsage_Responses(t *testing.T) {
+	parser := NewOpenAIParser()
+
+	resp := &event.HttpResponseEvent{
+		HttpRequestEvent: event.HttpRequestEvent{SSLContext: 1, Path: "/v1/responses"},
+		ResponsePayload: []byte(`{
+			"model": "gpt-5",
+			"output": [{"type": "function_call", "call_id": "call_1", "name": "get_weather", "arguments": "{\"city\":\"SF\"}"}]
+		}`),
+	}
+
+	events := parser.ExtractToolUsage(resp)
+	require.Len(t, events, 1)
+	assert.Equal(t, "call_1", events[0].ToolID)
+	assert.Equal(t, "get_weather", events[0].ToolName)
+
+	req := &event.HttpRequestEvent{
+		SSLContext: 1,
+		Path:       "/v1/responses",
+		RequestPayload: []byte(`{
+			"input": [{"type": "function_call_output", "call_id": "call_1", "output": "72F and sunny"}]
+		}`),
+	}
+
+	results := parser.ExtractToolUsage(req)
+	require.Len(t, results, 1)
+	assert.Equal(t, event.ToolUsageTypeResult, results[0].UsageType)
+	assert.Equal(t, "72F and sunny", results[0].Output)
+}
+
+func TestOpenAIParser_ExtractToolUsage_ChatCompletionsStream(t *testing.T) {
+	parser := NewOpenAIParser()
+	sse := event.HttpRequestEvent{SSLContext: 1, Path: "/v1/chat/completions"}
+
+	// First chunk introduces both tool calls with their IDs and names, and
+	// the first fragment of arguments for each.
+	events := parser.ExtractToolUsage(&event.SSEEvent{
+		HttpRequestEvent: sse,
+		Data: []byte(`{"choices":[{"delta":{"tool_calls":[
+			{"index":0,"id":"call_1","function":{"name":"get_weather","arguments":"{\"city\":"}},
+			{"index":1,"id":"call_2","function":{"name":"get_time","arguments":"{\"tz\":"}}
+		]}}]}`),
+	})
+	assert.Empty(t, events, "no tool call is complete until finish_reason arrives")
+
+	// Arguments trickle in across further chunks, keyed by index.
+	events = parser.ExtractToolUsage(&event.SSEEvent{
+		HttpRequestEvent: sse,
+		Data:             []byte(`{"choices":[{"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"SF\"}"}}]}}]}`),
+	})
+	assert.Empty(t, events)
+
+	events = parser.ExtractToolUsage(&event.SSEEvent{
+		HttpRequestEvent: sse,
+		Data:             []byte(`{"choices":[{"delta":{"tool_calls":[{"index":1,"function":{"arguments":"\"UTC\"}"}}]}}]}`),
+	})
+	assert.Empty(t, events)
+
+	// The chunk carrying finish_reason flushes both completed tool calls.
+	events = parser.ExtractToolUsage(&event.SSEEvent{
+		HttpRequestEvent: sse,
+		Data:             []byte(`{"choices":[{"delta":{},"finish_reason":"tool_calls"}]}`),
+	})
+	require.Len(t, events, 2)
+
+	byID := map[string]*event.ToolUsageEvent{}
+	for _, e := range events {
+		byID[e.ToolID] = e
+	}
+	require.Contains(t, byID, "call_1")
+	require.Contains(t, byID, "call_2")
+	assert.Equal(t, "get_weather", byID["call_1"].ToolName)
+	assert.Equal(t, `{"city":"SF"}`, byID["call_1"].Input)
+	assert.Equal(t, "get_time", byID["call_2"].ToolName)
+	assert.Equal(t, `{"tz":"UTC"}`, byID["call_2"].Input)
+
+	// Completed calls are removed from the accumulator, so a later stream
+	// on the same session starts clean instead of replaying stale state.
+	events = parser.ExtractToolUsage(&event.SSEEvent{
+		HttpRequestEvent: sse,
+		Data:             []byte(`{"choices":[{"delta":{},"finish_reason":"stop"}]}`),
+	})
+	assert.Empty(t, events)
+}
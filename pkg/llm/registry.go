@@ -0,0 +1,61 @@
+package llm
+
+import "strings"
+
+// registryEntry pairs a host pattern with the parser it should dispatch to.
+type registryEntry struct {
+	pattern string
+	parser  ProviderParser
+}
+
+// Registry maps a captured HTTP host to the ProviderParser that understands
+// its API. Centralizing the mapping here means adding support for a new
+// host (e.g. an Azure-hosted OpenAI deployment) is a Register call, not a
+// change to every place that dispatches on provider.
+//
+// Patterns are matched case-insensitively. A pattern beginning with "*."
+// is a wildcard that matches any subdomain of the remainder (e.g.
+// "*.openai.azure.com" matches "my-deployment.openai.azure.com" but not
+// "openai.azure.com" itself); any other pattern must match the host
+// exactly.
+type Registry struct {
+	entries []registryEntry
+}
+
+// NewRegistry creates an empty provider registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register associates hostPattern with p. Registering a pattern a second
+// time replaces the parser it points to.
+func (r *Registry) Register(hostPattern string, p ProviderParser) {
+	hostPattern = strings.ToLower(hostPattern)
+	for i, e := range r.entries {
+		if e.pattern == hostPattern {
+			r.entries[i].parser = p
+			return
+		}
+	}
+	r.entries = append(r.entries, registryEntry{pattern: hostPattern, parser: p})
+}
+
+// ParserForHost returns the parser registered for host, or false if no
+// pattern matches. Exact patterns are preferred over wildcard patterns,
+// regardless of registration order.
+func (r *Registry) ParserForHost(host string) (ProviderParser, bool) {
+	host = strings.ToLower(host)
+
+	for _, e := range r.entries {
+		if e.pattern == host {
+			return e.parser, true
+		}
+	}
+	for _, e := range r.entries {
+		suffix, ok := strings.CutPrefix(e.pattern, "*.")
+		if ok && strings.HasSuffix(host, "."+suffix) {
+			return e.parser, true
+		}
+	}
+	return nil, false
+}
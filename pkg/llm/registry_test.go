@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// stubParser is a no-op ProviderParser, used only to verify Registry routes
+// to the parser instance it was given.
+type stubParser struct{}
+
+func (stubParser) ParseRequest(*event.HttpRequestEvent) (*event.LLMEvent, error) { return nil, nil }
+func (stubParser) ParseResponse(*event.HttpResponseEvent) (*event.LLMEvent, error) {
+	return nil, nil
+}
+func (stubParser) ParseStreamEvent(*event.SSEEvent) (*event.LLMEvent, bool, error) {
+	return nil, false, nil
+}
+func (stubParser) ExtractToolUsage(event.Event) []*event.ToolUsageEvent { return nil }
+
+func TestRegistry_ParserForHost(t *testing.T) {
+	openai := stubParser{}
+	anthropic := stubParser{}
+	azure := stubParser{}
+
+	r := NewRegistry()
+	r.Register("api.openai.com", openai)
+	r.Register("api.anthropic.com", anthropic)
+	r.Register("*.openai.azure.com", azure)
+
+	tests := []struct {
+		name string
+		host string
+		want ProviderParser
+		ok   bool
+	}{
+		{name: "exact match", host: "api.openai.com", want: openai, ok: true},
+		{name: "exact match case insensitive", host: "API.OPENAI.COM", want: openai, ok: true},
+		{name: "second exact match", host: "api.anthropic.com", want: anthropic, ok: true},
+		{name: "wildcard subdomain match", host: "my-deployment.openai.azure.com", want: azure, ok: true},
+		{name: "wildcard does not match bare suffix", host: "openai.azure.com", ok: false},
+		{name: "wildcard is case insensitive", host: "MY-DEPLOYMENT.OPENAI.AZURE.COM", want: azure, ok: true},
+		{name: "unregistered host", host: "example.com", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.ParserForHost(tt.host)
+			if ok != tt.ok {
+				t.Fatalf("ParserForHost(%q) ok = %v, want %v", tt.host, ok, tt.ok)
+			}
+			if ok && got != tt.want {
+				t.Errorf("ParserForHost(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_RegisterReplacesExistingPattern(t *testing.T) {
+	first := stubParser{}
+	second := stubParser{}
+
+	r := NewRegistry()
+	r.Register("api.openai.com", first)
+	r.Register("api.openai.com", second)
+
+	got, ok := r.ParserForHost("api.openai.com")
+	if !ok {
+		t.Fatal("ParserForHost() ok = false, want true")
+	}
+	if got != second {
+		t.Error("expected re-registering a pattern to replace its parser")
+	}
+}
+
+func TestRegistry_ExactMatchPreferredOverWildcard(t *testing.T) {
+	exact := stubParser{}
+	wildcard := stubParser{}
+
+	r := NewRegistry()
+	r.Register("*.googleapis.com", wildcard)
+	r.Register("generativelanguage.googleapis.com", exact)
+
+	got, ok := r.ParserForHost("generativelanguage.googleapis.com")
+	if !ok {
+		t.Fatal("ParserForHost() ok = false, want true")
+	}
+	if got != exact {
+		t.Error("expected an exact match to win over a wildcard match")
+	}
+}
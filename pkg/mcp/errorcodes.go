@@ -0,0 +1,27 @@
+package mcp
+
+// JSON-RPC 2.0 reserves -32768 to -32000 for pre-defined errors:
+// https://www.jsonrpc.org/specification#error_object
+// MCP builds on top of that reserved range for a couple of its own
+// transport/session errors.
+var jsonRPCErrorCodes = map[int]string{
+	-32700: "Parse error",
+	-32600: "Invalid Request",
+	-32601: "Method not found",
+	-32602: "Invalid params",
+	-32603: "Internal error",
+
+	// MCP-specific
+	-32001: "Request timeout",
+	-32002: "Resource not found",
+}
+
+// GetErrorCodeDescription returns a human-readable label for a JSON-RPC or
+// MCP-specific error code, falling back to "Unknown error" for codes outside
+// that catalog.
+func GetErrorCodeDescription(code int) string {
+	if desc, ok := jsonRPCErrorCodes[code]; ok {
+		return desc
+	}
+	return "Unknown error"
+}
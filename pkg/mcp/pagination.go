@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	// paginationCacheSize bounds the number of concurrently tracked
+	// paginated list sequences (one entry per session+method pair).
+	paginationCacheSize = 1024
+	// paginationCacheTTL is how long a sequence's running count survives
+	// between pages. Generous compared to requestIDCacheTTL since a client
+	// may pause between pages (e.g. a human paging through a tool list).
+	paginationCacheTTL = 5 * time.Minute
+)
+
+// paginatedListResultField maps the list methods that support cursor-based
+// pagination (per the MCP spec) to the name of the array field holding
+// their items in the response's "result" object.
+var paginatedListResultField = map[string]string{
+	"tools/list":     "tools",
+	"resources/list": "resources",
+	"prompts/list":   "prompts",
+}
+
+// paginationKey scopes a paginated list sequence to a session and method,
+// so two sessions (or two different list methods in the same session)
+// never share a running count.
+type paginationKey struct {
+	session string
+	method  string
+}
+
+// paginationState is the running count for one in-progress paginated
+// sequence, keyed by paginationKey.
+type paginationState struct {
+	page      int
+	itemCount int
+}
+
+// trackPaginatedList annotates a tools/list, resources/list, or
+// prompts/list response with PageInfo linking it to the session's earlier
+// pages of the same method, so a multi-page listing reports an accurate
+// running total instead of just the current page's count. No-op for any
+// other message.
+func (p *Parser) trackPaginatedList(msg *event.JSONRPCMessage, stdio *event.StdioTransport, http *event.HttpTransport, tcp *event.TCPTransport) {
+	if msg.MessageType != event.JSONRPCMessageTypeResponse || msg.Request == nil {
+		return
+	}
+
+	itemsField, ok := paginatedListResultField[msg.Request.Method]
+	if !ok {
+		return
+	}
+
+	result, ok := msg.Result.(map[string]interface{})
+	if !ok {
+		return
+	}
+	items, _ := result[itemsField].([]interface{})
+	nextCursor, _ := result["nextCursor"].(string)
+	cursor, _ := msg.Request.Params["cursor"].(string)
+
+	key := paginationKey{session: p.sessionKey(stdio, http, tcp), method: msg.Request.Method}
+
+	var state *paginationState
+	if cursor != "" {
+		state, _ = p.paginationCache.Get(key)
+	}
+	if state == nil {
+		state = &paginationState{}
+	}
+
+	state.page++
+	state.itemCount += len(items)
+
+	msg.Page = &event.PageInfo{
+		Page:           state.page,
+		ItemCount:      len(items),
+		TotalItemCount: state.itemCount,
+		HasMore:        nextCursor != "",
+	}
+
+	if nextCursor == "" {
+		p.paginationCache.Remove(key)
+	} else {
+		p.paginationCache.Add(key, state)
+	}
+}
+
+func newPaginationCache() *expirable.LRU[paginationKey, *paginationState] {
+	return expirable.NewLRU[paginationKey, *paginationState](paginationCacheSize, nil, paginationCacheTTL)
+}
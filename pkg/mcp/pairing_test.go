@@ -237,6 +237,13 @@ func TestRequestResponsePairing_ErrorResponse(t *testing.T) {
 	errorEvent := createFSAggregatedEvent(errorData, event.EventTypeFSWrite, 200, "server", 100, "client")
 	parser.ParseDataStdio(errorEvent)
 
+	// Drain the tool call result event emitted alongside the response
+	select {
+	case <-mockBus.Events():
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Timeout waiting for tool call result event")
+	}
+
 	// Read the error response from the bus
 	var responseMsg *event.MCPEvent
 	select {
@@ -323,6 +330,13 @@ func TestRequestResponsePairing_HTTPTransport(t *testing.T) {
 	responseEvent := createHttpResponseEvent(responseData, 300, "curl", "api.example.com")
 	parser.ParseDataHttp(responseEvent)
 
+	// Drain the tool call result event emitted alongside the response
+	select {
+	case <-mockBus.Events():
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Timeout waiting for tool call result event")
+	}
+
 	// Read the response from the bus
 	var responseMsg *event.MCPEvent
 	select {
@@ -374,6 +388,13 @@ func TestRequestResponsePairing_RequestParams(t *testing.T) {
 	responseEvent := createFSAggregatedEvent(responseData, event.EventTypeFSWrite, 200, "server", 100, "client")
 	parser.ParseDataStdio(responseEvent)
 
+	// Drain the tool call result event emitted alongside the response
+	select {
+	case <-mockBus.Events():
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Timeout waiting for tool call result event")
+	}
+
 	// Read the response from the bus
 	var responseMsg *event.MCPEvent
 	select {
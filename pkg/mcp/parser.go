@@ -3,12 +3,16 @@ package mcp
 import (
 	"bytes"
 	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
+	"github.com/alex-ilgayev/mcpspy/pkg/container"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
 	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/sirupsen/logrus"
@@ -20,6 +24,14 @@ var (
 	requestIDCacheTTL  = 5 * time.Second
 	seenHashCacheSize  = 4096
 	seenHashCacheTTL   = 2 * time.Second
+
+	// sseSeenIDCacheSize/TTL bound the cache of SSE "id:" values already
+	// processed per stream. TTL is much longer than seenHashCacheTTL's
+	// because a client's reconnect-with-Last-Event-ID can happen well after
+	// the original frame arrived (backoff delay, network blip), and replayed
+	// frames must still be recognized as duplicates when that happens.
+	sseSeenIDCacheSize = 4096
+	sseSeenIDCacheTTL  = 5 * time.Minute
 )
 
 // Protocol resources:
@@ -69,6 +81,20 @@ var allowedMCPMethods = map[string]string{
 	"notifications/roots/list_changed": "Root list changed",
 }
 
+// pendingRequest bundles a cached request message with the transport
+// context it arrived on and whether it has since been answered, so that if
+// it's evicted from requestIDCache unanswered, the eviction callback can
+// report which process chain the timed-out request belongs to.
+type pendingRequest struct {
+	msg           *event.JSONRPCMessage
+	transportType event.TransportType
+	stdio         *event.StdioTransport
+	http          *event.HttpTransport
+	tcp           *event.TCPTransport
+	timestamp     time.Time
+	answered      atomic.Bool
+}
+
 // Parser handles parsing of MCP messages
 // Subscribes to the following events:
 // - EventTypeFSAggregatedRead
@@ -76,31 +102,180 @@ var allowedMCPMethods = map[string]string{
 // - EventTypeHttpRequest
 // - EventTypeHttpResponse
 // - EventTypeHttpSSE
+// - EventTypeTCPAggregated
 //
 // Emits the following events:
 // - EventTypeMCPMessage
+// - EventTypeRequestTimeout
+// - EventTypeToolResultSchemaWarning
+// - EventTypeToolCallResult
+// - EventTypeLargeToolArgs
+// - EventTypeDuplicateMessage
 type Parser struct {
 	// Cache for correlating requests and responses by ID.
-	// Stores full request messages to enable pairing with their responses.
+	// Stores pending request messages to enable pairing with their
+	// responses, and reports unanswered ones as timeouts on eviction.
 	// Thread-safe.
-	requestIDCache *expirable.LRU[string, *event.JSONRPCMessage]
+	requestIDCache *expirable.LRU[string, *pendingRequest]
 
 	// Cache for detecting duplicate messages.
 	// Once we see a hash, we don't emit it again (first one wins).
 	// Relevant for docker-based MCPs which may emit duplicates.
+	// Keyed by the raw SHA-1 digest rather than its hex encoding, to avoid
+	// an allocation per message on this hot path.
+	// Thread-safe.
+	seenHashCache *expirable.LRU[[sha1.Size]byte, struct{}]
+
+	// Cache of SSE "id:" values already processed, keyed by "host|id", so a
+	// frame replayed after a client reconnects with Last-Event-ID is
+	// recognized and dropped instead of re-emitted as a new message.
 	// Thread-safe.
-	seenHashCache *expirable.LRU[string, struct{}]
+	sseSeenIDCache *expirable.LRU[string, struct{}]
+
+	// Cache of running item counts for in-progress tools/list,
+	// resources/list, and prompts/list pagination sequences.
+	// Thread-safe.
+	paginationCache *expirable.LRU[paginationKey, *paginationState]
+
+	// When true, accept messages with method+id even if the jsonrpc field is
+	// missing or not exactly "2.0" (tagging them as non-conformant), to aid
+	// debugging of buggy MCP servers. Defaults to false (strict).
+	lenientJSONRPC bool
+
+	// clock supplies the current time for emitted events. Defaults to the
+	// wall clock; tests substitute a clock.Fake for reproducible timestamps.
+	clock clock.Clock
+
+	// maxToolArgsBytes is the serialized size above which a tools/call
+	// request's arguments are flagged as unusually large.
+	maxToolArgsBytes int
+
+	// correlationIDMode, when true, tags request/response pairs with a
+	// stable CorrelationID instead of embedding the full request in the
+	// response. Defaults to false (embedded Request).
+	correlationIDMode bool
+
+	// requestTimeout is how long a request waits in requestIDCache for its
+	// response before handleRequestTimeout reports it as unanswered.
+	// Defaults to requestIDCacheTTL.
+	requestTimeout time.Duration
+
+	// strict, when true, validates request/response payloads against
+	// strictSchemas and attaches violations to the emitted MCPEvent.
+	// Defaults to false.
+	strict bool
+
+	// correlatedOnly, when true, suppresses the standalone MCPEvent for a
+	// request and relies on handleRequestResponseCorrelation having already
+	// attached it to the eventual response, so only one combined
+	// request+response+latency record is ever emitted per exchange.
+	// Unanswered requests still surface via RequestTimeoutEvent. Defaults
+	// to false (both legs emitted separately, as they arrive).
+	correlatedOnly bool
+
+	// showDupes, when true, stops folding messages that match an
+	// already-seen hash in seenHashCache: every hop is parsed and emitted
+	// as its own MCPEvent instead of only the first. Defaults to false.
+	showDupes bool
+
+	// containers resolves the container ID of each side of a stdio hop,
+	// caching lookups per PID since a process's cgroup membership doesn't
+	// change over its lifetime.
+	containers *container.Resolver
 
 	eventBus bus.EventBus
 }
 
-// NewParser creates a new MCP parser
+// NewParser creates a new MCP parser with strict JSON-RPC version checking.
 func NewParser(eventBus bus.EventBus) (*Parser, error) {
+	return NewParserWithConfig(eventBus, Config{})
+}
+
+// Config controls optional Parser behavior.
+type Config struct {
+	// LenientJSONRPC accepts messages missing or mismatching "jsonrpc":"2.0"
+	// as long as they otherwise look like a request/response/notification.
+	// Useful for debugging non-conformant MCP server implementations.
+	LenientJSONRPC bool
+
+	// Clock supplies the current time for emitted events. Defaults to the
+	// wall clock when nil. Tests set this to a clock.Fake for deterministic,
+	// golden-file-comparable output.
+	Clock clock.Clock
+
+	// MaxToolArgsBytes is the serialized size, in bytes, above which a
+	// tools/call request's arguments are flagged as unusually large.
+	// Defaults to defaultMaxToolArgsBytes when zero.
+	MaxToolArgsBytes int
+
+	// CorrelationIDMode, when true, attaches a stable CorrelationID
+	// (derived from the session and JSON-RPC id) to both a request and its
+	// response instead of embedding the full request in the response. This
+	// cuts file size for request-heavy JSONL captures at the cost of
+	// requiring downstream tools to join the two records themselves.
+	// Defaults to false (embedded Request).
+	CorrelationIDMode bool
+
+	// Strict, when true, additionally validates request params and
+	// response results against the MCP schema for the methods
+	// strictSchemas covers, attaching any violations found to the emitted
+	// MCPEvent's ValidationErrors rather than dropping the message.
+	// Defaults to false.
+	Strict bool
+
+	// CorrelatedOnly, when true, holds a request until its response (or
+	// timeout) arrives and emits a single combined record with the full
+	// exchange and latency, instead of emitting the request and response
+	// as two separate records. Useful for report-style output. Defaults
+	// to false.
+	CorrelatedOnly bool
+
+	// RequestTimeout is how long a request waits for its response before
+	// it's evicted from requestIDCache and reported as unanswered via a
+	// RequestTimeoutEvent. Defaults to requestIDCacheTTL when zero.
+	RequestTimeout time.Duration
+
+	// ShowDupes, when true, stops folding messages that match an
+	// already-seen hash: every hop is parsed and emitted as its own
+	// MCPEvent, so a multi-hop relay (e.g. a Docker proxy) shows its full
+	// per-hop timing instead of only the first hop. A DuplicateMessageEvent
+	// is still published for each duplicate either way. Defaults to false.
+	ShowDupes bool
+}
+
+// defaultMaxToolArgsBytes is the default threshold for
+// Config.MaxToolArgsBytes: large enough to not flag typical tool calls,
+// small enough to catch accidental data dumps.
+const defaultMaxToolArgsBytes = 64 * 1024
+
+// NewParserWithConfig creates a new MCP parser with custom config.
+func NewParserWithConfig(eventBus bus.EventBus, cfg Config) (*Parser, error) {
+	if cfg.Clock == nil {
+		cfg.Clock = clock.New()
+	}
+	if cfg.MaxToolArgsBytes == 0 {
+		cfg.MaxToolArgsBytes = defaultMaxToolArgsBytes
+	}
+	if cfg.RequestTimeout == 0 {
+		cfg.RequestTimeout = requestIDCacheTTL
+	}
+
 	p := &Parser{
-		requestIDCache: expirable.NewLRU[string, *event.JSONRPCMessage](requestIDCacheSize, nil, requestIDCacheTTL),
-		seenHashCache:  expirable.NewLRU[string, struct{}](seenHashCacheSize, nil, seenHashCacheTTL),
-		eventBus:       eventBus,
+		seenHashCache:     expirable.NewLRU[[sha1.Size]byte, struct{}](seenHashCacheSize, nil, seenHashCacheTTL),
+		sseSeenIDCache:    expirable.NewLRU[string, struct{}](sseSeenIDCacheSize, nil, sseSeenIDCacheTTL),
+		paginationCache:   newPaginationCache(),
+		lenientJSONRPC:    cfg.LenientJSONRPC,
+		clock:             cfg.Clock,
+		maxToolArgsBytes:  cfg.MaxToolArgsBytes,
+		correlationIDMode: cfg.CorrelationIDMode,
+		strict:            cfg.Strict,
+		correlatedOnly:    cfg.CorrelatedOnly,
+		requestTimeout:    cfg.RequestTimeout,
+		showDupes:         cfg.ShowDupes,
+		containers:        container.NewResolver(),
+		eventBus:          eventBus,
 	}
+	p.requestIDCache = expirable.NewLRU[string, *pendingRequest](requestIDCacheSize, p.handleRequestTimeout, cfg.RequestTimeout)
 
 	if err := p.eventBus.Subscribe(event.EventTypeFSAggregatedRead, p.ParseDataStdio); err != nil {
 		return nil, err
@@ -121,12 +296,22 @@ func NewParser(eventBus bus.EventBus) (*Parser, error) {
 		p.Close()
 		return nil, err
 	}
+	if err := p.eventBus.Subscribe(event.EventTypeTCPAggregated, p.ParseDataTCP); err != nil {
+		p.Close()
+		return nil, err
+	}
+	if err := p.eventBus.Subscribe(event.EventTypeHttpWebSocket, p.ParseDataWebSocket); err != nil {
+		p.Close()
+		return nil, err
+	}
 
 	return p, nil
 }
 
 // ParseDataStdio attempts to parse MCP messages from aggregated Stdio data.
-// The parsing flow is split into several parts:
+// A top-level JSON array is treated as a JSON-RPC batch: each element is
+// parsed and published as its own MCPEvent, sharing the batch's process hop.
+// The parsing flow for each message is split into several parts:
 // 1. Duplicate detection (drop duplicates, first one wins)
 // 2. JSON-RPC parsing
 // 3. MCP validation
@@ -150,70 +335,185 @@ func (p *Parser) ParseDataStdio(e event.Event) {
 
 	// Use JSON decoder to handle multi-line JSON properly
 	decoder := json.NewDecoder(bytes.NewReader(buf))
+	var lastGoodOffset int64
 	for {
 		var jsonData json.RawMessage
 		if err := decoder.Decode(&jsonData); err != nil {
 			if err == io.EOF {
 				break
 			}
-			logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to decode JSON")
+			// The decoder never hands us a token for a document that runs
+			// out mid-way, so a kernel-truncated message (--capture-bytes)
+			// never reaches handleStdioEntry through the normal path above.
+			// Feed what's left of the buffer there directly: parseJSONRPC
+			// falls back to best-effort method/id extraction for exactly
+			// this case.
+			remaining := bytes.TrimSpace(buf[lastGoodOffset:])
+			if len(remaining) > 0 {
+				p.handleStdioEntry(remaining, e, stdioEvent)
+			} else {
+				logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to decode JSON")
+				transportType := event.TransportTypeStdio
+				if stdioEvent.IsUnixSocketFd() {
+					transportType = event.TransportTypeUnixSocket
+				}
+				p.eventBus.Publish(&event.ParseErrorEvent{
+					Timestamp:     p.clock.Now(),
+					TransportType: transportType,
+					Reason:        err.Error(),
+				})
+			}
 			return
 		}
+		lastGoodOffset = decoder.InputOffset()
 
-		if len(bytes.TrimSpace(jsonData)) == 0 {
+		trimmed := bytes.TrimSpace(jsonData)
+		if len(trimmed) == 0 {
 			continue
 		}
 
-		// Part 1: Duplicate detection
-		hash := p.calculateHash(jsonData)
-		if p.isDuplicate(hash) {
-			continue // Skip duplicates, first one wins
+		// MCP messages are always JSON objects, or a JSON-RPC batch
+		// represented as an array of objects. Some servers emit concatenated
+		// JSON with no separator (`}{`), which the decoder above handles
+		// fine, but the same decode can also pick up a stray top-level
+		// primitive token (e.g. a bare `0` or `true`) sitting between
+		// messages. That's not a malformed message worth logging about, so
+		// skip it quietly rather than letting it fall through to JSON-RPC
+		// parsing/validation and get logged as an error.
+		switch trimmed[0] {
+		case '{':
+			if p.handleStdioEntry(trimmed, e, stdioEvent) {
+				return
+			}
+		case '[':
+			batch := gjson.ParseBytes(trimmed)
+			if !batch.IsArray() {
+				logrus.WithFields(e.LogFields()).Debug("Failed to parse JSON-RPC batch")
+				return
+			}
+			// Every entry in the batch arrived on the same process hop, so
+			// they all share stdioEvent's transport info and participate in
+			// the same duplicate-detection hash space as any other message.
+			var abort bool
+			batch.ForEach(func(_, entry gjson.Result) bool {
+				if p.handleStdioEntry([]byte(entry.Raw), e, stdioEvent) {
+					abort = true
+					return false
+				}
+				return true
+			})
+			if abort {
+				return
+			}
+		default:
+			continue
 		}
+	}
+}
 
-		// Part 2 & 3: Parse JSON-RPC and validate MCP
-		jsonRpcMsg, err := p.parseJSONRPC(jsonData)
-		if err != nil {
-			logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to parse JSON-RPC")
-			return
+// handleStdioEntry parses, validates, correlates, and publishes a single
+// JSON-RPC message (either a standalone message or one element of a batch)
+// read from stdio. It reports stop=true when the caller should abort
+// processing the rest of the buffer, mirroring the errors that previously
+// ended the ParseDataStdio loop outright.
+func (p *Parser) handleStdioEntry(jsonData json.RawMessage, e event.Event, stdioEvent *event.FSAggregatedEvent) (stop bool) {
+	// Part 1: Duplicate detection
+	hash := p.calculateHash(jsonData)
+	if p.isDuplicate(hash) {
+		transportType := event.TransportTypeStdio
+		if stdioEvent.IsUnixSocketFd() {
+			transportType = event.TransportTypeUnixSocket
 		}
-
-		if err := p.validateMCPMessage(jsonRpcMsg); err != nil {
-			logrus.
-				WithFields(e.LogFields()).
-				WithFields(jsonRpcMsg.LogFields()).
-				WithError(err).
-				Debug("Invalid MCP message")
-			return
+		method, id := duplicateMessageInfo(jsonData)
+		p.publishDuplicateMessage(method, id, transportType, &event.StdioTransport{
+			FromPID:         stdioEvent.FromPID,
+			FromComm:        stdioEvent.FromCommStr(),
+			ToPID:           stdioEvent.ToPID,
+			ToComm:          stdioEvent.ToCommStr(),
+			FromContainerID: p.containers.Resolve(stdioEvent.FromPID),
+			ToContainerID:   p.containers.Resolve(stdioEvent.ToPID),
+		}, nil, nil)
+		if !p.showDupes {
+			return false // Skip duplicates, first one wins
 		}
+	}
 
-		// Part 4: Handle request/response correlation
-		if err := p.handleRequestResponseCorrelation(&jsonRpcMsg); err != nil {
-			// Drop responses without matching request IDs
-			logrus.
-				WithFields(e.LogFields()).
-				WithFields(jsonRpcMsg.LogFields()).
-				Debug("Dropping response without matching request ID")
-			return
+	// Part 2 & 3: Parse JSON-RPC and validate MCP
+	jsonRpcMsg, err := p.parseJSONRPC(jsonData)
+	if err != nil {
+		logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to parse JSON-RPC")
+		transportType := event.TransportTypeStdio
+		if stdioEvent.IsUnixSocketFd() {
+			transportType = event.TransportTypeUnixSocket
 		}
+		p.eventBus.Publish(&event.ParseErrorEvent{
+			Timestamp:     p.clock.Now(),
+			TransportType: transportType,
+			Reason:        err.Error(),
+		})
+		return true
+	}
 
-		// Create message with kernel-provided correlation
-		msg := &event.MCPEvent{
-			Timestamp:     time.Now(),
-			Raw:           string(jsonData),
-			TransportType: event.TransportTypeStdio,
-			StdioTransport: &event.StdioTransport{
-				FromPID:  stdioEvent.FromPID,
-				FromComm: stdioEvent.FromCommStr(),
-				ToPID:    stdioEvent.ToPID,
-				ToComm:   stdioEvent.ToCommStr(),
-			},
-			JSONRPCMessage: jsonRpcMsg,
-		}
+	if err := p.validateMCPMessage(jsonRpcMsg); err != nil {
+		logrus.
+			WithFields(e.LogFields()).
+			WithFields(jsonRpcMsg.LogFields()).
+			WithError(err).
+			Debug("Invalid MCP message")
+		return true
+	}
+
+	stdioTransport := &event.StdioTransport{
+		FromPID:         stdioEvent.FromPID,
+		FromComm:        stdioEvent.FromCommStr(),
+		ToPID:           stdioEvent.ToPID,
+		ToComm:          stdioEvent.ToCommStr(),
+		FromContainerID: p.containers.Resolve(stdioEvent.FromPID),
+		ToContainerID:   p.containers.Resolve(stdioEvent.ToPID),
+	}
+
+	// A handful of MCP clients (e.g. some Claude Desktop builds) talk over a
+	// Unix domain socket rather than a stdio pipe; the session manager tags
+	// which one it saw so it can be surfaced distinctly instead of being
+	// reported as plain stdio.
+	transportType := event.TransportTypeStdio
+	if stdioEvent.IsUnixSocketFd() {
+		transportType = event.TransportTypeUnixSocket
+	}
+
+	// Part 4: Handle request/response correlation
+	if err := p.handleRequestResponseCorrelation(&jsonRpcMsg, transportType, stdioTransport, nil, nil); err != nil {
+		// Drop responses without matching request IDs
+		logrus.
+			WithFields(e.LogFields()).
+			WithFields(jsonRpcMsg.LogFields()).
+			Debug("Dropping response without matching request ID")
+		return true
+	}
+
+	p.trackPaginatedList(&jsonRpcMsg, stdioTransport, nil, nil)
+	p.checkToolCallResultSchema(jsonRpcMsg, transportType, stdioTransport, nil)
+	p.emitToolCallResultEvent(jsonRpcMsg, transportType, stdioTransport, nil)
+	p.checkLargeToolCallArgs(jsonRpcMsg, transportType, stdioTransport, nil)
 
-		logrus.WithFields(msg.LogFields()).Trace(fmt.Sprintf("event#%s", msg.Type().String()))
+	if p.holdForCorrelation(jsonRpcMsg) {
+		return false
+	}
 
-		p.eventBus.Publish(msg)
+	// Create message with kernel-provided correlation
+	msg := &event.MCPEvent{
+		Timestamp:        p.clock.Now(),
+		Raw:              string(jsonData),
+		TransportType:    transportType,
+		StdioTransport:   stdioTransport,
+		JSONRPCMessage:   jsonRpcMsg,
+		ValidationErrors: p.validationErrors(jsonRpcMsg),
 	}
+
+	logrus.WithFields(msg.LogFields()).Trace(fmt.Sprintf("event#%s", msg.Type().String()))
+
+	p.eventBus.Publish(msg)
+	return false
 }
 
 // ParseDataHttp attempts to parse MCP messages from HTTP payload data
@@ -225,7 +525,12 @@ func (p *Parser) ParseDataHttp(e event.Event) {
 	var pid uint32
 	var comm string
 	var host string
+	var method string
+	var path string
 	var isRequest bool
+	var lastEventID string
+	var remoteAddr string
+	var localAddr string
 
 	switch event := e.(type) {
 	case *event.HttpRequestEvent:
@@ -233,25 +538,50 @@ func (p *Parser) ParseDataHttp(e event.Event) {
 		pid = event.PID
 		comm = event.Comm()
 		host = event.Host
+		method = event.Method
+		path = event.Path
 		isRequest = true
+		remoteAddr = event.RemoteAddr
+		localAddr = event.LocalAddr
 	case *event.HttpResponseEvent:
 		buf = event.ResponsePayload
 		pid = event.PID
 		comm = event.Comm()
 		host = event.Host
+		method = event.Method
+		path = event.Path
 		isRequest = false
+		remoteAddr = event.RemoteAddr
+		localAddr = event.LocalAddr
 	case *event.SSEEvent:
 		buf = event.Data
 		pid = event.PID
 		comm = event.Comm()
 		host = event.Host
+		method = event.Method
+		path = event.Path
 		isRequest = false
+		lastEventID = event.LastEventID
+		remoteAddr = event.RemoteAddr
+		localAddr = event.LocalAddr
 	default:
 		return
 	}
 
 	logrus.WithFields(e.LogFields()).Trace("Parsing HTTP data for MCP")
 
+	// A non-empty "id:" field means the SSE stream can be resumed with
+	// Last-Event-ID, and the same id may be sent again after a reconnect:
+	// drop it before even decoding, since it's already been emitted.
+	if lastEventID != "" {
+		key := host + "|" + lastEventID
+		if _, seen := p.sseSeenIDCache.Get(key); seen {
+			logrus.WithFields(e.LogFields()).Debug("Dropping replayed SSE frame (duplicate Last-Event-ID)")
+			return
+		}
+		p.sseSeenIDCache.Add(key, struct{}{})
+	}
+
 	// Use JSON decoder to handle multi-line JSON properly
 	decoder := json.NewDecoder(bytes.NewReader(buf))
 	for {
@@ -261,73 +591,307 @@ func (p *Parser) ParseDataHttp(e event.Event) {
 				break
 			}
 			logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to decode JSON")
+			p.eventBus.Publish(&event.ParseErrorEvent{
+				Timestamp:     p.clock.Now(),
+				TransportType: event.TransportTypeHTTP,
+				Reason:        err.Error(),
+			})
 			return
 		}
 
-		if len(bytes.TrimSpace(jsonData)) == 0 {
+		trimmed := bytes.TrimSpace(jsonData)
+		if len(trimmed) == 0 {
 			continue
 		}
 
-		// Parse the message
-		jsonRpcMsg, err := p.parseJSONRPC(jsonData)
-		if err != nil {
-			logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to parse JSON-RPC")
-			return
+		// The streamable HTTP transport allows a POST body (and, symmetrically,
+		// its response) to be a JSON-RPC batch: a JSON array of individual
+		// request/response objects sent in one round trip. Each element is
+		// correlated and emitted independently, exactly like a non-batched
+		// message, since nothing about batching changes request/response
+		// pairing by id.
+		if trimmed[0] == '[' {
+			batch := gjson.ParseBytes(trimmed)
+			if !batch.IsArray() {
+				logrus.WithFields(e.LogFields()).Debug("Failed to parse JSON-RPC batch")
+				return
+			}
+			var abort bool
+			batch.ForEach(func(_, entry gjson.Result) bool {
+				if p.handleHTTPEntry([]byte(entry.Raw), e, pid, comm, host, method, path, isRequest, remoteAddr, localAddr) {
+					abort = true
+					return false
+				}
+				return true
+			})
+			if abort {
+				return
+			}
+			continue
 		}
 
-		if err := p.validateMCPMessage(jsonRpcMsg); err != nil {
-			logrus.
-				WithFields(e.LogFields()).
-				WithFields(jsonRpcMsg.LogFields()).
-				WithError(err).Debug("Invalid MCP message")
+		if p.handleHTTPEntry(jsonData, e, pid, comm, host, method, path, isRequest, remoteAddr, localAddr) {
 			return
 		}
+	}
+}
 
-		// Handle request/response correlation
-		if err := p.handleRequestResponseCorrelation(&jsonRpcMsg); err != nil {
-			// Drop responses without matching request IDs
-			logrus.
-				WithFields(e.LogFields()).
-				WithFields(jsonRpcMsg.LogFields()).
-				Debug("Dropping response without matching request ID")
-			continue
-		}
-
-		// Create http transport info from correlated events
-		msg := &event.MCPEvent{
-			Timestamp:     time.Now(),
-			Raw:           string(jsonData),
+// handleHTTPEntry parses, validates, correlates, and publishes a single
+// JSON-RPC message (either a standalone message or one element of a
+// batched array) extracted from an HTTP request/response/SSE payload. It
+// reports stop=true when the caller should abort processing the rest of
+// the payload, mirroring the errors that previously ended ParseDataHttp
+// outright before batch support was added.
+func (p *Parser) handleHTTPEntry(jsonData json.RawMessage, e event.Event, pid uint32, comm string, host string, method string, path string, isRequest bool, remoteAddr string, localAddr string) (stop bool) {
+	jsonRpcMsg, err := p.parseJSONRPC(jsonData)
+	if err != nil {
+		logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to parse JSON-RPC")
+		p.eventBus.Publish(&event.ParseErrorEvent{
+			Timestamp:     p.clock.Now(),
 			TransportType: event.TransportTypeHTTP,
-			HttpTransport: &event.HttpTransport{
-				PID:       pid,
-				Comm:      comm,
-				Host:      host,
-				IsRequest: isRequest,
-			},
-			JSONRPCMessage: jsonRpcMsg,
+			Reason:        err.Error(),
+		})
+		return true
+	}
+
+	if err := p.validateMCPMessage(jsonRpcMsg); err != nil {
+		logrus.
+			WithFields(e.LogFields()).
+			WithFields(jsonRpcMsg.LogFields()).
+			WithError(err).Debug("Invalid MCP message")
+		return true
+	}
+
+	httpTransport := &event.HttpTransport{
+		PID:        pid,
+		Comm:       comm,
+		Host:       host,
+		HTTPMethod: method,
+		Path:       path,
+		IsRequest:  isRequest,
+		RemoteAddr: remoteAddr,
+		LocalAddr:  localAddr,
+	}
+
+	// Handle request/response correlation
+	if err := p.handleRequestResponseCorrelation(&jsonRpcMsg, event.TransportTypeHTTP, nil, httpTransport, nil); err != nil {
+		// Drop responses without matching request IDs
+		logrus.
+			WithFields(e.LogFields()).
+			WithFields(jsonRpcMsg.LogFields()).
+			Debug("Dropping response without matching request ID")
+		return false
+	}
+
+	p.trackPaginatedList(&jsonRpcMsg, nil, httpTransport, nil)
+	p.checkToolCallResultSchema(jsonRpcMsg, event.TransportTypeHTTP, nil, httpTransport)
+	p.emitToolCallResultEvent(jsonRpcMsg, event.TransportTypeHTTP, nil, httpTransport)
+	p.checkLargeToolCallArgs(jsonRpcMsg, event.TransportTypeHTTP, nil, httpTransport)
+
+	if p.holdForCorrelation(jsonRpcMsg) {
+		return false
+	}
+
+	// Create http transport info from correlated events
+	msg := &event.MCPEvent{
+		Timestamp:        p.clock.Now(),
+		Raw:              string(jsonData),
+		TransportType:    event.TransportTypeHTTP,
+		HttpTransport:    httpTransport,
+		JSONRPCMessage:   jsonRpcMsg,
+		ValidationErrors: p.validationErrors(jsonRpcMsg),
+	}
+
+	logrus.WithFields(msg.LogFields()).Trace(fmt.Sprintf("event#%s", msg.Type().String()))
+
+	p.eventBus.Publish(msg)
+	return false
+}
+
+// ParseDataTCP attempts to parse an MCP message from a bare JSON-RPC payload
+// reassembled off a TCP socket by pkg/tcp, for deployments that skip HTTP
+// framing entirely. Unlike ParseDataStdio/ParseDataHttp, each event here is
+// already exactly one complete JSON document, so there's no decoder loop.
+func (p *Parser) ParseDataTCP(e event.Event) {
+	tcpEvent, ok := e.(*event.TCPAggregatedEvent)
+	if !ok {
+		return
+	}
+
+	jsonData := tcpEvent.Payload
+	if len(jsonData) == 0 {
+		return
+	}
+
+	logrus.WithFields(e.LogFields()).Trace("Parsing TCP data for MCP")
+
+	hash := p.calculateHash(jsonData)
+	if p.isDuplicate(hash) {
+		method, id := duplicateMessageInfo(jsonData)
+		p.publishDuplicateMessage(method, id, event.TransportTypeTCP, nil, nil, &event.TCPTransport{
+			PID:  tcpEvent.PID,
+			Comm: tcpEvent.Comm(),
+		})
+		if !p.showDupes {
+			return
 		}
+	}
+
+	jsonRpcMsg, err := p.parseJSONRPC(jsonData)
+	if err != nil {
+		logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to parse JSON-RPC")
+		p.eventBus.Publish(&event.ParseErrorEvent{
+			Timestamp:     p.clock.Now(),
+			TransportType: event.TransportTypeTCP,
+			Reason:        err.Error(),
+		})
+		return
+	}
+
+	if err := p.validateMCPMessage(jsonRpcMsg); err != nil {
+		logrus.
+			WithFields(e.LogFields()).
+			WithFields(jsonRpcMsg.LogFields()).
+			WithError(err).Debug("Invalid MCP message")
+		return
+	}
+
+	tcpTransport := &event.TCPTransport{
+		PID:     tcpEvent.PID,
+		Comm:    tcpEvent.Comm(),
+		SrcAddr: tcpEvent.SrcAddrStr(),
+		SrcPort: tcpEvent.SrcPort,
+		DstAddr: tcpEvent.DstAddrStr(),
+		DstPort: tcpEvent.DstPortNum(),
+	}
+
+	if err := p.handleRequestResponseCorrelation(&jsonRpcMsg, event.TransportTypeTCP, nil, nil, tcpTransport); err != nil {
+		logrus.
+			WithFields(e.LogFields()).
+			WithFields(jsonRpcMsg.LogFields()).
+			Debug("Dropping response without matching request ID")
+		return
+	}
+
+	p.trackPaginatedList(&jsonRpcMsg, nil, nil, tcpTransport)
+
+	if p.holdForCorrelation(jsonRpcMsg) {
+		return
+	}
+
+	msg := &event.MCPEvent{
+		Timestamp:        p.clock.Now(),
+		Raw:              string(jsonData),
+		TransportType:    event.TransportTypeTCP,
+		TCPTransport:     tcpTransport,
+		JSONRPCMessage:   jsonRpcMsg,
+		ValidationErrors: p.validationErrors(jsonRpcMsg),
+	}
+
+	logrus.WithFields(msg.LogFields()).Trace(fmt.Sprintf("event#%s", msg.Type().String()))
+
+	p.eventBus.Publish(msg)
+}
+
+// ParseDataWebSocket attempts to parse an MCP message from a single
+// reassembled WebSocket text message, extracted by pkg/http from an
+// HTTP connection that upgraded to the WebSocket protocol. Like
+// ParseDataTCP, each event here is already exactly one complete message, so
+// there's no decoder loop; RFC6455 framing (masking, fragmentation) is
+// already resolved by the time this runs.
+func (p *Parser) ParseDataWebSocket(e event.Event) {
+	wsEvent, ok := e.(*event.WebSocketMessageEvent)
+	if !ok {
+		return
+	}
+
+	jsonData := wsEvent.Data
+	if len(jsonData) == 0 {
+		return
+	}
+
+	logrus.WithFields(e.LogFields()).Trace("Parsing WebSocket data for MCP")
+
+	jsonRpcMsg, err := p.parseJSONRPC(jsonData)
+	if err != nil {
+		logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to parse JSON-RPC")
+		p.eventBus.Publish(&event.ParseErrorEvent{
+			Timestamp:     p.clock.Now(),
+			TransportType: event.TransportTypeWebSocket,
+			Reason:        err.Error(),
+		})
+		return
+	}
+
+	if err := p.validateMCPMessage(jsonRpcMsg); err != nil {
+		logrus.
+			WithFields(e.LogFields()).
+			WithFields(jsonRpcMsg.LogFields()).
+			WithError(err).Debug("Invalid MCP message")
+		return
+	}
+
+	wsTransport := &event.HttpTransport{
+		PID:        wsEvent.PID,
+		Comm:       wsEvent.Comm(),
+		Host:       wsEvent.Host,
+		HTTPMethod: wsEvent.Method,
+		Path:       wsEvent.Path,
+		IsRequest:  wsEvent.IsRequest,
+	}
+
+	if err := p.handleRequestResponseCorrelation(&jsonRpcMsg, event.TransportTypeWebSocket, nil, wsTransport, nil); err != nil {
+		logrus.
+			WithFields(e.LogFields()).
+			WithFields(jsonRpcMsg.LogFields()).
+			Debug("Dropping response without matching request ID")
+		return
+	}
 
-		logrus.WithFields(msg.LogFields()).Trace(fmt.Sprintf("event#%s", msg.Type().String()))
+	p.trackPaginatedList(&jsonRpcMsg, nil, wsTransport, nil)
 
-		p.eventBus.Publish(msg)
+	if p.holdForCorrelation(jsonRpcMsg) {
+		return
 	}
+
+	msg := &event.MCPEvent{
+		Timestamp:        p.clock.Now(),
+		Raw:              string(jsonData),
+		TransportType:    event.TransportTypeWebSocket,
+		HttpTransport:    wsTransport,
+		JSONRPCMessage:   jsonRpcMsg,
+		ValidationErrors: p.validationErrors(jsonRpcMsg),
+	}
+
+	logrus.WithFields(msg.LogFields()).Trace(fmt.Sprintf("event#%s", msg.Type().String()))
+
+	p.eventBus.Publish(msg)
 }
 
 // parseJSONRPC parses a single JSON-RPC message
 func (p *Parser) parseJSONRPC(data []byte) (event.JSONRPCMessage, error) {
 	// Validate JSON
 	if !gjson.ValidBytes(data) {
+		// Not a syntax error necessarily worth dropping: with --capture-bytes
+		// set, this may be a tail cut off by the kernel's capture length
+		// limit rather than a malformed message. Recover what we can.
+		if msg, ok := parseTruncatedPrefix(data); ok {
+			return msg, nil
+		}
 		return event.JSONRPCMessage{}, fmt.Errorf("invalid JSON")
 	}
 
 	result := gjson.ParseBytes(data)
 
 	// Check for jsonrpc field
-	if result.Get("jsonrpc").String() != "2.0" {
+	isConformant := result.Get("jsonrpc").String() == "2.0"
+	if !isConformant && !p.lenientJSONRPC {
 		return event.JSONRPCMessage{}, fmt.Errorf("not JSON-RPC 2.0")
 	}
 
-	msg := event.JSONRPCMessage{}
+	msg := event.JSONRPCMessage{
+		NonConformant: !isConformant,
+	}
 
 	// Determine message type
 	// Requirements for Request type: method and id
@@ -341,13 +905,19 @@ func (p *Parser) parseJSONRPC(data []byte) (event.JSONRPCMessage, error) {
 		// Parse params if present
 		if params := result.Get("params"); params.Exists() {
 			msg.Params = parseParams(params)
+			msg.Meta = extractMeta(msg.Params)
+			msg.ParamsBytes = len(params.Raw)
 		}
 	} else if result.Get("id").Exists() && (result.Get("result").Exists() || result.Get("error").Exists()) {
 		msg.MessageType = event.JSONRPCMessageTypeResponse
 		msg.ID = parseID(result.Get("id"))
 
-		if result.Get("result").Exists() {
-			msg.Result = result.Get("result").Value()
+		if resultField := result.Get("result"); resultField.Exists() {
+			msg.Result = resultField.Value()
+			if resultMap, ok := msg.Result.(map[string]interface{}); ok {
+				msg.Meta = extractMeta(resultMap)
+			}
+			msg.ResultBytes = len(resultField.Raw)
 		}
 
 		if errResult := result.Get("error"); errResult.Exists() {
@@ -364,6 +934,8 @@ func (p *Parser) parseJSONRPC(data []byte) (event.JSONRPCMessage, error) {
 		// Parse params if present
 		if params := result.Get("params"); params.Exists() {
 			msg.Params = parseParams(params)
+			msg.Meta = extractMeta(msg.Params)
+			msg.ParamsBytes = len(params.Raw)
 		}
 	} else {
 		return event.JSONRPCMessage{}, fmt.Errorf("unknown JSON-RPC message type")
@@ -372,6 +944,197 @@ func (p *Parser) parseJSONRPC(data []byte) (event.JSONRPCMessage, error) {
 	return msg, nil
 }
 
+// toolResultCheck is one data-driven structural check run against a
+// tools/call result. Each check inspects the result object independently
+// and reports zero or more human-readable violations, so adding a new check
+// doesn't require touching the call sites.
+type toolResultCheck struct {
+	name  string
+	check func(result map[string]interface{}) []string
+}
+
+// toolResultChecks validates a tools/call result's content[] against the
+// MCP content schema (https://modelcontextprotocol.io/specification/2025-06-18/schema#contentblock).
+// These catch server bugs that produce a well-formed JSON-RPC response
+// whose result would still break a conformant client.
+var toolResultChecks = []toolResultCheck{
+	{
+		name: "content-block-type",
+		check: func(result map[string]interface{}) []string {
+			content, ok := result["content"].([]interface{})
+			if !ok {
+				return nil
+			}
+			var violations []string
+			for i, item := range content {
+				block, ok := item.(map[string]interface{})
+				if !ok {
+					violations = append(violations, fmt.Sprintf("content[%d] is not an object", i))
+					continue
+				}
+				if _, ok := block["type"]; !ok {
+					violations = append(violations, fmt.Sprintf("content[%d] missing \"type\" field", i))
+				}
+			}
+			return violations
+		},
+	},
+	{
+		name: "is-error-has-content",
+		check: func(result map[string]interface{}) []string {
+			isError, _ := result["isError"].(bool)
+			if !isError {
+				return nil
+			}
+			if content, ok := result["content"].([]interface{}); !ok || len(content) == 0 {
+				return []string{"isError is true but content is empty"}
+			}
+			return nil
+		},
+	},
+}
+
+// validateToolCallResult runs the data-driven toolResultChecks against a
+// tools/call response's result and returns every violation found. A
+// non-object result (other than a JSON-RPC error response, which has no
+// result at all) is itself a violation.
+func validateToolCallResult(msg event.JSONRPCMessage) []string {
+	if msg.Error.Message != "" || msg.Error.Code != 0 {
+		// JSON-RPC level error, not a malformed tool result.
+		return nil
+	}
+
+	resultMap, ok := msg.Result.(map[string]interface{})
+	if !ok {
+		return []string{"tools/call result is not a JSON object"}
+	}
+
+	var violations []string
+	for _, c := range toolResultChecks {
+		violations = append(violations, c.check(resultMap)...)
+	}
+	return violations
+}
+
+// checkToolCallResultSchema publishes a ToolResultSchemaWarningEvent if msg
+// is a tools/call response whose result violates the MCP content schema.
+// This is separate from validateMCPMessage: a schema-invalid result is
+// still a structurally valid JSON-RPC response and is never dropped, only
+// flagged.
+func (p *Parser) checkToolCallResultSchema(msg event.JSONRPCMessage, transportType event.TransportType, stdio *event.StdioTransport, http *event.HttpTransport) {
+	if msg.MessageType != event.JSONRPCMessageTypeResponse || msg.Request == nil || msg.Request.Method != "tools/call" {
+		return
+	}
+
+	violations := validateToolCallResult(msg)
+	if len(violations) == 0 {
+		return
+	}
+
+	warningEvent := &event.ToolResultSchemaWarningEvent{
+		Timestamp:      p.clock.Now(),
+		ID:             msg.ID,
+		Violations:     violations,
+		TransportType:  transportType,
+		StdioTransport: stdio,
+		HttpTransport:  http,
+	}
+
+	logrus.WithFields(warningEvent.LogFields()).Warn("tools/call result violates the MCP content schema")
+
+	p.eventBus.Publish(warningEvent)
+}
+
+// emitToolCallResultEvent publishes a ToolCallResultEvent if msg is a
+// tools/call response, summarizing the tool name, how long it took, and
+// whether it errored so consumers don't need to join the request and
+// response MCPEvents themselves.
+func (p *Parser) emitToolCallResultEvent(msg event.JSONRPCMessage, transportType event.TransportType, stdio *event.StdioTransport, http *event.HttpTransport) {
+	if msg.MessageType != event.JSONRPCMessageTypeResponse || msg.Request == nil || msg.Request.Method != "tools/call" {
+		return
+	}
+
+	toolName, _ := msg.Request.Params["name"].(string)
+	arguments, _ := msg.Request.Params["arguments"].(map[string]interface{})
+
+	resultEvent := &event.ToolCallResultEvent{
+		Timestamp:      p.clock.Now(),
+		ID:             msg.ID,
+		ToolName:       toolName,
+		Arguments:      arguments,
+		DurationMs:     msg.Latency.Milliseconds(),
+		IsError:        msg.Error.Message != "",
+		TransportType:  transportType,
+		StdioTransport: stdio,
+		HttpTransport:  http,
+	}
+
+	logrus.WithFields(resultEvent.LogFields()).Trace("tools/call result correlated")
+
+	p.eventBus.Publish(resultEvent)
+}
+
+// checkLargeToolCallArgs publishes a LargeToolArgsEvent if msg is a
+// tools/call request whose serialized arguments exceed maxToolArgsBytes.
+// Unlike checkToolCallResultSchema, this inspects the request itself rather
+// than its eventual response, so it fires as soon as the call is seen.
+func (p *Parser) checkLargeToolCallArgs(msg event.JSONRPCMessage, transportType event.TransportType, stdio *event.StdioTransport, http *event.HttpTransport) {
+	if msg.MessageType != event.JSONRPCMessageTypeRequest || msg.Method != "tools/call" {
+		return
+	}
+
+	arguments, ok := msg.Params["arguments"]
+	if !ok {
+		return
+	}
+
+	encoded, err := json.Marshal(arguments)
+	if err != nil {
+		return
+	}
+	size := len(encoded)
+	if size <= p.maxToolArgsBytes {
+		return
+	}
+
+	toolName, _ := msg.Params["name"].(string)
+
+	warningEvent := &event.LargeToolArgsEvent{
+		Timestamp:      p.clock.Now(),
+		ID:             msg.ID,
+		ToolName:       toolName,
+		SizeBytes:      size,
+		Threshold:      p.maxToolArgsBytes,
+		TransportType:  transportType,
+		StdioTransport: stdio,
+		HttpTransport:  http,
+	}
+
+	logrus.WithFields(warningEvent.LogFields()).Warn("tools/call arguments exceed size threshold")
+
+	p.eventBus.Publish(warningEvent)
+}
+
+// validationErrors returns msg's strict schema violations when strict mode
+// is enabled, or nil otherwise. Unlike validateMCPMessage, a non-empty
+// result never drops the message - it's attached to the emitted MCPEvent
+// for the console/output layer to flag.
+func (p *Parser) validationErrors(msg event.JSONRPCMessage) []string {
+	if !p.strict {
+		return nil
+	}
+	return validateStrictSchema(msg)
+}
+
+// holdForCorrelation reports whether msg's standalone MCPEvent should be
+// suppressed because correlatedOnly is enabled and msg is a request: its
+// cached copy (already stored by handleRequestResponseCorrelation) will be
+// attached to the eventual response instead, or reported via
+// RequestTimeoutEvent if none arrives.
+func (p *Parser) holdForCorrelation(msg event.JSONRPCMessage) bool {
+	return p.correlatedOnly && msg.MessageType == event.JSONRPCMessageTypeRequest
+}
+
 // validateMCPMessage validates that the message is a valid MCP message.
 // Currently, we only validate the method.
 // TODO: Validate that responses are valid (with matching id for requests).
@@ -408,10 +1171,11 @@ func (p *Parser) validateMCPMessage(msg event.JSONRPCMessage) error {
 	return fmt.Errorf("unknown JSON-RPC message type: %s", msg.MessageType)
 }
 
-// calculateHash creates a hash of the buffer content for duplicate detection
-func (p *Parser) calculateHash(buf []byte) string {
-	hash := sha1.Sum(buf)
-	return fmt.Sprintf("%x", hash)
+// calculateHash creates a hash of the buffer content for duplicate detection.
+// Returns the raw digest rather than a hex string to avoid allocating on
+// this hot path; hashes are only ever compared, never displayed.
+func (p *Parser) calculateHash(buf []byte) [sha1.Size]byte {
+	return sha1.Sum(buf)
 }
 
 // idToCacheKey converts a request/response ID to a cache key string
@@ -426,8 +1190,55 @@ func (p *Parser) idToCacheKey(id interface{}) string {
 	}
 }
 
-// cacheRequestMessage stores a request message for future response correlation
-func (p *Parser) cacheRequestMessage(msg *event.JSONRPCMessage) error {
+// sessionKey derives a scope for the requestIDCache from the transport a
+// message arrived on, so that JSON-RPC ids are only correlated within the
+// same session: two unrelated stdio pipe pairs, or two unrelated HTTP
+// clients, may legally reuse the same id. This matters most for streamable
+// HTTP, where a single SSE stream can carry responses to multiple
+// concurrently-POSTed requests for the same session and must not be
+// confused with another session's ids.
+func (p *Parser) sessionKey(stdio *event.StdioTransport, http *event.HttpTransport, tcp *event.TCPTransport) string {
+	switch {
+	case tcp != nil:
+		// A TCP connection has no higher-level session identifier, so the
+		// 4-tuple itself is the scope: two unrelated sockets never share ids.
+		return fmt.Sprintf("tcp:%s:%d:%s:%d", tcp.SrcAddr, tcp.SrcPort, tcp.DstAddr, tcp.DstPort)
+	case http != nil:
+		// Host+path is the closest thing we have to a session identifier
+		// for streamable HTTP (there's no captured Mcp-Session-Id): a POST
+		// request and the SSE stream carrying its response both target the
+		// same MCP server endpoint. This still can't distinguish two
+		// concurrent sessions hitting the exact same endpoint, but it's a
+		// meaningful improvement over keying on host alone, which would
+		// cross-correlate any two sessions to the same server.
+		return fmt.Sprintf("http:%s:%s", http.Host, http.Path)
+	case stdio != nil:
+		// The two directions of a pipe report (FromPID, ToPID) reversed
+		// (request: writer->reader, response: reader->writer), so sort the
+		// pair to key both directions of the same pipe identically.
+		a, b := stdio.FromPID, stdio.ToPID
+		if a > b {
+			a, b = b, a
+		}
+		return fmt.Sprintf("stdio:%d:%d", a, b)
+	default:
+		return ""
+	}
+}
+
+// correlationID derives a stable identifier shared by a request and its
+// response, for CorrelationIDMode. It hashes the same session+id key used
+// to index the requestIDCache, so it can be computed independently on
+// either side of the exchange without any shared state.
+func (p *Parser) correlationID(id interface{}, stdio *event.StdioTransport, http *event.HttpTransport, tcp *event.TCPTransport) string {
+	key := p.sessionKey(stdio, http, tcp) + "|" + p.idToCacheKey(id)
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheRequestMessage stores a request message, along with the transport
+// it arrived on, for future response correlation
+func (p *Parser) cacheRequestMessage(msg *event.JSONRPCMessage, transportType event.TransportType, stdio *event.StdioTransport, http *event.HttpTransport, tcp *event.TCPTransport) error {
 	if msg == nil || msg.ID == nil {
 		return fmt.Errorf("invalid message")
 	}
@@ -435,41 +1246,89 @@ func (p *Parser) cacheRequestMessage(msg *event.JSONRPCMessage) error {
 		// This shouldn't happen. Only responses should have Request field set.
 		return fmt.Errorf("message already has Request field set")
 	}
-	key := p.idToCacheKey(msg.ID)
-	p.requestIDCache.Add(key, msg)
+	key := p.sessionKey(stdio, http, tcp) + "|" + p.idToCacheKey(msg.ID)
+	p.requestIDCache.Add(key, &pendingRequest{
+		msg:           msg,
+		transportType: transportType,
+		stdio:         stdio,
+		http:          http,
+		tcp:           tcp,
+		timestamp:     p.clock.Now(),
+	})
 
 	return nil
 }
 
-// getRequestByID retrieves a cached request message by its ID
-// Returns the request message and true if found, nil and false otherwise
-func (p *Parser) getRequestByID(id interface{}) (*event.JSONRPCMessage, bool) {
+// getRequestByID retrieves a cached request message by its ID within the
+// given session, marking it as answered so it's not reported as a timeout
+// when it's later evicted. Returns the request message, the time it was
+// cached, and true if found, or nil/zero/false otherwise.
+func (p *Parser) getRequestByID(id interface{}, stdio *event.StdioTransport, http *event.HttpTransport, tcp *event.TCPTransport) (*event.JSONRPCMessage, time.Time, bool) {
 	if id == nil {
-		return nil, false
+		return nil, time.Time{}, false
+	}
+	key := p.sessionKey(stdio, http, tcp) + "|" + p.idToCacheKey(id)
+	pending, exists := p.requestIDCache.Get(key)
+	if !exists {
+		return nil, time.Time{}, false
+	}
+	pending.answered.Store(true)
+	return pending.msg, pending.timestamp, true
+}
+
+// handleRequestTimeout is the requestIDCache eviction callback. It publishes
+// a RequestTimeoutEvent for any request that was never answered before
+// falling out of the cache.
+func (p *Parser) handleRequestTimeout(_ string, pending *pendingRequest) {
+	if pending.answered.Load() {
+		return
 	}
-	key := p.idToCacheKey(id)
-	req, exists := p.requestIDCache.Get(key)
-	return req, exists
+
+	timeoutEvent := &event.RequestTimeoutEvent{
+		Timestamp:      p.clock.Now(),
+		Method:         pending.msg.Method,
+		ID:             pending.msg.ID,
+		TTL:            p.requestTimeout,
+		TransportType:  pending.transportType,
+		StdioTransport: pending.stdio,
+		HttpTransport:  pending.http,
+		TCPTransport:   pending.tcp,
+	}
+
+	logrus.WithFields(timeoutEvent.LogFields()).Debug("Request evicted without a response")
+
+	p.eventBus.Publish(timeoutEvent)
 }
 
 // handleRequestResponseCorrelation handles caching request messages and pairing responses with their requests.
-// For request messages, it caches the full message for future correlation.
+// For request messages, it caches the full message (and the transport it arrived on) for future correlation.
 // For response messages, it looks up and attaches the corresponding request.
 // Returns true if the message should be kept, false if it should be dropped.
-func (p *Parser) handleRequestResponseCorrelation(msg *event.JSONRPCMessage) error {
+func (p *Parser) handleRequestResponseCorrelation(msg *event.JSONRPCMessage, transportType event.TransportType, stdio *event.StdioTransport, http *event.HttpTransport, tcp *event.TCPTransport) error {
 	switch msg.MessageType {
 	case event.JSONRPCMessageTypeRequest:
+		if p.correlationIDMode {
+			msg.CorrelationID = p.correlationID(msg.ID, stdio, http, tcp)
+		}
 		// Cache the full request message for future response pairing
-		return p.cacheRequestMessage(msg)
+		return p.cacheRequestMessage(msg, transportType, stdio, http, tcp)
 	case event.JSONRPCMessageTypeResponse:
 		// Look up the corresponding request and attach it to the response
-		req, exists := p.getRequestByID(msg.ID)
+		req, reqTimestamp, exists := p.getRequestByID(msg.ID, stdio, http, tcp)
 		if !exists {
 			// Drop responses without matching requests
 			return fmt.Errorf("response without matching request ID")
 		}
-		// Attach the request to the response
-		msg.Request = req
+		if p.correlationIDMode {
+			// Downstream tools join on CorrelationID instead; omit the
+			// embedded request to save space.
+			msg.CorrelationID = p.correlationID(msg.ID, stdio, http, tcp)
+		} else {
+			// Attach the request to the response, along with how long it
+			// took to answer.
+			msg.Request = req
+		}
+		msg.Latency = p.clock.Now().Sub(reqTimestamp)
 		return nil
 	}
 	// Notifications don't have IDs, always keep them
@@ -478,7 +1337,7 @@ func (p *Parser) handleRequestResponseCorrelation(msg *event.JSONRPCMessage) err
 
 // isDuplicate checks if we've seen this hash before and marks it as seen.
 // Returns true if it's a duplicate (already seen).
-func (p *Parser) isDuplicate(hash string) bool {
+func (p *Parser) isDuplicate(hash [sha1.Size]byte) bool {
 	_, exists := p.seenHashCache.Get(hash)
 	if exists {
 		return true // Duplicate - we've seen this before
@@ -488,12 +1347,74 @@ func (p *Parser) isDuplicate(hash string) bool {
 	return false
 }
 
+// duplicateMessageInfo extracts just the method and id from a raw JSON-RPC
+// payload already known to be a duplicate, for DuplicateMessageEvent. A
+// cheap gjson lookup rather than the full parseJSONRPC/validateMCPMessage
+// pipeline, so describing a folded duplicate doesn't cost what parsing a
+// kept message does.
+func duplicateMessageInfo(data []byte) (method string, id interface{}) {
+	method = gjson.GetBytes(data, "method").String()
+	if idRes := gjson.GetBytes(data, "id"); idRes.Exists() {
+		id = idRes.Value()
+	}
+	return method, id
+}
+
+// publishDuplicateMessage emits a DuplicateMessageEvent for a message hash
+// already seen on an earlier hop, so metrics/JSONL subscribers can measure
+// how many hops a relay (e.g. a Docker proxy) is folding instead of that
+// happening silently inside isDuplicate.
+func (p *Parser) publishDuplicateMessage(method string, id interface{}, transportType event.TransportType, stdio *event.StdioTransport, http *event.HttpTransport, tcp *event.TCPTransport) {
+	dup := &event.DuplicateMessageEvent{
+		Timestamp:      p.clock.Now(),
+		Method:         method,
+		ID:             id,
+		TransportType:  transportType,
+		StdioTransport: stdio,
+		HttpTransport:  http,
+		TCPTransport:   tcp,
+	}
+	logrus.WithFields(dup.LogFields()).Trace(fmt.Sprintf("event#%s", dup.Type().String()))
+	p.eventBus.Publish(dup)
+}
+
 func (p *Parser) Close() {
 	p.eventBus.Unsubscribe(event.EventTypeFSAggregatedRead, p.ParseDataStdio)
 	p.eventBus.Unsubscribe(event.EventTypeFSAggregatedWrite, p.ParseDataStdio)
 	p.eventBus.Unsubscribe(event.EventTypeHttpRequest, p.ParseDataHttp)
 	p.eventBus.Unsubscribe(event.EventTypeHttpResponse, p.ParseDataHttp)
 	p.eventBus.Unsubscribe(event.EventTypeHttpSSE, p.ParseDataHttp)
+	p.eventBus.Unsubscribe(event.EventTypeTCPAggregated, p.ParseDataTCP)
+	p.eventBus.Unsubscribe(event.EventTypeHttpWebSocket, p.ParseDataWebSocket)
+}
+
+// parseTruncatedPrefix recovers a best-effort request or notification from a
+// syntactically invalid JSON-RPC payload, on the assumption it's not
+// malformed but merely cut short by a kernel-side capture length limit
+// (--capture-bytes). gjson's field lookups tolerate a missing closing brace,
+// so method/id are still extractable as long as they appear before the
+// point of truncation, which holds for the params-heavy messages (e.g.
+// tools/call) this matters most for. Returns ok=false if method itself was
+// truncated away, in which case nothing useful can be recovered.
+func parseTruncatedPrefix(data []byte) (event.JSONRPCMessage, bool) {
+	method := gjson.GetBytes(data, "method")
+	if !method.Exists() {
+		return event.JSONRPCMessage{}, false
+	}
+
+	msg := event.JSONRPCMessage{
+		Method:    method.String(),
+		Truncated: true,
+	}
+
+	if id := gjson.GetBytes(data, "id"); id.Exists() {
+		msg.MessageType = event.JSONRPCMessageTypeRequest
+		msg.ID = parseID(id)
+	} else {
+		msg.MessageType = event.JSONRPCMessageTypeNotification
+	}
+
+	return msg, true
 }
 
 // parseID parses the ID field which can be string or number
@@ -514,6 +1435,86 @@ func parseParams(params gjson.Result) map[string]interface{} {
 	return result
 }
 
+// extractMeta pulls the MCP "_meta" extension object out of a parsed params
+// or result map, if present. Returns nil when no "_meta" object was set.
+func extractMeta(fields map[string]interface{}) map[string]interface{} {
+	meta, ok := fields["_meta"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return meta
+}
+
+// ExplainStats reports how many messages in a counting-only pass would be
+// kept or dropped by the parser, broken down by reason. It lets users
+// validate a filter/capture configuration against a sample capture before
+// running a real session.
+type ExplainStats struct {
+	Total             int `json:"total"`
+	Kept              int `json:"kept"`
+	InvalidJSON       int `json:"invalid_json"`
+	InvalidMCPMessage int `json:"invalid_mcp_message"`
+	Duplicate         int `json:"duplicate"`
+	UnpairedResponse  int `json:"unpaired_response"`
+}
+
+// Explain runs the same parsing pipeline as ParseDataStdio over buf, but only
+// counts outcomes instead of publishing events. It shares the
+// duplicate-detection and request/response correlation caches with the
+// running parser, so results reflect the parser's current state.
+//
+// Like ParseDataStdio, a single malformed or rejected message stops
+// processing of the rest of buf (the kernel delivers one complete JSON
+// message per aggregated event, so in practice this only affects
+// hand-crafted multi-message input such as a sample capture file).
+func (p *Parser) Explain(buf []byte) ExplainStats {
+	var stats ExplainStats
+
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	for {
+		var jsonData json.RawMessage
+		if err := decoder.Decode(&jsonData); err != nil {
+			break
+		}
+
+		if len(bytes.TrimSpace(jsonData)) == 0 {
+			continue
+		}
+
+		stats.Total++
+
+		// Part 1: Duplicate detection
+		hash := p.calculateHash(jsonData)
+		if p.isDuplicate(hash) {
+			stats.Duplicate++
+			continue
+		}
+
+		// Part 2 & 3: Parse JSON-RPC and validate MCP
+		jsonRpcMsg, err := p.parseJSONRPC(jsonData)
+		if err != nil {
+			stats.InvalidJSON++
+			break
+		}
+
+		if err := p.validateMCPMessage(jsonRpcMsg); err != nil {
+			stats.InvalidMCPMessage++
+			break
+		}
+
+		// Part 4: Handle request/response correlation. Explain operates on a
+		// standalone buffer with no transport of its own to report.
+		if err := p.handleRequestResponseCorrelation(&jsonRpcMsg, event.TransportTypeStdio, nil, nil, nil); err != nil {
+			stats.UnpairedResponse++
+			break
+		}
+
+		stats.Kept++
+	}
+
+	return stats
+}
+
 // GetMethodDescription returns a human-readable description of the method
 func GetMethodDescription(method string) string {
 	if info, ok := allowedMCPMethods[method]; ok {
@@ -1,11 +1,13 @@
 package mcp
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/clock"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
 )
 
@@ -39,6 +41,7 @@ func createFSAggregatedEvent(data []byte, eventType event.EventType, fromPID uin
 		toPID,
 		toCommBytes,
 		0, // filePtr (not needed for tests)
+		false,
 		data,
 	)
 }
@@ -75,6 +78,12 @@ func createHttpResponseEvent(data []byte, pid uint32, comm string, host string)
 
 // Helper function to create SSEEvent for HTTP SSE tests
 func createSSEEvent(data []byte, pid uint32, comm string, host string) *event.SSEEvent {
+	return createSSEEventWithLastID(data, pid, comm, host, "")
+}
+
+// createSSEEventWithLastID is like createSSEEvent but also sets
+// LastEventID, for tests covering Last-Event-ID reconnect dedup.
+func createSSEEventWithLastID(data []byte, pid uint32, comm string, host string, lastEventID string) *event.SSEEvent {
 	e := &event.SSEEvent{
 		EventHeader: event.EventHeader{
 			EventType: event.EventTypeHttpSSE,
@@ -83,7 +92,8 @@ func createSSEEvent(data []byte, pid uint32, comm string, host string) *event.SS
 		HttpRequestEvent: event.HttpRequestEvent{
 			Host: host,
 		},
-		Data: data,
+		Data:        data,
+		LastEventID: lastEventID,
 	}
 	copy(e.CommBytes[:], []byte(comm))
 	return e
@@ -107,6 +117,10 @@ func TestParseJSONRPC_ValidMessages(t *testing.T) {
 		hasParams      bool
 		hasResult      bool
 		hasError       bool
+		// expectToolCallResult is set for the response to the earlier
+		// "Basic request" (tools/call) case, which also emits a
+		// EventTypeToolCallResult event ahead of the EventTypeMCPMessage.
+		expectToolCallResult bool
 	}{
 		{
 			name:           "Basic request",
@@ -132,18 +146,20 @@ func TestParseJSONRPC_ValidMessages(t *testing.T) {
 			expectedID:     int64(2),
 		},
 		{
-			name:         "Success response",
-			data:         []byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"OK"}]}}`),
-			expectedType: event.JSONRPCMessageTypeResponse,
-			expectedID:   int64(1),
-			hasResult:    true,
+			name:                 "Success response",
+			data:                 []byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"OK"}]}}`),
+			expectedType:         event.JSONRPCMessageTypeResponse,
+			expectedID:           int64(1),
+			hasResult:            true,
+			expectToolCallResult: true,
 		},
 		{
-			name:         "Error response",
-			data:         []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid params"}}`),
-			expectedType: event.JSONRPCMessageTypeResponse,
-			expectedID:   int64(1),
-			hasError:     true,
+			name:                 "Error response",
+			data:                 []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid params"}}`),
+			expectedType:         event.JSONRPCMessageTypeResponse,
+			expectedID:           int64(1),
+			hasError:             true,
+			expectToolCallResult: true,
 		},
 		{
 			name:           "Notification",
@@ -168,6 +184,17 @@ func TestParseJSONRPC_ValidMessages(t *testing.T) {
 			// Process the event (publishes to bus)
 			parser.ParseDataStdio(fsEvent)
 
+			if tt.expectToolCallResult {
+				select {
+				case evt := <-mockBus.Events():
+					if evt.Type() != event.EventTypeToolCallResult {
+						t.Fatalf("Expected EventTypeToolCallResult, got %v", evt.Type())
+					}
+				case <-time.After(100 * time.Millisecond):
+					t.Fatal("No tool call result event received")
+				}
+			}
+
 			// Read from bus
 			select {
 			case evt := <-mockBus.Events():
@@ -227,6 +254,142 @@ func TestParseJSONRPC_ValidMessages(t *testing.T) {
 	}
 }
 
+func TestParseJSONRPC_Meta(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	t.Run("progressToken extracted from request params", func(t *testing.T) {
+		data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test","_meta":{"progressToken":"abc-123"}}}`)
+		fsEvent := createFSAggregatedEvent(data, event.EventTypeFSRead, 100, "writer", 200, "reader")
+
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			msg := evt.(*event.MCPEvent)
+			if msg.Meta == nil {
+				t.Fatal("Expected Meta to be set")
+			}
+			if got := msg.ExtractProgressToken(); got != "abc-123" {
+				t.Errorf("ExtractProgressToken() = %q, want %q", got, "abc-123")
+			}
+			// _meta should remain accessible through Params as well
+			if _, ok := msg.Params["_meta"]; !ok {
+				t.Error("Expected _meta to remain present in Params")
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received")
+		}
+	})
+
+	t.Run("vendor keys extracted from response result", func(t *testing.T) {
+		reqData := []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+		reqEvent := createFSAggregatedEvent(reqData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(reqEvent)
+		<-mockBus.Events()
+
+		respData := []byte(`{"jsonrpc":"2.0","id":2,"result":{"tools":[],"_meta":{"com.example/vendorKey":"value"}}}`)
+		respEvent := createFSAggregatedEvent(respData, event.EventTypeFSWrite, 200, "reader", 100, "writer")
+		parser.ParseDataStdio(respEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			msg := evt.(*event.MCPEvent)
+			if msg.Meta == nil {
+				t.Fatal("Expected Meta to be set")
+			}
+			if got, ok := msg.Meta["com.example/vendorKey"]; !ok || got != "value" {
+				t.Errorf("Meta[%q] = %v, want %q", "com.example/vendorKey", got, "value")
+			}
+			// The original request should still be preserved through correlation
+			if msg.Request == nil || msg.Request.Method != "tools/list" {
+				t.Error("Expected Request to be correlated")
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received")
+		}
+	})
+}
+
+// TestParseJSONRPC_PayloadSizes verifies ParamsBytes/ResultBytes are the
+// exact byte length of the raw "params"/"result" JSON value, not a size
+// recomputed from the decoded Go value (which could differ, e.g. due to key
+// reordering or whitespace in the original payload).
+func TestParseJSONRPC_PayloadSizes(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	t.Run("request params sized from raw bytes", func(t *testing.T) {
+		rawParams := `{"name":  "test",   "arguments": {"a": 1}}`
+		data := []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":%s}`, rawParams))
+		fsEvent := createFSAggregatedEvent(data, event.EventTypeFSRead, 100, "writer", 200, "reader")
+
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			msg := evt.(*event.MCPEvent)
+			if msg.ParamsBytes != len(rawParams) {
+				t.Errorf("ParamsBytes = %d, want %d (raw payload length)", msg.ParamsBytes, len(rawParams))
+			}
+			if msg.ResultBytes != 0 {
+				t.Errorf("ResultBytes = %d, want 0 for a request", msg.ResultBytes)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received")
+		}
+	})
+
+	t.Run("response result sized from raw bytes", func(t *testing.T) {
+		reqData := []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+		reqEvent := createFSAggregatedEvent(reqData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(reqEvent)
+		<-mockBus.Events()
+
+		rawResult := `{"tools":  []}`
+		respData := []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":2,"result":%s}`, rawResult))
+		respEvent := createFSAggregatedEvent(respData, event.EventTypeFSWrite, 200, "reader", 100, "writer")
+		parser.ParseDataStdio(respEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			msg := evt.(*event.MCPEvent)
+			if msg.ResultBytes != len(rawResult) {
+				t.Errorf("ResultBytes = %d, want %d (raw payload length)", msg.ResultBytes, len(rawResult))
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received")
+		}
+	})
+
+	t.Run("absent params/result stay zero", func(t *testing.T) {
+		data := []byte(`{"jsonrpc":"2.0","id":3,"method":"ping"}`)
+		fsEvent := createFSAggregatedEvent(data, event.EventTypeFSRead, 100, "writer", 200, "reader")
+
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			msg := evt.(*event.MCPEvent)
+			if msg.ParamsBytes != 0 || msg.ResultBytes != 0 {
+				t.Errorf("expected both byte counts to be 0, got params=%d result=%d", msg.ParamsBytes, msg.ResultBytes)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received")
+		}
+	})
+}
+
 func TestParseJSONRPC_AllSupportedMethods(t *testing.T) {
 	mockBus := tu.NewMockBus()
 	parser, err := NewParser(mockBus)
@@ -756,16 +919,21 @@ func TestParseJSONRPC_InvalidMessages(t *testing.T) {
 		name        string
 		data        []byte
 		expectError string
+		// expectParseError mirrors TestParseDataHttp_InvalidMessages: set for
+		// failures caught by parseJSONRPC itself, not validateMCPMessage.
+		expectParseError bool
 	}{
 		{
-			name:        "Missing jsonrpc field",
-			data:        []byte(`{"id":1,"method":"tools/call"}`),
-			expectError: "not JSON-RPC 2.0",
+			name:             "Missing jsonrpc field",
+			data:             []byte(`{"id":1,"method":"tools/call"}`),
+			expectError:      "not JSON-RPC 2.0",
+			expectParseError: true,
 		},
 		{
-			name:        "Wrong jsonrpc version",
-			data:        []byte(`{"jsonrpc":"1.0","id":1,"method":"tools/call"}`),
-			expectError: "not JSON-RPC 2.0",
+			name:             "Wrong jsonrpc version",
+			data:             []byte(`{"jsonrpc":"1.0","id":1,"method":"tools/call"}`),
+			expectError:      "not JSON-RPC 2.0",
+			expectParseError: true,
 		},
 		{
 			name:        "Unknown method",
@@ -773,9 +941,10 @@ func TestParseJSONRPC_InvalidMessages(t *testing.T) {
 			expectError: "unknown MCP method",
 		},
 		{
-			name:        "Response without ID",
-			data:        []byte(`{"jsonrpc":"2.0","result":{"status":"ok"}}`),
-			expectError: "unknown JSON-RPC message type",
+			name:             "Response without ID",
+			data:             []byte(`{"jsonrpc":"2.0","result":{"status":"ok"}}`),
+			expectError:      "unknown JSON-RPC message type",
+			expectParseError: true,
 		},
 		{
 			name:        "Unknown notification method",
@@ -783,9 +952,10 @@ func TestParseJSONRPC_InvalidMessages(t *testing.T) {
 			expectError: "unknown MCP method",
 		},
 		{
-			name:        "Ambiguous message (no method, no result/error)",
-			data:        []byte(`{"jsonrpc":"2.0","id":1}`),
-			expectError: "unknown JSON-RPC message type",
+			name:             "Ambiguous message (no method, no result/error)",
+			data:             []byte(`{"jsonrpc":"2.0","id":1}`),
+			expectError:      "unknown JSON-RPC message type",
+			expectParseError: true,
 		},
 	}
 
@@ -797,6 +967,18 @@ func TestParseJSONRPC_InvalidMessages(t *testing.T) {
 			// Process the event (should not publish due to error)
 			parser.ParseDataStdio(fsEvent)
 
+			if tt.expectParseError {
+				select {
+				case evt := <-mockBus.Events():
+					if _, ok := evt.(*event.ParseErrorEvent); !ok {
+						t.Errorf("Expected a ParseErrorEvent, but got event of type %v", evt.Type())
+					}
+				case <-time.After(50 * time.Millisecond):
+					t.Error("Expected a ParseErrorEvent, but got none")
+				}
+				return
+			}
+
 			// Check that NO event was published (timeout = expected behavior for errors)
 			select {
 			case evt := <-mockBus.Events():
@@ -965,6 +1147,157 @@ func TestParseData_MultipleMessages(t *testing.T) {
 	}
 }
 
+func TestParseData_JSONRPCBatch(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	// Prime a pending request so the response inside the batch below can
+	// correlate against it.
+	pendingReq := []byte(`{"jsonrpc":"2.0","id":99,"method":"tools/list"}`)
+	fsEvent := createFSAggregatedEvent(pendingReq, event.EventTypeFSRead, 100, "writer", 200, "reader")
+	parser.ParseDataStdio(fsEvent)
+	<-mockBus.Events()
+
+	// A 3-element JSON-RPC batch mixing a request, a notification, and a
+	// response, sent as a single top-level array.
+	batch := []byte(`[` +
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}},` +
+		`{"jsonrpc":"2.0","method":"notifications/progress","params":{"value":50}},` +
+		`{"jsonrpc":"2.0","id":99,"result":{"tools":[]}}` +
+		`]`)
+	fsEvent = createFSAggregatedEvent(batch, event.EventTypeFSRead, 200, "reader", 100, "writer")
+	parser.ParseDataStdio(fsEvent)
+
+	expectedTypes := []event.JSONRPCMessageType{
+		event.JSONRPCMessageTypeRequest,
+		event.JSONRPCMessageTypeNotification,
+		event.JSONRPCMessageTypeResponse,
+	}
+	for i, expectedType := range expectedTypes {
+		select {
+		case evt := <-mockBus.Events():
+			msg := evt.(*event.MCPEvent)
+			if msg.MessageType != expectedType {
+				t.Errorf("Batch entry %d: expected type %s, got %s", i, expectedType, msg.MessageType)
+			}
+			if msg.StdioTransport.FromPID != 200 || msg.StdioTransport.ToPID != 100 {
+				t.Errorf("Batch entry %d: expected shared process hop 200->100, got %d->%d",
+					i, msg.StdioTransport.FromPID, msg.StdioTransport.ToPID)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected batch entry %d, but timed out", i)
+		}
+	}
+
+	select {
+	case evt := <-mockBus.Events():
+		t.Errorf("Expected only 3 batch entries, but got extra event of type %v", evt.Type())
+	case <-time.After(50 * time.Millisecond):
+		// Success
+	}
+}
+
+// TestParseDataHttp_JSONRPCBatch covers the streamable HTTP transport's
+// batching: a POST body carrying a JSON-RPC batch, and a response batch
+// answering each element by id. Each element must be correlated and emitted
+// independently, just like a non-batched exchange.
+func TestParseDataHttp_JSONRPCBatch(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	// A 2-element batched POST: two tools/call requests with distinct ids.
+	reqBatch := []byte(`[` +
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"search"}},` +
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"fetch"}}` +
+		`]`)
+	parser.ParseDataHttp(createHttpRequestEvent(reqBatch, 100, "http-client", "mcp-server.example.com"))
+
+	for i, wantID := range []int64{1, 2} {
+		select {
+		case evt := <-mockBus.Events():
+			msg := evt.(*event.MCPEvent)
+			if msg.MessageType != event.JSONRPCMessageTypeRequest || msg.ID != wantID {
+				t.Errorf("Batch request %d: expected request id %d, got type=%v id=%v", i, wantID, msg.MessageType, msg.ID)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected batch request %d, but timed out", i)
+		}
+	}
+
+	// A 2-element batched response, answering both by id, out of order.
+	respBatch := []byte(`[` +
+		`{"jsonrpc":"2.0","id":2,"result":{"tool":"fetch"}},` +
+		`{"jsonrpc":"2.0","id":1,"result":{"tool":"search"}}` +
+		`]`)
+	parser.ParseDataHttp(createHttpResponseEvent(respBatch, 100, "http-client", "mcp-server.example.com"))
+
+	for i, wantID := range []int64{2, 1} {
+		msg := drainMCPEvent(t, mockBus.Events())
+		if msg.MessageType != event.JSONRPCMessageTypeResponse || msg.ID != wantID {
+			t.Errorf("Batch response %d: expected response id %d, got type=%v id=%v", i, wantID, msg.MessageType, msg.ID)
+		}
+		if msg.Request == nil || msg.Request.ID != wantID {
+			t.Errorf("Batch response %d: expected correlated request id %d, got %v", i, wantID, msg.Request)
+		}
+	}
+
+	select {
+	case evt := <-mockBus.Events():
+		if _, ok := evt.(*event.MCPEvent); ok {
+			t.Errorf("Expected only 2 batch responses, but got extra MCPEvent")
+		}
+	case <-time.After(50 * time.Millisecond):
+		// Success
+	}
+}
+
+func TestParseData_SkipsStrayPrimitiveToken(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	// A stray top-level primitive token (`0`) sandwiched between two
+	// concatenated JSON objects with no separator, as some servers emit.
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}0{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"test"}}`)
+
+	fsEvent := createFSAggregatedEvent(data, event.EventTypeFSRead, 100, "writer", 200, "reader")
+	parser.ParseDataStdio(fsEvent)
+
+	expectedIDs := []interface{}{int64(1), int64(2)}
+	for i, expectedID := range expectedIDs {
+		select {
+		case evt := <-mockBus.Events():
+			msg := evt.(*event.MCPEvent)
+			if msg.ID != expectedID {
+				t.Errorf("Message %d: expected ID %v, got %v", i, expectedID, msg.ID)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected message %d, but timed out (stray token likely broke parsing)", i)
+		}
+	}
+
+	select {
+	case evt := <-mockBus.Events():
+		t.Errorf("Expected only 2 messages, but got extra event of type %v", evt.Type())
+	case <-time.After(50 * time.Millisecond):
+		// Success - stray token produced no extra/error event
+	}
+}
+
 func TestExtractToolName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1133,6 +1466,32 @@ func TestGetMethodDescription(t *testing.T) {
 	}
 }
 
+func TestGetErrorCodeDescription(t *testing.T) {
+	tests := []struct {
+		code     int
+		expected string
+	}{
+		{-32700, "Parse error"},
+		{-32600, "Invalid Request"},
+		{-32601, "Method not found"},
+		{-32602, "Invalid params"},
+		{-32603, "Internal error"},
+		{-32001, "Request timeout"},
+		{-32002, "Resource not found"},
+		{-32099, "Unknown error"},
+		{0, "Unknown error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d", tt.code), func(t *testing.T) {
+			result := GetErrorCodeDescription(tt.code)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestParseData_UnsupportedEventType(t *testing.T) {
 	mockBus := tu.NewMockBus()
 	parser, err := NewParser(mockBus)
@@ -1169,6 +1528,11 @@ func TestParseDataHttp_ValidMessages(t *testing.T) {
 		hasParams      bool
 		hasResult      bool
 		hasError       bool
+		// expectToolCallResult is set for the response to the earlier
+		// "HTTP Request - Basic request" (tools/call) case, which also
+		// emits a EventTypeToolCallResult event ahead of the
+		// EventTypeMCPMessage.
+		expectToolCallResult bool
 	}{
 		{
 			name:           "HTTP Request - Basic request",
@@ -1180,12 +1544,13 @@ func TestParseDataHttp_ValidMessages(t *testing.T) {
 			hasParams:      true,
 		},
 		{
-			name:         "HTTP Response - Success",
-			data:         []byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"OK"}]}}`),
-			eventType:    event.EventTypeHttpResponse,
-			expectedType: event.JSONRPCMessageTypeResponse,
-			expectedID:   int64(1),
-			hasResult:    true,
+			name:                 "HTTP Response - Success",
+			data:                 []byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"OK"}]}}`),
+			eventType:            event.EventTypeHttpResponse,
+			expectedType:         event.JSONRPCMessageTypeResponse,
+			expectedID:           int64(1),
+			hasResult:            true,
+			expectToolCallResult: true,
 		},
 		{
 			name:           "HTTP SSE - Notification",
@@ -1205,12 +1570,13 @@ func TestParseDataHttp_ValidMessages(t *testing.T) {
 			hasParams:      true,
 		},
 		{
-			name:         "HTTP Response - Error",
-			data:         []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid params"}}`),
-			eventType:    event.EventTypeHttpResponse,
-			expectedType: event.JSONRPCMessageTypeResponse,
-			expectedID:   int64(1),
-			hasError:     true,
+			name:                 "HTTP Response - Error",
+			data:                 []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid params"}}`),
+			eventType:            event.EventTypeHttpResponse,
+			expectedType:         event.JSONRPCMessageTypeResponse,
+			expectedID:           int64(1),
+			hasError:             true,
+			expectToolCallResult: true,
 		},
 	}
 
@@ -1228,6 +1594,17 @@ func TestParseDataHttp_ValidMessages(t *testing.T) {
 
 			parser.ParseDataHttp(httpEvent)
 
+			if tt.expectToolCallResult {
+				select {
+				case evt := <-mockBus.Events():
+					if evt.Type() != event.EventTypeToolCallResult {
+						t.Fatalf("Expected EventTypeToolCallResult, got %v", evt.Type())
+					}
+				case <-time.After(100 * time.Millisecond):
+					t.Fatal("No tool call result event received")
+				}
+			}
+
 			select {
 			case evt := <-mockBus.Events():
 				if evt.Type() != event.EventTypeMCPMessage {
@@ -1388,24 +1765,33 @@ func TestParseDataHttp_InvalidMessages(t *testing.T) {
 		data        []byte
 		eventType   event.EventType
 		expectError string
+		// expectParseError is true when the failure happens before MCP-level
+		// validation (decode or JSON-RPC envelope errors), which now also
+		// publishes a ParseErrorEvent for metrics. Failures caught by
+		// validateMCPMessage (e.g. an unknown method) don't, since that's a
+		// semantically valid JSON-RPC message, not a parse failure.
+		expectParseError bool
 	}{
 		{
-			name:        "Invalid JSON",
-			data:        []byte(`{"invalid": json}`),
-			eventType:   event.EventTypeHttpRequest,
-			expectError: "failed to parse JSON-RPC: invalid JSON",
+			name:             "Invalid JSON",
+			data:             []byte(`{"invalid": json}`),
+			eventType:        event.EventTypeHttpRequest,
+			expectError:      "failed to parse JSON-RPC: invalid JSON",
+			expectParseError: true,
 		},
 		{
-			name:        "Missing jsonrpc field",
-			data:        []byte(`{"id":1,"method":"tools/call"}`),
-			eventType:   event.EventTypeHttpResponse,
-			expectError: "failed to parse JSON-RPC: not JSON-RPC 2.0",
+			name:             "Missing jsonrpc field",
+			data:             []byte(`{"id":1,"method":"tools/call"}`),
+			eventType:        event.EventTypeHttpResponse,
+			expectError:      "failed to parse JSON-RPC: not JSON-RPC 2.0",
+			expectParseError: true,
 		},
 		{
-			name:        "Wrong jsonrpc version",
-			data:        []byte(`{"jsonrpc":"1.0","id":1,"method":"tools/call"}`),
-			eventType:   event.EventTypeHttpSSE,
-			expectError: "failed to parse JSON-RPC: not JSON-RPC 2.0",
+			name:             "Wrong jsonrpc version",
+			data:             []byte(`{"jsonrpc":"1.0","id":1,"method":"tools/call"}`),
+			eventType:        event.EventTypeHttpSSE,
+			expectError:      "failed to parse JSON-RPC: not JSON-RPC 2.0",
+			expectParseError: true,
 		},
 		{
 			name:        "Unknown method",
@@ -1414,10 +1800,11 @@ func TestParseDataHttp_InvalidMessages(t *testing.T) {
 			expectError: "invalid MCP message: unknown MCP method",
 		},
 		{
-			name:        "Response without ID",
-			data:        []byte(`{"jsonrpc":"2.0","result":{"status":"ok"}}`),
-			eventType:   event.EventTypeHttpResponse,
-			expectError: "failed to parse JSON-RPC: unknown JSON-RPC message type",
+			name:             "Response without ID",
+			data:             []byte(`{"jsonrpc":"2.0","result":{"status":"ok"}}`),
+			eventType:        event.EventTypeHttpResponse,
+			expectError:      "failed to parse JSON-RPC: unknown JSON-RPC message type",
+			expectParseError: true,
 		},
 		{
 			name:        "Unknown notification method",
@@ -1441,6 +1828,18 @@ func TestParseDataHttp_InvalidMessages(t *testing.T) {
 
 			parser.ParseDataHttp(httpEvent)
 
+			if tt.expectParseError {
+				select {
+				case evt := <-mockBus.Events():
+					if _, ok := evt.(*event.ParseErrorEvent); !ok {
+						t.Errorf("Expected a ParseErrorEvent, but got event of type %v", evt.Type())
+					}
+				case <-time.After(50 * time.Millisecond):
+					t.Error("Expected a ParseErrorEvent, but got none")
+				}
+				return
+			}
+
 			// Check that NO event was published (timeout = expected behavior for errors)
 			select {
 			case evt := <-mockBus.Events():
@@ -1644,6 +2043,8 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 		pid       uint32
 		comm      string
 		host      string
+		method    string
+		path      string
 		isRequest bool
 	}{
 		{
@@ -1653,6 +2054,8 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 			pid:       1234,
 			comm:      "custom-server",
 			host:      "api.example.org",
+			method:    "POST",
+			path:      "/mcp",
 			isRequest: true,
 		},
 		{
@@ -1662,6 +2065,8 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 			pid:       5678,
 			comm:      "backend-service",
 			host:      "internal.api.com",
+			method:    "POST",
+			path:      "/mcp",
 			isRequest: false,
 		},
 		{
@@ -1671,6 +2076,8 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 			pid:       9999,
 			comm:      "mcp-client",
 			host:      "localhost:8080",
+			method:    "GET",
+			path:      "/mcp",
 			isRequest: true,
 		},
 		{
@@ -1680,6 +2087,8 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 			pid:       100,
 			comm:      "test-process",
 			host:      "",
+			method:    "",
+			path:      "",
 			isRequest: false,
 		},
 	}
@@ -1688,13 +2097,14 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Cache request for responses
 			if tt.eventType == event.EventTypeHttpResponse {
-				// Create a mock request message to cache
+				// Create a mock request message to cache, scoped to the same
+				// session (PID+Host) the response will arrive on.
 				mockRequest := &event.JSONRPCMessage{
 					MessageType: event.JSONRPCMessageTypeRequest,
 					ID:          int64(1),
 					Method:      "tools/list",
 				}
-				parser.cacheRequestMessage(mockRequest)
+				parser.cacheRequestMessage(mockRequest, event.TransportTypeHTTP, nil, &event.HttpTransport{PID: tt.pid, Host: tt.host, Path: tt.path}, nil)
 			}
 
 			var httpEvent event.Event
@@ -1706,6 +2116,8 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 						PID:       tt.pid,
 					},
 					Host:           tt.host,
+					Method:         tt.method,
+					Path:           tt.path,
 					RequestPayload: tt.data,
 				}
 				copy(e.CommBytes[:], []byte(tt.comm))
@@ -1717,7 +2129,9 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 						PID:       tt.pid,
 					},
 					HttpRequestEvent: event.HttpRequestEvent{
-						Host: tt.host,
+						Host:   tt.host,
+						Method: tt.method,
+						Path:   tt.path,
 					},
 					ResponsePayload: tt.data,
 				}
@@ -1730,7 +2144,9 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 						PID:       tt.pid,
 					},
 					HttpRequestEvent: event.HttpRequestEvent{
-						Host: tt.host,
+						Host:   tt.host,
+						Method: tt.method,
+						Path:   tt.path,
 					},
 					Data: tt.data,
 				}
@@ -1774,6 +2190,14 @@ func TestParseDataHttp_HttpTransportFields(t *testing.T) {
 					t.Errorf("Expected HttpTransport.Host '%s', got '%s'", tt.host, msg.HttpTransport.Host)
 				}
 
+				if msg.HttpTransport.HTTPMethod != tt.method {
+					t.Errorf("Expected HttpTransport.HTTPMethod '%s', got '%s'", tt.method, msg.HttpTransport.HTTPMethod)
+				}
+
+				if msg.HttpTransport.Path != tt.path {
+					t.Errorf("Expected HttpTransport.Path '%s', got '%s'", tt.path, msg.HttpTransport.Path)
+				}
+
 				// IsRequest is automatically determined by event type
 				expectedIsRequest := (tt.eventType == event.EventTypeHttpRequest)
 				if msg.HttpTransport.IsRequest != expectedIsRequest {
@@ -1817,6 +2241,16 @@ func TestRequestIDCaching_Stdio(t *testing.T) {
 		fsEvent = createFSAggregatedEvent(responseData, event.EventTypeFSRead, 200, "reader", 100, "writer")
 		parser.ParseDataStdio(fsEvent)
 
+		// Read the tool call result event emitted alongside the response
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeToolCallResult {
+				t.Fatalf("Expected EventTypeToolCallResult, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No tool call result event received for response")
+		}
+
 		// Read response from bus
 		select {
 		case evt := <-mockBus.Events():
@@ -2003,6 +2437,16 @@ func TestRequestIDCaching_Http(t *testing.T) {
 		httpResponseEvent := createHttpResponseEvent(responseData, 200, "http-server", "example.com")
 		parser.ParseDataHttp(httpResponseEvent)
 
+		// Read the tool call result event emitted alongside the response
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeToolCallResult {
+				t.Fatalf("Expected EventTypeToolCallResult, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No tool call result event received for response")
+		}
+
 		// Read response from bus
 		select {
 		case evt := <-mockBus.Events():
@@ -2158,6 +2602,77 @@ func TestRequestIDCaching_Http(t *testing.T) {
 	})
 }
 
+// TestRequestIDCaching_SSEMultiplexing verifies that a single SSE stream
+// carrying responses to two concurrently-POSTed requests correlates each
+// response back to its own request, even when the responses arrive
+// interleaved and out of order.
+func TestRequestIDCaching_SSEMultiplexing(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	// Two concurrent POST requests to the same MCP server, carried by the
+	// same session (PID+Host).
+	req1 := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"first"}}`)
+	req2 := []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"second"}}`)
+	for _, data := range [][]byte{req1, req2} {
+		httpEvent := createHttpRequestEvent(data, 100, "http-client", "mcp-server.example.com")
+		parser.ParseDataHttp(httpEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeMCPMessage {
+				t.Fatalf("Expected EventTypeMCPMessage for request, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received for request")
+		}
+	}
+
+	// A single SSE stream delivers both responses interleaved, id 2 first.
+	sseData := []byte(`{"jsonrpc":"2.0","id":2,"result":{"tool":"second"}}
+{"jsonrpc":"2.0","id":1,"result":{"tool":"first"}}`)
+	sseEvent := createSSEEvent(sseData, 100, "http-client", "mcp-server.example.com")
+	parser.ParseDataHttp(sseEvent)
+
+	wantToolByID := map[interface{}]string{
+		int64(1): "first",
+		int64(2): "second",
+	}
+	for i := 0; i < 2; i++ {
+		// Drain the tool call result event emitted alongside each response.
+		select {
+		case <-mockBus.Events():
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No tool call result event received")
+		}
+
+		select {
+		case evt := <-mockBus.Events():
+			msg, ok := evt.(*event.MCPEvent)
+			if !ok {
+				t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+			}
+			if msg.Request == nil {
+				t.Fatalf("Response with id %v should be paired with its request", msg.ID)
+			}
+			wantTool, ok := wantToolByID[msg.Request.ID]
+			if !ok {
+				t.Fatalf("Unexpected correlated request id %v", msg.Request.ID)
+			}
+			if msg.Request.Params["name"] != wantTool {
+				t.Errorf("Response id %v paired with request for tool %q, want %q", msg.ID, msg.Request.Params["name"], wantTool)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received for SSE response")
+		}
+	}
+}
+
 func TestRequestIDCaching_MixedIDTypes(t *testing.T) {
 	mockBus := tu.NewMockBus()
 	parser, err := NewParser(mockBus)
@@ -2214,7 +2729,250 @@ func TestRequestIDCaching_MixedIDTypes(t *testing.T) {
 	})
 }
 
-func TestIDToCacheKey(t *testing.T) {
+func TestRequestTimeout(t *testing.T) {
+	// Speed up the cache TTL so the test doesn't have to wait on the real
+	// 5 second default.
+	origTTL := requestIDCacheTTL
+	requestIDCacheTTL = 50 * time.Millisecond
+	defer func() { requestIDCacheTTL = origTTL }()
+
+	t.Run("Unanswered request produces a timeout event", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+		fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+
+		// Drain the MCP message event for the request itself.
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeMCPMessage {
+				t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received for request")
+		}
+
+		var timeout *event.RequestTimeoutEvent
+		for i := 0; i < 5; i++ {
+			select {
+			case evt := <-mockBus.Events():
+				if t, ok := evt.(*event.RequestTimeoutEvent); ok {
+					timeout = t
+				}
+			case <-time.After(200 * time.Millisecond):
+			}
+			if timeout != nil {
+				break
+			}
+		}
+		if timeout == nil {
+			t.Fatal("Expected a RequestTimeoutEvent for the unanswered request, got none")
+		}
+		if timeout.Method != "tools/call" {
+			t.Errorf("Expected method tools/call, got %q", timeout.Method)
+		}
+		if timeout.ID != int64(1) {
+			t.Errorf("Expected ID 1, got %v", timeout.ID)
+		}
+		if timeout.TTL != requestIDCacheTTL {
+			t.Errorf("Expected TTL %v, got %v", requestIDCacheTTL, timeout.TTL)
+		}
+		if timeout.StdioTransport == nil || timeout.StdioTransport.FromPID != 100 || timeout.StdioTransport.ToPID != 200 {
+			t.Errorf("Expected stdio transport with FromPID 100 and ToPID 200, got %+v", timeout.StdioTransport)
+		}
+	})
+
+	t.Run("Answered request does not produce a timeout event", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+		fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeMCPMessage {
+				t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received for request")
+		}
+
+		responseData := []byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"ok"}}`)
+		fsEvent = createFSAggregatedEvent(responseData, event.EventTypeFSRead, 200, "reader", 100, "writer")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeToolCallResult {
+				t.Fatalf("Expected EventTypeToolCallResult, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No tool call result event received for response")
+		}
+
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeMCPMessage {
+				t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received for response")
+		}
+
+		for i := 0; i < 5; i++ {
+			select {
+			case evt := <-mockBus.Events():
+				if evt.Type() == event.EventTypeRequestTimeout {
+					t.Fatalf("Expected no timeout event for an answered request, but got one")
+				}
+			case <-time.After(200 * time.Millisecond):
+			}
+		}
+	})
+}
+
+// TestRequestTimeout_ConfigurableViaRequestTimeout confirms that
+// Config.RequestTimeout (wired to --correlation-timeout) controls how long
+// an unanswered request waits before it's reported, independent of the
+// requestIDCacheTTL default.
+func TestRequestTimeout_ConfigurableViaRequestTimeout(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParserWithConfig(mockBus, Config{RequestTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+	fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+	parser.ParseDataStdio(fsEvent)
+
+	select {
+	case evt := <-mockBus.Events():
+		if evt.Type() != event.EventTypeMCPMessage {
+			t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No MCP event received for request")
+	}
+
+	var timeout *event.RequestTimeoutEvent
+	for i := 0; i < 5; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			if t, ok := evt.(*event.RequestTimeoutEvent); ok {
+				timeout = t
+			}
+		case <-time.After(200 * time.Millisecond):
+		}
+		if timeout != nil {
+			break
+		}
+	}
+	if timeout == nil {
+		t.Fatal("Expected a RequestTimeoutEvent for the unanswered request, got none")
+	}
+	if timeout.TTL != 50*time.Millisecond {
+		t.Errorf("Expected TTL 50ms from Config.RequestTimeout, got %v", timeout.TTL)
+	}
+}
+
+// TestCorrelatedOnly_CombinedEmission confirms that with CorrelatedOnly
+// enabled, a request produces no standalone MCPEvent - only the eventual
+// response does, carrying the full exchange (embedded Request + latency).
+func TestCorrelatedOnly_CombinedEmission(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParserWithConfig(mockBus, Config{CorrelatedOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+	fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+	parser.ParseDataStdio(fsEvent)
+
+	select {
+	case evt := <-mockBus.Events():
+		t.Fatalf("Expected no standalone event for the held request, got %v", evt.Type())
+	case <-time.After(50 * time.Millisecond):
+		// Expected: the request is held until its response arrives.
+	}
+
+	responseData := []byte(`{"jsonrpc":"2.0","id":1,"result":{"tool":"test"}}`)
+	fsEvent = createFSAggregatedEvent(responseData, event.EventTypeFSRead, 200, "reader", 100, "writer")
+	parser.ParseDataStdio(fsEvent)
+
+	evt := drainMCPEvent(t, mockBus.Events())
+	if evt.Request == nil || evt.Request.Method != "tools/call" {
+		t.Fatalf("Expected the combined record to embed the original request, got %+v", evt.Request)
+	}
+	if evt.MessageType != event.JSONRPCMessageTypeResponse {
+		t.Fatalf("Expected a response-shaped combined record, got %v", evt.MessageType)
+	}
+}
+
+// TestCorrelatedOnly_TimeoutEmission confirms that with CorrelatedOnly
+// enabled, a request that's never answered still surfaces (via
+// RequestTimeoutEvent) rather than vanishing silently, even though its
+// standalone MCPEvent was suppressed.
+func TestCorrelatedOnly_TimeoutEmission(t *testing.T) {
+	origTTL := requestIDCacheTTL
+	requestIDCacheTTL = 50 * time.Millisecond
+	defer func() { requestIDCacheTTL = origTTL }()
+
+	mockBus := tu.NewMockBus()
+	parser, err := NewParserWithConfig(mockBus, Config{CorrelatedOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+	fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+	parser.ParseDataStdio(fsEvent)
+
+	var timeout *event.RequestTimeoutEvent
+	for i := 0; i < 5; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			to, ok := evt.(*event.RequestTimeoutEvent)
+			if !ok {
+				t.Fatalf("Expected only a RequestTimeoutEvent for the held+unanswered request, got %v", evt.Type())
+			}
+			timeout = to
+		case <-time.After(200 * time.Millisecond):
+		}
+		if timeout != nil {
+			break
+		}
+	}
+	if timeout == nil {
+		t.Fatal("Expected a RequestTimeoutEvent for the unanswered request, got none")
+	}
+	if timeout.Method != "tools/call" {
+		t.Errorf("Expected method tools/call, got %q", timeout.Method)
+	}
+}
+
+func TestIDToCacheKey(t *testing.T) {
 	parser, err := NewParser(tu.NewMockBus())
 	if err != nil {
 		t.Fatalf("Failed to create parser: %v", err)
@@ -2273,17 +3031,17 @@ func TestValidateResponseID(t *testing.T) {
 		MessageType: event.JSONRPCMessageTypeRequest,
 		ID:          int64(1),
 		Method:      "tools/list",
-	})
+	}, event.TransportTypeStdio, nil, nil, nil)
 	parser.cacheRequestMessage(&event.JSONRPCMessage{
 		MessageType: event.JSONRPCMessageTypeRequest,
 		ID:          "test-123",
 		Method:      "initialize",
-	})
+	}, event.TransportTypeStdio, nil, nil, nil)
 	parser.cacheRequestMessage(&event.JSONRPCMessage{
 		MessageType: event.JSONRPCMessageTypeRequest,
 		ID:          int64(42),
 		Method:      "resources/list",
-	})
+	}, event.TransportTypeStdio, nil, nil, nil)
 
 	tests := []struct {
 		name     string
@@ -2329,7 +3087,7 @@ func TestValidateResponseID(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, exists := parser.getRequestByID(tt.id)
+			_, _, exists := parser.getRequestByID(tt.id, nil, nil, nil)
 			if exists != tt.expected {
 				t.Errorf("Expected validation result %v, got %v", tt.expected, exists)
 			}
@@ -2363,28 +3121,32 @@ func TestDuplicateDetection(t *testing.T) {
 		t.Fatal("No MCP event received for first occurrence")
 	}
 
-	// Second occurrence (same data): B(200) -> C(300) - should be dropped as duplicate
+	// Second occurrence (same data): B(200) -> C(300) - folded as a
+	// duplicate, but reported via a DuplicateMessageEvent rather than an
+	// MCPEvent.
 	fsEvent = createFSAggregatedEvent(data, event.EventTypeFSRead, 200, "proc-b", 300, "proc-c")
 	parser.ParseDataStdio(fsEvent)
 
-	// Should NOT publish event (duplicate)
 	select {
 	case evt := <-mockBus.Events():
-		t.Errorf("Expected no event (duplicate), but got event of type %v", evt.Type())
-	case <-time.After(50 * time.Millisecond):
-		// Success - duplicate was dropped
+		if evt.Type() != event.EventTypeDuplicateMessage {
+			t.Errorf("Expected EventTypeDuplicateMessage for folded duplicate, got %v", evt.Type())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No DuplicateMessageEvent received for second occurrence")
 	}
 
-	// Third occurrence (same data): C(300) -> D(400) - should also be dropped
+	// Third occurrence (same data): C(300) -> D(400) - should also be folded
 	fsEvent = createFSAggregatedEvent(data, event.EventTypeFSRead, 300, "proc-c", 400, "proc-d")
 	parser.ParseDataStdio(fsEvent)
 
-	// Should NOT publish event (duplicate)
 	select {
 	case evt := <-mockBus.Events():
-		t.Errorf("Expected no event (duplicate), but got event of type %v", evt.Type())
-	case <-time.After(50 * time.Millisecond):
-		// Success - duplicate was dropped
+		if evt.Type() != event.EventTypeDuplicateMessage {
+			t.Errorf("Expected EventTypeDuplicateMessage for folded duplicate, got %v", evt.Type())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No DuplicateMessageEvent received for third occurrence")
 	}
 }
 
@@ -2429,3 +3191,1013 @@ func TestDuplicateDetection_DifferentData(t *testing.T) {
 		t.Fatal("No MCP event received for second message (different data)")
 	}
 }
+
+// TestDuplicateMessageEvent_CarriesHopDetails verifies that a folded
+// duplicate's DuplicateMessageEvent identifies the method/id it collapsed
+// and the hop it arrived on, so a subscriber (e.g. a session's duplicate
+// counter) can attribute it correctly instead of just seeing a bare count.
+func TestDuplicateMessageEvent_CarriesHopDetails(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	data := []byte(`{"jsonrpc":"2.0","id":7,"method":"tools/list"}`)
+
+	parser.ParseDataStdio(createFSAggregatedEvent(data, event.EventTypeFSRead, 100, "proc-a", 200, "proc-b"))
+	<-mockBus.Events() // first occurrence: EventTypeMCPMessage
+
+	parser.ParseDataStdio(createFSAggregatedEvent(data, event.EventTypeFSRead, 200, "proc-b", 300, "proc-c"))
+
+	select {
+	case evt := <-mockBus.Events():
+		dup, ok := evt.(*event.DuplicateMessageEvent)
+		if !ok {
+			t.Fatalf("Expected *event.DuplicateMessageEvent, got %T", evt)
+		}
+		if dup.Method != "tools/list" {
+			t.Errorf("Method = %q, want %q", dup.Method, "tools/list")
+		}
+		if dup.StdioTransport == nil || dup.StdioTransport.FromPID != 200 || dup.StdioTransport.ToPID != 300 {
+			t.Errorf("unexpected StdioTransport: %+v", dup.StdioTransport)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No DuplicateMessageEvent received")
+	}
+}
+
+// TestDuplicateDetection_ShowDupesEmitsEachHop verifies that Config.ShowDupes
+// stops folding duplicate hops: every hop of an already-seen message is
+// still parsed and published as its own MCPEvent, alongside the
+// DuplicateMessageEvent that still reports it as a duplicate.
+func TestDuplicateDetection_ShowDupesEmitsEachHop(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParserWithConfig(mockBus, Config{ShowDupes: true})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	parser.ParseDataStdio(createFSAggregatedEvent(data, event.EventTypeFSRead, 100, "proc-a", 200, "proc-b"))
+	select {
+	case evt := <-mockBus.Events():
+		if evt.Type() != event.EventTypeMCPMessage {
+			t.Fatalf("Expected EventTypeMCPMessage for first hop, got %v", evt.Type())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No MCP event received for first hop")
+	}
+
+	// Second hop of the same message: with ShowDupes, it's still reported
+	// as a duplicate AND emitted as its own MCPEvent.
+	parser.ParseDataStdio(createFSAggregatedEvent(data, event.EventTypeFSRead, 200, "proc-b", 300, "proc-c"))
+
+	sawDuplicate, sawMCPMessage := false, false
+	for i := 0; i < 2; i++ {
+		select {
+		case evt := <-mockBus.Events():
+			switch evt.Type() {
+			case event.EventTypeDuplicateMessage:
+				sawDuplicate = true
+			case event.EventTypeMCPMessage:
+				sawMCPMessage = true
+			default:
+				t.Errorf("Unexpected event type %v for second hop", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Timed out waiting for events from second hop")
+		}
+	}
+	if !sawDuplicate {
+		t.Error("Expected a DuplicateMessageEvent for the second hop")
+	}
+	if !sawMCPMessage {
+		t.Error("Expected an MCPEvent for the second hop (ShowDupes should stop folding)")
+	}
+}
+
+// TestExplain_MatchesActualFilteredOutput verifies that the counting-only
+// Explain pass agrees with what a real ParseDataStdio run would keep/drop
+// for the same fixed input.
+func TestExplain_MatchesActualFilteredOutput(t *testing.T) {
+	lines := [][]byte{
+		[]byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`),
+		[]byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`),         // duplicate of above
+		[]byte(`{"jsonrpc":"2.0","id":2,"method":"not/a/real/method"}`),  // unknown method; stops processing
+		[]byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`), // never reached
+	}
+	var combined []byte
+	for _, l := range lines {
+		combined = append(combined, l...)
+		combined = append(combined, '\n')
+	}
+
+	// Run Explain on one parser instance.
+	mockBus := tu.NewMockBus()
+	explainParser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer explainParser.Close()
+	defer mockBus.Close()
+
+	stats := explainParser.Explain(combined)
+
+	if stats.Total != 3 {
+		t.Fatalf("expected total 3, got %d", stats.Total)
+	}
+	if stats.Duplicate != 1 {
+		t.Errorf("expected 1 duplicate, got %d", stats.Duplicate)
+	}
+	if stats.InvalidMCPMessage != 1 {
+		t.Errorf("expected 1 invalid MCP message, got %d", stats.InvalidMCPMessage)
+	}
+	if stats.Kept != 1 {
+		t.Errorf("expected 1 kept message, got %d", stats.Kept)
+	}
+
+	// Run the real pipeline on an independent parser and count the actual
+	// published events; it must match Explain's Kept count.
+	mockBus2 := tu.NewMockBus()
+	liveParser, err := NewParser(mockBus2)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer liveParser.Close()
+	defer mockBus2.Close()
+
+	fsEvent := createFSAggregatedEvent(combined, event.EventTypeFSRead, 100, "proc-a", 200, "proc-b")
+	liveParser.ParseDataStdio(fsEvent)
+
+	kept := 0
+drain:
+	for {
+		select {
+		case evt := <-mockBus2.Events():
+			if evt.Type() == event.EventTypeMCPMessage {
+				kept++
+			}
+		case <-time.After(50 * time.Millisecond):
+			break drain
+		}
+	}
+
+	if kept != stats.Kept {
+		t.Errorf("Explain reported %d kept, but live pipeline published %d", stats.Kept, kept)
+	}
+}
+
+// TestStrictVsLenientJSONRPC verifies that a version-less message is
+// rejected in strict (default) mode and accepted, tagged non-conformant,
+// in lenient mode.
+func TestStrictVsLenientJSONRPC(t *testing.T) {
+	versionLess := []byte(`{"id":1,"method":"tools/list"}`)
+
+	t.Run("strict mode drops it", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		fsEvent := createFSAggregatedEvent(versionLess, event.EventTypeFSRead, 100, "proc-a", 200, "proc-b")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			if _, ok := evt.(*event.ParseErrorEvent); !ok {
+				t.Fatalf("expected a ParseErrorEvent in strict mode, got %v", evt.Type())
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("expected a ParseErrorEvent in strict mode, got none")
+		}
+	})
+
+	t.Run("lenient mode accepts and tags it", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParserWithConfig(mockBus, Config{LenientJSONRPC: true})
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		fsEvent := createFSAggregatedEvent(versionLess, event.EventTypeFSRead, 100, "proc-a", 200, "proc-b")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			msg, ok := evt.(*event.MCPEvent)
+			if !ok {
+				t.Fatalf("expected MCPEvent, got %T", evt)
+			}
+			if !msg.NonConformant {
+				t.Error("expected message to be tagged NonConformant")
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("expected an event in lenient mode")
+		}
+	})
+}
+
+// TestParseData_GoldenWithFakeClock pins the parser's clock to a fixed
+// instant so the emitted event's JSON encoding is byte-for-byte
+// reproducible, which isn't possible with the real wall clock.
+func TestParseData_GoldenWithFakeClock(t *testing.T) {
+	fakeClock := clock.NewFake(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	mockBus := tu.NewMockBus()
+	parser, err := NewParserWithConfig(mockBus, Config{Clock: fakeClock})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+	fsEvent := createFSAggregatedEvent(data, event.EventTypeFSRead, 100, "writer", 200, "reader")
+	parser.ParseDataStdio(fsEvent)
+
+	var msg *event.MCPEvent
+	select {
+	case evt := <-mockBus.Events():
+		msg = evt.(*event.MCPEvent)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No MCP event received")
+	}
+
+	got, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %v", err)
+	}
+
+	const want = `{"timestamp":"2024-01-02T03:04:05Z","transport_type":"stdio","stdio_transport":{"from_pid":100,"from_comm":"writer","to_pid":200,"to_comm":"reader"},"type":"request","id":1,"method":"tools/call","params":{"name":"test"},"error":{},"params_bytes":15,"raw":"{\"jsonrpc\":\"2.0\",\"id\":1,\"method\":\"tools/call\",\"params\":{\"name\":\"test\"}}"}`
+	if string(got) != want {
+		t.Errorf("golden mismatch:\n got:  %s\n want: %s", got, want)
+	}
+}
+
+// BenchmarkCalculateHash confirms calculateHash stays allocation-free: it
+// must return the raw digest rather than a hex-encoded string, since the
+// latter allocates on every stdio and HTTP message.
+func BenchmarkCalculateHash(b *testing.B) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		b.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parser.calculateHash(data)
+	}
+}
+
+func TestValidateToolCallResult(t *testing.T) {
+	tests := []struct {
+		name           string
+		msg            event.JSONRPCMessage
+		wantViolations int
+	}{
+		{
+			name: "Conforming result with text content",
+			msg: event.JSONRPCMessage{
+				Result: map[string]interface{}{
+					"content": []interface{}{
+						map[string]interface{}{"type": "text", "text": "hello"},
+					},
+				},
+			},
+			wantViolations: 0,
+		},
+		{
+			name: "Conforming error result with content",
+			msg: event.JSONRPCMessage{
+				Result: map[string]interface{}{
+					"isError": true,
+					"content": []interface{}{
+						map[string]interface{}{"type": "text", "text": "boom"},
+					},
+				},
+			},
+			wantViolations: 0,
+		},
+		{
+			name: "Content block missing type",
+			msg: event.JSONRPCMessage{
+				Result: map[string]interface{}{
+					"content": []interface{}{
+						map[string]interface{}{"text": "hello"},
+					},
+				},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "Content block is not an object",
+			msg: event.JSONRPCMessage{
+				Result: map[string]interface{}{
+					"content": []interface{}{"not-an-object"},
+				},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "isError true with empty content",
+			msg: event.JSONRPCMessage{
+				Result: map[string]interface{}{
+					"isError": true,
+					"content": []interface{}{},
+				},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "isError true with missing content",
+			msg: event.JSONRPCMessage{
+				Result: map[string]interface{}{
+					"isError": true,
+				},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "Result is not an object",
+			msg: event.JSONRPCMessage{
+				Result: "not-an-object",
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "JSON-RPC error response is not a malformed tool result",
+			msg: event.JSONRPCMessage{
+				Error: event.JSONRPCError{Code: -32000, Message: "tool failed"},
+			},
+			wantViolations: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := validateToolCallResult(tt.msg)
+			if len(violations) != tt.wantViolations {
+				t.Errorf("validateToolCallResult() = %v, want %d violation(s)", violations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestToolCallResultSchemaWarning(t *testing.T) {
+	t.Run("Malformed tools/call result produces a warning event", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+		fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+		<-mockBus.Events() // drain the request's MCP event
+
+		responseData := []byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"text":"missing type"}]}}`)
+		fsEvent = createFSAggregatedEvent(responseData, event.EventTypeFSRead, 200, "reader", 100, "writer")
+		parser.ParseDataStdio(fsEvent)
+
+		var warning *event.ToolResultSchemaWarningEvent
+		for i := 0; i < 2; i++ {
+			select {
+			case evt := <-mockBus.Events():
+				if w, ok := evt.(*event.ToolResultSchemaWarningEvent); ok {
+					warning = w
+				}
+			case <-time.After(100 * time.Millisecond):
+				t.Fatal("Expected a ToolResultSchemaWarningEvent, got none")
+			}
+		}
+		if warning == nil {
+			t.Fatal("Expected a ToolResultSchemaWarningEvent, got none")
+		}
+		if warning.ID != int64(1) {
+			t.Errorf("Expected ID 1, got %v", warning.ID)
+		}
+		if len(warning.Violations) != 1 {
+			t.Errorf("Expected 1 violation, got %v", warning.Violations)
+		}
+	})
+
+	t.Run("Conforming tools/call result produces no warning event", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+		fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+		<-mockBus.Events() // drain the request's MCP event
+
+		responseData := []byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"ok"}]}}`)
+		fsEvent = createFSAggregatedEvent(responseData, event.EventTypeFSRead, 200, "reader", 100, "writer")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeToolCallResult {
+				t.Fatalf("Expected EventTypeToolCallResult, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No tool call result event received for response")
+		}
+
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeMCPMessage {
+				t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received for response")
+		}
+
+		select {
+		case evt := <-mockBus.Events():
+			t.Fatalf("Expected no further events for a conforming result, got %v", evt.Type())
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+func TestLargeToolArgsWarning(t *testing.T) {
+	t.Run("Oversized tools/call arguments produce a warning event", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParserWithConfig(mockBus, Config{MaxToolArgsBytes: 32})
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test","arguments":{"path":"/some/very/long/path/that/pushes/us/over/the/threshold"}}}`)
+		fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			warning, ok := evt.(*event.LargeToolArgsEvent)
+			if !ok {
+				t.Fatalf("Expected LargeToolArgsEvent, got %v", evt.Type())
+			}
+			if warning.ToolName != "test" {
+				t.Errorf("Expected tool name %q, got %q", "test", warning.ToolName)
+			}
+			if warning.Threshold != 32 {
+				t.Errorf("Expected threshold 32, got %d", warning.Threshold)
+			}
+			if warning.SizeBytes <= warning.Threshold {
+				t.Errorf("Expected size %d to exceed threshold %d", warning.SizeBytes, warning.Threshold)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Expected a LargeToolArgsEvent, got none")
+		}
+
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeMCPMessage {
+				t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received for request")
+		}
+	})
+
+	t.Run("Normal-sized tools/call arguments produce no warning event", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParserWithConfig(mockBus, Config{MaxToolArgsBytes: 32})
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		requestData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test","arguments":{"path":"/tmp"}}}`)
+		fsEvent := createFSAggregatedEvent(requestData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeMCPMessage {
+				t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received for request")
+		}
+
+		select {
+		case evt := <-mockBus.Events():
+			t.Fatalf("Expected no further events for normal-sized arguments, got %v", evt.Type())
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("Default threshold is used when MaxToolArgsBytes is unset", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		if parser.maxToolArgsBytes != defaultMaxToolArgsBytes {
+			t.Errorf("Expected default threshold %d, got %d", defaultMaxToolArgsBytes, parser.maxToolArgsBytes)
+		}
+	})
+}
+
+// TestTruncatedPrefix_ExtractsMethodAndID verifies that a JSON-RPC message
+// cut short by a kernel-side --capture-bytes limit still yields method/id,
+// as long as they appear before the point of truncation, even though the
+// document as a whole is invalid JSON.
+func TestTruncatedPrefix_ExtractsMethodAndID(t *testing.T) {
+	t.Run("Truncated request yields method and id", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		// A tools/call request whose "arguments" value was cut off mid-string.
+		truncated := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"big_tool","arguments":{"data":"AAAAAAAAAAAAAAAAAA`)
+		fsEvent := createFSAggregatedEvent(truncated, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			msg, ok := evt.(*event.MCPEvent)
+			if !ok {
+				t.Fatalf("expected MCPEvent, got %T", evt)
+			}
+			if !msg.Truncated {
+				t.Error("expected message to be tagged Truncated")
+			}
+			if msg.Method != "tools/call" {
+				t.Errorf("expected method %q, got %q", "tools/call", msg.Method)
+			}
+			if msg.ID != int64(1) {
+				t.Errorf("expected id 1, got %v", msg.ID)
+			}
+			if msg.Params != nil {
+				t.Errorf("expected params to not be recoverable from a truncated prefix, got %v", msg.Params)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("expected an MCPEvent recovered from the truncated prefix")
+		}
+	})
+
+	t.Run("Normal, complete request is unaffected", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+		fsEvent := createFSAggregatedEvent(data, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			msg, ok := evt.(*event.MCPEvent)
+			if !ok {
+				t.Fatalf("expected MCPEvent, got %T", evt)
+			}
+			if msg.Truncated {
+				t.Error("expected a complete message to not be tagged Truncated")
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("expected an MCPEvent")
+		}
+	})
+
+	t.Run("Truncation before method is unrecoverable", func(t *testing.T) {
+		mockBus := tu.NewMockBus()
+		parser, err := NewParser(mockBus)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+		defer mockBus.Close()
+
+		truncated := []byte(`{"jsonrpc":"2.0","id":1,"met`)
+		fsEvent := createFSAggregatedEvent(truncated, event.EventTypeFSRead, 100, "writer", 200, "reader")
+		parser.ParseDataStdio(fsEvent)
+
+		select {
+		case evt := <-mockBus.Events():
+			if _, ok := evt.(*event.ParseErrorEvent); !ok {
+				t.Fatalf("expected a ParseErrorEvent for a prefix truncated before method, got %v", evt.Type())
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("expected a ParseErrorEvent for a prefix truncated before method, got none")
+		}
+	})
+}
+
+// createFSAggregatedEventUnixSocket is like createFSAggregatedEvent but
+// tags the event as coming from a Unix domain socket fd instead of a pipe.
+func createFSAggregatedEventUnixSocket(data []byte, eventType event.EventType, fromPID uint32, fromComm string, toPID uint32, toComm string) *event.FSAggregatedEvent {
+	var comm [16]uint8
+	var fromCommBytes [16]uint8
+	var toCommBytes [16]uint8
+
+	copy(comm[:], []byte(fromComm))
+	copy(fromCommBytes[:], []byte(fromComm))
+	copy(toCommBytes[:], []byte(toComm))
+
+	aggregatedType := eventType
+	switch eventType {
+	case event.EventTypeFSRead:
+		aggregatedType = event.EventTypeFSAggregatedRead
+	case event.EventTypeFSWrite:
+		aggregatedType = event.EventTypeFSAggregatedWrite
+	}
+
+	return event.NewFSAggregatedEvent(
+		aggregatedType,
+		fromPID,
+		comm,
+		0, // inode (not needed for tests)
+		fromPID,
+		fromCommBytes,
+		toPID,
+		toCommBytes,
+		0, // filePtr (not needed for tests)
+		true,
+		data,
+	)
+}
+
+// TestParseDataStdio_UnixSocketTransport verifies that a message aggregated
+// from a Unix domain socket fd is tagged TransportTypeUnixSocket rather than
+// TransportTypeStdio.
+func TestParseDataStdio_UnixSocketTransport(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test"}}`)
+	fsEvent := createFSAggregatedEventUnixSocket(data, event.EventTypeFSRead, 100, "writer", 200, "reader")
+	parser.ParseDataStdio(fsEvent)
+
+	select {
+	case evt := <-mockBus.Events():
+		msg, ok := evt.(*event.MCPEvent)
+		if !ok {
+			t.Fatalf("expected MCPEvent, got %T", evt)
+		}
+		if msg.TransportType != event.TransportTypeUnixSocket {
+			t.Errorf("expected TransportTypeUnixSocket, got %v", msg.TransportType)
+		}
+		if msg.StdioTransport == nil || msg.StdioTransport.FromPID != 100 || msg.StdioTransport.ToPID != 200 {
+			t.Errorf("expected StdioTransport to carry from/to PIDs, got %+v", msg.StdioTransport)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected an MCPEvent for the Unix socket session")
+	}
+}
+
+func TestParseDataStdio_CorrelationIDMode(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParserWithConfig(mockBus, Config{CorrelationIDMode: true})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	reqData := []byte(`{"jsonrpc":"2.0","id":5,"method":"tools/list"}`)
+	reqEvent := createFSAggregatedEvent(reqData, event.EventTypeFSRead, 100, "writer", 200, "reader")
+	parser.ParseDataStdio(reqEvent)
+
+	var reqMsg *event.MCPEvent
+	select {
+	case evt := <-mockBus.Events():
+		reqMsg = evt.(*event.MCPEvent)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No MCP event received for request")
+	}
+	if reqMsg.CorrelationID == "" {
+		t.Error("Expected request to carry a non-empty CorrelationID")
+	}
+
+	respData := []byte(`{"jsonrpc":"2.0","id":5,"result":{"tools":[]}}`)
+	respEvent := createFSAggregatedEvent(respData, event.EventTypeFSWrite, 200, "reader", 100, "writer")
+	parser.ParseDataStdio(respEvent)
+
+	select {
+	case evt := <-mockBus.Events():
+		respMsg := evt.(*event.MCPEvent)
+		if respMsg.CorrelationID == "" {
+			t.Error("Expected response to carry a non-empty CorrelationID")
+		}
+		if respMsg.CorrelationID != reqMsg.CorrelationID {
+			t.Errorf("CorrelationID mismatch: request %q, response %q", reqMsg.CorrelationID, respMsg.CorrelationID)
+		}
+		if respMsg.Request != nil {
+			t.Error("Expected embedded Request to be omitted in CorrelationIDMode")
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No MCP event received for response")
+	}
+}
+
+// TestParseDataHttp_SSEReplayedFrameDeduped exercises Last-Event-ID
+// resumption: a client that loses its SSE connection reconnects with the
+// last id it saw, and the server replays the response it's not sure arrived
+// - the same logical response shows up as three separate SSE frames with
+// the same id, once for the original delivery and twice more for
+// reconnects. Only the first should reach the event bus.
+func TestParseDataHttp_SSEReplayedFrameDeduped(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	reqData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"search"}}`)
+	reqEvent := createHttpRequestEvent(reqData, 100, "http-client", "mcp-server.example.com")
+	parser.ParseDataHttp(reqEvent)
+
+	select {
+	case <-mockBus.Events():
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No MCP event received for request")
+	}
+
+	respData := []byte(`{"jsonrpc":"2.0","id":1,"result":{"tool":"search"}}`)
+	for i := 0; i < 3; i++ {
+		sseEvent := createSSEEventWithLastID(respData, 100, "http-client", "mcp-server.example.com", "42")
+		parser.ParseDataHttp(sseEvent)
+	}
+
+	// Drain the single tool call result event and the single MCPEvent that
+	// should have made it through, despite three deliveries.
+	select {
+	case <-mockBus.Events():
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No tool call result event received")
+	}
+	select {
+	case evt := <-mockBus.Events():
+		if evt.Type() != event.EventTypeMCPMessage {
+			t.Fatalf("Expected EventTypeMCPMessage, got %v", evt.Type())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No MCP event received for response")
+	}
+
+	select {
+	case evt := <-mockBus.Events():
+		t.Fatalf("Expected no further events from replayed frames, got %v", evt.Type())
+	case <-time.After(50 * time.Millisecond):
+		// Expected: the two replayed frames were dropped.
+	}
+}
+
+// TestParseDataHttp_SSEDistinctIDsNotDeduped confirms frames with distinct
+// ids aren't mistaken for replays of each other, even when delivered back
+// to back as separate SSE events (e.g. a response split across three
+// reconnects of an otherwise unrelated stream).
+func TestParseDataHttp_SSEDistinctIDsNotDeduped(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	for i, id := range []string{"1", "2", "3"} {
+		reqID := int64(i + 1)
+		reqData := []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":"t%d"}}`, reqID, reqID))
+		parser.ParseDataHttp(createHttpRequestEvent(reqData, 100, "http-client", "mcp-server.example.com"))
+		select {
+		case <-mockBus.Events():
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("No MCP event received for request %d", reqID)
+		}
+
+		respData := []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"tool":"t%d"}}`, reqID, reqID))
+		parser.ParseDataHttp(createSSEEventWithLastID(respData, 100, "http-client", "mcp-server.example.com", id))
+
+		select {
+		case <-mockBus.Events():
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("No tool call result event received for id %s", id)
+		}
+		select {
+		case evt := <-mockBus.Events():
+			if evt.Type() != event.EventTypeMCPMessage {
+				t.Fatalf("Expected EventTypeMCPMessage for id %s, got %v", id, evt.Type())
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("No MCP event received for response with id %s", id)
+		}
+	}
+}
+
+// drainMCPEvent reads the next MCPEvent off the bus, skipping over events of
+// other types, so tests don't have to hardcode how many intermediate events
+// (e.g. tool call result events) a given method emits.
+func drainMCPEvent(t *testing.T, events <-chan event.Event) *event.MCPEvent {
+	t.Helper()
+	for {
+		select {
+		case evt := <-events:
+			if mcpEvt, ok := evt.(*event.MCPEvent); ok {
+				return mcpEvt
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("No MCP event received")
+		}
+	}
+}
+
+// TestParseDataHttp_ResourcesListPaginationLinking covers a two-page
+// resources/list sequence: the first page carries a nextCursor, the second
+// page's request echoes it back and carries none, and the running total
+// should span both pages.
+func TestParseDataHttp_ResourcesListPaginationLinking(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	req1 := []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list","params":{}}`)
+	parser.ParseDataHttp(createHttpRequestEvent(req1, 100, "http-client", "mcp-server.example.com"))
+	drainMCPEvent(t, mockBus.Events())
+
+	resp1 := []byte(`{"jsonrpc":"2.0","id":1,"result":{"resources":[{"uri":"a"},{"uri":"b"}],"nextCursor":"page2"}}`)
+	parser.ParseDataHttp(createHttpResponseEvent(resp1, 100, "http-client", "mcp-server.example.com"))
+	evt1 := drainMCPEvent(t, mockBus.Events())
+
+	if evt1.Page == nil {
+		t.Fatal("Expected Page to be set on first page")
+	}
+	if evt1.Page.Page != 1 || evt1.Page.ItemCount != 2 || evt1.Page.TotalItemCount != 2 || !evt1.Page.HasMore {
+		t.Fatalf("Unexpected Page for first page: %+v", evt1.Page)
+	}
+
+	req2 := []byte(`{"jsonrpc":"2.0","id":2,"method":"resources/list","params":{"cursor":"page2"}}`)
+	parser.ParseDataHttp(createHttpRequestEvent(req2, 100, "http-client", "mcp-server.example.com"))
+	drainMCPEvent(t, mockBus.Events())
+
+	resp2 := []byte(`{"jsonrpc":"2.0","id":2,"result":{"resources":[{"uri":"c"}]}}`)
+	parser.ParseDataHttp(createHttpResponseEvent(resp2, 100, "http-client", "mcp-server.example.com"))
+	evt2 := drainMCPEvent(t, mockBus.Events())
+
+	if evt2.Page == nil {
+		t.Fatal("Expected Page to be set on second page")
+	}
+	if evt2.Page.Page != 2 || evt2.Page.ItemCount != 1 || evt2.Page.TotalItemCount != 3 || evt2.Page.HasMore {
+		t.Fatalf("Unexpected Page for second page: %+v", evt2.Page)
+	}
+}
+
+// TestParseDataHttp_PromptsListPaginationLinking mirrors
+// TestParseDataHttp_ResourcesListPaginationLinking for prompts/list, to
+// confirm the same tracking code handles both methods independently.
+func TestParseDataHttp_PromptsListPaginationLinking(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	req1 := []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/list","params":{}}`)
+	parser.ParseDataHttp(createHttpRequestEvent(req1, 100, "http-client", "mcp-server.example.com"))
+	drainMCPEvent(t, mockBus.Events())
+
+	resp1 := []byte(`{"jsonrpc":"2.0","id":1,"result":{"prompts":[{"name":"p1"}],"nextCursor":"page2"}}`)
+	parser.ParseDataHttp(createHttpResponseEvent(resp1, 100, "http-client", "mcp-server.example.com"))
+	evt1 := drainMCPEvent(t, mockBus.Events())
+
+	if evt1.Page == nil || evt1.Page.Page != 1 || evt1.Page.TotalItemCount != 1 || !evt1.Page.HasMore {
+		t.Fatalf("Unexpected Page for first page: %+v", evt1.Page)
+	}
+
+	req2 := []byte(`{"jsonrpc":"2.0","id":2,"method":"prompts/list","params":{"cursor":"page2"}}`)
+	parser.ParseDataHttp(createHttpRequestEvent(req2, 100, "http-client", "mcp-server.example.com"))
+	drainMCPEvent(t, mockBus.Events())
+
+	resp2 := []byte(`{"jsonrpc":"2.0","id":2,"result":{"prompts":[{"name":"p2"},{"name":"p3"}]}}`)
+	parser.ParseDataHttp(createHttpResponseEvent(resp2, 100, "http-client", "mcp-server.example.com"))
+	evt2 := drainMCPEvent(t, mockBus.Events())
+
+	if evt2.Page == nil || evt2.Page.Page != 2 || evt2.Page.TotalItemCount != 3 || evt2.Page.HasMore {
+		t.Fatalf("Unexpected Page for second page: %+v", evt2.Page)
+	}
+}
+
+// TestParseDataHttp_ConcurrentSessionsSamePathDontCrossCorrelate covers two
+// concurrent HTTP sessions on the same host using overlapping JSON-RPC ids:
+// without a session identifier to key on, each session's own path
+// distinguishes it from the other, so a response with id 1 only ever pairs
+// with the request for the same session.
+func TestParseDataHttp_ConcurrentSessionsSamePathDontCrossCorrelate(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	httpRequest := func(id int, method string, host, path string) *event.HttpRequestEvent {
+		e := &event.HttpRequestEvent{
+			EventHeader: event.EventHeader{
+				EventType: event.EventTypeHttpRequest,
+				PID:       100,
+			},
+			Host:           host,
+			Path:           path,
+			RequestPayload: []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%q,"params":{}}`, id, method)),
+		}
+		copy(e.CommBytes[:], []byte("http-client"))
+		return e
+	}
+	httpResponse := func(id int, result, host, path string) *event.HttpResponseEvent {
+		e := &event.HttpResponseEvent{
+			EventHeader: event.EventHeader{
+				EventType: event.EventTypeHttpResponse,
+				PID:       100,
+			},
+			HttpRequestEvent: event.HttpRequestEvent{
+				Host: host,
+				Path: path,
+			},
+			ResponsePayload: []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, id, result)),
+		}
+		copy(e.CommBytes[:], []byte("http-client"))
+		return e
+	}
+
+	// Two unrelated clients talking to the same host but different session
+	// paths, both issuing a request with id 1.
+	parser.ParseDataHttp(httpRequest(1, "tools/call", "mcp-server.example.com", "/session/a"))
+	drainMCPEvent(t, mockBus.Events())
+	parser.ParseDataHttp(httpRequest(1, "resources/read", "mcp-server.example.com", "/session/b"))
+	drainMCPEvent(t, mockBus.Events())
+
+	// The response for session b's id 1 must pair with session b's
+	// request, not session a's.
+	parser.ParseDataHttp(httpResponse(1, `{"contents":[]}`, "mcp-server.example.com", "/session/b"))
+	respB := drainMCPEvent(t, mockBus.Events())
+	if respB.Request == nil {
+		t.Fatal("Expected session b's response to have a correlated Request")
+	}
+	if respB.Request.Method != "resources/read" {
+		t.Errorf("session b response correlated with method %q, want %q", respB.Request.Method, "resources/read")
+	}
+
+	parser.ParseDataHttp(httpResponse(1, `{"content":[]}`, "mcp-server.example.com", "/session/a"))
+	respA := drainMCPEvent(t, mockBus.Events())
+	if respA.Request == nil {
+		t.Fatal("Expected session a's response to have a correlated Request")
+	}
+	if respA.Request.Method != "tools/call" {
+		t.Errorf("session a response correlated with method %q, want %q", respA.Request.Method, "tools/call")
+	}
+}
@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// strictMethodSchema is the pair of structural checks run against a single
+// method's request params and response result when strict mode is enabled.
+// Either half may be nil if that direction isn't covered.
+type strictMethodSchema struct {
+	checkParams func(params map[string]interface{}) []string
+	checkResult func(result map[string]interface{}) []string
+}
+
+// requireStringField reports a violation if field is missing from obj or
+// isn't a string, and is shared by every strict check below - most MCP
+// schema violations worth flagging in v1 are a required string field being
+// absent or the wrong type.
+func requireStringField(obj map[string]interface{}, field string) []string {
+	v, ok := obj[field]
+	if !ok {
+		return []string{field + " is required"}
+	}
+	if _, ok := v.(string); !ok {
+		return []string{field + " must be a string"}
+	}
+	return nil
+}
+
+// requireObjectField reports a violation if field is missing from obj or
+// isn't a JSON object.
+func requireObjectField(obj map[string]interface{}, field string) []string {
+	v, ok := obj[field]
+	if !ok {
+		return []string{field + " is required"}
+	}
+	if _, ok := v.(map[string]interface{}); !ok {
+		return []string{field + " must be an object"}
+	}
+	return nil
+}
+
+// requireArrayField reports a violation if field is missing from obj or
+// isn't a JSON array.
+func requireArrayField(obj map[string]interface{}, field string) []string {
+	v, ok := obj[field]
+	if !ok {
+		return []string{field + " is required"}
+	}
+	if _, ok := v.([]interface{}); !ok {
+		return []string{field + " must be an array"}
+	}
+	return nil
+}
+
+// strictSchemas covers a subset of the MCP 2025-06-18 schema
+// (https://modelcontextprotocol.io/specification/2025-06-18/schema) for the
+// methods most likely to surface a malformed server implementation: the
+// handshake, and the three most commonly used tool/resource operations.
+// Extending coverage to other methods is a matter of adding another entry.
+var strictSchemas = map[string]strictMethodSchema{
+	"initialize": {
+		checkParams: func(params map[string]interface{}) []string {
+			var violations []string
+			violations = append(violations, requireStringField(params, "protocolVersion")...)
+			violations = append(violations, requireObjectField(params, "capabilities")...)
+			violations = append(violations, requireObjectField(params, "clientInfo")...)
+			return violations
+		},
+		checkResult: func(result map[string]interface{}) []string {
+			var violations []string
+			violations = append(violations, requireStringField(result, "protocolVersion")...)
+			violations = append(violations, requireObjectField(result, "capabilities")...)
+			violations = append(violations, requireObjectField(result, "serverInfo")...)
+			return violations
+		},
+	},
+	"tools/call": {
+		checkParams: func(params map[string]interface{}) []string {
+			return requireStringField(params, "name")
+		},
+	},
+	"tools/list": {
+		checkResult: func(result map[string]interface{}) []string {
+			violations := requireArrayField(result, "tools")
+			tools, _ := result["tools"].([]interface{})
+			for i, t := range tools {
+				tool, ok := t.(map[string]interface{})
+				if !ok {
+					violations = append(violations, fmt.Sprintf("tools[%d] is not an object", i))
+					continue
+				}
+				for _, v := range requireStringField(tool, "name") {
+					violations = append(violations, fmt.Sprintf("tools[%d].%s", i, v))
+				}
+				for _, v := range requireObjectField(tool, "inputSchema") {
+					violations = append(violations, fmt.Sprintf("tools[%d].%s", i, v))
+				}
+			}
+			return violations
+		},
+	},
+	"resources/read": {
+		checkParams: func(params map[string]interface{}) []string {
+			return requireStringField(params, "uri")
+		},
+		checkResult: func(result map[string]interface{}) []string {
+			violations := requireArrayField(result, "contents")
+			contents, _ := result["contents"].([]interface{})
+			for i, c := range contents {
+				content, ok := c.(map[string]interface{})
+				if !ok {
+					violations = append(violations, fmt.Sprintf("contents[%d] is not an object", i))
+					continue
+				}
+				for _, v := range requireStringField(content, "uri") {
+					violations = append(violations, fmt.Sprintf("contents[%d].%s", i, v))
+				}
+				_, hasText := content["text"]
+				_, hasBlob := content["blob"]
+				if !hasText && !hasBlob {
+					violations = append(violations, fmt.Sprintf("contents[%d] has neither text nor blob", i))
+				}
+			}
+			return violations
+		},
+	},
+}
+
+// validateStrictSchema checks msg's params (for a request) or result (for a
+// response) against strictSchemas, returning every violation found. Returns
+// nil for methods strictSchemas doesn't cover, or for message types/shapes
+// it doesn't apply to - the caller still emits the message either way, this
+// only decides what to attach to ValidationErrors.
+func validateStrictSchema(msg event.JSONRPCMessage) []string {
+	switch msg.MessageType {
+	case event.JSONRPCMessageTypeRequest:
+		schema, ok := strictSchemas[msg.Method]
+		if !ok || schema.checkParams == nil {
+			return nil
+		}
+		return schema.checkParams(msg.Params)
+	case event.JSONRPCMessageTypeResponse:
+		if msg.Request == nil || msg.Error.Message != "" {
+			return nil
+		}
+		schema, ok := strictSchemas[msg.Request.Method]
+		if !ok || schema.checkResult == nil {
+			return nil
+		}
+		result, ok := msg.Result.(map[string]interface{})
+		if !ok {
+			return []string{msg.Request.Method + " result is not a JSON object"}
+		}
+		return schema.checkResult(result)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestValidateStrictSchema(t *testing.T) {
+	tests := []struct {
+		name           string
+		msg            event.JSONRPCMessage
+		wantViolations int
+	}{
+		{
+			name: "Valid initialize request",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeRequest,
+				Method:      "initialize",
+				Params: map[string]interface{}{
+					"protocolVersion": "2025-06-18",
+					"capabilities":    map[string]interface{}{},
+					"clientInfo":      map[string]interface{}{"name": "test", "version": "1.0"},
+				},
+			},
+			wantViolations: 0,
+		},
+		{
+			name: "initialize request missing clientInfo",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeRequest,
+				Method:      "initialize",
+				Params: map[string]interface{}{
+					"protocolVersion": "2025-06-18",
+					"capabilities":    map[string]interface{}{},
+				},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "initialize response missing serverInfo and wrong-typed protocolVersion",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeResponse,
+				Request:     &event.JSONRPCMessage{Method: "initialize"},
+				Result: map[string]interface{}{
+					"protocolVersion": 123,
+					"capabilities":    map[string]interface{}{},
+				},
+			},
+			wantViolations: 2,
+		},
+		{
+			name: "tools/call request missing name",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeRequest,
+				Method:      "tools/call",
+				Params:      map[string]interface{}{"arguments": map[string]interface{}{}},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "tools/list response with malformed tool entry",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeResponse,
+				Request:     &event.JSONRPCMessage{Method: "tools/list"},
+				Result: map[string]interface{}{
+					"tools": []interface{}{
+						map[string]interface{}{"name": "search"},
+					},
+				},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "tools/list response fully valid",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeResponse,
+				Request:     &event.JSONRPCMessage{Method: "tools/list"},
+				Result: map[string]interface{}{
+					"tools": []interface{}{
+						map[string]interface{}{"name": "search", "inputSchema": map[string]interface{}{}},
+					},
+				},
+			},
+			wantViolations: 0,
+		},
+		{
+			name: "resources/read request missing uri",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeRequest,
+				Method:      "resources/read",
+				Params:      map[string]interface{}{},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "resources/read response content with neither text nor blob",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeResponse,
+				Request:     &event.JSONRPCMessage{Method: "resources/read"},
+				Result: map[string]interface{}{
+					"contents": []interface{}{
+						map[string]interface{}{"uri": "file:///a"},
+					},
+				},
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "Error response is not validated against the result schema",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeResponse,
+				Request:     &event.JSONRPCMessage{Method: "tools/call"},
+				Error:       event.JSONRPCError{Code: -32000, Message: "boom"},
+			},
+			wantViolations: 0,
+		},
+		{
+			name: "Uncovered method is never flagged",
+			msg: event.JSONRPCMessage{
+				MessageType: event.JSONRPCMessageTypeRequest,
+				Method:      "ping",
+				Params:      map[string]interface{}{},
+			},
+			wantViolations: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := validateStrictSchema(tt.msg)
+			if len(violations) != tt.wantViolations {
+				t.Errorf("validateStrictSchema() = %v, want %d violation(s)", violations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+// TestParseDataHttp_StrictModeAttachesValidationErrors confirms strict mode
+// flags a schema-violating tools/call request without dropping it.
+func TestParseDataHttp_StrictModeAttachesValidationErrors(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParserWithConfig(mockBus, Config{Strict: true})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	reqData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"arguments":{}}}`)
+	parser.ParseDataHttp(createHttpRequestEvent(reqData, 100, "http-client", "mcp-server.example.com"))
+
+	evt := drainMCPEvent(t, mockBus.Events())
+	if len(evt.ValidationErrors) == 0 {
+		t.Fatal("Expected ValidationErrors to be set for a tools/call request missing \"name\"")
+	}
+}
+
+// TestParseDataHttp_StrictModeOffSkipsValidation confirms ValidationErrors
+// stays empty when strict mode isn't enabled, even for a message that would
+// otherwise violate the schema.
+func TestParseDataHttp_StrictModeOffSkipsValidation(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	parser, err := NewParser(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	defer mockBus.Close()
+
+	reqData := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"arguments":{}}}`)
+	parser.ParseDataHttp(createHttpRequestEvent(reqData, 100, "http-client", "mcp-server.example.com"))
+
+	evt := drainMCPEvent(t, mockBus.Events())
+	if len(evt.ValidationErrors) != 0 {
+		t.Fatalf("Expected no ValidationErrors with strict mode off, got %v", evt.ValidationErrors)
+	}
+}
@@ -0,0 +1,226 @@
+// Package metrics exposes mcpspy's internal counters as a Prometheus
+// text-format /metrics endpoint, for long-running server deployments that
+// want to scrape them instead of reading console/TUI output. It subscribes
+// passively to the event bus, like the other cross-cutting components in
+// this repo (pkg/alert, pkg/bridge), so display code is untouched.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// Config controls the metrics HTTP server and the external stats sources
+// it polls at scrape time.
+type Config struct {
+	// Addr is the listen address for the /metrics endpoint, e.g.
+	// "localhost:9090". Required.
+	Addr string
+
+	// LibraryStats, if set, reports the current number of hooked and
+	// failed SSL library attachments (ebpf.LibraryManager.Stats).
+	LibraryStats func() (hooked int, failed int)
+
+	// EBPFReadErrors, if set, reports the number of ring buffer read
+	// errors observed so far (ebpf.Loader.ReadErrors).
+	EBPFReadErrors func() uint64
+}
+
+type messageKey struct {
+	method    string
+	transport event.TransportType
+}
+
+// Server accumulates counters off the event bus and serves them as
+// Prometheus text format.
+//
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+// - EventTypeParseError
+// - EventTypeRequestTimeout
+// - EventTypeFSBufferDropped
+type Server struct {
+	eventBus bus.EventBus
+	config   Config
+	httpSrv  *http.Server
+
+	mu                sync.Mutex
+	messagesByKey     map[messageKey]uint64
+	parseErrors       uint64
+	correlationHits   uint64
+	correlationMisses uint64
+	fsBufferDrops     uint64
+}
+
+// New creates a Server and starts serving Config.Addr in the background.
+// Call Close to unsubscribe and shut the HTTP server down.
+func New(eventBus bus.EventBus, config Config) (*Server, error) {
+	if config.Addr == "" {
+		return nil, fmt.Errorf("metrics: Addr is required")
+	}
+
+	s := &Server{
+		eventBus:      eventBus,
+		config:        config,
+		messagesByKey: make(map[messageKey]uint64),
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, s.recordMessage); err != nil {
+		return nil, err
+	}
+	if err := eventBus.Subscribe(event.EventTypeParseError, s.recordParseError); err != nil {
+		return nil, err
+	}
+	if err := eventBus.Subscribe(event.EventTypeRequestTimeout, s.recordTimeout); err != nil {
+		return nil, err
+	}
+	if err := eventBus.Subscribe(event.EventTypeFSBufferDropped, s.recordFSBufferDropped); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	s.httpSrv = &http.Server{Addr: config.Addr, Handler: mux}
+
+	go func() {
+		logrus.WithField("addr", config.Addr).Info("Serving Prometheus metrics")
+		if err := s.httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.WithError(err).Error("Metrics server stopped")
+		}
+	}()
+
+	return s, nil
+}
+
+func (s *Server) recordMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.messagesByKey[messageKey{method: msg.Method, transport: msg.TransportType}]++
+	// A response only reaches here once handleRequestResponseCorrelation has
+	// already paired it with its request (or CorrelationIDMode has tagged
+	// it), so its mere presence is a correlation hit. Requests that are
+	// never answered are counted as misses via EventTypeRequestTimeout
+	// instead; responses dropped for lacking a matching request never
+	// publish an MCPEvent and so aren't visible here.
+	if msg.MessageType == event.JSONRPCMessageTypeResponse && (msg.Request != nil || msg.CorrelationID != "") {
+		s.correlationHits++
+	}
+	s.mu.Unlock()
+}
+
+func (s *Server) recordParseError(e event.Event) {
+	if _, ok := e.(*event.ParseErrorEvent); !ok {
+		return
+	}
+	s.mu.Lock()
+	s.parseErrors++
+	s.mu.Unlock()
+}
+
+func (s *Server) recordTimeout(e event.Event) {
+	if _, ok := e.(*event.RequestTimeoutEvent); !ok {
+		return
+	}
+	s.mu.Lock()
+	s.correlationMisses++
+	s.mu.Unlock()
+}
+
+func (s *Server) recordFSBufferDropped(e event.Event) {
+	if _, ok := e.(*event.FSBufferDroppedEvent); !ok {
+		return
+	}
+	s.mu.Lock()
+	s.fsBufferDrops++
+	s.mu.Unlock()
+}
+
+// handleMetrics renders the accumulated counters in Prometheus text
+// exposition format.
+func (s *Server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	messagesByKey := make(map[messageKey]uint64, len(s.messagesByKey))
+	for k, v := range s.messagesByKey {
+		messagesByKey[k] = v
+	}
+	parseErrors := s.parseErrors
+	correlationHits := s.correlationHits
+	correlationMisses := s.correlationMisses
+	fsBufferDrops := s.fsBufferDrops
+	s.mu.Unlock()
+
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "# HELP mcpspy_mcp_messages_total Total number of parsed MCP messages, by method and transport.")
+	fmt.Fprintln(&b, "# TYPE mcpspy_mcp_messages_total counter")
+	keys := make([]messageKey, 0, len(messagesByKey))
+	for k := range messagesByKey {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].transport < keys[j].transport
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&b, "mcpspy_mcp_messages_total{method=%q,transport=%q} %d\n", k.method, k.transport, messagesByKey[k])
+	}
+
+	fmt.Fprintln(&b, "# HELP mcpspy_parse_errors_total Total number of stdio/HTTP payloads that failed to parse as JSON-RPC.")
+	fmt.Fprintln(&b, "# TYPE mcpspy_parse_errors_total counter")
+	fmt.Fprintf(&b, "mcpspy_parse_errors_total %d\n", parseErrors)
+
+	fmt.Fprintln(&b, "# HELP mcpspy_correlation_hits_total Total number of responses successfully correlated with their request.")
+	fmt.Fprintln(&b, "# TYPE mcpspy_correlation_hits_total counter")
+	fmt.Fprintf(&b, "mcpspy_correlation_hits_total %d\n", correlationHits)
+
+	fmt.Fprintln(&b, "# HELP mcpspy_correlation_misses_total Total number of requests evicted from the correlation cache without ever receiving a response.")
+	fmt.Fprintln(&b, "# TYPE mcpspy_correlation_misses_total counter")
+	fmt.Fprintf(&b, "mcpspy_correlation_misses_total %d\n", correlationMisses)
+
+	fmt.Fprintln(&b, "# HELP mcpspy_fs_buffer_dropped_total Total number of stdio reassembly buffers discarded before a complete JSON message could be decoded, because they exceeded the per-session size cap.")
+	fmt.Fprintln(&b, "# TYPE mcpspy_fs_buffer_dropped_total counter")
+	fmt.Fprintf(&b, "mcpspy_fs_buffer_dropped_total %d\n", fsBufferDrops)
+
+	if s.config.LibraryStats != nil {
+		hooked, failed := s.config.LibraryStats()
+		fmt.Fprintln(&b, "# HELP mcpspy_ssl_libraries_hooked Current number of SSL libraries with probes attached.")
+		fmt.Fprintln(&b, "# TYPE mcpspy_ssl_libraries_hooked gauge")
+		fmt.Fprintf(&b, "mcpspy_ssl_libraries_hooked %d\n", hooked)
+		fmt.Fprintln(&b, "# HELP mcpspy_ssl_libraries_failed Current number of SSL libraries that failed to attach.")
+		fmt.Fprintln(&b, "# TYPE mcpspy_ssl_libraries_failed gauge")
+		fmt.Fprintf(&b, "mcpspy_ssl_libraries_failed %d\n", failed)
+	}
+
+	if s.config.EBPFReadErrors != nil {
+		fmt.Fprintln(&b, "# HELP mcpspy_ebpf_ringbuf_read_errors_total Total number of eBPF ring buffer read errors.")
+		fmt.Fprintln(&b, "# TYPE mcpspy_ebpf_ringbuf_read_errors_total counter")
+		fmt.Fprintf(&b, "mcpspy_ebpf_ringbuf_read_errors_total %d\n", s.config.EBPFReadErrors())
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// Close unsubscribes from the event bus and shuts the HTTP server down,
+// waiting for in-flight requests to finish or ctx to be cancelled.
+func (s *Server) Close(ctx context.Context) error {
+	s.eventBus.Unsubscribe(event.EventTypeMCPMessage, s.recordMessage)
+	s.eventBus.Unsubscribe(event.EventTypeParseError, s.recordParseError)
+	s.eventBus.Unsubscribe(event.EventTypeRequestTimeout, s.recordTimeout)
+	return s.httpSrv.Shutdown(ctx)
+}
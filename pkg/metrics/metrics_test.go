@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestServer_RendersCounters(t *testing.T) {
+	eventBus := bus.New()
+	defer eventBus.Close()
+
+	server, err := New(eventBus, Config{
+		Addr:         "127.0.0.1:0",
+		LibraryStats: func() (int, int) { return 2, 1 },
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		server.Close(ctx)
+	}()
+
+	eventBus.Publish(&event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+		},
+		TransportType: event.TransportTypeStdio,
+	})
+	eventBus.Publish(&event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			Method:      "tools/call",
+			Request:     &event.JSONRPCMessage{Method: "tools/call"},
+		},
+		TransportType: event.TransportTypeStdio,
+	})
+	eventBus.Publish(&event.ParseErrorEvent{TransportType: event.TransportTypeStdio})
+	eventBus.Publish(&event.RequestTimeoutEvent{Method: "tools/list"})
+	eventBus.Publish(&event.FSBufferDroppedEvent{})
+
+	time.Sleep(50 * time.Millisecond)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	server.handleMetrics(rec, req)
+
+	body := rec.Body.String()
+	checks := []string{
+		`mcpspy_mcp_messages_total{method="tools/call",transport="stdio"} 2`,
+		"mcpspy_parse_errors_total 1",
+		"mcpspy_correlation_hits_total 1",
+		"mcpspy_correlation_misses_total 1",
+		"mcpspy_ssl_libraries_hooked 2",
+		"mcpspy_ssl_libraries_failed 1",
+		"mcpspy_fs_buffer_dropped_total 1",
+	}
+	for _, want := range checks {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics output to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestNew_RequiresAddr(t *testing.T) {
+	eventBus := bus.New()
+	defer eventBus.Close()
+
+	if _, err := New(eventBus, Config{}); err == nil {
+		t.Error("expected an error when Addr is empty")
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/alex-ilgayev/mcpspy/pkg/bus"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/alex-ilgayev/mcpspy/pkg/latency"
 	"github.com/alex-ilgayev/mcpspy/pkg/mcp"
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
@@ -18,10 +20,63 @@ import (
 // Subscribes to the following events:
 // - EventTypeMCPMessage
 // - EventTypeLLMMessage
+// - EventTypeSecurityAlert
+// - EventTypeToolUsage
+// - EventTypeToolCallCorrelation
+// - EventTypeBridgeCorrelation
+// - EventTypeRequestTimeout
+// - EventTypeAlert
+// - EventTypeToolCallResult
+// - EventTypeLargeToolArgs
+// - EventTypeAggregatorEdge
+// - EventTypeProtocolViolation
+// - EventTypeProtocolVersionMismatch
+// - EventTypeGRPCDetected
+// - EventTypeBaselineAnomaly
+// - EventTypeUnexpectedEgress
+// - EventTypeRuleMatch
 type ConsoleDisplay struct {
 	writer      io.Writer
 	showBuffers bool
 	eventBus    bus.EventBus
+
+	// pidFilter, when set, restricts output to events attributable to a PID
+	// it accepts (e.g. scoping to a process tree in `mcpspy run`). Nil means
+	// no filtering.
+	pidFilter func(pid uint32) bool
+
+	// bufferMethods, when set, restricts full raw-buffer printing (under
+	// showBuffers) to these JSON-RPC methods; other methods still get the
+	// compact summary line. Nil means no restriction.
+	bufferMethods map[string]bool
+
+	// suppressSuccessfulPings, when true, hides ping requests and their
+	// successful pong responses from output. Pings that time out are still
+	// surfaced via printRequestTimeout, and all pings remain counted by any
+	// subscriber reading directly off the event bus (e.g. session stats).
+	suppressSuccessfulPings bool
+
+	// redactPatterns restricts which JSON keys in printed buffers have their
+	// values masked. Nil or empty disables redaction.
+	redactPatterns []string
+
+	// showArgs, when true, renders a compact summary of a tools/call
+	// request's arguments inline next to the tool name (--show-args).
+	showArgs bool
+
+	// quiet, when true, suppresses per-message output entirely. Intended for
+	// --summary-only CI runs where only the final PrintSummary line matters.
+	quiet bool
+
+	// plain, when true, replaces the colorized multi-line message layout
+	// with one uncolored, unboxed line per event ("timestamp transport
+	// from->to type method"), for piping to grep/less/log collectors.
+	plain bool
+
+	// pidCommFilter, when set, restricts output to MCPEvents attributable to
+	// a PID or process name it accepts (--pid/--comm). Nil means no
+	// filtering.
+	pidCommFilter *pidCommFilter
 }
 
 // NewConsoleDisplay creates a new display handler for console output with custom writer
@@ -52,36 +107,259 @@ func NewConsoleDisplay(writer io.Writer, showBuffers bool, eventBus bus.EventBus
 		return nil, err
 	}
 
+	// Subscribe to tool call correlation events
+	if err := eventBus.Subscribe(event.EventTypeToolCallCorrelation, d.printToolCallCorrelation); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to bridge correlation events
+	if err := eventBus.Subscribe(event.EventTypeBridgeCorrelation, d.printBridgeCorrelation); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to request timeouts, so a dead peer (e.g. one that stops
+	// answering pings) is surfaced even when the request that exposed it
+	// would otherwise be suppressed (--suppress-pings).
+	if err := eventBus.Subscribe(event.EventTypeRequestTimeout, d.printRequestTimeout); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to error-rate alerts
+	if err := eventBus.Subscribe(event.EventTypeAlert, d.printAlert); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to tool call results
+	if err := eventBus.Subscribe(event.EventTypeToolCallResult, d.printToolCallResult); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to oversized tool call arguments
+	if err := eventBus.Subscribe(event.EventTypeLargeToolArgs, d.printLargeToolArgs); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to aggregator/gateway fan-out edges
+	if err := eventBus.Subscribe(event.EventTypeAggregatorEdge, d.printAggregatorEdge); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to initialize handshake violations
+	if err := eventBus.Subscribe(event.EventTypeProtocolViolation, d.printProtocolViolation); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to negotiated protocol version mismatches
+	if err := eventBus.Subscribe(event.EventTypeProtocolVersionMismatch, d.printProtocolVersionMismatch); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to gRPC detection on otherwise-unparsed HTTP/2 connections
+	if err := eventBus.Subscribe(event.EventTypeGRPCDetected, d.printGRPCDetected); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to baseline deviation anomalies
+	if err := eventBus.Subscribe(event.EventTypeBaselineAnomaly, d.printBaselineAnomaly); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to MCP servers contacting unexpected external hosts
+	if err := eventBus.Subscribe(event.EventTypeUnexpectedEgress, d.printUnexpectedEgress); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to user-defined rule matches
+	if err := eventBus.Subscribe(event.EventTypeRuleMatch, d.printRuleMatch); err != nil {
+		return nil, err
+	}
+
 	return d, nil
 }
 
-// Colors for different elements
+// SetPIDFilter restricts subsequent output to events attributable to a PID
+// accepted by filter. Pass nil to remove any existing filter.
+func (d *ConsoleDisplay) SetPIDFilter(filter func(pid uint32) bool) {
+	d.pidFilter = filter
+}
+
+// SetSuppressSuccessfulPings controls whether ping requests and their
+// successful pong responses are hidden from output.
+func (d *ConsoleDisplay) SetSuppressSuccessfulPings(suppress bool) {
+	d.suppressSuccessfulPings = suppress
+}
+
+// SetBufferMethods restricts which methods' raw buffers are printed when
+// showBuffers is enabled; other methods still show the compact summary
+// line. Pass nil or an empty slice to print buffers for every method.
+func (d *ConsoleDisplay) SetBufferMethods(methods []string) {
+	if len(methods) == 0 {
+		d.bufferMethods = nil
+		return
+	}
+	set := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		set[method] = true
+	}
+	d.bufferMethods = set
+}
+
+// SetRedactPatterns controls which JSON keys in printed buffers have their
+// values masked with RedactedPlaceholder, matched case-insensitively by
+// substring. Pass nil or an empty slice to disable redaction.
+func (d *ConsoleDisplay) SetRedactPatterns(patterns []string) {
+	d.redactPatterns = patterns
+}
+
+// SetShowArgs controls whether a tools/call request's arguments are
+// rendered inline next to the tool name (--show-args).
+func (d *ConsoleDisplay) SetShowArgs(show bool) {
+	d.showArgs = show
+}
+
+// SetQuiet suppresses per-message output when quiet is true, leaving
+// PrintHeader/PrintInfo/PrintStats/PrintSummary as the only output. Intended
+// for --summary-only CI runs.
+func (d *ConsoleDisplay) SetQuiet(quiet bool) {
+	d.quiet = quiet
+}
+
+// SetPlain switches to a compact, uncolored, one-line-per-event format
+// ("timestamp transport from->to type method") with no box-drawing, for
+// piping to grep/less/log collectors. It does not affect --output JSONL.
+func (d *ConsoleDisplay) SetPlain(plain bool) {
+	d.plain = plain
+}
+
+// SetPIDCommFilter restricts subsequent output to MCPEvents attributable to
+// one of pids or whose process name contains one of comms (case-insensitive
+// substring match); both are ANDed together when non-empty. Pass two nil/empty
+// slices to remove any existing filter.
+func (d *ConsoleDisplay) SetPIDCommFilter(pids []uint32, comms []string) {
+	d.pidCommFilter = newPIDCommFilter(pids, comms)
+}
+
+// acceptsBufferMethod reports whether msg's raw buffer should be printed
+// given the current buffer method restriction. Responses don't carry their
+// own method, so the originating request's method is used instead.
+func (d *ConsoleDisplay) acceptsBufferMethod(msg *event.MCPEvent) bool {
+	if d.bufferMethods == nil {
+		return true
+	}
+	method := msg.Method
+	if method == "" && msg.Request != nil {
+		method = msg.Request.Method
+	}
+	return d.bufferMethods[method]
+}
+
+// acceptsMessage reports whether msg should be displayed given the current
+// PID filter and ping suppression setting.
+func (d *ConsoleDisplay) acceptsMessage(msg *event.MCPEvent) bool {
+	if d.suppressSuccessfulPings && isSuccessfulPing(msg) {
+		return false
+	}
+
+	if !d.pidCommFilter.Accepts(msg) {
+		return false
+	}
+
+	if d.pidFilter == nil {
+		return true
+	}
+	switch msg.TransportType {
+	case event.TransportTypeStdio:
+		if msg.StdioTransport != nil {
+			return d.pidFilter(msg.FromPID) || d.pidFilter(msg.ToPID)
+		}
+	case event.TransportTypeHTTP:
+		if msg.HttpTransport != nil {
+			return d.pidFilter(msg.HttpTransport.PID)
+		}
+	}
+	return true
+}
+
+// isSuccessfulPing reports whether msg is one half of a ping/pong keepalive
+// pair that completed without error: either the "ping" request itself, or a
+// response to one that didn't come back as a JSON-RPC error. Ping requests
+// that never get a response are reported separately as RequestTimeoutEvents,
+// which printRequestTimeout always surfaces regardless of suppression.
+func isSuccessfulPing(msg *event.MCPEvent) bool {
+	switch msg.MessageType {
+	case event.JSONRPCMessageTypeRequest:
+		return msg.Method == "ping"
+	case event.JSONRPCMessageTypeResponse:
+		return msg.Request != nil && msg.Request.Method == "ping" && msg.Error.Message == ""
+	default:
+		return false
+	}
+}
+
+// Colors for different elements. These are repointed at activeTheme's
+// fields by applyActiveTheme (called from init and from SetColorTheme), so
+// every call site below stays untouched when the theme changes.
 var (
-	timestampColor     = color.New(color.FgHiBlack)
-	transportColor     = color.New(color.FgHiCyan)
-	pidColor           = color.New(color.FgCyan)
-	commColor          = color.New(color.FgYellow)
-	methodColor        = color.New(color.FgGreen)
-	errorColor         = color.New(color.FgRed)
-	errorCodeColor     = color.New(color.FgHiRed)
-	headerColor        = color.New(color.FgWhite, color.Bold)
-	idColor            = color.New(color.FgHiBlack)
-	securityAlertColor = color.New(color.FgRed, color.Bold)
-	securityWarnColor  = color.New(color.FgYellow, color.Bold)
-	securityLowColor   = color.New(color.FgYellow)
-	llmModelColor      = color.New(color.FgMagenta)
-	toolColor          = color.New(color.FgCyan, color.Bold)
+	timestampColor     *color.Color
+	transportColor     *color.Color
+	pidColor           *color.Color
+	commColor          *color.Color
+	methodColor        *color.Color
+	errorColor         *color.Color
+	errorCodeColor     *color.Color
+	errorLabelColor    *color.Color
+	validationErrColor *color.Color
+	headerColor        *color.Color
+	idColor            *color.Color
+	securityAlertColor *color.Color
+	securityWarnColor  *color.Color
+	securityLowColor   *color.Color
+	llmModelColor      *color.Color
+	toolColor          *color.Color
+	passColor          *color.Color
+	failColor          *color.Color
+
+	// Colors for notifications/message log levels (RFC 5424-style severities)
+	logLevelErrorColor *color.Color
+	logLevelWarnColor  *color.Color
+	logLevelDimColor   *color.Color
 )
 
-// PrintHeader prints the MCPSpy header
+func init() {
+	applyActiveTheme()
+}
+
+// logLevelColor returns the display color for an MCP server log level
+// (notifications/message's "level" param), or nil if the level is unset or
+// unrecognized, in which case the default method color should be used.
+func logLevelColor(level string) *color.Color {
+	switch level {
+	case "error", "critical", "alert", "emergency":
+		return logLevelErrorColor
+	case "warning", "notice":
+		return logLevelWarnColor
+	case "info", "debug":
+		return logLevelDimColor
+	default:
+		return nil
+	}
+}
+
+// PrintHeader prints the MCPSpy header. In plain mode the ASCII-art banner
+// and box-drawing rule are skipped in favor of a single plain line.
 func (d *ConsoleDisplay) PrintHeader() {
+	if d.plain {
+		fmt.Fprintln(d.writer, "MCPSpy - Monitoring Model Context Protocol Communication")
+		return
+	}
+
 	header := `
 ███╗   ███╗ ██████╗██████╗ ███████╗██████╗ ██╗   ██╗
 ████╗ ████║██╔════╝██╔══██╗██╔════╝██╔══██╗╚██╗ ██╔╝
-██╔████╔██║██║     ██████╔╝███████╗██████╔╝ ╚████╔╝ 
-██║╚██╔╝██║██║     ██╔═══╝ ╚════██║██╔═══╝   ╚██╔╝  
-██║ ╚═╝ ██║╚██████╗██║     ███████║██║        ██║   
-╚═╝     ╚═╝ ╚═════╝╚═╝     ╚══════╝╚═╝        ╚═╝   
+██╔████╔██║██║     ██████╔╝███████╗██████╔╝ ╚████╔╝
+██║╚██╔╝██║██║     ██╔═══╝ ╚════██║██╔═══╝   ╚██╔╝
+██║ ╚═╝ ██║╚██████╗██║     ███████║██║        ██║
+╚═╝     ╚═╝ ╚═════╝╚═╝     ╚══════╝╚═╝        ╚═╝
 `
 	headerColor.Fprintln(d.writer, header)
 	fmt.Fprintln(d.writer, "MCP Protocol Spy - Monitoring Model Context Protocol Communication")
@@ -107,6 +385,84 @@ func (d *ConsoleDisplay) PrintStats(stats map[string]int) {
 	table.Render()
 }
 
+// PrintDuplicates reports how many duplicate hops of an already-seen
+// message were folded during the run instead of being emitted as their own
+// MCPEvent (see mcp.Config.ShowDupes to emit every hop instead).
+func (d *ConsoleDisplay) PrintDuplicates(count int) {
+	fmt.Fprintf(d.writer, "Duplicate hops folded: %d\n", count)
+}
+
+// PrintSummary prints a compact one-line summary of a run, for --summary-only
+// CI use where a full event stream or statistics table is too verbose.
+// expectNoErrors, when true, fails the line if errorCount > 0.
+func (d *ConsoleDisplay) PrintSummary(total, methods, errorCount int, duration time.Duration, expectNoErrors bool) {
+	result := ""
+	if expectNoErrors {
+		if errorCount > 0 {
+			result = fmt.Sprintf(" result=%s", failColor.Sprint("FAIL"))
+		} else {
+			result = fmt.Sprintf(" result=%s", passColor.Sprint("PASS"))
+		}
+	}
+
+	fmt.Fprintf(d.writer, "Summary: messages=%d methods=%d errors=%d duration=%s%s\n",
+		total, methods, errorCount, duration.Round(time.Millisecond), result)
+}
+
+// PrintLatencyHistogram prints a table of request→response latency
+// percentiles, overall and per method. dropped is the number of requests
+// evicted from the correlation cache without ever receiving a response;
+// these are excluded from the percentiles above and reported separately.
+func (d *ConsoleDisplay) PrintLatencyHistogram(overall latency.Percentiles, byMethod map[string]latency.Percentiles, dropped int) {
+	fmt.Fprintln(d.writer, "\n"+strings.Repeat("─", 80))
+	headerColor.Fprintln(d.writer, "Latency (request → response):")
+
+	table := tablewriter.NewWriter(d.writer)
+	table.SetHeader([]string{"Method", "Count", "p50", "p90", "p99"})
+	table.SetBorder(false)
+	table.SetColumnSeparator("│")
+	table.SetRowSeparator("─")
+	table.SetHeaderLine(true)
+
+	table.Append([]string{"(overall)", fmt.Sprintf("%d", overall.Count), overall.P50.Round(time.Millisecond).String(), overall.P90.Round(time.Millisecond).String(), overall.P99.Round(time.Millisecond).String()})
+	for method, p := range byMethod {
+		table.Append([]string{method, fmt.Sprintf("%d", p.Count), p.P50.Round(time.Millisecond).String(), p.P90.Round(time.Millisecond).String(), p.P99.Round(time.Millisecond).String()})
+	}
+
+	table.Render()
+	fmt.Fprintf(d.writer, "Dropped (never answered): %d\n", dropped)
+}
+
+// PayloadSizeStats summarizes the average size of the raw "params"/"result"
+// JSON values seen for a method, for PrintPayloadSizes.
+type PayloadSizeStats struct {
+	AvgParamsBytes float64
+	ParamsCount    int
+	AvgResultBytes float64
+	ResultCount    int
+}
+
+// PrintPayloadSizes prints a table of average params/result payload size per
+// method, to help identify bandwidth-heavy methods/tools. Methods that never
+// carried a params or result value (e.g. ping) show 0 for that column.
+func (d *ConsoleDisplay) PrintPayloadSizes(byMethod map[string]PayloadSizeStats) {
+	fmt.Fprintln(d.writer, "\n"+strings.Repeat("─", 80))
+	headerColor.Fprintln(d.writer, "Payload sizes (avg bytes, from raw JSON):")
+
+	table := tablewriter.NewWriter(d.writer)
+	table.SetHeader([]string{"Method", "Avg Params", "Avg Result"})
+	table.SetBorder(false)
+	table.SetColumnSeparator("│")
+	table.SetRowSeparator("─")
+	table.SetHeaderLine(true)
+
+	for method, s := range byMethod {
+		table.Append([]string{method, fmt.Sprintf("%.0f", s.AvgParamsBytes), fmt.Sprintf("%.0f", s.AvgResultBytes)})
+	}
+
+	table.Render()
+}
+
 // PrintInfo prints an info message
 func (d *ConsoleDisplay) PrintInfo(format string, args ...interface{}) {
 	fmt.Fprintf(d.writer, format+"\n", args...)
@@ -119,6 +475,10 @@ func (d *ConsoleDisplay) printMessage(e event.Event) {
 		return
 	}
 
+	if d.quiet || !d.acceptsMessage(msg) {
+		return
+	}
+
 	// Format timestamp
 	ts := timestampColor.Sprint(msg.Timestamp.Format("15:04:05.000"))
 	fmt.Fprintf(d.writer, "%s ", ts)
@@ -132,44 +492,84 @@ func (d *ConsoleDisplay) printMessage(e event.Event) {
 	// Print a new line after the message info
 	fmt.Fprintln(d.writer)
 
-	// Print buffer content if requested
-	if d.showBuffers && msg.Raw != "" {
+	// Print any --strict schema violations, in red, right under the message
+	// it belongs to. The message itself was already emitted above - this
+	// only flags it, it's never dropped for failing validation.
+	for _, v := range msg.ValidationErrors {
+		fmt.Fprintf(d.writer, "  %s %s\n", validationErrColor.Sprint("⚠ schema:"), validationErrColor.Sprint(v))
+	}
+
+	// Print buffer content if requested, optionally restricted to selected
+	// methods (--buffer-methods) to avoid overwhelming output for
+	// high-frequency methods.
+	if d.showBuffers && msg.Raw != "" && d.acceptsBufferMethod(msg) {
 		d.printBuffer(msg.Raw)
 	}
 }
 
+// containerIDDisplayLen is how many characters of a container ID are shown,
+// matching the short form the Docker CLI itself uses.
+const containerIDDisplayLen = 12
+
+// pidLabel formats pid for display, appending "@<container>" (shortened to
+// containerIDDisplayLen) when the hop was resolved to a container.
+func pidLabel(pid uint32, containerID string) string {
+	if containerID == "" {
+		return fmt.Sprintf("%d", pid)
+	}
+	if len(containerID) > containerIDDisplayLen {
+		containerID = containerID[:containerIDDisplayLen]
+	}
+	return fmt.Sprintf("%d@%s", pid, containerID)
+}
+
 // printCommFlow formats the communication flow for a given message
 // Format: [transport] [from] → [to]
 func (d *ConsoleDisplay) printCommFlow(msg *event.MCPEvent) {
 	var commFlow string
 
 	switch msg.TransportType {
-	case event.TransportTypeStdio:
+	case event.TransportTypeStdio, event.TransportTypeUnixSocket:
 		if msg.StdioTransport != nil {
+			label := "STDIO"
+			if msg.TransportType == event.TransportTypeUnixSocket {
+				label = "UNIXSOCK"
+			}
 			commFlow = fmt.Sprintf("%s %s[%s] → %s[%s]",
-				transportColor.Sprint("STDIO"),
+				transportColor.Sprint(label),
 				commColor.Sprint(msg.FromComm),
-				pidColor.Sprint(msg.FromPID),
+				pidColor.Sprint(pidLabel(msg.FromPID, msg.StdioTransport.FromContainerID)),
 				commColor.Sprint(msg.ToComm),
-				pidColor.Sprint(msg.ToPID),
+				pidColor.Sprint(pidLabel(msg.ToPID, msg.StdioTransport.ToContainerID)),
 			)
 		} else {
 			logrus.Warnf("unknown stdio transport: %v", msg.StdioTransport)
 			commFlow = transportColor.Sprint("UNKN")
 		}
-	case event.TransportTypeHTTP:
+	case event.TransportTypeHTTP, event.TransportTypeWebSocket:
 		if msg.HttpTransport != nil {
+			label := "HTTP"
+			if msg.TransportType == event.TransportTypeWebSocket {
+				label = "WS"
+			}
+			if msg.HttpTransport.HTTPMethod != "" && msg.HttpTransport.Path != "" {
+				label = fmt.Sprintf("%s %s %s", label, msg.HttpTransport.HTTPMethod, msg.HttpTransport.Path)
+			}
+			host := msg.HttpTransport.Host
+			if msg.HttpTransport.RemoteAddr != "" {
+				host = fmt.Sprintf("%s (%s)", host, msg.HttpTransport.RemoteAddr)
+			}
 			if msg.HttpTransport.IsRequest {
 				commFlow = fmt.Sprintf("%s %s[%s] → %s",
-					transportColor.Sprint("HTTP"),
+					transportColor.Sprint(label),
 					commColor.Sprint(msg.HttpTransport.Comm),
 					pidColor.Sprint(msg.HttpTransport.PID),
-					commColor.Sprint(msg.HttpTransport.Host),
+					commColor.Sprint(host),
 				)
 			} else {
 				commFlow = fmt.Sprintf("%s %s → %s[%s]",
-					transportColor.Sprint("HTTP"),
-					commColor.Sprint(msg.HttpTransport.Host),
+					transportColor.Sprint(label),
+					commColor.Sprint(host),
 					commColor.Sprint(msg.HttpTransport.Comm),
 					pidColor.Sprint(msg.HttpTransport.PID),
 				)
@@ -198,19 +598,47 @@ func (d *ConsoleDisplay) printMessageInfo(msg *event.MCPEvent) {
 			if toolName := msg.ExtractToolName(); toolName != "" {
 				msgInfo += fmt.Sprintf(" (%s)", toolName)
 			}
+			if d.showArgs {
+				if summary := d.toolArgsSummary(msg); summary != "" {
+					msgInfo += fmt.Sprintf(" [%s]", summary)
+				}
+			}
 		case "resources/read":
 			if uri := msg.ExtractResourceURI(); uri != "" {
 				msgInfo += fmt.Sprintf(" (%s)", uri)
 			}
+		case "sampling/createMessage":
+			if sr, ok := msg.ExtractSamplingRequest(); ok {
+				if hint := msg.ExtractSamplingModelHint(); hint != "" {
+					msgInfo += fmt.Sprintf(" (%s, maxTokens=%d)", hint, sr.MaxTokens)
+				} else {
+					msgInfo += fmt.Sprintf(" (maxTokens=%d)", sr.MaxTokens)
+				}
+			}
+		case "elicitation/create":
+			if er, ok := msg.ExtractElicitationRequest(); ok && er.Message != "" {
+				msgInfo += fmt.Sprintf(" (%s)", er.Message)
+			}
 		}
 	case event.JSONRPCMessageTypeResponse:
 		if msg.Error.Message != "" {
-			msgInfo = fmt.Sprintf("%s ERR  %s %s", idColor.Sprint(fmt.Sprintf("[%v]", msg.ID)), errorColor.Sprint(msg.Error.Message), errorCodeColor.Sprintf("(Code: %d)", msg.Error.Code))
+			codeInfo := fmt.Sprintf("(Code: %d, %s)", msg.Error.Code, mcp.GetErrorCodeDescription(msg.Error.Code))
+			msgInfo = fmt.Sprintf("%s %s %s %s", idColor.Sprint(fmt.Sprintf("[%v]", msg.ID)), errorLabelColor.Sprint("ERR"), errorColor.Sprint(msg.Error.Message), errorCodeColor.Sprint(codeInfo))
+		} else if er, ok := msg.ExtractElicitationResponse(); ok {
+			msgInfo = fmt.Sprintf("%s RESP OK (elicitation: %s)", idColor.Sprint(fmt.Sprintf("[%v]", msg.ID)), er.Action)
 		} else {
 			msgInfo = fmt.Sprintf("%s RESP OK", idColor.Sprint(fmt.Sprintf("[%v]", msg.ID)))
 		}
 	case event.JSONRPCMessageTypeNotification:
-		msgInfo = fmt.Sprintf("%s NOTF %s", idColor.Sprint("[-]"), methodColor.Sprint(msg.Method))
+		methodText := methodColor.Sprint(msg.Method)
+		level, _ := msg.Params["level"].(string)
+		if lc := logLevelColor(level); lc != nil {
+			methodText = lc.Sprint(msg.Method)
+		}
+		msgInfo = fmt.Sprintf("%s NOTF %s", idColor.Sprint("[-]"), methodText)
+		if summary := msg.ExtractNotificationSummary(); summary != "" {
+			msgInfo += fmt.Sprintf(" (%s)", summary)
+		}
 	default:
 		msgInfo = "UNKN"
 	}
@@ -222,6 +650,39 @@ func (d *ConsoleDisplay) printMessageInfo(msg *event.MCPEvent) {
 	fmt.Fprintf(d.writer, "%s ", msgInfo)
 }
 
+// toolArgsSummary returns msg's tool-call argument summary (see
+// --show-args) with any argument key matching d.redactPatterns masked.
+// Redaction runs on a round-tripped copy of the arguments so the live
+// event's Params, shared with every other subscriber, is never mutated.
+func (d *ConsoleDisplay) toolArgsSummary(msg *event.MCPEvent) string {
+	if len(d.redactPatterns) == 0 {
+		return msg.ExtractToolArgsSummary()
+	}
+
+	args, ok := msg.Params["arguments"]
+	if !ok {
+		return ""
+	}
+
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return msg.ExtractToolArgsSummary()
+	}
+
+	var redacted map[string]interface{}
+	if err := json.Unmarshal(redactJSONBytes(raw, d.redactPatterns), &redacted); err != nil {
+		return msg.ExtractToolArgsSummary()
+	}
+
+	redactedMsg := &event.MCPEvent{
+		JSONRPCMessage: event.JSONRPCMessage{
+			Method: msg.Method,
+			Params: map[string]interface{}{"arguments": redacted},
+		},
+	}
+	return redactedMsg.ExtractToolArgsSummary()
+}
+
 // printBuffer prints the raw message content with proper JSON formatting
 func (d *ConsoleDisplay) printBuffer(content string) {
 	// Try to parse and pretty-print JSON
@@ -229,7 +690,8 @@ func (d *ConsoleDisplay) printBuffer(content string) {
 	var jsonObj interface{}
 
 	if err := json.Unmarshal([]byte(content), &jsonObj); err == nil {
-		// Valid JSON - pretty print it
+		// Valid JSON - pretty print it, masking any sensitive fields first
+		jsonObj = redactJSON(jsonObj, d.redactPatterns)
 		if prettyBytes, err := json.MarshalIndent(jsonObj, "", "  "); err == nil {
 			prettyContent = string(prettyBytes)
 		} else {
@@ -243,6 +705,17 @@ func (d *ConsoleDisplay) printBuffer(content string) {
 	// Split into lines and print with consistent formatting
 	lines := strings.Split(prettyContent, "\n")
 
+	// Plain mode drops the Unicode box-drawing border so output stays
+	// grep/less-friendly; the lines themselves are printed as-is.
+	if d.plain {
+		for _, line := range lines {
+			if line != "" {
+				fmt.Fprintln(d.writer, line)
+			}
+		}
+		return
+	}
+
 	// Print top border
 	fmt.Fprintln(d.writer, "┌────")
 
@@ -264,6 +737,10 @@ func (d *ConsoleDisplay) printSecurityAlert(e event.Event) {
 		return
 	}
 
+	if !d.acceptsMessage(alert.MCPEvent) {
+		return
+	}
+
 	// Format timestamp
 	ts := timestampColor.Sprint(alert.Timestamp.Format("15:04:05.000"))
 
@@ -292,6 +769,295 @@ func (d *ConsoleDisplay) printSecurityAlert(e event.Event) {
 	fmt.Fprintf(d.writer, "  Content:     %s\n\n", alert.AnalyzedText)
 }
 
+// printRequestTimeout prints a prominent warning for a request that never
+// received a response, most commonly a ping to a peer that's stopped
+// responding. Shown regardless of --suppress-pings, since a timeout is
+// exactly the diagnostically valuable case that flag is meant to preserve.
+func (d *ConsoleDisplay) printRequestTimeout(e event.Event) {
+	timeout, ok := e.(*event.RequestTimeoutEvent)
+	if !ok {
+		return
+	}
+
+	if d.pidFilter != nil {
+		switch timeout.TransportType {
+		case event.TransportTypeStdio:
+			if timeout.StdioTransport != nil && !d.pidFilter(timeout.StdioTransport.FromPID) && !d.pidFilter(timeout.StdioTransport.ToPID) {
+				return
+			}
+		case event.TransportTypeHTTP:
+			if timeout.HttpTransport != nil && !d.pidFilter(timeout.HttpTransport.PID) {
+				return
+			}
+		}
+	}
+
+	ts := timestampColor.Sprint(timeout.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s %s %s %s timed out after %s, id=%v\n",
+		ts,
+		securityWarnColor.Sprint("[!]"),
+		methodColor.Sprint(timeout.Method),
+		securityWarnColor.Sprint("did not receive a response"),
+		timeout.TTL,
+		timeout.ID,
+	)
+}
+
+// printAlert prints a prominent warning when the error rate for a method
+// (or overall, when Method is "") crosses its configured threshold.
+func (d *ConsoleDisplay) printAlert(e event.Event) {
+	alert, ok := e.(*event.AlertEvent)
+	if !ok {
+		return
+	}
+
+	scope := alert.Method
+	if scope == "" {
+		scope = "all methods"
+	}
+
+	ts := timestampColor.Sprint(alert.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s %s ERROR RATE ALERT: %s is at %.0f%% errors (%d/%d) over the last %s, above the %.0f%% threshold\n",
+		ts,
+		securityAlertColor.Sprint("[!]"),
+		methodColor.Sprint(scope),
+		alert.ErrorRate*100,
+		alert.Errors,
+		alert.Total,
+		alert.Window,
+		alert.Threshold*100,
+	)
+}
+
+// printToolCallResult prints a concise tool-timing line once a tools/call
+// request has been paired with its response.
+func (d *ConsoleDisplay) printToolCallResult(e event.Event) {
+	result, ok := e.(*event.ToolCallResultEvent)
+	if !ok {
+		return
+	}
+
+	if d.pidFilter != nil {
+		switch result.TransportType {
+		case event.TransportTypeStdio:
+			if result.StdioTransport != nil && !d.pidFilter(result.StdioTransport.FromPID) && !d.pidFilter(result.StdioTransport.ToPID) {
+				return
+			}
+		case event.TransportTypeHTTP:
+			if result.HttpTransport != nil && !d.pidFilter(result.HttpTransport.PID) {
+				return
+			}
+		}
+	}
+
+	status := "ok"
+	statusColored := methodColor.Sprint(status)
+	if result.IsError {
+		status = "error"
+		statusColored = errorColor.Sprint(status)
+	}
+
+	ts := timestampColor.Sprint(result.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s tool call %s took %dms (%s)\n",
+		ts,
+		toolColor.Sprint(result.ToolName),
+		result.DurationMs,
+		statusColored,
+	)
+}
+
+// printLargeToolArgs warns when a tools/call request's arguments exceed the
+// configured size threshold.
+func (d *ConsoleDisplay) printLargeToolArgs(e event.Event) {
+	warning, ok := e.(*event.LargeToolArgsEvent)
+	if !ok {
+		return
+	}
+
+	if d.pidFilter != nil {
+		switch warning.TransportType {
+		case event.TransportTypeStdio:
+			if warning.StdioTransport != nil && !d.pidFilter(warning.StdioTransport.FromPID) && !d.pidFilter(warning.StdioTransport.ToPID) {
+				return
+			}
+		case event.TransportTypeHTTP:
+			if warning.HttpTransport != nil && !d.pidFilter(warning.HttpTransport.PID) {
+				return
+			}
+		}
+	}
+
+	ts := timestampColor.Sprint(warning.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s %s tool call %s arguments are %d bytes, above the %d byte threshold\n",
+		ts,
+		securityWarnColor.Sprint("[!]"),
+		toolColor.Sprint(warning.ToolName),
+		warning.SizeBytes,
+		warning.Threshold,
+	)
+}
+
+// printProtocolViolation warns when a message is observed out of order
+// relative to the initialize/notifications-initialized handshake.
+func (d *ConsoleDisplay) printProtocolViolation(e event.Event) {
+	violation, ok := e.(*event.ProtocolViolationEvent)
+	if !ok {
+		return
+	}
+
+	if d.pidFilter != nil {
+		switch violation.TransportType {
+		case event.TransportTypeStdio:
+			if violation.StdioTransport != nil && !d.pidFilter(violation.StdioTransport.FromPID) && !d.pidFilter(violation.StdioTransport.ToPID) {
+				return
+			}
+		case event.TransportTypeHTTP:
+			if violation.HttpTransport != nil && !d.pidFilter(violation.HttpTransport.PID) {
+				return
+			}
+		}
+	}
+
+	ts := timestampColor.Sprint(violation.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s %s protocol violation: %s\n",
+		ts,
+		securityWarnColor.Sprint("[!]"),
+		violation.Reason,
+	)
+}
+
+// printProtocolVersionMismatch warns when a session's initialize handshake
+// negotiated a server protocolVersion different from what the client asked
+// for, or either side landed on a deprecated version.
+func (d *ConsoleDisplay) printProtocolVersionMismatch(e event.Event) {
+	mismatch, ok := e.(*event.ProtocolVersionMismatchEvent)
+	if !ok {
+		return
+	}
+
+	if d.pidFilter != nil {
+		switch mismatch.TransportType {
+		case event.TransportTypeStdio:
+			if mismatch.StdioTransport != nil && !d.pidFilter(mismatch.StdioTransport.FromPID) && !d.pidFilter(mismatch.StdioTransport.ToPID) {
+				return
+			}
+		case event.TransportTypeHTTP:
+			if mismatch.HttpTransport != nil && !d.pidFilter(mismatch.HttpTransport.PID) {
+				return
+			}
+		}
+	}
+
+	ts := timestampColor.Sprint(mismatch.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s %s %s\n",
+		ts,
+		securityWarnColor.Sprint("[!]"),
+		mismatch.Reason,
+	)
+}
+
+// printGRPCDetected reports an HTTP/2 connection carrying gRPC traffic, a
+// transport mcpspy can't otherwise parse.
+func (d *ConsoleDisplay) printGRPCDetected(e event.Event) {
+	detected, ok := e.(*event.GRPCDetectedEvent)
+	if !ok {
+		return
+	}
+
+	if d.pidFilter != nil && !d.pidFilter(detected.PID) {
+		return
+	}
+
+	ts := timestampColor.Sprint(time.Now().Format("15:04:05.000"))
+	method := detected.Method
+	if method == "" {
+		method = "<unknown method>"
+	}
+	fmt.Fprintf(d.writer, "%s %s gRPC traffic detected: %s %s[%d]\n",
+		ts,
+		toolColor.Sprint("[grpc]"),
+		methodColor.Sprint(method),
+		detected.Comm(), detected.PID,
+	)
+}
+
+// printBaselineAnomaly warns when a live MCP message uses a method, tool,
+// or host that wasn't present in the recorded --baseline profile.
+func (d *ConsoleDisplay) printBaselineAnomaly(e event.Event) {
+	anomaly, ok := e.(*event.BaselineAnomalyEvent)
+	if !ok {
+		return
+	}
+
+	if d.pidFilter != nil {
+		switch anomaly.TransportType {
+		case event.TransportTypeStdio:
+			if anomaly.StdioTransport != nil && !d.pidFilter(anomaly.StdioTransport.FromPID) && !d.pidFilter(anomaly.StdioTransport.ToPID) {
+				return
+			}
+		case event.TransportTypeHTTP:
+			if anomaly.HttpTransport != nil && !d.pidFilter(anomaly.HttpTransport.PID) {
+				return
+			}
+		}
+	}
+
+	ts := timestampColor.Sprint(anomaly.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s %s baseline anomaly: unexpected %s %q (method %s)\n",
+		ts,
+		securityWarnColor.Sprint("[!]"),
+		anomaly.Kind,
+		anomaly.Value,
+		anomaly.Method,
+	)
+}
+
+// printUnexpectedEgress warns when a PID identified as an MCP server makes
+// an outbound HTTP/TLS request outside its stdio conversation with its
+// client.
+func (d *ConsoleDisplay) printUnexpectedEgress(e event.Event) {
+	egress, ok := e.(*event.UnexpectedEgressEvent)
+	if !ok {
+		return
+	}
+
+	if d.pidFilter != nil && !d.pidFilter(egress.ServerPID) {
+		return
+	}
+
+	ts := timestampColor.Sprint(egress.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s %s MCP server %s[%d] contacted unexpected host: %s %s\n",
+		ts,
+		securityWarnColor.Sprint("[!]"),
+		egress.ServerComm, egress.ServerPID,
+		egress.Method, egress.Host,
+	)
+}
+
+// printRuleMatch highlights a live message that matched a user-defined
+// rule (see pkg/rules) whose action is to alert rather than just log.
+func (d *ConsoleDisplay) printRuleMatch(e event.Event) {
+	match, ok := e.(*event.RuleMatchEvent)
+	if !ok {
+		return
+	}
+
+	ts := timestampColor.Sprint(match.Timestamp.Format("15:04:05.000"))
+	fmt.Fprintf(d.writer, "%s %s RULE MATCH %q: %s",
+		ts,
+		securityAlertColor.Sprint("[!]"),
+		match.Rule,
+		methodColor.Sprint(match.Method),
+	)
+	if match.Tool != "" {
+		fmt.Fprintf(d.writer, " tool=%s", match.Tool)
+	}
+	if match.ResourceURI != "" {
+		fmt.Fprintf(d.writer, " resource=%s", match.ResourceURI)
+	}
+	fmt.Fprintln(d.writer)
+}
+
 // getRiskIndicator returns visual indicator based on risk level
 func (d *ConsoleDisplay) getRiskIndicator(level event.RiskLevel) string {
 	switch level {
@@ -331,6 +1097,10 @@ func (d *ConsoleDisplay) printLLMMessage(e event.Event) {
 		return
 	}
 
+	if d.pidFilter != nil && !d.pidFilter(msg.PID) {
+		return
+	}
+
 	// Format: TIMESTAMP LLM [FROM] → [TO] [MODEL] TYPE "content..."
 	ts := timestampColor.Sprint(msg.Timestamp.Format("15:04:05.000"))
 
@@ -400,6 +1170,10 @@ func (d *ConsoleDisplay) printToolUsage(e event.Event) {
 		return
 	}
 
+	if d.pidFilter != nil && !d.pidFilter(msg.PID) {
+		return
+	}
+
 	// Format: TIMESTAMP TOOL comm[pid] → host TYPE TOOLNAME [ID] summary
 	ts := timestampColor.Sprint(msg.Timestamp.Format("15:04:05.000"))
 
@@ -447,6 +1221,60 @@ func (d *ConsoleDisplay) printToolUsage(e event.Event) {
 	fmt.Fprintf(d.writer, "%s %s %s %s %s %s\n", ts, toolColor.Sprint("TOOL"), commFlow, msgType, toolInfo, summary)
 }
 
+// printToolCallCorrelation prints the link between an LLM tool_use
+// invocation and the MCP tools/call request it triggered
+func (d *ConsoleDisplay) printToolCallCorrelation(e event.Event) {
+	msg, ok := e.(*event.ToolCallCorrelationEvent)
+	if !ok {
+		return
+	}
+
+	ts := timestampColor.Sprint(msg.Timestamp.Format("15:04:05.000"))
+	toolInfo := toolColor.Sprint(msg.ToolName)
+	if msg.LLMToolID != "" {
+		toolInfo += idColor.Sprintf(" [%s]", msg.LLMToolID)
+	}
+
+	fmt.Fprintf(d.writer, "%s %s %s model→mcp %s (+%s)\n",
+		ts, toolColor.Sprint("LINK"), toolInfo, idColor.Sprintf("id=%v", msg.MCPRequestID), msg.Latency.Round(time.Millisecond))
+}
+
+// printBridgeCorrelation prints the link between the two sightings of a
+// message that crossed a stdio-to-HTTP bridge process.
+func (d *ConsoleDisplay) printBridgeCorrelation(e event.Event) {
+	msg, ok := e.(*event.BridgeCorrelationEvent)
+	if !ok {
+		return
+	}
+
+	ts := timestampColor.Sprint(msg.Timestamp.Format("15:04:05.000"))
+	bridgeInfo := toolColor.Sprintf("%s[%d]", msg.BridgeComm, msg.BridgePID)
+
+	fmt.Fprintf(d.writer, "%s %s %s stdio↔http via %s %s (+%s)\n",
+		ts, toolColor.Sprint("BRIDGE"), methodColor.Sprint(msg.Method), bridgeInfo,
+		idColor.Sprintf("id=%v", msg.ID), msg.Latency.Round(time.Millisecond))
+}
+
+// printAggregatorEdge prints a call relayed by an MCP gateway/aggregator to
+// one of its backends, labeling it as a fan-out once a second distinct
+// backend has been seen handling the same client request.
+func (d *ConsoleDisplay) printAggregatorEdge(e event.Event) {
+	msg, ok := e.(*event.AggregatorEdgeEvent)
+	if !ok {
+		return
+	}
+
+	ts := timestampColor.Sprint(msg.Timestamp.Format("15:04:05.000"))
+	label := "AGGREGATE"
+	if msg.FanOutSize > 1 {
+		label = fmt.Sprintf("AGGREGATE fan-out x%d", msg.FanOutSize)
+	}
+
+	fmt.Fprintf(d.writer, "%s %s %s %s[%d]→%s[%d]→%s[%d]\n",
+		ts, toolColor.Sprint(label), methodColor.Sprint(msg.Method),
+		msg.ClientComm, msg.ClientPID, msg.AggregatorComm, msg.AggregatorPID, msg.BackendComm, msg.BackendPID)
+}
+
 // formatToolInput formats tool invocation input for display
 func formatToolInput(toolName, input string) string {
 	// Try to extract key parameter based on tool name
@@ -0,0 +1,433 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/fatih/color"
+)
+
+func logNotification(level string) *event.MCPEvent {
+	return &event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 1, FromComm: "mcp-server", ToPID: 2, ToComm: "claude"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeNotification,
+			Method:      "notifications/message",
+			Params:      map[string]interface{}{"level": level, "data": "boom"},
+		},
+	}
+}
+
+func TestConsoleDisplay_ColorDisabledShowsPlainLevelPrefix(t *testing.T) {
+	orig := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = orig }()
+
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	if _, err := NewConsoleDisplay(&buf, false, eventBus); err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+
+	eventBus.Publish(logNotification("error"))
+
+	out := buf.String()
+	if !strings.Contains(out, "NOTF notifications/message") {
+		t.Errorf("expected a plain, uncolored notification line, got:\n%q", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("expected no ANSI escape codes with --no-color, got:\n%q", out)
+	}
+}
+
+func TestConsoleDisplay_ColorEnabledColorsEachLevel(t *testing.T) {
+	orig := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = orig }()
+
+	cases := []struct {
+		level string
+		want  *color.Color
+	}{
+		{"error", logLevelErrorColor},
+		{"warning", logLevelWarnColor},
+		{"info", logLevelDimColor},
+		{"debug", logLevelDimColor},
+	}
+
+	for _, tc := range cases {
+		eventBus := tu.NewMockBus()
+		var buf bytes.Buffer
+
+		if _, err := NewConsoleDisplay(&buf, false, eventBus); err != nil {
+			t.Fatalf("NewConsoleDisplay() error = %v", err)
+		}
+
+		eventBus.Publish(logNotification(tc.level))
+
+		out := buf.String()
+		want := tc.want.Sprint("notifications/message")
+		if !strings.Contains(out, want) {
+			t.Errorf("level %q: expected colored method %q in output, got:\n%q", tc.level, want, out)
+		}
+	}
+}
+
+// TestConsoleDisplay_PIDFilterScopesOutput exercises the filtering used by
+// `mcpspy run` to scope output to a launched command's process tree: only
+// traffic involving an accepted PID should be printed.
+func TestConsoleDisplay_PIDFilterScopesOutput(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, false, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+
+	d.SetPIDFilter(func(pid uint32) bool { return pid == 200 })
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 200, FromComm: "claude", ToPID: 201, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 900, FromComm: "other", ToPID: 901, ToComm: "other-server"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, "claude") {
+		t.Errorf("expected output to include the accepted PID's traffic, got:\n%s", out)
+	}
+	if strings.Contains(out, "other-server") {
+		t.Errorf("expected output to exclude traffic outside the PID filter, got:\n%s", out)
+	}
+}
+
+func TestConsoleDisplay_BufferMethodsRestrictsRawBufferDisplay(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, true, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+	d.SetBufferMethods([]string{"tools/call"})
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 1, FromComm: "claude", ToPID: 2, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+		Raw: `{"selected":"payload"}`,
+	})
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 1, FromComm: "claude", ToPID: 2, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(2),
+			Method:      "resources/read",
+		},
+		Raw: `{"excluded":"payload"}`,
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, "selected") {
+		t.Errorf("expected raw buffer for the selected method to be printed, got:\n%s", out)
+	}
+	if strings.Contains(out, "excluded") {
+		t.Errorf("expected raw buffer for a non-selected method to be suppressed, got:\n%s", out)
+	}
+}
+
+func TestConsoleDisplay_PlainModeSkipsHeaderAndBoxDrawing(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, true, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+	d.SetPlain(true)
+	d.PrintHeader()
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 1, FromComm: "claude", ToPID: 2, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+		Raw: `{"name":"search"}`,
+	})
+
+	out := buf.String()
+	if strings.Contains(out, "█") {
+		t.Errorf("expected plain mode to skip the ASCII-art banner, got:\n%s", out)
+	}
+	if strings.Contains(out, "┌────") || strings.Contains(out, "└────") || strings.Contains(out, "│ ") {
+		t.Errorf("expected plain mode to skip box-drawing borders, got:\n%s", out)
+	}
+	if !strings.Contains(out, "mcp-server") || !strings.Contains(out, "search") {
+		t.Errorf("expected plain mode to still print the event details, got:\n%s", out)
+	}
+}
+
+func TestConsoleDisplay_NoPIDFilterShowsEverything(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, false, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+	_ = d
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 900, FromComm: "other", ToPID: 901, ToComm: "other-server"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+
+	if !strings.Contains(buf.String(), "other-server") {
+		t.Errorf("expected output without a PID filter to include all traffic, got:\n%s", buf.String())
+	}
+}
+
+// TestConsoleDisplay_SuppressSuccessfulPingsHidesPairButKeepsTimeout exercises
+// --suppress-pings: a successful ping/pong pair should be hidden, an
+// unrelated message should still show, and a ping that timed out (reported
+// separately as a RequestTimeoutEvent) should always surface.
+func TestConsoleDisplay_SuppressSuccessfulPingsHidesPairButKeepsTimeout(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, false, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+	d.SetSuppressSuccessfulPings(true)
+
+	stdio := &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"}
+
+	// A successful ping/pong pair.
+	pingReq := &event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "ping"}
+	eventBus.Publish(&event.MCPEvent{TransportType: event.TransportTypeStdio, StdioTransport: stdio, JSONRPCMessage: *pingReq})
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: stdio,
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeResponse, ID: int64(1), Request: pingReq},
+	})
+
+	// An unrelated message, which must still show.
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: stdio,
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(2), Method: "tools/list"},
+	})
+
+	// A ping that timed out, reported on its own event type.
+	eventBus.Publish(&event.RequestTimeoutEvent{
+		Method:         "ping",
+		ID:             int64(3),
+		TTL:            5 * time.Second,
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: stdio,
+	})
+
+	out := buf.String()
+	if strings.Contains(out, "[1]") {
+		t.Errorf("expected the successful ping/pong pair to be suppressed, got:\n%s", out)
+	}
+	if !strings.Contains(out, "tools/list") {
+		t.Errorf("expected the unrelated message to still be shown, got:\n%s", out)
+	}
+	if !strings.Contains(out, "ping") || !strings.Contains(out, "timed out") {
+		t.Errorf("expected the timed-out ping to be surfaced, got:\n%s", out)
+	}
+}
+
+// TestConsoleDisplay_SuppressSuccessfulPingsStillShowsFailedPing ensures an
+// error response to a ping (a failure observed directly, not via timeout) is
+// not hidden by --suppress-pings.
+func TestConsoleDisplay_SuppressSuccessfulPingsStillShowsFailedPing(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, false, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+	d.SetSuppressSuccessfulPings(true)
+
+	stdio := &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"}
+	pingReq := &event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "ping"}
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: stdio,
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeResponse, ID: int64(1), Request: pingReq, Error: event.JSONRPCError{Code: -32000, Message: "boom"}},
+	})
+
+	if !strings.Contains(buf.String(), "boom") {
+		t.Errorf("expected a failed ping response to still be shown, got:\n%s", buf.String())
+	}
+}
+
+func TestConsoleDisplay_PIDCommFilterScopesOutput(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, false, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+	d.SetPIDCommFilter([]uint32{200}, nil)
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 200, FromComm: "claude", ToPID: 201, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+	})
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 900, FromComm: "other", ToPID: 901, ToComm: "other-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, "claude") {
+		t.Errorf("expected output to include traffic matching --pid, got:\n%s", out)
+	}
+	if strings.Contains(out, "other-server") {
+		t.Errorf("expected output to exclude traffic outside --pid, got:\n%s", out)
+	}
+}
+
+func TestConsoleDisplay_QuietSuppressesPerMessageOutput(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, false, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+	d.SetQuiet(true)
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+	})
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no per-message output while quiet, got:\n%s", buf.String())
+	}
+
+	d.PrintSummary(1, 1, 0, 0, false)
+	if buf.Len() == 0 {
+		t.Error("expected PrintSummary to still produce output while quiet")
+	}
+}
+
+func TestConsoleDisplay_PrintSummary(t *testing.T) {
+	orig := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = orig }()
+
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewConsoleDisplay(&buf, false, eventBus)
+	if err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+
+	d.PrintSummary(12, 3, 2, 1500*time.Millisecond, true)
+	out := buf.String()
+
+	for _, want := range []string{"messages=12", "methods=3", "errors=2", "duration=1.5s", "result=FAIL"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("PrintSummary() output missing %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "\n") != 1 {
+		t.Errorf("expected a single-line summary, got:\n%s", out)
+	}
+}
+
+func TestPidLabel(t *testing.T) {
+	tests := []struct {
+		pid         uint32
+		containerID string
+		want        string
+	}{
+		{pid: 100, containerID: "", want: "100"},
+		{pid: 100, containerID: "abcdef0123456789", want: "100@abcdef012345"},
+		{pid: 100, containerID: "abc123", want: "100@abc123"},
+	}
+	for _, tt := range tests {
+		if got := pidLabel(tt.pid, tt.containerID); got != tt.want {
+			t.Errorf("pidLabel(%d, %q) = %q, want %q", tt.pid, tt.containerID, got, tt.want)
+		}
+	}
+}
+
+func TestConsoleDisplay_StdioCommFlowShowsContainerID(t *testing.T) {
+	orig := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = orig }()
+
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	if _, err := NewConsoleDisplay(&buf, false, eventBus); err != nil {
+		t.Fatalf("NewConsoleDisplay() error = %v", err)
+	}
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType: event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{
+			FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server",
+			ToContainerID: "abcdef0123456789",
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, "200@abcdef012345") {
+		t.Errorf("expected output to show the backend's container ID, got:\n%s", out)
+	}
+	if strings.Contains(out, "100@") {
+		t.Errorf("expected no container suffix on the client PID (no ContainerID set), got:\n%s", out)
+	}
+}
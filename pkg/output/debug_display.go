@@ -12,6 +12,7 @@ import (
 
 	"github.com/alex-ilgayev/mcpspy/pkg/bus"
 	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/alex-ilgayev/mcpspy/pkg/tlsinfo"
 	"github.com/fatih/color"
 )
 
@@ -30,9 +31,12 @@ type DebugDisplay struct {
 	writer    io.Writer
 	eventBus  bus.EventBus
 	config    DebugFilterConfig
-	hostRegex *regexp.Regexp               // Compiled host regex (nil = no filter)
-	stats     map[event.EventType]*uint64  // Atomic counters per event type
-	mu        sync.Mutex                   // Protects writer access
+	hostRegex *regexp.Regexp              // Compiled host regex (nil = no filter)
+	stats     map[event.EventType]*uint64 // Atomic counters per event type
+	hookStats map[event.HookID]*uint64    // Atomic counters per originating eBPF hook
+	tlsInfo   *tlsinfo.Tracker            // TLS version/cipher postures by SSL context
+	tlsByHost map[string]tlsinfo.Posture  // Most recent posture observed per host
+	mu        sync.Mutex                  // Protects writer access
 }
 
 // Colors for debug output
@@ -48,11 +52,19 @@ var (
 
 // NewDebugDisplay creates a new debug display handler
 func NewDebugDisplay(writer io.Writer, eventBus bus.EventBus, config DebugFilterConfig) (*DebugDisplay, error) {
+	tlsTracker, err := tlsinfo.New(eventBus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TLS info tracker: %w", err)
+	}
+
 	d := &DebugDisplay{
-		writer:   writer,
-		eventBus: eventBus,
-		config:   config,
-		stats:    make(map[event.EventType]*uint64),
+		writer:    writer,
+		eventBus:  eventBus,
+		config:    config,
+		stats:     make(map[event.EventType]*uint64),
+		hookStats: make(map[event.HookID]*uint64),
+		tlsInfo:   tlsTracker,
+		tlsByHost: make(map[string]tlsinfo.Posture),
 	}
 
 	// Compile host regex if provided
@@ -70,6 +82,12 @@ func NewDebugDisplay(writer io.Writer, eventBus bus.EventBus, config DebugFilter
 		d.stats[et] = &counter
 	}
 
+	// Initialize per-hook counters for all eBPF hooks
+	for _, hid := range allHookIDs() {
+		counter := uint64(0)
+		d.hookStats[hid] = &counter
+	}
+
 	// Subscribe to all event types
 	subscriptions := []struct {
 		eventType event.EventType
@@ -81,6 +99,7 @@ func NewDebugDisplay(writer io.Writer, eventBus bus.EventBus, config DebugFilter
 		{event.EventTypeTlsPayloadSend, d.handleTLSPayloadEvent},
 		{event.EventTypeTlsPayloadRecv, d.handleTLSPayloadEvent},
 		{event.EventTypeTlsFree, d.handleTLSFreeEvent},
+		{event.EventTypeTlsHandshake, d.handleTLSHandshakeEvent},
 		{event.EventTypeHttpRequest, d.handleHttpRequestEvent},
 		{event.EventTypeHttpResponse, d.handleHttpResponseEvent},
 		{event.EventTypeHttpSSE, d.handleSSEEvent},
@@ -101,8 +120,10 @@ func NewDebugDisplay(writer io.Writer, eventBus bus.EventBus, config DebugFilter
 	return d, nil
 }
 
-// Close is a no-op for now (event bus Close() handles cleanup)
-func (d *DebugDisplay) Close() {}
+// Close unsubscribes the display's internal TLS info tracker.
+func (d *DebugDisplay) Close() {
+	d.tlsInfo.Close()
+}
 
 // PrintHeader prints debug mode header
 func (d *DebugDisplay) PrintHeader() {
@@ -153,6 +174,49 @@ func (d *DebugDisplay) PrintStats() {
 	}
 	fmt.Fprintln(d.writer, strings.Repeat("-", 40))
 	fmt.Fprintf(d.writer, "  %-25s %d\n", "TOTAL", total)
+
+	fmt.Fprintln(d.writer, "\nHook Statistics:")
+	fmt.Fprintln(d.writer, strings.Repeat("-", 40))
+	for _, hid := range allHookIDs() {
+		count := atomic.LoadUint64(d.hookStats[hid])
+		if count > 0 {
+			fmt.Fprintf(d.writer, "  %-25s %d\n", hid.String(), count)
+		}
+	}
+
+	if len(d.tlsByHost) > 0 {
+		fmt.Fprintln(d.writer, "\nTLS Posture by Host:")
+		fmt.Fprintln(d.writer, strings.Repeat("-", 40))
+		for host, posture := range d.tlsByHost {
+			fmt.Fprintf(d.writer, "  %-40s %s\n", host, posture.String())
+		}
+	}
+}
+
+// recordHook increments the per-hook counter for the eBPF hook that produced e.
+// Events not originated from eBPF (e.g. derived HTTP/MCP events) carry HookUnknown
+// and are not tracked here.
+func (d *DebugDisplay) recordHook(hid event.HookID) {
+	if counter, ok := d.hookStats[hid]; ok {
+		atomic.AddUint64(counter, 1)
+	}
+}
+
+// recordTLSPosture looks up the TLS posture observed for sslCtx and, if
+// found, remembers it as the most recent posture seen for host.
+func (d *DebugDisplay) recordTLSPosture(host string, sslCtx uint64) {
+	if host == "" {
+		return
+	}
+
+	posture, ok := d.tlsInfo.Lookup(sslCtx)
+	if !ok {
+		return
+	}
+
+	d.mu.Lock()
+	d.tlsByHost[host] = posture
+	d.mu.Unlock()
 }
 
 // shouldDisplay checks if event passes all filters
@@ -264,6 +328,7 @@ func (d *DebugDisplay) handleFSDataEvent(e event.Event) {
 	}
 
 	atomic.AddUint64(d.stats[fsEvent.EventType], 1)
+	d.recordHook(fsEvent.HookID)
 
 	if !d.shouldDisplay(fsEvent.EventType, fsEvent.PID, fsEvent.Comm(), "") {
 		return
@@ -286,6 +351,7 @@ func (d *DebugDisplay) handleLibraryEvent(e event.Event) {
 	}
 
 	atomic.AddUint64(d.stats[event.EventTypeLibrary], 1)
+	d.recordHook(libEvent.HookID)
 
 	if !d.shouldDisplay(event.EventTypeLibrary, libEvent.PID, libEvent.Comm(), "") {
 		return
@@ -305,6 +371,7 @@ func (d *DebugDisplay) handleTLSPayloadEvent(e event.Event) {
 	}
 
 	atomic.AddUint64(d.stats[tlsEvent.EventType], 1)
+	d.recordHook(tlsEvent.HookID)
 
 	if !d.shouldDisplay(tlsEvent.EventType, tlsEvent.PID, tlsEvent.Comm(), "") {
 		return
@@ -325,6 +392,7 @@ func (d *DebugDisplay) handleTLSFreeEvent(e event.Event) {
 	}
 
 	atomic.AddUint64(d.stats[event.EventTypeTlsFree], 1)
+	d.recordHook(tlsFreeEvent.HookID)
 
 	if !d.shouldDisplay(event.EventTypeTlsFree, tlsFreeEvent.PID, tlsFreeEvent.Comm(), "") {
 		return
@@ -335,6 +403,26 @@ func (d *DebugDisplay) handleTLSFreeEvent(e event.Event) {
 	d.printEventLine(event.EventTypeTlsFree, time.Now(), tlsFreeEvent.PID, tlsFreeEvent.Comm(), details)
 }
 
+func (d *DebugDisplay) handleTLSHandshakeEvent(e event.Event) {
+	handshakeEvent, ok := e.(*event.TlsHandshakeEvent)
+	if !ok {
+		return
+	}
+
+	atomic.AddUint64(d.stats[event.EventTypeTlsHandshake], 1)
+	d.recordHook(handshakeEvent.HookID)
+
+	if !d.shouldDisplay(event.EventTypeTlsHandshake, handshakeEvent.PID, handshakeEvent.Comm(), "") {
+		return
+	}
+
+	details := fmt.Sprintf("ssl_ctx=0x%x %s=%s",
+		handshakeEvent.SSLContext, handshakeEvent.HookID.String(), handshakeEvent.Value(),
+	)
+
+	d.printEventLine(event.EventTypeTlsHandshake, time.Now(), handshakeEvent.PID, handshakeEvent.Comm(), details)
+}
+
 func (d *DebugDisplay) handleHttpRequestEvent(e event.Event) {
 	httpEvent, ok := e.(*event.HttpRequestEvent)
 	if !ok {
@@ -342,6 +430,7 @@ func (d *DebugDisplay) handleHttpRequestEvent(e event.Event) {
 	}
 
 	atomic.AddUint64(d.stats[event.EventTypeHttpRequest], 1)
+	d.recordTLSPosture(httpEvent.Host, httpEvent.SSLContext)
 
 	if !d.shouldDisplay(event.EventTypeHttpRequest, httpEvent.PID, httpEvent.Comm(), httpEvent.Host+httpEvent.Path) {
 		return
@@ -366,6 +455,7 @@ func (d *DebugDisplay) handleHttpResponseEvent(e event.Event) {
 	}
 
 	atomic.AddUint64(d.stats[event.EventTypeHttpResponse], 1)
+	d.recordTLSPosture(httpEvent.Host, httpEvent.SSLContext)
 
 	if !d.shouldDisplay(event.EventTypeHttpResponse, httpEvent.PID, httpEvent.Comm(), httpEvent.Host+httpEvent.Path) {
 		return
@@ -420,10 +510,10 @@ func (d *DebugDisplay) handleMCPEvent(e event.Event) {
 	var host string
 	var transport string
 
-	if mcpEvent.TransportType == event.TransportTypeStdio && mcpEvent.StdioTransport != nil {
+	if (mcpEvent.TransportType == event.TransportTypeStdio || mcpEvent.TransportType == event.TransportTypeUnixSocket) && mcpEvent.StdioTransport != nil {
 		pid = mcpEvent.StdioTransport.FromPID
 		comm = mcpEvent.StdioTransport.FromComm
-		transport = "stdio"
+		transport = string(mcpEvent.TransportType)
 	} else if mcpEvent.HttpTransport != nil {
 		pid = mcpEvent.HttpTransport.PID
 		comm = mcpEvent.HttpTransport.Comm
@@ -580,6 +670,7 @@ func allEventTypes() []event.EventType {
 		event.EventTypeTlsPayloadSend,
 		event.EventTypeTlsPayloadRecv,
 		event.EventTypeTlsFree,
+		event.EventTypeTlsHandshake,
 		event.EventTypeHttpRequest,
 		event.EventTypeHttpResponse,
 		event.EventTypeHttpSSE,
@@ -592,6 +683,22 @@ func allEventTypes() []event.EventType {
 	}
 }
 
+func allHookIDs() []event.HookID {
+	return []event.HookID{
+		event.HookVFSRead,
+		event.HookVFSWrite,
+		event.HookLibraryIter,
+		event.HookSecurityFileOpen,
+		event.HookSSLRead,
+		event.HookSSLReadEx,
+		event.HookSSLWrite,
+		event.HookSSLWriteEx,
+		event.HookSSLFree,
+		event.HookSSLGetVersion,
+		event.HookSSLCipherGetName,
+	}
+}
+
 func truncateComm(s string, maxLen int) string {
 	if len(s) > maxLen {
 		return s[:maxLen-3] + "..."
@@ -608,6 +715,7 @@ func ParseEventTypeName(name string) (event.EventType, bool) {
 		"tls_send":            event.EventTypeTlsPayloadSend,
 		"tls_recv":            event.EventTypeTlsPayloadRecv,
 		"tls_free":            event.EventTypeTlsFree,
+		"tls_handshake":       event.EventTypeTlsHandshake,
 		"http_request":        event.EventTypeHttpRequest,
 		"http_response":       event.EventTypeHttpResponse,
 		"http_sse":            event.EventTypeHttpSSE,
@@ -626,7 +734,7 @@ func ParseEventTypeName(name string) (event.EventType, bool) {
 func AllEventTypeNames() []string {
 	return []string{
 		"fs_read", "fs_write", "library",
-		"tls_send", "tls_recv", "tls_free",
+		"tls_send", "tls_recv", "tls_free", "tls_handshake",
 		"http_request", "http_response", "http_sse",
 		"mcp_message", "fs_aggregated_read", "fs_aggregated_write",
 		"security_alert", "llm_message", "tool_usage",
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestDebugDisplay_PerHookStatsAccumulate(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewDebugDisplay(&buf, eventBus, DebugFilterConfig{})
+	if err != nil {
+		t.Fatalf("NewDebugDisplay() error = %v", err)
+	}
+
+	d.handleFSDataEvent(&event.FSDataEvent{
+		FSEventBase: event.FSEventBase{
+			EventHeader: event.EventHeader{EventType: event.EventTypeFSRead, HookID: event.HookVFSRead},
+		},
+	})
+	d.handleFSDataEvent(&event.FSDataEvent{
+		FSEventBase: event.FSEventBase{
+			EventHeader: event.EventHeader{EventType: event.EventTypeFSRead, HookID: event.HookVFSRead},
+		},
+	})
+	d.handleTLSFreeEvent(&event.TlsFreeEvent{
+		EventHeader: event.EventHeader{EventType: event.EventTypeTlsFree, HookID: event.HookSSLFree},
+	})
+
+	if got := d.hookStats[event.HookVFSRead]; *got != 2 {
+		t.Errorf("hookStats[HookVFSRead] = %d, want 2", *got)
+	}
+	if got := d.hookStats[event.HookSSLFree]; *got != 1 {
+		t.Errorf("hookStats[HookSSLFree] = %d, want 1", *got)
+	}
+
+	d.PrintStats()
+	out := buf.String()
+	if !bytes.Contains([]byte(out), []byte("Hook Statistics:")) {
+		t.Errorf("PrintStats() output missing hook statistics section:\n%s", out)
+	}
+}
+
+func TestDebugDisplay_RecordHookIgnoresUnknown(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewDebugDisplay(&buf, eventBus, DebugFilterConfig{})
+	if err != nil {
+		t.Fatalf("NewDebugDisplay() error = %v", err)
+	}
+
+	d.recordHook(event.HookUnknown)
+
+	for hid, counter := range d.hookStats {
+		if *counter != 0 {
+			t.Errorf("hookStats[%s] = %d, want 0", hid, *counter)
+		}
+	}
+}
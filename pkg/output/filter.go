@@ -0,0 +1,92 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// pidCommFilter restricts MCPEvents to those attributable to a set of PIDs
+// and/or process names, shared by ConsoleDisplay and JSONLDisplay so
+// --pid/--comm behave identically regardless of output mode. A nil
+// *pidCommFilter (the zero value via newPIDCommFilter) accepts everything.
+type pidCommFilter struct {
+	pids  map[uint32]bool
+	comms []string // lowercased substrings
+}
+
+// newPIDCommFilter builds a filter from a PID set and comm substrings.
+// Returns nil (accept everything) if both are empty.
+func newPIDCommFilter(pids []uint32, comms []string) *pidCommFilter {
+	if len(pids) == 0 && len(comms) == 0 {
+		return nil
+	}
+
+	f := &pidCommFilter{}
+	if len(pids) > 0 {
+		f.pids = make(map[uint32]bool, len(pids))
+		for _, p := range pids {
+			f.pids[p] = true
+		}
+	}
+	for _, c := range comms {
+		f.comms = append(f.comms, strings.ToLower(c))
+	}
+	return f
+}
+
+// Accepts reports whether msg matches this filter. The PID and comm criteria
+// are ANDed together when both are configured; within each criterion, a
+// match on either endpoint (from/to, or the single HTTP endpoint) is enough.
+func (f *pidCommFilter) Accepts(msg *event.MCPEvent) bool {
+	if f == nil {
+		return true
+	}
+	if f.pids != nil && !f.matchesPID(msg) {
+		return false
+	}
+	if len(f.comms) > 0 && !f.matchesComm(msg) {
+		return false
+	}
+	return true
+}
+
+func (f *pidCommFilter) matchesPID(msg *event.MCPEvent) bool {
+	switch msg.TransportType {
+	case event.TransportTypeStdio:
+		if msg.StdioTransport != nil {
+			return f.pids[msg.FromPID] || f.pids[msg.ToPID]
+		}
+	case event.TransportTypeHTTP:
+		if msg.HttpTransport != nil {
+			return f.pids[msg.HttpTransport.PID]
+		}
+	}
+	return false
+}
+
+func (f *pidCommFilter) matchesComm(msg *event.MCPEvent) bool {
+	switch msg.TransportType {
+	case event.TransportTypeStdio:
+		if msg.StdioTransport != nil {
+			return commContainsAny(msg.FromComm, f.comms) || commContainsAny(msg.ToComm, f.comms)
+		}
+	case event.TransportTypeHTTP:
+		if msg.HttpTransport != nil {
+			return commContainsAny(msg.HttpTransport.Comm, f.comms)
+		}
+	}
+	return false
+}
+
+// commContainsAny reports whether comm contains any of substrings
+// (case-insensitive).
+func commContainsAny(comm string, substrings []string) bool {
+	comm = strings.ToLower(comm)
+	for _, s := range substrings {
+		if strings.Contains(comm, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func stdioMsg(fromPID, toPID uint32, fromComm, toComm string) *event.MCPEvent {
+	return &event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: fromPID, FromComm: fromComm, ToPID: toPID, ToComm: toComm},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+	}
+}
+
+func TestPIDCommFilter_NilAcceptsEverything(t *testing.T) {
+	var f *pidCommFilter
+	if !f.Accepts(stdioMsg(1, 2, "a", "b")) {
+		t.Error("nil filter should accept everything")
+	}
+}
+
+func TestPIDCommFilter_MatchesSourcePIDNotDestPID(t *testing.T) {
+	f := newPIDCommFilter([]uint32{100}, nil)
+
+	if !f.Accepts(stdioMsg(100, 200, "claude", "mcp-server")) {
+		t.Error("expected a match on the source PID to be accepted")
+	}
+	if f.Accepts(stdioMsg(300, 400, "claude", "mcp-server")) {
+		t.Error("expected a message matching neither PID to be rejected")
+	}
+	if !f.Accepts(stdioMsg(999, 100, "claude", "mcp-server")) {
+		t.Error("expected a match on the dest PID to also be accepted")
+	}
+}
+
+func TestPIDCommFilter_CommSubstringCaseInsensitive(t *testing.T) {
+	f := newPIDCommFilter(nil, []string{"CLAUDE"})
+
+	if !f.Accepts(stdioMsg(1, 2, "claude-desktop", "mcp-server")) {
+		t.Error("expected a case-insensitive substring match on the source comm to be accepted")
+	}
+	if f.Accepts(stdioMsg(1, 2, "cursor", "mcp-server")) {
+		t.Error("expected a non-matching comm pair to be rejected")
+	}
+}
+
+func TestPIDCommFilter_PIDAndCommAreANDed(t *testing.T) {
+	f := newPIDCommFilter([]uint32{100}, []string{"claude"})
+
+	// Matches PID but not comm.
+	if f.Accepts(stdioMsg(100, 200, "other", "mcp-server")) {
+		t.Error("expected PID match alone, without a comm match, to be rejected")
+	}
+	// Matches both.
+	if !f.Accepts(stdioMsg(100, 200, "claude", "mcp-server")) {
+		t.Error("expected a message matching both PID and comm to be accepted")
+	}
+}
+
+func TestPIDCommFilter_HTTPTransportMatchesSingleEndpoint(t *testing.T) {
+	f := newPIDCommFilter([]uint32{50}, nil)
+
+	msg := &event.MCPEvent{
+		TransportType: event.TransportTypeHTTP,
+		HttpTransport: &event.HttpTransport{PID: 50, Comm: "claude"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+		},
+	}
+	if !f.Accepts(msg) {
+		t.Error("expected HTTP transport PID match to be accepted")
+	}
+
+	msg.HttpTransport.PID = 999
+	if f.Accepts(msg) {
+		t.Error("expected HTTP transport PID mismatch to be rejected")
+	}
+}
@@ -0,0 +1,109 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/sirupsen/logrus"
+)
+
+// InspectorDisplay writes captured MCP messages as a single JSON array
+// document shaped like the MCP Inspector's session log, so a capture can be
+// loaded directly into the official Inspector UI for replay.
+//
+// Unlike JSONLDisplay, the output isn't valid until Close is called: a JSON
+// array can't be streamed line-by-line the way JSONL can, so messages are
+// buffered in memory and the whole array is written out on Close.
+//
+// Subscribes to:
+// - EventTypeMCPMessage
+type InspectorDisplay struct {
+	writer   io.Writer
+	eventBus bus.EventBus
+
+	mu       sync.Mutex
+	messages []inspectorMessage
+}
+
+// inspectorMessage is a single entry in the Inspector-compatible session
+// log: an ordered record of one JSON-RPC message with enough context
+// (direction, transport, timestamp) to replay the conversation.
+type inspectorMessage struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Direction string          `json:"direction"`
+	Transport string          `json:"transport"`
+	Message   json.RawMessage `json:"message"`
+}
+
+// NewMCPInspectorDisplay creates a new display handler that accumulates MCP
+// messages and writes them to w as a single JSON array on Close.
+func NewMCPInspectorDisplay(w io.Writer, eventBus bus.EventBus) (*InspectorDisplay, error) {
+	d := &InspectorDisplay{
+		writer:   w,
+		eventBus: eventBus,
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, d.recordMessage); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// PrintHeader does nothing for Inspector output (no header needed).
+func (d *InspectorDisplay) PrintHeader() {}
+
+// PrintStats does nothing for Inspector output (stats not applicable).
+func (d *InspectorDisplay) PrintStats(stats map[string]int) {}
+
+// PrintInfo does nothing for Inspector output (info messages not applicable).
+func (d *InspectorDisplay) PrintInfo(format string, args ...interface{}) {}
+
+// recordMessage buffers a single MCP message for inclusion in the array
+// written by Close, direction-labeled by its JSON-RPC message type.
+func (d *InspectorDisplay) recordMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	raw, err := json.Marshal(msg.JSONRPCMessage)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal message for inspector output")
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.messages = append(d.messages, inspectorMessage{
+		Timestamp: msg.Timestamp,
+		Direction: string(msg.MessageType),
+		Transport: string(msg.TransportType),
+		Message:   raw,
+	})
+}
+
+// Close unsubscribes from the event bus and flushes the accumulated
+// messages to w as a single, valid JSON array.
+func (d *InspectorDisplay) Close() error {
+	d.eventBus.Unsubscribe(event.EventTypeMCPMessage, d.recordMessage)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := json.MarshalIndent(d.messages, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal inspector session log: %w", err)
+	}
+
+	if _, err := d.writer.Write(data); err != nil {
+		return fmt.Errorf("failed to write inspector session log: %w", err)
+	}
+
+	return nil
+}
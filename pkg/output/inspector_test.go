@@ -0,0 +1,77 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestInspectorDisplay_WritesClosedJSONArrayOnClose(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewMCPInspectorDisplay(&buf, eventBus)
+	if err != nil {
+		t.Fatalf("NewMCPInspectorDisplay() error = %v", err)
+	}
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+	})
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 200, FromComm: "mcp-server", ToPID: 100, ToComm: "claude"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeResponse, ID: int64(1)},
+	})
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing written before Close, got:\n%s", buf.String())
+	}
+
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var entries []inspectorMessage
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("Close() did not produce a valid JSON array: %v\noutput:\n%s", err, buf.String())
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Direction != "request" || entries[1].Direction != "response" {
+		t.Errorf("unexpected directions: %q, %q", entries[0].Direction, entries[1].Direction)
+	}
+	if entries[0].Transport != "stdio" {
+		t.Errorf("expected transport 'stdio', got %q", entries[0].Transport)
+	}
+}
+
+func TestInspectorDisplay_IgnoresNonMCPEvents(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	d, err := NewMCPInspectorDisplay(&buf, eventBus)
+	if err != nil {
+		t.Fatalf("NewMCPInspectorDisplay() error = %v", err)
+	}
+
+	eventBus.Publish(&event.SecurityAlertEvent{RiskLevel: event.RiskLevelHigh})
+
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var entries []inspectorMessage
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("Close() did not produce a valid JSON array: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(entries))
+	}
+}
@@ -14,9 +14,42 @@ import (
 // Subscribes to the following events:
 // - EventTypeMCPMessage
 // - EventTypeLLMMessage
+// - EventTypeSecurityAlert
+// - EventTypeToolUsage
+// - EventTypeToolCallCorrelation
+// - EventTypeBridgeCorrelation
+// - EventTypeAlert
+// - EventTypeToolCallResult
+// - EventTypeLargeToolArgs
+// - EventTypeAggregatorEdge
+// - EventTypeProtocolViolation
+// - EventTypeProtocolVersionMismatch
+// - EventTypeGRPCDetected
+// - EventTypeBaselineAnomaly
+// - EventTypeUnexpectedEgress
+// - EventTypeRuleMatch
 type JSONLDisplay struct {
 	writer   io.Writer
 	eventBus bus.EventBus
+
+	// redactPatterns restricts which JSON keys in the written events have
+	// their values masked. Nil or empty disables redaction.
+	redactPatterns []string
+
+	// pidCommFilter, when set, restricts written output to MCPEvents
+	// attributable to a PID or process name it accepts (--pid/--comm). Nil
+	// means no filtering.
+	pidCommFilter *pidCommFilter
+
+	// pidFilter, when set, restricts written output to MCPEvents
+	// attributable to a PID it accepts (e.g. --follow-children). Nil means no
+	// filtering. ANDed with pidCommFilter when both are set.
+	pidFilter func(pid uint32) bool
+
+	// labels is attached as a "labels" field to every written record
+	// (--label), so JSONL from multiple captures can be told apart after
+	// being aggregated into one store. Nil or empty omits the field.
+	labels map[string]string
 }
 
 // NewJSONLDisplay creates a new display handler for JSONL output with custom writer
@@ -46,9 +79,119 @@ func NewJSONLDisplay(writer io.Writer, eventBus bus.EventBus) (*JSONLDisplay, er
 		return nil, err
 	}
 
+	// Subscribe to tool call correlation events
+	if err := eventBus.Subscribe(event.EventTypeToolCallCorrelation, j.printToolCallCorrelation); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to bridge correlation events
+	if err := eventBus.Subscribe(event.EventTypeBridgeCorrelation, j.printBridgeCorrelation); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to error-rate alerts
+	if err := eventBus.Subscribe(event.EventTypeAlert, j.printAlert); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to tool call results
+	if err := eventBus.Subscribe(event.EventTypeToolCallResult, j.printToolCallResult); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to oversized tool call arguments
+	if err := eventBus.Subscribe(event.EventTypeLargeToolArgs, j.printLargeToolArgs); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to aggregator/gateway fan-out edges
+	if err := eventBus.Subscribe(event.EventTypeAggregatorEdge, j.printAggregatorEdge); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to initialize handshake violations
+	if err := eventBus.Subscribe(event.EventTypeProtocolViolation, j.printProtocolViolation); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to negotiated protocol version mismatches
+	if err := eventBus.Subscribe(event.EventTypeProtocolVersionMismatch, j.printProtocolVersionMismatch); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to gRPC detection on otherwise-unparsed HTTP/2 connections
+	if err := eventBus.Subscribe(event.EventTypeGRPCDetected, j.printGRPCDetected); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to baseline deviation anomalies
+	if err := eventBus.Subscribe(event.EventTypeBaselineAnomaly, j.printBaselineAnomaly); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to MCP servers contacting unexpected external hosts
+	if err := eventBus.Subscribe(event.EventTypeUnexpectedEgress, j.printUnexpectedEgress); err != nil {
+		return nil, err
+	}
+
+	// Subscribe to user-defined rule matches
+	if err := eventBus.Subscribe(event.EventTypeRuleMatch, j.printRuleMatch); err != nil {
+		return nil, err
+	}
+
 	return j, nil
 }
 
+// SetRedactPatterns controls which JSON keys in written events have their
+// values masked with RedactedPlaceholder, matched case-insensitively by
+// substring. Pass nil or an empty slice to disable redaction.
+func (j *JSONLDisplay) SetRedactPatterns(patterns []string) {
+	j.redactPatterns = patterns
+}
+
+// SetPIDCommFilter restricts subsequent writes to MCPEvents attributable to
+// one of pids or whose process name contains one of comms (case-insensitive
+// substring match); both are ANDed together when non-empty. Pass two nil/empty
+// slices to remove any existing filter.
+func (j *JSONLDisplay) SetPIDCommFilter(pids []uint32, comms []string) {
+	j.pidCommFilter = newPIDCommFilter(pids, comms)
+}
+
+// SetPIDFilter restricts subsequent writes to MCPEvents attributable to a
+// PID accepted by filter. Pass nil to remove any existing filter.
+func (j *JSONLDisplay) SetPIDFilter(filter func(pid uint32) bool) {
+	j.pidFilter = filter
+}
+
+// acceptsMessage reports whether msg passes both the static --pid/--comm
+// filter and the dynamic PID filter (e.g. --follow-children).
+func (j *JSONLDisplay) acceptsMessage(msg *event.MCPEvent) bool {
+	if !j.pidCommFilter.Accepts(msg) {
+		return false
+	}
+	if j.pidFilter == nil {
+		return true
+	}
+	switch msg.TransportType {
+	case event.TransportTypeStdio:
+		if msg.StdioTransport != nil {
+			return j.pidFilter(msg.FromPID) || j.pidFilter(msg.ToPID)
+		}
+	case event.TransportTypeHTTP:
+		if msg.HttpTransport != nil {
+			return j.pidFilter(msg.HttpTransport.PID)
+		}
+	}
+	return true
+}
+
+// SetLabels attaches labels to every subsequently written record as a
+// top-level "labels" field (--label). Pass nil or an empty map to stop
+// attaching labels.
+func (j *JSONLDisplay) SetLabels(labels map[string]string) {
+	j.labels = labels
+}
+
 // PrintHeader does nothing for JSONL output (no header needed)
 func (j *JSONLDisplay) PrintHeader() {
 	// No header for JSONL output
@@ -64,6 +207,18 @@ func (j *JSONLDisplay) PrintInfo(format string, args ...interface{}) {
 	// No info messages for JSONL format
 }
 
+// marshal encodes v to JSON, masking any field whose key matches a redact
+// pattern and attaching the configured labels (if any) before returning it.
+func (j *JSONLDisplay) marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	data = redactJSONBytes(data, j.redactPatterns)
+	data = injectLabelsJSONBytes(data, j.labels)
+	return data, nil
+}
+
 // printMessage outputs a single MCP message in JSON format
 func (j *JSONLDisplay) printMessage(e event.Event) {
 	msg, ok := e.(*event.MCPEvent)
@@ -71,7 +226,11 @@ func (j *JSONLDisplay) printMessage(e event.Event) {
 		return
 	}
 
-	data, err := json.Marshal(msg)
+	if !j.acceptsMessage(msg) {
+		return
+	}
+
+	data, err := j.marshal(msg)
 	if err != nil {
 		logrus.WithError(err).Error("failed to marshal message")
 		return
@@ -87,7 +246,7 @@ func (j *JSONLDisplay) printSecurityAlert(e event.Event) {
 		return
 	}
 
-	data, err := json.Marshal(alert)
+	data, err := j.marshal(alert)
 	if err != nil {
 		logrus.WithError(err).Error("failed to marshal security alert")
 		return
@@ -105,7 +264,7 @@ func (j *JSONLDisplay) printLLMMessage(e event.Event) {
 
 	// Skip individual stream chunks to reduce noise (optional - can be configurable)
 	// For now, we include all messages including chunks for comprehensive logging
-	data, err := json.Marshal(msg)
+	data, err := j.marshal(msg)
 	if err != nil {
 		logrus.WithError(err).Error("failed to marshal LLM message")
 		return
@@ -121,7 +280,7 @@ func (j *JSONLDisplay) printToolUsage(e event.Event) {
 		return
 	}
 
-	data, err := json.Marshal(msg)
+	data, err := j.marshal(msg)
 	if err != nil {
 		logrus.WithError(err).Error("failed to marshal tool usage event")
 		return
@@ -129,3 +288,196 @@ func (j *JSONLDisplay) printToolUsage(e event.Event) {
 
 	fmt.Fprintf(j.writer, "%s\n", string(data))
 }
+
+// printToolCallCorrelation outputs a tool call correlation event in JSON format
+func (j *JSONLDisplay) printToolCallCorrelation(e event.Event) {
+	msg, ok := e.(*event.ToolCallCorrelationEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(msg)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal tool call correlation event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printBridgeCorrelation outputs a bridge correlation event in JSON format
+func (j *JSONLDisplay) printBridgeCorrelation(e event.Event) {
+	msg, ok := e.(*event.BridgeCorrelationEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(msg)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal bridge correlation event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printAggregatorEdge outputs an aggregator edge event in JSON format
+func (j *JSONLDisplay) printAggregatorEdge(e event.Event) {
+	msg, ok := e.(*event.AggregatorEdgeEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(msg)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal aggregator edge event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printToolCallResult outputs a tool call result event in JSON format
+func (j *JSONLDisplay) printToolCallResult(e event.Event) {
+	result, ok := e.(*event.ToolCallResultEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(result)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal tool call result event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printLargeToolArgs outputs an oversized tool-call-arguments warning in JSON format
+func (j *JSONLDisplay) printLargeToolArgs(e event.Event) {
+	warning, ok := e.(*event.LargeToolArgsEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(warning)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal large tool args event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printProtocolViolation outputs an initialize handshake violation in JSON format
+func (j *JSONLDisplay) printProtocolViolation(e event.Event) {
+	violation, ok := e.(*event.ProtocolViolationEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(violation)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal protocol violation event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printProtocolVersionMismatch outputs a negotiated protocol version
+// mismatch in JSON format
+func (j *JSONLDisplay) printProtocolVersionMismatch(e event.Event) {
+	mismatch, ok := e.(*event.ProtocolVersionMismatchEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(mismatch)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal protocol version mismatch event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printGRPCDetected outputs a gRPC-on-HTTP/2 detection event in JSON format
+func (j *JSONLDisplay) printGRPCDetected(e event.Event) {
+	detected, ok := e.(*event.GRPCDetectedEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(detected)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal gRPC detected event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printBaselineAnomaly outputs a baseline deviation anomaly in JSON format
+func (j *JSONLDisplay) printBaselineAnomaly(e event.Event) {
+	anomaly, ok := e.(*event.BaselineAnomalyEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(anomaly)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal baseline anomaly event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printRuleMatch outputs a user-defined rule match in JSON format
+func (j *JSONLDisplay) printRuleMatch(e event.Event) {
+	match, ok := e.(*event.RuleMatchEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(match)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal rule match event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printUnexpectedEgress outputs an unexpected-egress event in JSON format
+func (j *JSONLDisplay) printUnexpectedEgress(e event.Event) {
+	egress, ok := e.(*event.UnexpectedEgressEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(egress)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal unexpected egress event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
+
+// printAlert outputs an error-rate alert event in JSON format
+func (j *JSONLDisplay) printAlert(e event.Event) {
+	alert, ok := e.(*event.AlertEvent)
+	if !ok {
+		return
+	}
+
+	data, err := j.marshal(alert)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal alert event")
+		return
+	}
+
+	fmt.Fprintf(j.writer, "%s\n", string(data))
+}
@@ -0,0 +1,140 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestJSONLDisplay_PIDCommFilterScopesOutput(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	j, err := NewJSONLDisplay(&buf, eventBus)
+	if err != nil {
+		t.Fatalf("NewJSONLDisplay() error = %v", err)
+	}
+	j.SetPIDCommFilter(nil, []string{"claude"})
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+	})
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 300, FromComm: "cursor", ToPID: 400, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(2), Method: "tools/call"},
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, `"from_comm":"claude"`) {
+		t.Errorf("expected output to include traffic matching --comm, got:\n%s", out)
+	}
+	if strings.Contains(out, "cursor") {
+		t.Errorf("expected output to exclude traffic outside --comm, got:\n%s", out)
+	}
+}
+
+func TestJSONLDisplay_PIDFilterScopesOutput(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	j, err := NewJSONLDisplay(&buf, eventBus)
+	if err != nil {
+		t.Fatalf("NewJSONLDisplay() error = %v", err)
+	}
+	j.SetPIDFilter(func(pid uint32) bool { return pid == 200 })
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 200, FromComm: "claude", ToPID: 201, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+	})
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 900, FromComm: "other", ToPID: 901, ToComm: "other-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(2), Method: "tools/call"},
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, "claude") {
+		t.Errorf("expected output to include the accepted PID's traffic, got:\n%s", out)
+	}
+	if strings.Contains(out, "other-server") {
+		t.Errorf("expected output to exclude traffic outside the PID filter, got:\n%s", out)
+	}
+}
+
+func TestJSONLDisplay_LabelsAttachedToEveryRecord(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	j, err := NewJSONLDisplay(&buf, eventBus)
+	if err != nil {
+		t.Fatalf("NewJSONLDisplay() error = %v", err)
+	}
+	j.SetLabels(map[string]string{"run": "ci-42", "host": "build-1"})
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+	})
+	eventBus.Publish(&event.SecurityAlertEvent{RiskScore: 0.9})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d output lines, want 2:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("Unmarshal(%q) error = %v", line, err)
+		}
+		labels, ok := decoded["labels"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("record missing labels field: %s", line)
+		}
+		if labels["run"] != "ci-42" || labels["host"] != "build-1" {
+			t.Errorf("labels = %v, want run=ci-42 host=build-1", labels)
+		}
+	}
+}
+
+func TestJSONLDisplay_RedactMasksConfiguredKeys(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	j, err := NewJSONLDisplay(&buf, eventBus)
+	if err != nil {
+		t.Fatalf("NewJSONLDisplay() error = %v", err)
+	}
+	j.SetRedactPatterns(DefaultRedactPatterns)
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "tools/call",
+			Params:      map[string]interface{}{"api_key": "sk-abc123", "query": "weather"},
+		},
+	})
+
+	out := buf.String()
+	if strings.Contains(out, "sk-abc123") {
+		t.Errorf("expected api_key value to be redacted, got:\n%s", out)
+	}
+	if !strings.Contains(out, RedactedPlaceholder) {
+		t.Errorf("expected redaction placeholder in output, got:\n%s", out)
+	}
+	if !strings.Contains(out, "weather") {
+		t.Errorf("expected unrelated field to survive redaction, got:\n%s", out)
+	}
+}
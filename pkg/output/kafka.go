@@ -0,0 +1,114 @@
+//go:build kafka
+
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+)
+
+// KafkaDisplay publishes captured events to a Kafka topic.
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+// - EventTypeLLMMessage
+//
+// Messages are keyed by session id (PID for stdio, PID for HTTP) so that the
+// Kafka partitioner preserves per-session ordering.
+//
+// Only built when compiled with `-tags kafka`, to keep the default build
+// free of the kafka-go dependency for users who don't need it.
+type KafkaDisplay struct {
+	writer   *kafka.Writer
+	eventBus bus.EventBus
+}
+
+// NewKafkaDisplay creates a display handler that produces events to Kafka.
+func NewKafkaDisplay(brokers []string, topic string, eventBus bus.EventBus) (*KafkaDisplay, error) {
+	k := &KafkaDisplay{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{}, // Partition by key for per-session ordering
+			BatchTimeout: 100 * time.Millisecond,
+			RequiredAcks: kafka.RequireOne,
+			Async:        true, // Don't block capture on producer backpressure
+			Completion: func(messages []kafka.Message, err error) {
+				if err != nil {
+					logrus.WithError(err).Warn("Failed to produce event(s) to Kafka")
+				}
+			},
+		},
+		eventBus: eventBus,
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, k.produceMessage); err != nil {
+		return nil, err
+	}
+	if err := eventBus.Subscribe(event.EventTypeLLMMessage, k.produceLLMMessage); err != nil {
+		k.Close()
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func (k *KafkaDisplay) produceMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	var key string
+	if msg.StdioTransport != nil {
+		key = strconv.FormatUint(uint64(msg.StdioTransport.FromPID), 10)
+	} else if msg.HttpTransport != nil {
+		key = strconv.FormatUint(uint64(msg.HttpTransport.PID), 10)
+	}
+
+	k.produce(key, msg)
+}
+
+func (k *KafkaDisplay) produceLLMMessage(e event.Event) {
+	msg, ok := e.(*event.LLMEvent)
+	if !ok {
+		return
+	}
+
+	k.produce(strconv.FormatUint(msg.SessionID, 10), msg)
+}
+
+func (k *KafkaDisplay) produce(key string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to marshal event for Kafka")
+		return
+	}
+
+	// Async writer buffers internally and retries transient errors without
+	// blocking the caller; errors surface via the Completion callback above.
+	if err := k.writer.WriteMessages(context.Background(), kafka.Message{
+		Key:   []byte(key),
+		Value: data,
+	}); err != nil {
+		logrus.WithError(err).Warn("Failed to enqueue event for Kafka")
+	}
+}
+
+func (k *KafkaDisplay) Close() error {
+	k.eventBus.Unsubscribe(event.EventTypeMCPMessage, k.produceMessage)
+	k.eventBus.Unsubscribe(event.EventTypeLLMMessage, k.produceLLMMessage)
+
+	if err := k.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close Kafka writer: %w", err)
+	}
+
+	return nil
+}
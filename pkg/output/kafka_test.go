@@ -0,0 +1,51 @@
+//go:build kafka
+
+package output
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// TestKafkaDisplay_ProducesMessage exercises the display against a local
+// Kafka broker. Requires a broker on localhost:9092 (e.g. `make
+// kafka-up` or `docker run -p 9092:9092 ...`); skipped otherwise since no
+// embedded broker is vendored for unit tests.
+func TestKafkaDisplay_ProducesMessage(t *testing.T) {
+	conn, err := net.DialTimeout("tcp", "localhost:9092", 200*time.Millisecond)
+	if err != nil {
+		t.Skip("no local Kafka broker on localhost:9092, skipping")
+	}
+	conn.Close()
+
+	eventBus := tu.NewMockBus()
+	defer eventBus.Close()
+
+	display, err := NewKafkaDisplay([]string{"localhost:9092"}, "mcpspy-test", eventBus)
+	if err != nil {
+		t.Fatalf("Failed to create Kafka display: %v", err)
+	}
+	defer display.Close()
+
+	msg := &event.MCPEvent{
+		TransportType: event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{
+			FromPID: 123,
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeNotification,
+			Method:      "notifications/initialized",
+		},
+	}
+
+	display.produceMessage(msg)
+
+	// Give the async writer a moment to flush; a real assertion would
+	// consume from the topic, but that requires a configured reader offset
+	// which is out of scope for this smoke test.
+	time.Sleep(200 * time.Millisecond)
+}
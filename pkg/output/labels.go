@@ -0,0 +1,25 @@
+package output
+
+import "encoding/json"
+
+// injectLabelsJSONBytes re-encodes a JSON document with a top-level "labels"
+// field set to labels, overwriting any existing "labels" key. If data isn't
+// a JSON object (or isn't valid JSON at all), it's returned unchanged.
+func injectLabelsJSONBytes(data []byte, labels map[string]string) []byte {
+	if len(labels) == 0 {
+		return data
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return data
+	}
+
+	decoded["labels"] = labels
+
+	out, err := json.Marshal(decoded)
+	if err != nil {
+		return data
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInjectLabelsJSONBytes_AddsLabelsField(t *testing.T) {
+	out := injectLabelsJSONBytes([]byte(`{"method":"tools/call"}`), map[string]string{"run": "ci-42"})
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	labels, ok := decoded["labels"].(map[string]interface{})
+	if !ok || labels["run"] != "ci-42" {
+		t.Errorf("labels = %v, want {run: ci-42}", decoded["labels"])
+	}
+	if decoded["method"] != "tools/call" {
+		t.Errorf("method = %v, want unchanged", decoded["method"])
+	}
+}
+
+func TestInjectLabelsJSONBytes_NoLabelsPassesThrough(t *testing.T) {
+	raw := []byte(`{"method":"tools/call"}`)
+	if out := injectLabelsJSONBytes(raw, nil); string(out) != string(raw) {
+		t.Errorf("injectLabelsJSONBytes() = %q, want unchanged %q", out, raw)
+	}
+}
+
+func TestInjectLabelsJSONBytes_InvalidJSONPassesThrough(t *testing.T) {
+	raw := []byte("not json")
+	if out := injectLabelsJSONBytes(raw, map[string]string{"run": "ci-42"}); string(out) != string(raw) {
+		t.Errorf("injectLabelsJSONBytes() = %q, want unchanged %q", out, raw)
+	}
+}
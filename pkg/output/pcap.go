@@ -0,0 +1,187 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/sirupsen/logrus"
+)
+
+// pcapSnapLen is large enough to hold any MCP message we're willing to
+// reassemble (see pkg/mcp.Config.MaxMessageBytes) plus its synthetic
+// headers, so WritePacket never truncates.
+const pcapSnapLen = 10 * 1024 * 1024
+
+// PcapDisplay writes each MCP message as a synthetic loopback packet to a
+// pcap file, so a capture can be opened in Wireshark and inspected with its
+// JSON dissector.
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+type PcapDisplay struct {
+	writer *pcapgo.Writer
+
+	// pidCommFilter, when set, restricts written output to MCPEvents
+	// attributable to a PID or process name it accepts (--pid/--comm). Nil
+	// means no filtering.
+	pidCommFilter *pidCommFilter
+
+	// pidFilter, when set, restricts written output to MCPEvents
+	// attributable to a PID it accepts (e.g. --follow-children). Nil means no
+	// filtering. ANDed with pidCommFilter when both are set.
+	pidFilter func(pid uint32) bool
+
+	// seq numbers outgoing packets to keep source TCP sequence numbers
+	// monotonically increasing per-direction, which is what lets Wireshark
+	// treat a capture as a single ordered TCP stream instead of flagging
+	// every packet as out-of-order.
+	seq uint32
+}
+
+// NewPcapDisplay creates a display handler that writes MCP messages to w as
+// a pcap capture (loopback link type). w is written to the end of the
+// process's life; the caller is responsible for creating/closing it.
+func NewPcapDisplay(w io.Writer, eventBus bus.EventBus) (*PcapDisplay, error) {
+	pcapWriter := pcapgo.NewWriter(w)
+	if err := pcapWriter.WriteFileHeader(pcapSnapLen, layers.LinkTypeLoop); err != nil {
+		return nil, fmt.Errorf("failed to write pcap file header: %w", err)
+	}
+
+	p := &PcapDisplay{writer: pcapWriter}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, p.writeMessage); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// SetPIDCommFilter restricts subsequent writes to MCPEvents attributable to
+// one of pids or whose process name contains one of comms (case-insensitive
+// substring match); both are ANDed together when non-empty. Pass two nil/empty
+// slices to remove any existing filter.
+func (p *PcapDisplay) SetPIDCommFilter(pids []uint32, comms []string) {
+	p.pidCommFilter = newPIDCommFilter(pids, comms)
+}
+
+// SetPIDFilter restricts subsequent writes to MCPEvents attributable to a
+// PID accepted by filter. Pass nil to remove any existing filter.
+func (p *PcapDisplay) SetPIDFilter(filter func(pid uint32) bool) {
+	p.pidFilter = filter
+}
+
+// acceptsMessage reports whether msg passes both the static --pid/--comm
+// filter and the dynamic PID filter (e.g. --follow-children).
+func (p *PcapDisplay) acceptsMessage(msg *event.MCPEvent) bool {
+	if !p.pidCommFilter.Accepts(msg) {
+		return false
+	}
+	if p.pidFilter == nil {
+		return true
+	}
+	switch msg.TransportType {
+	case event.TransportTypeStdio:
+		if msg.StdioTransport != nil {
+			return p.pidFilter(msg.FromPID) || p.pidFilter(msg.ToPID)
+		}
+	case event.TransportTypeHTTP:
+		if msg.HttpTransport != nil {
+			return p.pidFilter(msg.HttpTransport.PID)
+		}
+	}
+	return true
+}
+
+// writeMessage encodes a single MCP message as a synthetic loopback/IPv4/TCP
+// packet carrying the raw JSON-RPC as its payload, and appends it to the pcap
+// file.
+func (p *PcapDisplay) writeMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	if !p.acceptsMessage(msg) {
+		return
+	}
+
+	data, err := p.buildPacket(msg)
+	if err != nil {
+		logrus.WithError(err).Error("failed to build synthetic packet for pcap export")
+		return
+	}
+
+	ci := gopacket.CaptureInfo{
+		Timestamp:     msg.Timestamp,
+		CaptureLength: len(data),
+		Length:        len(data),
+	}
+	if err := p.writer.WritePacket(ci, data); err != nil {
+		logrus.WithError(err).Error("failed to write pcap packet")
+	}
+}
+
+// buildPacket serializes msg as a loopback frame carrying a minimal
+// IPv4/TCP/payload stack. There's no real socket behind an MCP message (it's
+// reconstructed from stdio or an already-terminated HTTP/TCP connection), so
+// the addressing is synthetic: both endpoints are 127.0.0.1, and the PID of
+// the process that produced/consumed the message is encoded as the TCP
+// source port (masked to 16 bits) since that's the only header field
+// Wireshark's JSON dissector leaves free for us to repurpose. The PID is also
+// duplicated into the payload as a one-line comment-style prefix for
+// capture tools that don't cross-reference ports.
+func (p *PcapDisplay) buildPacket(msg *event.MCPEvent) ([]byte, error) {
+	pid := p.pid(msg)
+
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolTCP,
+		SrcIP:    []byte{127, 0, 0, 1},
+		DstIP:    []byte{127, 0, 0, 1},
+	}
+	tcp := &layers.TCP{
+		SrcPort: layers.TCPPort(uint16(pid)),
+		DstPort: layers.TCPPort(4242),
+		PSH:     true,
+		ACK:     true,
+		Seq:     p.seq,
+		Window:  65535,
+	}
+	p.seq += uint32(len(msg.Raw))
+	if err := tcp.SetNetworkLayerForChecksum(ip); err != nil {
+		return nil, err
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	err := gopacket.SerializeLayers(buf, opts,
+		&layers.Loopback{Family: layers.ProtocolFamilyIPv4},
+		ip,
+		tcp,
+		gopacket.Payload(msg.Raw),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// pid returns the PID attributable to msg across whichever transport carried
+// it, or 0 if none is available.
+func (p *PcapDisplay) pid(msg *event.MCPEvent) uint32 {
+	switch {
+	case msg.StdioTransport != nil:
+		return msg.StdioTransport.FromPID
+	case msg.HttpTransport != nil:
+		return msg.HttpTransport.PID
+	case msg.TCPTransport != nil:
+		return msg.TCPTransport.PID
+	default:
+		return 0
+	}
+}
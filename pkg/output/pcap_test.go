@@ -0,0 +1,124 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+func TestPcapDisplay_WritesParseablePacketWithPIDAndPayload(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	if _, err := NewPcapDisplay(&buf, eventBus); err != nil {
+		t.Fatalf("NewPcapDisplay() error = %v", err)
+	}
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 4242, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+		Raw:            `{"jsonrpc":"2.0","id":1,"method":"tools/call"}`,
+	})
+
+	reader, err := pcapgo.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("pcapgo.NewReader() error = %v", err)
+	}
+	if reader.LinkType() != layers.LinkTypeLoop {
+		t.Errorf("expected loopback link type, got %v", reader.LinkType())
+	}
+
+	data, _, err := reader.ReadPacketData()
+	if err != nil {
+		t.Fatalf("ReadPacketData() error = %v", err)
+	}
+
+	pkt := gopacket.NewPacket(data, layers.LayerTypeLoopback, gopacket.Default)
+	tcpLayer := pkt.Layer(layers.LayerTypeTCP)
+	if tcpLayer == nil {
+		t.Fatalf("expected a TCP layer, got none. Layers: %v", pkt.Layers())
+	}
+	tcp := tcpLayer.(*layers.TCP)
+	if uint32(tcp.SrcPort) != 4242 {
+		t.Errorf("expected source port to encode the PID 4242, got %d", tcp.SrcPort)
+	}
+
+	appLayer := pkt.ApplicationLayer()
+	if appLayer == nil {
+		t.Fatalf("expected an application-layer payload, got none")
+	}
+	if string(appLayer.Payload()) != `{"jsonrpc":"2.0","id":1,"method":"tools/call"}` {
+		t.Errorf("expected payload to carry the raw JSON-RPC message, got %q", string(appLayer.Payload()))
+	}
+}
+
+func TestPcapDisplay_PIDCommFilterScopesOutput(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	p, err := NewPcapDisplay(&buf, eventBus)
+	if err != nil {
+		t.Fatalf("NewPcapDisplay() error = %v", err)
+	}
+	p.SetPIDCommFilter(nil, []string{"claude"})
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, FromComm: "cursor", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+		Raw:            `{"jsonrpc":"2.0","id":1,"method":"tools/call"}`,
+	})
+
+	if buf.Len() != 24 {
+		t.Errorf("expected only the 24-byte pcap file header (filtered message dropped), got %d bytes", buf.Len())
+	}
+}
+
+func TestPcapDisplay_PIDFilterScopesOutput(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	var buf bytes.Buffer
+
+	p, err := NewPcapDisplay(&buf, eventBus)
+	if err != nil {
+		t.Fatalf("NewPcapDisplay() error = %v", err)
+	}
+	p.SetPIDFilter(func(pid uint32) bool { return pid == 200 })
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, FromComm: "cursor", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+		Raw:            `{"jsonrpc":"2.0","id":1,"method":"tools/call"}`,
+	})
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 900, FromComm: "other", ToPID: 901, ToComm: "other-server"},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(2), Method: "tools/call"},
+		Raw:            `{"jsonrpc":"2.0","id":2,"method":"tools/call"}`,
+	})
+
+	reader, err := pcapgo.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("pcapgo.NewReader() error = %v", err)
+	}
+
+	data, _, err := reader.ReadPacketData()
+	if err != nil {
+		t.Fatalf("ReadPacketData() error = %v", err)
+	}
+	pkt := gopacket.NewPacket(data, layers.LayerTypeLoopback, gopacket.Default)
+	appLayer := pkt.ApplicationLayer()
+	if appLayer == nil || string(appLayer.Payload()) != `{"jsonrpc":"2.0","id":1,"method":"tools/call"}` {
+		t.Fatalf("expected the accepted-PID message's payload, got %v", appLayer)
+	}
+
+	if _, _, err := reader.ReadPacketData(); err == nil {
+		t.Error("expected only one packet to be written; the rejected-PID message should have been dropped")
+	}
+}
@@ -0,0 +1,88 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// RedactedPlaceholder replaces any JSON value whose key matches a redact
+// pattern.
+const RedactedPlaceholder = "***REDACTED***"
+
+// DefaultRedactPatterns is the set of key substrings redacted by default in
+// printed buffers and JSONL output, covering the common names MCP servers
+// use for credentials passed through tool arguments and results.
+var DefaultRedactPatterns = []string{"token", "secret", "password", "api_key", "authorization"}
+
+// redactJSON walks an arbitrary decoded JSON value (as produced by
+// json.Unmarshal into interface{}) and replaces every value whose object key
+// matches one of patterns (case-insensitive substring match) with
+// RedactedPlaceholder. Matching happens on the key, not the value, so a
+// redacted field always becomes a string regardless of its original type.
+func redactJSON(v interface{}, patterns []string) interface{} {
+	if len(patterns) == 0 {
+		return v
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if keyMatchesRedactPattern(k, patterns) {
+				val[k] = RedactedPlaceholder
+			} else {
+				val[k] = redactJSON(child, patterns)
+			}
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactJSON(child, patterns)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// keyMatchesRedactPattern reports whether key should be redacted under
+// patterns. Matching is case-insensitive and by substring, with underscores
+// stripped from both sides first so "api_key" also matches "apiKey".
+func keyMatchesRedactPattern(key string, patterns []string) bool {
+	normalized := strings.ReplaceAll(strings.ToLower(key), "_", "")
+	for _, p := range patterns {
+		p = strings.ReplaceAll(strings.ToLower(p), "_", "")
+		if p != "" && strings.Contains(normalized, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactJSONBytes re-encodes a JSON document with redactJSON applied, for
+// reuse by consumers outside this package that need the same masking rules
+// applied to raw captured content (see pkg/webhook). If data isn't valid
+// JSON, it's returned unchanged (e.g. a non-JSON raw buffer captured
+// verbatim).
+func RedactJSONBytes(data []byte, patterns []string) []byte {
+	return redactJSONBytes(data, patterns)
+}
+
+// redactJSONBytes re-encodes a JSON document with redactJSON applied. If
+// data isn't valid JSON, it's returned unchanged (e.g. a non-JSON raw
+// buffer captured verbatim).
+func redactJSONBytes(data []byte, patterns []string) []byte {
+	if len(patterns) == 0 {
+		return data
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return data
+	}
+
+	redacted, err := json.Marshal(redactJSON(decoded, patterns))
+	if err != nil {
+		return data
+	}
+	return redacted
+}
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRedactJSON_TopLevelKey(t *testing.T) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(`{"api_key":"sk-abc123","name":"alice"}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	out := redactJSON(v, DefaultRedactPatterns).(map[string]interface{})
+	if out["api_key"] != RedactedPlaceholder {
+		t.Errorf("api_key = %v, want %s", out["api_key"], RedactedPlaceholder)
+	}
+	if out["name"] != "alice" {
+		t.Errorf("name = %v, want unchanged", out["name"])
+	}
+}
+
+func TestRedactJSON_NestedObject(t *testing.T) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(`{"params":{"headers":{"Authorization":"Bearer xyz"},"query":"weather"}}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	out := redactJSON(v, DefaultRedactPatterns)
+	params := out.(map[string]interface{})["params"].(map[string]interface{})
+	headers := params["headers"].(map[string]interface{})
+	if headers["Authorization"] != RedactedPlaceholder {
+		t.Errorf("Authorization = %v, want %s", headers["Authorization"], RedactedPlaceholder)
+	}
+	if params["query"] != "weather" {
+		t.Errorf("query = %v, want unchanged", params["query"])
+	}
+}
+
+func TestRedactJSON_ArrayOfObjects(t *testing.T) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(`{"credentials":[{"password":"p1"},{"password":"p2","user":"bob"}]}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	out := redactJSON(v, DefaultRedactPatterns)
+	creds := out.(map[string]interface{})["credentials"].([]interface{})
+	for i, c := range creds {
+		cm := c.(map[string]interface{})
+		if cm["password"] != RedactedPlaceholder {
+			t.Errorf("credentials[%d].password = %v, want %s", i, cm["password"], RedactedPlaceholder)
+		}
+	}
+	if creds[1].(map[string]interface{})["user"] != "bob" {
+		t.Errorf("credentials[1].user = %v, want unchanged", creds[1].(map[string]interface{})["user"])
+	}
+}
+
+func TestRedactJSON_KeyMatchIgnoresCaseAndUnderscore(t *testing.T) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(`{"apiKey":"sk-abc123","API-SECRET":"s1"}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	out := redactJSON(v, DefaultRedactPatterns).(map[string]interface{})
+	if out["apiKey"] != RedactedPlaceholder {
+		t.Errorf("apiKey = %v, want %s", out["apiKey"], RedactedPlaceholder)
+	}
+	if out["API-SECRET"] != RedactedPlaceholder {
+		t.Errorf("API-SECRET = %v, want %s", out["API-SECRET"], RedactedPlaceholder)
+	}
+}
+
+func TestRedactJSON_NoPatternsDisablesRedaction(t *testing.T) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(`{"token":"t1"}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	out := redactJSON(v, nil).(map[string]interface{})
+	if out["token"] != "t1" {
+		t.Errorf("token = %v, want unchanged when no patterns given", out["token"])
+	}
+}
+
+func TestRedactJSONBytes_InvalidJSONPassesThrough(t *testing.T) {
+	raw := []byte("not json")
+	if out := redactJSONBytes(raw, DefaultRedactPatterns); string(out) != string(raw) {
+		t.Errorf("redactJSONBytes() = %q, want unchanged %q", out, raw)
+	}
+}
@@ -0,0 +1,191 @@
+package output
+
+import "github.com/fatih/color"
+
+// Theme groups every color used by the console/TUI output, so selecting a
+// color scheme (--color-theme) is a single swap of this struct rather than
+// touching each color.New call individually.
+type Theme struct {
+	Timestamp     *color.Color
+	Transport     *color.Color
+	PID           *color.Color
+	Comm          *color.Color
+	Method        *color.Color
+	Error         *color.Color
+	ErrorCode     *color.Color
+	ErrorLabel    *color.Color
+	ValidationErr *color.Color
+	Header        *color.Color
+	ID            *color.Color
+
+	SecurityAlert *color.Color
+	SecurityWarn  *color.Color
+	SecurityLow   *color.Color
+
+	LLMModel *color.Color
+	Tool     *color.Color
+	Pass     *color.Color
+	Fail     *color.Color
+
+	LogLevelError *color.Color
+	LogLevelWarn  *color.Color
+	LogLevelDim   *color.Color
+}
+
+// darkTheme is the original palette, tuned for a dark terminal background.
+func darkTheme() Theme {
+	return Theme{
+		Timestamp:     color.New(color.FgHiBlack),
+		Transport:     color.New(color.FgHiCyan),
+		PID:           color.New(color.FgCyan),
+		Comm:          color.New(color.FgYellow),
+		Method:        color.New(color.FgGreen),
+		Error:         color.New(color.FgRed),
+		ErrorCode:     color.New(color.FgHiRed),
+		ErrorLabel:    color.New(color.FgRed, color.Bold),
+		ValidationErr: color.New(color.FgRed),
+		Header:        color.New(color.FgWhite, color.Bold),
+		ID:            color.New(color.FgHiBlack),
+		SecurityAlert: color.New(color.FgRed, color.Bold),
+		SecurityWarn:  color.New(color.FgYellow, color.Bold),
+		SecurityLow:   color.New(color.FgYellow),
+		LLMModel:      color.New(color.FgMagenta),
+		Tool:          color.New(color.FgCyan, color.Bold),
+		Pass:          color.New(color.FgGreen, color.Bold),
+		Fail:          color.New(color.FgRed, color.Bold),
+		LogLevelError: color.New(color.FgRed),
+		LogLevelWarn:  color.New(color.FgYellow),
+		LogLevelDim:   color.New(color.FgHiBlack),
+	}
+}
+
+// lightTheme swaps the hi-black/white accents that wash out on a light
+// terminal background for darker equivalents, keeping the rest of the
+// palette (which already reads fine on light backgrounds) unchanged.
+func lightTheme() Theme {
+	t := darkTheme()
+	t.Timestamp = color.New(color.FgBlack)
+	t.Header = color.New(color.FgBlack, color.Bold)
+	t.ID = color.New(color.FgBlack)
+	t.LogLevelDim = color.New(color.FgBlack)
+	return t
+}
+
+// colorblindTheme avoids relying on a red/green distinction (the most common
+// form of color blindness) by using blue for "good" and orange/yellow for
+// "bad", distinguished further by the surrounding text (ERR/OK labels)
+// rather than color alone.
+func colorblindTheme() Theme {
+	return Theme{
+		Timestamp:     color.New(color.FgHiBlack),
+		Transport:     color.New(color.FgHiCyan),
+		PID:           color.New(color.FgCyan),
+		Comm:          color.New(color.FgYellow),
+		Method:        color.New(color.FgBlue),
+		Error:         color.New(color.FgHiYellow),
+		ErrorCode:     color.New(color.FgHiYellow, color.Bold),
+		ErrorLabel:    color.New(color.FgHiYellow, color.Bold),
+		ValidationErr: color.New(color.FgHiYellow),
+		Header:        color.New(color.FgWhite, color.Bold),
+		ID:            color.New(color.FgHiBlack),
+		SecurityAlert: color.New(color.FgHiYellow, color.Bold),
+		SecurityWarn:  color.New(color.FgYellow, color.Bold),
+		SecurityLow:   color.New(color.FgYellow),
+		LLMModel:      color.New(color.FgMagenta),
+		Tool:          color.New(color.FgBlue, color.Bold),
+		Pass:          color.New(color.FgBlue, color.Bold),
+		Fail:          color.New(color.FgHiYellow, color.Bold),
+		LogLevelError: color.New(color.FgHiYellow),
+		LogLevelWarn:  color.New(color.FgYellow),
+		LogLevelDim:   color.New(color.FgHiBlack),
+	}
+}
+
+// monoTheme renders every element with DisableColor, for terminals/pipes
+// where escape codes are unwanted but --no-color wasn't passed (e.g.
+// selecting the theme explicitly in a config shared across environments).
+func monoTheme() Theme {
+	t := darkTheme()
+	v := &t
+	for _, c := range []**color.Color{
+		&v.Timestamp, &v.Transport, &v.PID, &v.Comm, &v.Method, &v.Error,
+		&v.ErrorCode, &v.ErrorLabel, &v.ValidationErr, &v.Header, &v.ID,
+		&v.SecurityAlert, &v.SecurityWarn, &v.SecurityLow, &v.LLMModel,
+		&v.Tool, &v.Pass, &v.Fail, &v.LogLevelError, &v.LogLevelWarn, &v.LogLevelDim,
+	} {
+		(*c).DisableColor()
+	}
+	return t
+}
+
+// ColorThemeNames lists the valid --color-theme values, in the order they
+// should be presented in help text.
+var ColorThemeNames = []string{"dark", "light", "colorblind", "mono"}
+
+// themeByName returns the named theme, or false if name isn't recognized.
+func themeByName(name string) (Theme, bool) {
+	switch name {
+	case "dark":
+		return darkTheme(), true
+	case "light":
+		return lightTheme(), true
+	case "colorblind":
+		return colorblindTheme(), true
+	case "mono":
+		return monoTheme(), true
+	default:
+		return Theme{}, false
+	}
+}
+
+// activeTheme backs the package-level color vars consumed throughout
+// console.go; SetColorTheme is the only thing that should reassign it.
+var activeTheme = darkTheme()
+
+// SetColorTheme selects the console color palette by name (one of
+// ColorThemeNames) and repoints every color variable used by the console
+// display at it. It's independent of --no-color/NO_COLOR, which disable
+// color output entirely regardless of the theme in effect.
+func SetColorTheme(name string) error {
+	t, ok := themeByName(name)
+	if !ok {
+		return &unknownThemeError{name: name}
+	}
+	activeTheme = t
+	applyActiveTheme()
+	return nil
+}
+
+// applyActiveTheme repoints the package-level color vars at activeTheme's
+// fields.
+func applyActiveTheme() {
+	timestampColor = activeTheme.Timestamp
+	transportColor = activeTheme.Transport
+	pidColor = activeTheme.PID
+	commColor = activeTheme.Comm
+	methodColor = activeTheme.Method
+	errorColor = activeTheme.Error
+	errorCodeColor = activeTheme.ErrorCode
+	errorLabelColor = activeTheme.ErrorLabel
+	validationErrColor = activeTheme.ValidationErr
+	headerColor = activeTheme.Header
+	idColor = activeTheme.ID
+	securityAlertColor = activeTheme.SecurityAlert
+	securityWarnColor = activeTheme.SecurityWarn
+	securityLowColor = activeTheme.SecurityLow
+	llmModelColor = activeTheme.LLMModel
+	toolColor = activeTheme.Tool
+	passColor = activeTheme.Pass
+	failColor = activeTheme.Fail
+	logLevelErrorColor = activeTheme.LogLevelError
+	logLevelWarnColor = activeTheme.LogLevelWarn
+	logLevelDimColor = activeTheme.LogLevelDim
+}
+
+type unknownThemeError struct {
+	name string
+}
+
+func (e *unknownThemeError) Error() string {
+	return "unknown color theme: " + e.name
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/fatih/color"
+)
+
+func TestSetColorTheme_UnknownNameErrors(t *testing.T) {
+	if err := SetColorTheme("nonexistent"); err == nil {
+		t.Fatal("expected an error for an unknown theme name")
+	}
+}
+
+func TestSetColorTheme_ChangesRenderedEscapeSequences(t *testing.T) {
+	orig := color.NoColor
+	color.NoColor = false
+	defer func() {
+		color.NoColor = orig
+		if err := SetColorTheme("dark"); err != nil {
+			t.Fatalf("failed to restore dark theme: %v", err)
+		}
+	}()
+
+	render := func(theme string) string {
+		if err := SetColorTheme(theme); err != nil {
+			t.Fatalf("SetColorTheme(%q) error = %v", theme, err)
+		}
+		eventBus := tu.NewMockBus()
+		var buf bytes.Buffer
+		if _, err := NewConsoleDisplay(&buf, false, eventBus); err != nil {
+			t.Fatalf("NewConsoleDisplay() error = %v", err)
+		}
+		eventBus.Publish(logNotification("error"))
+		return buf.String()
+	}
+
+	dark := render("dark")
+	mono := render("mono")
+
+	if !containsEscape(dark) {
+		t.Errorf("expected the dark theme to emit ANSI escape codes, got:\n%q", dark)
+	}
+	if containsEscape(mono) {
+		t.Errorf("expected the mono theme to emit no ANSI escape codes, got:\n%q", mono)
+	}
+
+	colorblind := render("colorblind")
+	if dark == colorblind {
+		t.Errorf("expected colorblind theme output to differ from dark theme output")
+	}
+}
+
+func containsEscape(s string) bool {
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == '\x1b' && s[i+1] == '[' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Package proctree answers whether a PID belongs to the process tree rooted
+// at another PID, by walking /proc ancestry. It backs `mcpspy run`, which
+// scopes monitoring to the process tree of a command it launched rather than
+// the whole host.
+package proctree
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxAncestryDepth bounds the /proc walk so a reused PID or a /proc read
+// racing against process exit can't cause an unbounded climb.
+const maxAncestryDepth = 64
+
+// Tree answers whether a PID is the root PID it was created with, or a
+// descendant of it. Results are cached per PID queried, since a PID's
+// ancestry up to the root doesn't change over its lifetime.
+type Tree struct {
+	root uint32
+
+	mu    sync.Mutex
+	known map[uint32]bool
+}
+
+// New returns a Tree scoped to the process tree rooted at root.
+func New(root uint32) *Tree {
+	return &Tree{
+		root:  root,
+		known: map[uint32]bool{root: true},
+	}
+}
+
+// Contains reports whether pid is the root PID or a descendant of it.
+func (t *Tree) Contains(pid uint32) bool {
+	t.mu.Lock()
+	if known, ok := t.known[pid]; ok {
+		t.mu.Unlock()
+		return known
+	}
+	t.mu.Unlock()
+
+	result := t.isDescendant(pid)
+
+	t.mu.Lock()
+	t.known[pid] = result
+	t.mu.Unlock()
+	return result
+}
+
+// isDescendant climbs the parent chain starting at pid looking for root.
+func (t *Tree) isDescendant(pid uint32) bool {
+	for depth := 0; depth < maxAncestryDepth; depth++ {
+		if pid == t.root {
+			return true
+		}
+		if pid == 0 {
+			return false
+		}
+		ppid, ok := parentPID(pid)
+		if !ok {
+			return false
+		}
+		pid = ppid
+	}
+	return false
+}
+
+// parentPID reads the parent PID of pid from /proc/<pid>/stat.
+func parentPID(pid uint32) (uint32, bool) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, false
+	}
+
+	// Fields after comm: state, ppid, pgrp, ... The comm field itself is
+	// parenthesized and may contain spaces or parens, so find the last ')'
+	// rather than splitting naively on spaces.
+	content := string(data)
+	end := strings.LastIndexByte(content, ')')
+	if end == -1 || end+2 >= len(content) {
+		return 0, false
+	}
+
+	fields := strings.Fields(content[end+2:])
+	if len(fields) < 2 {
+		return 0, false
+	}
+
+	ppid, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(ppid), true
+}
@@ -0,0 +1,34 @@
+package proctree
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestTree_RootIsContained(t *testing.T) {
+	tree := New(42)
+	if !tree.Contains(42) {
+		t.Error("expected root pid to be contained in its own tree")
+	}
+}
+
+func TestTree_ContainsSpawnedChild(t *testing.T) {
+	cmd := exec.Command("sleep", "2")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start child process: %v", err)
+	}
+	defer cmd.Process.Kill()
+
+	tree := New(uint32(os.Getpid()))
+	if !tree.Contains(uint32(cmd.Process.Pid)) {
+		t.Errorf("expected spawned child pid %d to be contained in tree rooted at %d", cmd.Process.Pid, os.Getpid())
+	}
+}
+
+func TestTree_UnrelatedAncestorNotContained(t *testing.T) {
+	tree := New(uint32(os.Getpid()))
+	if tree.Contains(1) {
+		t.Error("expected pid 1 (an ancestor, not a descendant) to not be contained")
+	}
+}
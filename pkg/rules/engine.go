@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Engine evaluates every live MCPEvent against a Config's compiled rules
+// and triggers whichever action matched.
+//
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+//
+// Emits the following events:
+// - EventTypeRuleMatch
+type Engine struct {
+	eventBus bus.EventBus
+	rules    []compiledRule
+	onExit   func()
+	client   *http.Client
+}
+
+// New compiles config's rules and starts evaluating live MCP traffic
+// against them. onExit is called for a rule whose Action is ActionExit; it
+// may be nil, in which case an exit rule only logs.
+func New(eventBus bus.EventBus, config *Config, onExit func()) (*Engine, error) {
+	compiled := make([]compiledRule, 0, len(config.Rules))
+	for _, r := range config.Rules {
+		c, err := compileRule(r)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, c)
+	}
+
+	eng := &Engine{
+		eventBus: eventBus,
+		rules:    compiled,
+		onExit:   onExit,
+		client:   &http.Client{Timeout: webhookTimeout},
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, eng.handleMCPMessage); err != nil {
+		return nil, err
+	}
+	return eng, nil
+}
+
+func (eng *Engine) handleMCPMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	for i := range eng.rules {
+		if eng.rules[i].matches(msg) {
+			eng.trigger(eng.rules[i].rule, msg)
+		}
+	}
+}
+
+func (eng *Engine) trigger(rule Rule, msg *event.MCPEvent) {
+	fields := logrus.Fields{
+		"rule":   rule.Name,
+		"method": msg.Method,
+	}
+	if tool := msg.ExtractToolName(); tool != "" {
+		fields["tool"] = tool
+	}
+	if uri := msg.ExtractResourceURI(); uri != "" {
+		fields["resource_uri"] = uri
+	}
+
+	switch rule.Action {
+	case ActionWebhook:
+		go eng.postWebhook(rule, msg)
+	case ActionAlert:
+		eng.eventBus.Publish(&event.RuleMatchEvent{
+			Timestamp:   time.Now(),
+			Rule:        rule.Name,
+			Method:      msg.Method,
+			Tool:        msg.ExtractToolName(),
+			ResourceURI: msg.ExtractResourceURI(),
+		})
+	case ActionExit:
+		logrus.WithFields(fields).Error("Rule matched; stopping mcpspy")
+		if eng.onExit != nil {
+			eng.onExit()
+		}
+	default:
+		logrus.WithFields(fields).Warn("Rule matched")
+	}
+}
+
+// postWebhook sends a JSON summary of the match to rule.Webhook. It runs in
+// its own goroutine from trigger so a slow or unreachable endpoint never
+// blocks event processing.
+func (eng *Engine) postWebhook(rule Rule, msg *event.MCPEvent) {
+	payload, err := json.Marshal(map[string]any{
+		"rule":         rule.Name,
+		"method":       msg.Method,
+		"tool":         msg.ExtractToolName(),
+		"resource_uri": msg.ExtractResourceURI(),
+		"timestamp":    time.Now(),
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("rule", rule.Name).Error("Failed to marshal rule webhook payload")
+		return
+	}
+
+	resp, err := eng.client.Post(rule.Webhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		logrus.WithError(err).WithField("rule", rule.Name).Error("Failed to POST rule webhook")
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		logrus.WithFields(logrus.Fields{"rule": rule.Name, "status": resp.StatusCode}).Error("Rule webhook returned a non-2xx status")
+	}
+}
+
+// Close unsubscribes from the event bus.
+func (eng *Engine) Close() {
+	eng.eventBus.Unsubscribe(event.EventTypeMCPMessage, eng.handleMCPMessage)
+}
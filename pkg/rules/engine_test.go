@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestEngine_AlertActionPublishesRuleMatchEvent(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	eng, err := New(mockBus, &Config{Rules: []Rule{
+		{Name: "shell-tool", Tool: "shell*", Action: ActionAlert},
+	}}, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer eng.Close()
+
+	mockBus.Publish(toolCallEvent("shell_exec"))
+
+	var match *event.RuleMatchEvent
+	for i := 0; i < 10 && match == nil; i++ {
+		select {
+		case e := <-mockBus.Events():
+			if m, ok := e.(*event.RuleMatchEvent); ok {
+				match = m
+			}
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	if match == nil {
+		t.Fatal("expected a RuleMatchEvent to be published")
+	}
+	if match.Rule != "shell-tool" {
+		t.Errorf("Rule = %q, want %q", match.Rule, "shell-tool")
+	}
+	if match.Tool != "shell_exec" {
+		t.Errorf("Tool = %q, want %q", match.Tool, "shell_exec")
+	}
+}
+
+func TestEngine_ExitActionCallsOnExit(t *testing.T) {
+	mockBus := tu.NewMockBus()
+
+	called := make(chan struct{})
+	eng, err := New(mockBus, &Config{Rules: []Rule{
+		{Name: "shell-tool", Tool: "shell*", Action: ActionExit},
+	}}, func() { close(called) })
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer eng.Close()
+
+	mockBus.Publish(toolCallEvent("shell_exec"))
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("expected onExit to be called after an exit rule matched")
+	}
+}
+
+func TestEngine_WebhookActionPostsMatch(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	mockBus := tu.NewMockBus()
+	eng, err := New(mockBus, &Config{Rules: []Rule{
+		{Name: "shell-tool", Tool: "shell*", Action: ActionWebhook, Webhook: server.URL},
+	}}, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer eng.Close()
+
+	mockBus.Publish(toolCallEvent("shell_exec"))
+
+	select {
+	case contentType := <-received:
+		if contentType != "application/json" {
+			t.Errorf("Content-Type = %q, want %q", contentType, "application/json")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the webhook to receive a POST after a matching rule")
+	}
+}
+
+func TestEngine_NonMatchingRuleTriggersNothing(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	eng, err := New(mockBus, &Config{Rules: []Rule{
+		{Name: "shell-tool", Tool: "shell*", Action: ActionAlert},
+	}}, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer eng.Close()
+
+	mockBus.Publish(toolCallEvent("read_file"))
+
+	for {
+		select {
+		case e := <-mockBus.Events():
+			if _, ok := e.(*event.RuleMatchEvent); ok {
+				t.Fatalf("expected no RuleMatchEvent for a non-matching rule, got %v", e)
+			}
+		case <-time.After(50 * time.Millisecond):
+			return
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Package rules evaluates live MCP traffic against user-defined rules -
+// matching on JSON-RPC method, tool name, resource URI, or param
+// substrings - and triggers an action on a match: a warn-level log line, a
+// highlighted console/JSONL alert, a non-zero exit, or a webhook POST. It's
+// the configurable counterpart to pkg/baseline (drift from a recorded
+// profile) and pkg/alert (a fixed error-rate threshold).
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// Action names a rule's Action field can take.
+const (
+	ActionLog     = "log"
+	ActionAlert   = "alert"
+	ActionExit    = "exit"
+	ActionWebhook = "webhook"
+)
+
+// Rule is a single user-defined matcher loaded from a YAML rules file.
+// Method, Tool, and ResourceURI are shell-style glob patterns (*, ?, [...])
+// by default, or a regular expression if prefixed with "regex:". An empty
+// pattern matches anything. Params, if set, requires at least one of its
+// substrings to appear anywhere in the message's raw JSON.
+type Rule struct {
+	Name        string   `yaml:"name"`
+	Method      string   `yaml:"method,omitempty"`
+	Tool        string   `yaml:"tool,omitempty"`
+	ResourceURI string   `yaml:"resource_uri,omitempty"`
+	Params      []string `yaml:"params,omitempty"`
+
+	// Action is one of ActionLog (default), ActionAlert, ActionExit, or
+	// ActionWebhook.
+	Action string `yaml:"action,omitempty"`
+	// Webhook is the URL a match is POSTed to when Action is ActionWebhook.
+	Webhook string `yaml:"webhook,omitempty"`
+}
+
+// Config is the top-level shape of a rules YAML file.
+type Config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadConfig reads and parses a rules file written in the format documented
+// on Rule.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+
+	for i, r := range cfg.Rules {
+		if r.Name == "" {
+			return nil, fmt.Errorf("rule #%d: name is required", i)
+		}
+		if r.Action == ActionWebhook && r.Webhook == "" {
+			return nil, fmt.Errorf("rule %q: action is %q but webhook is empty", r.Name, ActionWebhook)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// matcher is a compiled glob or regex pattern. A nil matcher matches
+// anything, the same as an empty pattern.
+type matcher struct {
+	regex *regexp.Regexp
+	glob  string
+}
+
+func compileMatcher(pattern string) (*matcher, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	if rx, ok := strings.CutPrefix(pattern, "regex:"); ok {
+		re, err := regexp.Compile(rx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex pattern %q: %w", rx, err)
+		}
+		return &matcher{regex: re}, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+	}
+	return &matcher{glob: pattern}, nil
+}
+
+func (m *matcher) match(s string) bool {
+	if m == nil {
+		return true
+	}
+	if m.regex != nil {
+		return m.regex.MatchString(s)
+	}
+	ok, _ := path.Match(m.glob, s)
+	return ok
+}
+
+// compiledRule pairs a Rule with its pre-compiled matchers so evaluating it
+// against each incoming MCPEvent doesn't re-parse patterns on the hot path.
+type compiledRule struct {
+	rule        Rule
+	method      *matcher
+	tool        *matcher
+	resourceURI *matcher
+}
+
+func compileRule(r Rule) (compiledRule, error) {
+	method, err := compileMatcher(r.Method)
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("rule %q: method: %w", r.Name, err)
+	}
+	tool, err := compileMatcher(r.Tool)
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("rule %q: tool: %w", r.Name, err)
+	}
+	resourceURI, err := compileMatcher(r.ResourceURI)
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("rule %q: resource_uri: %w", r.Name, err)
+	}
+
+	return compiledRule{rule: r, method: method, tool: tool, resourceURI: resourceURI}, nil
+}
+
+// matches reports whether msg satisfies every criterion set on the rule.
+// Criteria left empty in the YAML are wildcards and don't constrain the
+// match.
+func (c *compiledRule) matches(msg *event.MCPEvent) bool {
+	if !c.method.match(msg.Method) {
+		return false
+	}
+	if !c.tool.match(msg.ExtractToolName()) {
+		return false
+	}
+	if !c.resourceURI.match(msg.ExtractResourceURI()) {
+		return false
+	}
+
+	if len(c.rule.Params) == 0 {
+		return true
+	}
+	for _, substr := range c.rule.Params {
+		if strings.Contains(msg.Raw, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,306 @@
+// Package session assembles per-conversation JSON bundles from the flat
+// stream of MCP events, for post-session review.
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/sirupsen/logrus"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+const (
+	// sessionCacheSize bounds the number of concurrently tracked sessions.
+	sessionCacheSize = 1024
+	// sessionIdleTimeout is how long a session can go without a new message
+	// before it's considered ended and its bundle is flushed to disk.
+	sessionIdleTimeout = 5 * time.Minute
+)
+
+// Bundle is a self-contained record of a single MCP conversation.
+type Bundle struct {
+	SessionID    string                 `json:"session_id"`
+	StartedAt    time.Time              `json:"started_at"`
+	EndedAt      time.Time              `json:"ended_at"`
+	ClientInfo   map[string]interface{} `json:"client_info,omitempty"`
+	ServerInfo   map[string]interface{} `json:"server_info,omitempty"`
+	Capabilities map[string]interface{} `json:"capabilities,omitempty"`
+	// Instructions is the server's optional initialize.result.instructions
+	// string, guiding how the client should use the server. Worth surfacing
+	// for security review, since it's the server directing the client's
+	// (often LLM-driven) behavior.
+	Instructions string            `json:"instructions,omitempty"`
+	ToolsUsed    []string          `json:"tools_used,omitempty"`
+	Messages     []*event.MCPEvent `json:"messages"`
+	Stats        BundleStats       `json:"stats"`
+
+	toolsSeen map[string]bool
+}
+
+// BundleStats summarizes a bundle's message counts.
+type BundleStats struct {
+	TotalMessages int `json:"total_messages"`
+	Requests      int `json:"requests"`
+	Responses     int `json:"responses"`
+	Notifications int `json:"notifications"`
+	Errors        int `json:"errors"`
+}
+
+// ActiveSession is a point-in-time snapshot of an in-flight session, for
+// operators to inspect ongoing conversations without interrupting capture.
+type ActiveSession struct {
+	SessionID    string                 `json:"session_id"`
+	StartedAt    time.Time              `json:"started_at"`
+	LastActivity time.Time              `json:"last_activity"`
+	Age          time.Duration          `json:"age"`
+	ClientInfo   map[string]interface{} `json:"client_info,omitempty"`
+	ServerInfo   map[string]interface{} `json:"server_info,omitempty"`
+	Instructions string                 `json:"instructions,omitempty"`
+	MessageCount int                    `json:"message_count"`
+	Stats        BundleStats            `json:"stats"`
+}
+
+// defaultNoiseMethods is the set of methods stripped from a bundle's
+// Messages when Config.StripNoise is set and Config.StripMethods is nil:
+// protocol keepalive and fan-out notifications that carry no substantive
+// tool/resource/prompt or LLM content of their own.
+var defaultNoiseMethods = []string{
+	"ping",
+	"notifications/progress",
+	"notifications/tools/list_changed",
+	"notifications/resources/list_changed",
+	"notifications/prompts/list_changed",
+}
+
+// sessionKey identifies a conversation: the PID pair exchanging messages
+// for stdio, or the server host for HTTP (there's no captured
+// Mcp-Session-Id to key on, same limitation noted in the parser's own
+// per-transport sessionKey).
+type sessionKey string
+
+func stdioSessionKey(t *event.StdioTransport) sessionKey {
+	return sessionKey(fmt.Sprintf("stdio:%d:%d", t.FromPID, t.ToPID))
+}
+
+func httpSessionKey(t *event.HttpTransport) sessionKey {
+	return sessionKey(fmt.Sprintf("http:%s", t.Host))
+}
+
+// Config customizes a Bundler's behavior beyond the required eventBus/dir.
+type Config struct {
+	// StripNoise, when true, omits protocol keepalive/fan-out noise (see
+	// StripMethods) from each bundle's Messages, so exported transcripts
+	// focus on substantive tool/resource/prompt and LLM interactions.
+	// Stats still count every message, stripped or not.
+	StripNoise bool
+
+	// StripMethods overrides the default set of methods considered noise
+	// when StripNoise is set. Defaults to defaultNoiseMethods when nil.
+	StripMethods []string
+}
+
+// Bundler tracks in-flight MCP sessions and writes a JSON bundle for each
+// one to dir once it's been idle past sessionIdleTimeout.
+type Bundler struct {
+	dir      string
+	eventBus bus.EventBus
+	sessions *lru.LRU[sessionKey, *Bundle]
+	mu       sync.Mutex
+
+	stripNoise   bool
+	stripMethods map[string]bool
+}
+
+// New creates a Bundler that writes session bundles as "<session-id>.json"
+// files under dir. dir must already exist.
+func New(eventBus bus.EventBus, dir string) (*Bundler, error) {
+	return NewWithConfig(eventBus, dir, Config{})
+}
+
+// NewWithConfig creates a Bundler like New, with custom config.
+func NewWithConfig(eventBus bus.EventBus, dir string, cfg Config) (*Bundler, error) {
+	b := &Bundler{
+		dir:        dir,
+		eventBus:   eventBus,
+		stripNoise: cfg.StripNoise,
+	}
+	if cfg.StripNoise {
+		methods := cfg.StripMethods
+		if methods == nil {
+			methods = defaultNoiseMethods
+		}
+		b.stripMethods = make(map[string]bool, len(methods))
+		for _, m := range methods {
+			b.stripMethods[m] = true
+		}
+	}
+	b.sessions = lru.NewLRU[sessionKey, *Bundle](sessionCacheSize, b.handleEviction, sessionIdleTimeout)
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, b.handleMCPEvent); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// handleMCPEvent accumulates an incoming MCP message into its session's bundle.
+func (b *Bundler) handleMCPEvent(e event.Event) {
+	mcpEvent, ok := e.(*event.MCPEvent)
+	if !ok || (mcpEvent.StdioTransport == nil && mcpEvent.HttpTransport == nil) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key, bundle := b.getOrCreateBundle(mcpEvent)
+	if !b.isNoise(mcpEvent) {
+		bundle.Messages = append(bundle.Messages, mcpEvent.Copy())
+	}
+	bundle.EndedAt = mcpEvent.Timestamp
+	bundle.Stats.TotalMessages++
+
+	switch mcpEvent.MessageType {
+	case event.JSONRPCMessageTypeRequest:
+		bundle.Stats.Requests++
+		if mcpEvent.Method == "initialize" && mcpEvent.Params != nil {
+			if clientInfo, ok := mcpEvent.Params["clientInfo"].(map[string]interface{}); ok {
+				bundle.ClientInfo = clientInfo
+			}
+		}
+		if name := mcpEvent.ExtractToolName(); name != "" && !bundle.toolsSeen[name] {
+			bundle.toolsSeen[name] = true
+			bundle.ToolsUsed = append(bundle.ToolsUsed, name)
+		}
+	case event.JSONRPCMessageTypeResponse:
+		bundle.Stats.Responses++
+		if mcpEvent.Error.Message != "" {
+			bundle.Stats.Errors++
+		}
+		if mcpEvent.Request != nil && mcpEvent.Request.Method == "initialize" {
+			if result, ok := mcpEvent.Result.(map[string]interface{}); ok {
+				if serverInfo, ok := result["serverInfo"].(map[string]interface{}); ok {
+					bundle.ServerInfo = serverInfo
+				}
+				if caps, ok := result["capabilities"].(map[string]interface{}); ok {
+					bundle.Capabilities = caps
+				}
+				if instructions, ok := result["instructions"].(string); ok {
+					bundle.Instructions = instructions
+				}
+			}
+		}
+	case event.JSONRPCMessageTypeNotification:
+		bundle.Stats.Notifications++
+	}
+
+	// Re-add to reset the idle TTL now that we've seen activity.
+	b.sessions.Add(key, bundle)
+}
+
+// isNoise reports whether msg's method is in the configured strip set: its
+// own method for requests/notifications, or its originating request's
+// method for responses. Always false when StripNoise wasn't configured.
+func (b *Bundler) isNoise(msg *event.MCPEvent) bool {
+	if !b.stripNoise {
+		return false
+	}
+
+	method := msg.Method
+	if msg.MessageType == event.JSONRPCMessageTypeResponse && msg.Request != nil {
+		method = msg.Request.Method
+	}
+	return b.stripMethods[method]
+}
+
+// getOrCreateBundle returns the bundle for msg's session, creating one if needed.
+// Caller must hold b.mu.
+func (b *Bundler) getOrCreateBundle(msg *event.MCPEvent) (sessionKey, *Bundle) {
+	var key sessionKey
+	var sessionID string
+	if msg.StdioTransport != nil {
+		key = stdioSessionKey(msg.StdioTransport)
+		sessionID = fmt.Sprintf("pid%d-pid%d-%d", msg.StdioTransport.FromPID, msg.StdioTransport.ToPID, msg.Timestamp.UnixNano())
+	} else {
+		key = httpSessionKey(msg.HttpTransport)
+		sessionID = fmt.Sprintf("http-%s-%d", msg.HttpTransport.Host, msg.Timestamp.UnixNano())
+	}
+
+	if bundle, ok := b.sessions.Peek(key); ok {
+		return key, bundle
+	}
+
+	bundle := &Bundle{
+		SessionID: sessionID,
+		StartedAt: msg.Timestamp,
+		toolsSeen: make(map[string]bool),
+	}
+	b.sessions.Add(key, bundle)
+	return key, bundle
+}
+
+// ActiveSessions returns a snapshot of every session still in flight as of
+// asOf, briefly locking the session map so the result is consistent. Ages
+// are computed relative to asOf rather than time.Now() so callers (and
+// tests) can produce reproducible snapshots.
+func (b *Bundler) ActiveSessions(asOf time.Time) []ActiveSession {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bundles := b.sessions.Values()
+	sessions := make([]ActiveSession, 0, len(bundles))
+	for _, bundle := range bundles {
+		sessions = append(sessions, ActiveSession{
+			SessionID:    bundle.SessionID,
+			StartedAt:    bundle.StartedAt,
+			LastActivity: bundle.EndedAt,
+			Age:          asOf.Sub(bundle.StartedAt),
+			ClientInfo:   bundle.ClientInfo,
+			ServerInfo:   bundle.ServerInfo,
+			Instructions: bundle.Instructions,
+			MessageCount: bundle.Stats.TotalMessages,
+			Stats:        bundle.Stats,
+		})
+	}
+	return sessions
+}
+
+// handleEviction is invoked by the LRU when a session has been idle past
+// sessionIdleTimeout, or is explicitly removed on Close. It writes the
+// session's bundle to disk.
+func (b *Bundler) handleEviction(_ sessionKey, bundle *Bundle) {
+	path := filepath.Join(b.dir, bundle.SessionID+".json")
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		logrus.WithError(err).WithField("session_id", bundle.SessionID).Error("Failed to marshal session bundle")
+		return
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		logrus.WithError(err).WithField("path", path).Error("Failed to write session bundle")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"session_id": bundle.SessionID,
+		"path":       path,
+		"messages":   bundle.Stats.TotalMessages,
+	}).Info("Wrote session bundle")
+}
+
+// Close flushes all in-flight sessions to disk and unsubscribes from events.
+func (b *Bundler) Close() {
+	b.eventBus.Unsubscribe(event.EventTypeMCPMessage, b.handleMCPEvent)
+
+	if b.sessions != nil {
+		b.sessions.Purge()
+	}
+}
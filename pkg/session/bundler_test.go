@@ -0,0 +1,398 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func TestBundler_ProducesBundleFromCompleteSession(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	dir := t.TempDir()
+
+	b, err := New(mockBus, dir)
+	if err != nil {
+		t.Fatalf("Failed to create bundler: %v", err)
+	}
+	defer mockBus.Close()
+
+	transport := &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"}
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(1, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "initialize",
+			Params: map[string]interface{}{
+				"clientInfo": map[string]interface{}{"name": "claude", "version": "1.0"},
+			},
+		},
+	})
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(2, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+			Result: map[string]interface{}{
+				"serverInfo":   map[string]interface{}{"name": "mcp-server", "version": "2.0"},
+				"capabilities": map[string]interface{}{"tools": map[string]interface{}{}},
+				"instructions": "Always confirm with the user before calling delete_file.",
+			},
+			Request: &event.JSONRPCMessage{Method: "initialize"},
+		},
+	})
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(3, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(2),
+			Method:      "tools/call",
+			Params:      map[string]interface{}{"name": "get_weather"},
+		},
+	})
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(4, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(2),
+			Error:       event.JSONRPCError{Code: -32000, Message: "tool failed"},
+			Request:     &event.JSONRPCMessage{Method: "tools/call"},
+		},
+	})
+
+	// Simulate session end.
+	b.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected exactly one bundle file, got %d", len(entries))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if bundle.ClientInfo["name"] != "claude" {
+		t.Errorf("expected client info name claude, got %v", bundle.ClientInfo["name"])
+	}
+	if bundle.ServerInfo["name"] != "mcp-server" {
+		t.Errorf("expected server info name mcp-server, got %v", bundle.ServerInfo["name"])
+	}
+	if len(bundle.Capabilities) == 0 {
+		t.Error("expected non-empty capabilities")
+	}
+	if bundle.Instructions != "Always confirm with the user before calling delete_file." {
+		t.Errorf("expected instructions to be extracted from the initialize result, got %q", bundle.Instructions)
+	}
+	if len(bundle.ToolsUsed) != 1 || bundle.ToolsUsed[0] != "get_weather" {
+		t.Errorf("expected tools used [get_weather], got %v", bundle.ToolsUsed)
+	}
+	if bundle.Stats.TotalMessages != 4 {
+		t.Errorf("expected 4 total messages, got %d", bundle.Stats.TotalMessages)
+	}
+	if bundle.Stats.Requests != 2 || bundle.Stats.Responses != 2 {
+		t.Errorf("expected 2 requests and 2 responses, got %d/%d", bundle.Stats.Requests, bundle.Stats.Responses)
+	}
+	if bundle.Stats.Errors != 1 {
+		t.Errorf("expected 1 error, got %d", bundle.Stats.Errors)
+	}
+}
+
+func TestBundler_InstructionsAbsentFromInitializeResultLeavesBundleEmpty(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	dir := t.TempDir()
+
+	b, err := New(mockBus, dir)
+	if err != nil {
+		t.Fatalf("Failed to create bundler: %v", err)
+	}
+	defer mockBus.Close()
+
+	transport := &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"}
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(1, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+			Result: map[string]interface{}{
+				"serverInfo": map[string]interface{}{"name": "mcp-server"},
+			},
+			Request: &event.JSONRPCMessage{Method: "initialize"},
+		},
+	})
+
+	b.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected exactly one bundle file, got %d", len(entries))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if bundle.Instructions != "" {
+		t.Errorf("expected no instructions when absent from initialize result, got %q", bundle.Instructions)
+	}
+}
+
+func TestBundler_StripNoiseOmitsKeepaliveAndFanOutFromMessages(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	dir := t.TempDir()
+
+	b, err := NewWithConfig(mockBus, dir, Config{StripNoise: true})
+	if err != nil {
+		t.Fatalf("Failed to create bundler: %v", err)
+	}
+	defer mockBus.Close()
+
+	transport := &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"}
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(1, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "ping"},
+	})
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(2, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+			Request:     &event.JSONRPCMessage{Method: "ping"},
+		},
+	})
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(3, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeNotification, Method: "notifications/tools/list_changed"},
+	})
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(4, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(2),
+			Method:      "tools/call",
+			Params:      map[string]interface{}{"name": "get_weather"},
+		},
+	})
+
+	b.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected exactly one bundle file, got %d", len(entries))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(bundle.Messages) != 1 || bundle.Messages[0].Method != "tools/call" {
+		t.Errorf("expected only the tools/call request to survive stripping, got %d messages: %v", len(bundle.Messages), bundle.Messages)
+	}
+	if bundle.Stats.TotalMessages != 4 {
+		t.Errorf("expected stats to still count all 4 messages regardless of stripping, got %d", bundle.Stats.TotalMessages)
+	}
+}
+
+func TestBundler_GroupsHTTPSessionsByHost(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	dir := t.TempDir()
+
+	b, err := New(mockBus, dir)
+	if err != nil {
+		t.Fatalf("Failed to create bundler: %v", err)
+	}
+	defer mockBus.Close()
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Unix(1, 0),
+		TransportType: event.TransportTypeHTTP,
+		HttpTransport: &event.HttpTransport{PID: 1, Host: "example.com"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+		},
+	})
+	// A different host is a different session, same host is the same one.
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Unix(2, 0),
+		TransportType: event.TransportTypeHTTP,
+		HttpTransport: &event.HttpTransport{PID: 2, Host: "other.example.com"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+		},
+	})
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Unix(3, 0),
+		TransportType: event.TransportTypeHTTP,
+		HttpTransport: &event.HttpTransport{PID: 1, Host: "example.com"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			Request:     &event.JSONRPCMessage{Method: "tools/call"},
+		},
+	})
+
+	b.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 bundle files (one per host), got %d", len(entries))
+	}
+}
+
+func TestBundler_IgnoresEventsWithoutStdioOrHTTPTransport(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	dir := t.TempDir()
+
+	b, err := New(mockBus, dir)
+	if err != nil {
+		t.Fatalf("Failed to create bundler: %v", err)
+	}
+	defer mockBus.Close()
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:     time.Unix(1, 0),
+		TransportType: event.TransportTypeTCP,
+		TCPTransport:  &event.TCPTransport{SrcAddr: "127.0.0.1", SrcPort: 1234, DstAddr: "127.0.0.1", DstPort: 5678},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			Method:      "tools/call",
+		},
+	})
+
+	b.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no bundle files for events with neither stdio nor HTTP transport, got %d", len(entries))
+	}
+}
+
+func TestBundler_ActiveSessionsReflectsPublishedEvents(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	dir := t.TempDir()
+
+	b, err := New(mockBus, dir)
+	if err != nil {
+		t.Fatalf("Failed to create bundler: %v", err)
+	}
+	defer mockBus.Close()
+
+	transport := &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"}
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(1, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeRequest,
+			ID:          int64(1),
+			Method:      "initialize",
+			Params: map[string]interface{}{
+				"clientInfo": map[string]interface{}{"name": "claude", "version": "1.0"},
+			},
+		},
+	})
+
+	mockBus.Publish(&event.MCPEvent{
+		Timestamp:      time.Unix(2, 0),
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: transport,
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+			Result: map[string]interface{}{
+				"serverInfo": map[string]interface{}{"name": "mcp-server", "version": "2.0"},
+			},
+			Request: &event.JSONRPCMessage{Method: "initialize"},
+		},
+	})
+
+	snapshot := b.ActiveSessions(time.Unix(10, 0))
+	if len(snapshot) != 1 {
+		t.Fatalf("expected 1 active session, got %d", len(snapshot))
+	}
+
+	s := snapshot[0]
+	if s.MessageCount != 2 {
+		t.Errorf("expected 2 messages, got %d", s.MessageCount)
+	}
+	if s.ClientInfo["name"] != "claude" {
+		t.Errorf("expected client info name 'claude', got %v", s.ClientInfo["name"])
+	}
+	if s.ServerInfo["name"] != "mcp-server" {
+		t.Errorf("expected server info name 'mcp-server', got %v", s.ServerInfo["name"])
+	}
+	if s.Age != 9*time.Second {
+		t.Errorf("expected age 9s (from StartedAt=1 to asOf=10), got %v", s.Age)
+	}
+
+	b.Close()
+
+	// Once closed (sessions flushed/purged), no sessions remain active.
+	if snapshot := b.ActiveSessions(time.Unix(11, 0)); len(snapshot) != 0 {
+		t.Errorf("expected no active sessions after Close, got %d", len(snapshot))
+	}
+}
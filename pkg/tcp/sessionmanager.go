@@ -0,0 +1,210 @@
+// Package tcp reassembles bare JSON-RPC payloads captured directly off a
+// TCP socket (no HTTP framing) into complete JSON messages, keyed by the
+// socket's 4-tuple rather than a file descriptor.
+package tcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// sessionCacheSize bounds the number of concurrently tracked sockets,
+	// to protect against unbounded growth from a large number of
+	// connections.
+	sessionCacheSize = 4096
+	// sessionTTL evicts a session that hasn't seen a send in this long, so
+	// a connection that's gone quiet (e.g. closed without the process
+	// telling us) doesn't hold its buffer forever.
+	sessionTTL = 30 * time.Second
+
+	// maxSessionBufferBytes caps how much unparsed data a session will
+	// accumulate while waiting for a complete JSON document. Mirrors
+	// pkg/fs's equivalent cap for the same reason: beyond this size it's
+	// never going to be a realistic single MCP message.
+	maxSessionBufferBytes = 8 * 1024 * 1024
+)
+
+// sessionKey uniquely identifies a TCP connection by its socket 4-tuple.
+type sessionKey struct {
+	srcAddr [4]uint8
+	dstAddr [4]uint8
+	srcPort uint16
+	dstPort [2]uint8
+}
+
+// session tracks bare JSON-RPC reassembly for a single TCP connection.
+type session struct {
+	pid     uint32
+	comm    [16]uint8
+	srcAddr [4]uint8
+	dstAddr [4]uint8
+	srcPort uint16
+	dstPort [2]uint8
+
+	buf *bytes.Buffer
+}
+
+// SessionManager reassembles raw TCP payloads into complete JSON messages.
+// Subscribes to: EventTypeTCPData
+// Publishes: EventTypeTCPAggregated
+type SessionManager struct {
+	mu sync.Mutex
+
+	sessions *expirable.LRU[sessionKey, *session]
+	eventBus bus.EventBus
+}
+
+// NewSessionManager creates a new TCP session manager.
+func NewSessionManager(eventBus bus.EventBus) (*SessionManager, error) {
+	sm := &SessionManager{
+		eventBus: eventBus,
+	}
+	sm.sessions = expirable.NewLRU[sessionKey, *session](sessionCacheSize, sm.handleSessionEvicted, sessionTTL)
+
+	if err := sm.eventBus.Subscribe(event.EventTypeTCPData, sm.handleTCPEvent); err != nil {
+		return nil, err
+	}
+
+	return sm, nil
+}
+
+// handleSessionEvicted is the sessions cache's eviction callback. Unlike
+// pkg/fs, a dropped TCP reassembly buffer isn't reported as a warning
+// event: a connection simply going idle between unrelated messages is the
+// common case here (no fd-close hook to clean up after), not a symptom of
+// a malformed stream.
+func (s *SessionManager) handleSessionEvicted(_ sessionKey, _ *session) {}
+
+// handleTCPEvent is called by the event bus when raw TCP data events arrive.
+func (s *SessionManager) handleTCPEvent(e event.Event) {
+	tcpEvent, ok := e.(*event.TCPDataEvent)
+	if !ok {
+		return
+	}
+
+	if err := s.ProcessTCPEvent(tcpEvent); err != nil {
+		logrus.WithFields(e.LogFields()).WithError(err).Debug("Failed to process TCP event")
+	}
+}
+
+// ProcessTCPEvent processes a raw TCP data event and aggregates JSON payloads.
+func (s *SessionManager) ProcessTCPEvent(e *event.TCPDataEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := sessionKey{
+		srcAddr: e.SrcAddr,
+		dstAddr: e.DstAddr,
+		srcPort: e.SrcPort,
+		dstPort: e.DstPort,
+	}
+
+	sess, exists := s.sessions.Get(key)
+	if !exists {
+		sess = &session{
+			pid:     e.PID,
+			comm:    e.CommBytes,
+			srcAddr: e.SrcAddr,
+			dstAddr: e.DstAddr,
+			srcPort: e.SrcPort,
+			dstPort: e.DstPort,
+			buf:     &bytes.Buffer{},
+		}
+	}
+	s.sessions.Add(key, sess)
+
+	if _, err := sess.buf.Write(e.Buffer()); err != nil {
+		return err
+	}
+
+	truncated := e.Size > e.BufSize
+
+	if sess.buf.Len() > maxSessionBufferBytes {
+		sess.buf.Reset()
+		return nil
+	}
+
+	return s.tryEmitJsonEvent(sess, truncated)
+}
+
+// tryEmitJsonEvent attempts to parse and emit complete JSON messages,
+// mirroring pkg/fs.SessionManager.tryEmitJsonEvent.
+func (s *SessionManager) tryEmitJsonEvent(sess *session, truncated bool) error {
+	bufData := bytes.TrimSpace(sess.buf.Bytes())
+	if len(bufData) == 0 {
+		sess.buf.Reset()
+		return nil
+	}
+
+	if bufData[0] != '{' && bufData[0] != '[' {
+		return fmt.Errorf("invalid JSON start character: %c", bufData[0])
+	}
+
+	reader := bytes.NewReader(bufData)
+	decoder := json.NewDecoder(reader)
+	lastGoodPosition := int64(0)
+
+	for {
+		var jsonData json.RawMessage
+		if err := decoder.Decode(&jsonData); err != nil {
+			break
+		}
+
+		if len(bytes.TrimSpace(jsonData)) == 0 {
+			continue
+		}
+
+		s.emitJsonEvent(sess, jsonData)
+		lastGoodPosition = decoder.InputOffset()
+	}
+
+	remainingData := bufData[lastGoodPosition:]
+
+	if truncated && len(bytes.TrimSpace(remainingData)) > 0 {
+		s.emitJsonEvent(sess, remainingData)
+		sess.buf.Reset()
+		return nil
+	}
+
+	if lastGoodPosition > 0 {
+		sess.buf = bytes.NewBuffer(remainingData)
+	}
+
+	return nil
+}
+
+// emitJsonEvent emits a complete JSON message as a TCPAggregatedEvent.
+func (s *SessionManager) emitJsonEvent(sess *session, payload []byte) {
+	evt := event.NewTCPAggregatedEvent(
+		sess.pid,
+		sess.comm,
+		sess.srcAddr,
+		sess.dstAddr,
+		sess.srcPort,
+		sess.dstPort,
+		payload,
+	)
+
+	logrus.WithFields(evt.LogFields()).Trace(fmt.Sprintf("event#%s", evt.Type().String()))
+
+	s.eventBus.Publish(evt)
+}
+
+// Close unsubscribes from events and cleans up sessions.
+func (s *SessionManager) Close() {
+	s.eventBus.Unsubscribe(event.EventTypeTCPData, s.handleTCPEvent)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions.Purge()
+}
@@ -0,0 +1,136 @@
+package tcp
+
+import (
+	"testing"
+	"time"
+
+	testutil "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func receiveAggregatedEvent(ch <-chan event.Event, timeout time.Duration) (*event.TCPAggregatedEvent, bool) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case evt := <-ch:
+			if agg, ok := evt.(*event.TCPAggregatedEvent); ok {
+				return agg, true
+			}
+		case <-deadline:
+			return nil, false
+		}
+	}
+}
+
+func newTCPDataEvent(pid uint32, srcAddr, dstAddr [4]uint8, srcPort uint16, dstPort [2]uint8, payload []byte) *event.TCPDataEvent {
+	e := &event.TCPDataEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeTCPData,
+			PID:       pid,
+		},
+		SrcAddr: srcAddr,
+		DstAddr: dstAddr,
+		SrcPort: srcPort,
+		DstPort: dstPort,
+		Size:    uint32(len(payload)),
+		BufSize: uint32(len(payload)),
+	}
+	copy(e.Buf[:], payload)
+	return e
+}
+
+func TestSessionManager_SingleCompleteJson(t *testing.T) {
+	mockBus := testutil.NewMockBus()
+	defer mockBus.Close()
+
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	defer sm.Close()
+
+	jsonData := []byte(`{"jsonrpc":"2.0","method":"tools/call","id":1}`)
+	mockBus.Publish(newTCPDataEvent(1234, [4]uint8{127, 0, 0, 1}, [4]uint8{127, 0, 0, 1}, 5000, [2]uint8{0x1f, 0x90}, jsonData))
+
+	evt, ok := receiveAggregatedEvent(mockBus.Events(), 100*time.Millisecond)
+	if !ok {
+		t.Fatal("No TCPAggregatedEvent received")
+	}
+	if string(evt.Payload) != string(jsonData) {
+		t.Errorf("Payload = %s, want %s", evt.Payload, jsonData)
+	}
+	if evt.SrcAddrStr() != "127.0.0.1" || evt.DstAddrStr() != "127.0.0.1" {
+		t.Errorf("unexpected addresses: src=%s dst=%s", evt.SrcAddrStr(), evt.DstAddrStr())
+	}
+	if evt.DstPortNum() != 8080 {
+		t.Errorf("DstPortNum() = %d, want 8080", evt.DstPortNum())
+	}
+}
+
+func TestSessionManager_SplitAcrossMultipleSends(t *testing.T) {
+	mockBus := testutil.NewMockBus()
+	defer mockBus.Close()
+
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	defer sm.Close()
+
+	src := [4]uint8{10, 0, 0, 1}
+	dst := [4]uint8{10, 0, 0, 2}
+
+	full := []byte(`{"jsonrpc":"2.0","method":"tools/list","id":1}`)
+	mockBus.Publish(newTCPDataEvent(1, src, dst, 4000, [2]uint8{0x1f, 0x90}, full[:20]))
+	mockBus.Publish(newTCPDataEvent(1, src, dst, 4000, [2]uint8{0x1f, 0x90}, full[20:]))
+
+	evt, ok := receiveAggregatedEvent(mockBus.Events(), 100*time.Millisecond)
+	if !ok {
+		t.Fatal("No TCPAggregatedEvent received")
+	}
+	if string(evt.Payload) != string(full) {
+		t.Errorf("Payload = %s, want %s", evt.Payload, full)
+	}
+}
+
+func TestSessionManager_CorrelatesBySocketTuple(t *testing.T) {
+	mockBus := testutil.NewMockBus()
+	defer mockBus.Close()
+
+	sm, err := NewSessionManager(mockBus)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	defer sm.Close()
+
+	src := [4]uint8{10, 0, 0, 1}
+	dstA := [4]uint8{10, 0, 0, 2}
+	dstB := [4]uint8{10, 0, 0, 3}
+
+	// Two distinct connections interleave partial sends. Without
+	// per-tuple session isolation these would be wrongly concatenated
+	// into a single invalid JSON blob.
+	msgA := []byte(`{"jsonrpc":"2.0","method":"a","id":1}`)
+	msgB := []byte(`{"jsonrpc":"2.0","method":"b","id":2}`)
+
+	mockBus.Publish(newTCPDataEvent(1, src, dstA, 4000, [2]uint8{0x1f, 0x90}, msgA[:15]))
+	mockBus.Publish(newTCPDataEvent(1, src, dstB, 4001, [2]uint8{0x1f, 0x91}, msgB[:15]))
+	mockBus.Publish(newTCPDataEvent(1, src, dstA, 4000, [2]uint8{0x1f, 0x90}, msgA[15:]))
+	mockBus.Publish(newTCPDataEvent(1, src, dstB, 4001, [2]uint8{0x1f, 0x91}, msgB[15:]))
+
+	got := map[string]string{}
+	for i := 0; i < 2; i++ {
+		evt, ok := receiveAggregatedEvent(mockBus.Events(), 100*time.Millisecond)
+		if !ok {
+			t.Fatalf("expected 2 TCPAggregatedEvents, got %d", i)
+		}
+		got[evt.DstAddrStr()] = string(evt.Payload)
+	}
+
+	if got["10.0.0.2"] != string(msgA) {
+		t.Errorf("connection to 10.0.0.2 = %s, want %s", got["10.0.0.2"], msgA)
+	}
+	if got["10.0.0.3"] != string(msgB) {
+		t.Errorf("connection to 10.0.0.3 = %s, want %s", got["10.0.0.3"], msgB)
+	}
+}
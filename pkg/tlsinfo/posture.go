@@ -0,0 +1,73 @@
+// Package tlsinfo classifies negotiated TLS versions and cipher suites for
+// security posture reporting (e.g. "api.example.com: TLS1.2, weak cipher").
+package tlsinfo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// oldVersions lists protocol versions considered insecure by modern standards.
+var oldVersions = map[string]bool{
+	"SSLv2":   true,
+	"SSLv3":   true,
+	"TLSv1":   true,
+	"TLSv1.1": true,
+}
+
+// weakCipherSubstrings flags cipher suite names containing known-broken or
+// deprecated algorithms, matched case-insensitively.
+var weakCipherSubstrings = []string{
+	"NULL", "EXPORT", "ANON", "RC4", "DES", "MD5",
+}
+
+// Posture summarizes the negotiated TLS version and cipher suite for a
+// connection, as reported by the peer's own SSL_get_version/
+// SSL_CIPHER_get_name calls.
+type Posture struct {
+	Version string
+	Cipher  string
+}
+
+// IsWeak reports whether the negotiated version or cipher is considered
+// insecure (old protocol version, or a cipher using a broken/deprecated
+// algorithm).
+func (p Posture) IsWeak() bool {
+	return oldVersions[p.Version] || isWeakCipher(p.Cipher)
+}
+
+// String renders the posture for display, e.g. "TLSv1.2, weak cipher".
+func (p Posture) String() string {
+	if p.Version == "" {
+		return "unknown"
+	}
+
+	s := p.Version
+	if p.Cipher != "" {
+		if isWeakCipher(p.Cipher) {
+			s = fmt.Sprintf("%s, weak cipher (%s)", s, p.Cipher)
+		} else {
+			s = fmt.Sprintf("%s, %s", s, p.Cipher)
+		}
+	}
+	if oldVersions[p.Version] {
+		s += " (outdated)"
+	}
+
+	return s
+}
+
+func isWeakCipher(cipher string) bool {
+	if cipher == "" {
+		return false
+	}
+
+	upper := strings.ToUpper(cipher)
+	for _, substr := range weakCipherSubstrings {
+		if strings.Contains(upper, substr) {
+			return true
+		}
+	}
+
+	return false
+}
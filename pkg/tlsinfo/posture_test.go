@@ -0,0 +1,50 @@
+package tlsinfo
+
+import "testing"
+
+func TestPosture_IsWeak(t *testing.T) {
+	tests := []struct {
+		name     string
+		posture  Posture
+		wantWeak bool
+	}{
+		{"modern TLS1.3 with strong cipher", Posture{Version: "TLSv1.3", Cipher: "TLS_AES_256_GCM_SHA384"}, false},
+		{"TLS1.2 with strong cipher", Posture{Version: "TLSv1.2", Cipher: "ECDHE-RSA-AES256-GCM-SHA384"}, false},
+		{"outdated TLS1.1 protocol", Posture{Version: "TLSv1.1", Cipher: "ECDHE-RSA-AES256-GCM-SHA384"}, true},
+		{"outdated SSLv3 protocol", Posture{Version: "SSLv3"}, true},
+		{"RC4 cipher is weak regardless of version", Posture{Version: "TLSv1.2", Cipher: "ECDHE-RSA-RC4-SHA"}, true},
+		{"3DES cipher is weak", Posture{Version: "TLSv1.2", Cipher: "EDH-RSA-DES-CBC3-SHA"}, true},
+		{"NULL cipher is weak", Posture{Version: "TLSv1.2", Cipher: "ECDHE-RSA-NULL-SHA"}, true},
+		{"unknown posture is not flagged", Posture{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.posture.IsWeak(); got != tt.wantWeak {
+				t.Errorf("IsWeak() = %v, want %v", got, tt.wantWeak)
+			}
+		})
+	}
+}
+
+func TestPosture_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		posture Posture
+		want    string
+	}{
+		{"no data", Posture{}, "unknown"},
+		{"version only", Posture{Version: "TLSv1.3"}, "TLSv1.3"},
+		{"strong cipher", Posture{Version: "TLSv1.3", Cipher: "TLS_AES_256_GCM_SHA384"}, "TLSv1.3, TLS_AES_256_GCM_SHA384"},
+		{"weak cipher", Posture{Version: "TLSv1.2", Cipher: "ECDHE-RSA-RC4-SHA"}, "TLSv1.2, weak cipher (ECDHE-RSA-RC4-SHA)"},
+		{"outdated version", Posture{Version: "TLSv1.1"}, "TLSv1.1 (outdated)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.posture.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
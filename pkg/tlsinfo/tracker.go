@@ -0,0 +1,80 @@
+package tlsinfo
+
+import (
+	"sync"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// Tracker accumulates per-connection TLS postures from raw handshake
+// metadata events and makes them available for host-level reporting.
+//
+// The version is attributed to an exact SSL context (the hooked function's
+// argument); the cipher is attributed on a best-effort basis to the most
+// recently observed SSL context for the same PID, since the underlying
+// OpenSSL call only receives a cipher pointer, not the SSL context.
+type Tracker struct {
+	eventBus bus.EventBus
+
+	mu         sync.Mutex
+	postures   map[uint64]Posture // SSL context -> posture
+	lastSSLCtx map[uint32]uint64  // PID -> most recently seen SSL context
+}
+
+// New creates a Tracker subscribed to raw TLS handshake metadata events.
+func New(eventBus bus.EventBus) (*Tracker, error) {
+	t := &Tracker{
+		eventBus:   eventBus,
+		postures:   make(map[uint64]Posture),
+		lastSSLCtx: make(map[uint32]uint64),
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeTlsHandshake, t.handleTlsHandshakeEvent); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Tracker) handleTlsHandshakeEvent(e event.Event) {
+	handshakeEvent, ok := e.(*event.TlsHandshakeEvent)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch handshakeEvent.HookID {
+	case event.HookSSLGetVersion:
+		posture := t.postures[handshakeEvent.SSLContext]
+		posture.Version = handshakeEvent.Value()
+		t.postures[handshakeEvent.SSLContext] = posture
+		t.lastSSLCtx[handshakeEvent.PID] = handshakeEvent.SSLContext
+	case event.HookSSLCipherGetName:
+		// The cipher getter only receives a cipher pointer, so attribute it
+		// to the last SSL context we saw handshake metadata for on this PID.
+		sslCtx, ok := t.lastSSLCtx[handshakeEvent.PID]
+		if !ok {
+			return
+		}
+		posture := t.postures[sslCtx]
+		posture.Cipher = handshakeEvent.Value()
+		t.postures[sslCtx] = posture
+	}
+}
+
+// Lookup returns the posture observed for sslCtx, if any.
+func (t *Tracker) Lookup(sslCtx uint64) (Posture, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	posture, ok := t.postures[sslCtx]
+	return posture, ok
+}
+
+// Close unsubscribes from the event bus.
+func (t *Tracker) Close() {
+	t.eventBus.Unsubscribe(event.EventTypeTlsHandshake, t.handleTlsHandshakeEvent)
+}
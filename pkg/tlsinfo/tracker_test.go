@@ -0,0 +1,77 @@
+package tlsinfo
+
+import (
+	"testing"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func newHandshakeEvent(pid uint32, hookID event.HookID, sslCtx uint64, value string) *event.TlsHandshakeEvent {
+	e := &event.TlsHandshakeEvent{
+		EventHeader: event.EventHeader{
+			EventType: event.EventTypeTlsHandshake,
+			HookID:    hookID,
+			PID:       pid,
+		},
+		SSLContext: sslCtx,
+	}
+	copy(e.ValueBytes[:], value)
+	return e
+}
+
+func TestTracker_AttributesVersionAndCipherToSSLContext(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	tracker, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+	defer mockBus.Close()
+
+	const pid, sslCtx = 42, uint64(0xdeadbeef)
+
+	mockBus.Publish(newHandshakeEvent(pid, event.HookSSLGetVersion, sslCtx, "TLSv1.3"))
+	mockBus.Publish(newHandshakeEvent(pid, event.HookSSLCipherGetName, 0, "TLS_AES_256_GCM_SHA384"))
+
+	posture, ok := tracker.Lookup(sslCtx)
+	if !ok {
+		t.Fatal("expected a posture for the SSL context, got none")
+	}
+	if posture.Version != "TLSv1.3" {
+		t.Errorf("expected version TLSv1.3, got %q", posture.Version)
+	}
+	if posture.Cipher != "TLS_AES_256_GCM_SHA384" {
+		t.Errorf("expected cipher TLS_AES_256_GCM_SHA384, got %q", posture.Cipher)
+	}
+}
+
+func TestTracker_LookupMissReturnsFalse(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	tracker, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+	defer mockBus.Close()
+
+	if _, ok := tracker.Lookup(0x1234); ok {
+		t.Error("expected no posture for an unseen SSL context")
+	}
+}
+
+func TestTracker_CipherIgnoredWithoutPriorVersion(t *testing.T) {
+	mockBus := tu.NewMockBus()
+	tracker, err := New(mockBus)
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+	defer mockBus.Close()
+
+	mockBus.Publish(newHandshakeEvent(7, event.HookSSLCipherGetName, 0, "TLS_AES_256_GCM_SHA384"))
+
+	if _, ok := tracker.Lookup(0); ok {
+		t.Error("expected cipher-only event with no prior version to not create a posture")
+	}
+}
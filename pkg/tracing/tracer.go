@@ -0,0 +1,204 @@
+//go:build otel
+
+// Package tracing emits OpenTelemetry spans for correlated MCP
+// request/response pairs, so MCP latency shows up alongside the rest of a
+// deployment's traces in Jaeger/Tempo. It subscribes passively to the event
+// bus, like the other cross-cutting components in this repo (pkg/alert,
+// pkg/metrics), so the parser is untouched.
+//
+// Only built when compiled with `-tags otel`, to keep the default build
+// free of the OpenTelemetry/gRPC dependency tree for users who don't need
+// it.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// Config controls the OTLP/gRPC exporter.
+type Config struct {
+	// Endpoint is the OTLP/gRPC collector address, e.g. "localhost:4317".
+	// Required.
+	Endpoint string
+
+	// Insecure disables TLS when dialing Endpoint, for a local
+	// collector/sidecar reachable in plaintext.
+	Insecure bool
+}
+
+// Exporter emits a span per correlated MCP request/response pair, attributed
+// with the process chain that carried it, and records notifications as span
+// events on an ambient span kept open for the lifetime of their session.
+//
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+type Exporter struct {
+	eventBus bus.EventBus
+	tracer   trace.Tracer
+	provider *sdktrace.TracerProvider
+
+	mu       sync.Mutex
+	sessions map[string]trace.Span
+}
+
+// New dials Config.Endpoint and starts an Exporter. Call Close to flush
+// pending spans, end any still-open ambient session spans, and unsubscribe.
+func New(ctx context.Context, eventBus bus.EventBus, config Config) (*Exporter, error) {
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("tracing: Endpoint is required")
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exp, err := otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp))
+
+	e := &Exporter{
+		eventBus: eventBus,
+		tracer:   provider.Tracer("github.com/alex-ilgayev/mcpspy"),
+		provider: provider,
+		sessions: make(map[string]trace.Span),
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, e.handleMessage); err != nil {
+		_ = provider.Shutdown(ctx)
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// sessionKey identifies the ambient span a message's notifications are
+// recorded on, scoped to the hop that carried it since mcpspy has no
+// higher-level session identity yet.
+func sessionKey(msg *event.MCPEvent) string {
+	switch {
+	case msg.StdioTransport != nil:
+		return fmt.Sprintf("stdio:%d:%d", msg.StdioTransport.FromPID, msg.StdioTransport.ToPID)
+	case msg.HttpTransport != nil:
+		return fmt.Sprintf("http:%d:%s", msg.HttpTransport.PID, msg.HttpTransport.Host)
+	case msg.TCPTransport != nil:
+		return fmt.Sprintf("tcp:%s:%d-%s:%d", msg.TCPTransport.SrcAddr, msg.TCPTransport.SrcPort, msg.TCPTransport.DstAddr, msg.TCPTransport.DstPort)
+	default:
+		return "unknown"
+	}
+}
+
+// sessionSpan returns the ambient span for key, starting one if this is the
+// first message seen for it.
+func (e *Exporter) sessionSpan(key string) trace.Span {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if span, ok := e.sessions[key]; ok {
+		return span
+	}
+
+	_, span := e.tracer.Start(context.Background(), "mcp.session", trace.WithAttributes(attribute.String("mcp.session_key", key)))
+	e.sessions[key] = span
+	return span
+}
+
+// processChainAttributes describes the hop a message traveled over, for
+// attaching to a span as the "process chain" an operator would need to find
+// the client/server pair responsible for it.
+func processChainAttributes(msg *event.MCPEvent) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("mcp.transport", string(msg.TransportType))}
+
+	switch {
+	case msg.StdioTransport != nil:
+		attrs = append(attrs,
+			attribute.Int64("mcp.from_pid", int64(msg.StdioTransport.FromPID)),
+			attribute.String("mcp.from_comm", msg.StdioTransport.FromComm),
+			attribute.Int64("mcp.to_pid", int64(msg.StdioTransport.ToPID)),
+			attribute.String("mcp.to_comm", msg.StdioTransport.ToComm),
+		)
+	case msg.HttpTransport != nil:
+		attrs = append(attrs,
+			attribute.Int64("mcp.pid", int64(msg.HttpTransport.PID)),
+			attribute.String("mcp.comm", msg.HttpTransport.Comm),
+			attribute.String("mcp.host", msg.HttpTransport.Host),
+			attribute.String("mcp.path", msg.HttpTransport.Path),
+		)
+	case msg.TCPTransport != nil:
+		attrs = append(attrs,
+			attribute.Int64("mcp.pid", int64(msg.TCPTransport.PID)),
+			attribute.String("mcp.comm", msg.TCPTransport.Comm),
+			attribute.String("mcp.src", fmt.Sprintf("%s:%d", msg.TCPTransport.SrcAddr, msg.TCPTransport.SrcPort)),
+			attribute.String("mcp.dst", fmt.Sprintf("%s:%d", msg.TCPTransport.DstAddr, msg.TCPTransport.DstPort)),
+		)
+	}
+
+	return attrs
+}
+
+// handleMessage emits a span for a correlated response, or a span event on
+// the ambient session span for a notification. Lone requests produce
+// neither: the span is only known to have a start *and* end once the
+// response (carrying Latency) arrives.
+func (e *Exporter) handleMessage(ev event.Event) {
+	msg, ok := ev.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	session := e.sessionSpan(sessionKey(msg))
+
+	switch msg.MessageType {
+	case event.JSONRPCMessageTypeResponse:
+		if msg.Request == nil {
+			return
+		}
+
+		start := msg.Timestamp.Add(-msg.Latency)
+		ctx := trace.ContextWithSpan(context.Background(), session)
+		_, span := e.tracer.Start(ctx, msg.Request.Method, trace.WithTimestamp(start), trace.WithAttributes(processChainAttributes(msg)...))
+		if msg.Error.Code != 0 {
+			span.SetStatus(codes.Error, msg.Error.Message)
+			span.SetAttributes(attribute.Int("mcp.error_code", msg.Error.Code))
+		}
+		span.End(trace.WithTimestamp(msg.Timestamp))
+	case event.JSONRPCMessageTypeNotification:
+		session.AddEvent(msg.Method, trace.WithTimestamp(msg.Timestamp), trace.WithAttributes(processChainAttributes(msg)...))
+	}
+}
+
+// Close ends every still-open ambient session span, unsubscribes, and
+// flushes pending spans to the collector.
+func (e *Exporter) Close() error {
+	e.eventBus.Unsubscribe(event.EventTypeMCPMessage, e.handleMessage)
+
+	e.mu.Lock()
+	for _, span := range e.sessions {
+		span.End()
+	}
+	e.sessions = nil
+	e.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.provider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down OTLP trace provider: %w", err)
+	}
+	return nil
+}
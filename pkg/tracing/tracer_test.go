@@ -0,0 +1,120 @@
+//go:build otel
+
+package tracing
+
+import (
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+// newTestExporter builds an Exporter against an in-memory span recorder
+// instead of dialing a real OTLP/gRPC collector, so the request/response
+// span logic can be asserted without any external dependency.
+func newTestExporter(t *testing.T, eventBus bus.EventBus) (*Exporter, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	e := &Exporter{
+		eventBus: eventBus,
+		tracer:   provider.Tracer("test"),
+		provider: provider,
+		sessions: make(map[string]trace.Span),
+	}
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, e.handleMessage); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	return e, recorder
+}
+
+func TestExporter_ResponseEmitsSpanWithLatencyAndProcessChain(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	e, recorder := newTestExporter(t, eventBus)
+	defer e.Close()
+
+	now := time.Now()
+	req := &event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"}
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, FromComm: "claude", ToPID: 200, ToComm: "mcp-server"},
+		JSONRPCMessage: event.JSONRPCMessage{
+			MessageType: event.JSONRPCMessageTypeResponse,
+			ID:          int64(1),
+			Request:     req,
+			Latency:     50 * time.Millisecond,
+		},
+		Timestamp: now,
+	})
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d ended spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "tools/call" {
+		t.Errorf("span name = %q, want %q", span.Name(), "tools/call")
+	}
+	if got := span.EndTime().Sub(span.StartTime()); got != 50*time.Millisecond {
+		t.Errorf("span duration = %v, want 50ms", got)
+	}
+
+	var sawFromPID bool
+	for _, attr := range span.Attributes() {
+		if string(attr.Key) == "mcp.from_pid" && attr.Value.AsInt64() == 100 {
+			sawFromPID = true
+		}
+	}
+	if !sawFromPID {
+		t.Errorf("span attributes = %v, want mcp.from_pid=100", span.Attributes())
+	}
+}
+
+func TestExporter_NotificationRecordedAsSessionSpanEvent(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	e, _ := newTestExporter(t, eventBus)
+	defer e.Close()
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, ToPID: 200},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeNotification, Method: "notifications/initialized"},
+		Timestamp:      time.Now(),
+	})
+
+	key := "stdio:100:200"
+	e.mu.Lock()
+	session, ok := e.sessions[key]
+	e.mu.Unlock()
+	if !ok {
+		t.Fatalf("no ambient session span created for key %q", key)
+	}
+	if !session.IsRecording() {
+		t.Errorf("ambient session span for %q was ended, want still open", key)
+	}
+}
+
+func TestExporter_LoneRequestProducesNoSpan(t *testing.T) {
+	eventBus := tu.NewMockBus()
+	e, recorder := newTestExporter(t, eventBus)
+	defer e.Close()
+
+	eventBus.Publish(&event.MCPEvent{
+		TransportType:  event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{FromPID: 100, ToPID: 200},
+		JSONRPCMessage: event.JSONRPCMessage{MessageType: event.JSONRPCMessageTypeRequest, ID: int64(1), Method: "tools/call"},
+		Timestamp:      time.Now(),
+	})
+
+	if got := len(recorder.Ended()); got != 0 {
+		t.Errorf("got %d ended spans for a lone request, want 0", got)
+	}
+}
@@ -0,0 +1,274 @@
+// Package webhook batches live MCP traffic and rules-engine alerts and
+// POSTs them to a user-configured HTTP endpoint, for piping MCP activity
+// into Slack/Teams/PagerDuty-style incoming webhooks.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+	"github.com/alex-ilgayev/mcpspy/pkg/output"
+)
+
+const (
+	defaultQueueSize     = 256
+	defaultBatchSize     = 20
+	defaultBatchInterval = 5 * time.Second
+	defaultMaxRetries    = 3
+	defaultRetryDelay    = 2 * time.Second
+	postTimeout          = 10 * time.Second
+
+	// maxRawLen bounds how much of a message's raw content is included in
+	// a notification, post-redaction, to keep POST bodies small.
+	maxRawLen = 2048
+)
+
+// Config controls how the Notifier batches and delivers notifications.
+type Config struct {
+	// URL is the HTTP endpoint notifications are POSTed to.
+	URL string
+	// QueueSize is how many pending notifications can be buffered before
+	// new ones are dropped. Defaults to 256.
+	QueueSize int
+	// BatchSize is the most notifications sent in a single POST. Defaults
+	// to 20.
+	BatchSize int
+	// BatchInterval is the longest a partial batch waits before being sent
+	// anyway. Defaults to 5s.
+	BatchInterval time.Duration
+	// MaxRetries is how many times a failed POST is retried, with
+	// exponential backoff starting at 2s, before the batch is dropped.
+	// Defaults to 3.
+	MaxRetries int
+	// RedactPatterns restricts which JSON keys in a notification's raw
+	// content are masked, matched the same way as --redact. Nil disables
+	// redaction.
+	RedactPatterns []string
+}
+
+// Process identifies the process a notification's message was observed on,
+// when the transport exposes one.
+type Process struct {
+	PID  uint32 `json:"pid,omitempty"`
+	Comm string `json:"comm,omitempty"`
+}
+
+// Notification is the JSON payload sent to Config.URL for a single matched
+// event.
+type Notification struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Rule is the name of the rules-engine rule that matched, set only
+	// when this notification originates from an EventTypeRuleMatch rather
+	// than a live MCP message.
+	Rule      string              `json:"rule,omitempty"`
+	Method    string              `json:"method,omitempty"`
+	Transport event.TransportType `json:"transport,omitempty"`
+	Process   Process             `json:"process,omitempty"`
+	// Raw is the message's raw JSON-RPC payload, redacted and truncated to
+	// maxRawLen bytes.
+	Raw       string `json:"raw,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// Notifier batches live MCP traffic and rule-match alerts and POSTs them to
+// Config.URL as they accumulate, so downstream chat/paging integrations
+// don't get one HTTP request per message.
+//
+// Subscribes to the following events:
+// - EventTypeMCPMessage
+// - EventTypeRuleMatch
+type Notifier struct {
+	eventBus bus.EventBus
+	config   Config
+	client   *http.Client
+
+	queue chan Notification
+	wg    sync.WaitGroup
+}
+
+// New creates a Notifier and starts its batching worker.
+func New(eventBus bus.EventBus, config Config) (*Notifier, error) {
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaultQueueSize
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.BatchInterval <= 0 {
+		config.BatchInterval = defaultBatchInterval
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+
+	n := &Notifier{
+		eventBus: eventBus,
+		config:   config,
+		client:   &http.Client{Timeout: postTimeout},
+		queue:    make(chan Notification, config.QueueSize),
+	}
+
+	if err := eventBus.Subscribe(event.EventTypeMCPMessage, n.handleMCPMessage); err != nil {
+		return nil, err
+	}
+	if err := eventBus.Subscribe(event.EventTypeRuleMatch, n.handleRuleMatch); err != nil {
+		return nil, err
+	}
+
+	n.wg.Add(1)
+	go n.run()
+
+	return n, nil
+}
+
+func (n *Notifier) handleMCPMessage(e event.Event) {
+	msg, ok := e.(*event.MCPEvent)
+	if !ok {
+		return
+	}
+
+	raw, truncated := n.redactAndTruncate(msg.Raw)
+	n.enqueue(Notification{
+		Timestamp: msg.Timestamp,
+		Method:    msg.Method,
+		Transport: msg.TransportType,
+		Process:   processOf(msg),
+		Raw:       raw,
+		Truncated: truncated,
+	})
+}
+
+func (n *Notifier) handleRuleMatch(e event.Event) {
+	match, ok := e.(*event.RuleMatchEvent)
+	if !ok {
+		return
+	}
+
+	n.enqueue(Notification{
+		Timestamp: match.Timestamp,
+		Rule:      match.Rule,
+		Method:    match.Method,
+	})
+}
+
+// processOf summarizes which process a message was observed on: for stdio,
+// the server side of the hop; for HTTP/TCP, the single process captured on
+// that connection.
+func processOf(msg *event.MCPEvent) Process {
+	switch {
+	case msg.StdioTransport != nil:
+		return Process{PID: msg.StdioTransport.ToPID, Comm: msg.StdioTransport.ToComm}
+	case msg.HttpTransport != nil:
+		return Process{PID: msg.HttpTransport.PID, Comm: msg.HttpTransport.Comm}
+	case msg.TCPTransport != nil:
+		return Process{PID: msg.TCPTransport.PID, Comm: msg.TCPTransport.Comm}
+	default:
+		return Process{}
+	}
+}
+
+// redactAndTruncate applies Config.RedactPatterns to raw and caps the
+// result at maxRawLen, reporting whether it had to cut anything off.
+func (n *Notifier) redactAndTruncate(raw string) (string, bool) {
+	redacted := string(output.RedactJSONBytes([]byte(raw), n.config.RedactPatterns))
+	if len(redacted) <= maxRawLen {
+		return redacted, false
+	}
+	return redacted[:maxRawLen], true
+}
+
+// enqueue hands notif to the batching worker, dropping it if the queue is
+// already full rather than blocking the event bus dispatch goroutine.
+func (n *Notifier) enqueue(notif Notification) {
+	select {
+	case n.queue <- notif:
+	default:
+		logrus.Warn("Webhook notification queue is full; dropping event")
+	}
+}
+
+// run accumulates notifications into batches of up to Config.BatchSize and
+// sends each one once it's full or Config.BatchInterval has elapsed,
+// whichever comes first. It exits once queue is closed and drained, so a
+// final partial batch is still sent.
+func (n *Notifier) run() {
+	defer n.wg.Done()
+
+	batch := make([]Notification, 0, n.config.BatchSize)
+	timer := time.NewTimer(n.config.BatchInterval)
+	defer timer.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		n.post(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case notif, ok := <-n.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, notif)
+			if len(batch) >= n.config.BatchSize {
+				flush()
+				timer.Reset(n.config.BatchInterval)
+			}
+		case <-timer.C:
+			flush()
+			timer.Reset(n.config.BatchInterval)
+		}
+	}
+}
+
+// post sends batch to Config.URL, retrying a failed attempt with
+// exponential backoff (2s, 4s, 8s, ...) up to Config.MaxRetries times
+// before giving up on it.
+func (n *Notifier) post(batch []Notification) {
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to marshal webhook batch")
+		return
+	}
+
+	for attempt := 0; attempt <= n.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(defaultRetryDelay * time.Duration(1<<(attempt-1)))
+		}
+
+		resp, err := n.client.Post(n.config.URL, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			logrus.WithError(err).WithField("attempt", attempt+1).Warn("Failed to POST webhook batch")
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 300 {
+			return
+		}
+		logrus.WithFields(logrus.Fields{"attempt": attempt + 1, "status": resp.StatusCode}).Warn("Webhook batch POST returned a non-2xx status")
+	}
+
+	logrus.WithField("batch_size", len(batch)).Error("Giving up on webhook batch after exhausting retries")
+}
+
+// Close stops accepting new events, flushes whatever is already queued, and
+// waits for delivery to finish before returning, so a shutdown (Ctrl+C)
+// doesn't silently drop notifications still sitting in the queue.
+func (n *Notifier) Close() {
+	n.eventBus.Unsubscribe(event.EventTypeMCPMessage, n.handleMCPMessage)
+	n.eventBus.Unsubscribe(event.EventTypeRuleMatch, n.handleRuleMatch)
+	close(n.queue)
+	n.wg.Wait()
+}
@@ -0,0 +1,160 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	tu "github.com/alex-ilgayev/mcpspy/internal/testing"
+	"github.com/alex-ilgayev/mcpspy/pkg/bus"
+	"github.com/alex-ilgayev/mcpspy/pkg/event"
+)
+
+func toolCallEvent(tool string) *event.MCPEvent {
+	return &event.MCPEvent{
+		TransportType: event.TransportTypeStdio,
+		StdioTransport: &event.StdioTransport{
+			ToPID:  1234,
+			ToComm: "mcp-server",
+		},
+		JSONRPCMessage: event.JSONRPCMessage{
+			Method: "tools/call",
+			Params: map[string]interface{}{"name": tool},
+		},
+		Raw: `{"method":"tools/call","params":{"name":"` + tool + `"}}`,
+	}
+}
+
+func recvBatch(t *testing.T, received chan []Notification) []Notification {
+	select {
+	case batch := <-received:
+		return batch
+	case <-time.After(time.Second):
+		t.Fatal("expected a webhook POST")
+		return nil
+	}
+}
+
+func newNotifier(t *testing.T, cfg Config) (*Notifier, bus.EventBus, chan []Notification) {
+	received := make(chan []Notification, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []Notification
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Fatalf("failed to decode webhook payload: %v", err)
+		}
+		received <- batch
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg.URL = server.URL
+	mockBus := tu.NewMockBus()
+	n, err := New(mockBus, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(n.Close)
+
+	return n, mockBus, received
+}
+
+func TestNotifier_BatchesUntilIntervalElapses(t *testing.T) {
+	_, mockBus, received := newNotifier(t, Config{BatchSize: 10, BatchInterval: 20 * time.Millisecond})
+
+	mockBus.Publish(toolCallEvent("shell_exec"))
+	mockBus.Publish(toolCallEvent("read_file"))
+
+	batch := recvBatch(t, received)
+	if len(batch) != 2 {
+		t.Fatalf("len(batch) = %d, want 2", len(batch))
+	}
+	if batch[0].Method != "tools/call" {
+		t.Errorf("Method = %q, want %q", batch[0].Method, "tools/call")
+	}
+	if batch[0].Process.PID != 1234 || batch[0].Process.Comm != "mcp-server" {
+		t.Errorf("Process = %+v, want PID 1234 / comm mcp-server", batch[0].Process)
+	}
+}
+
+func TestNotifier_FlushesImmediatelyOnceBatchSizeReached(t *testing.T) {
+	_, mockBus, received := newNotifier(t, Config{BatchSize: 2, BatchInterval: time.Minute})
+
+	mockBus.Publish(toolCallEvent("shell_exec"))
+	mockBus.Publish(toolCallEvent("read_file"))
+
+	batch := recvBatch(t, received)
+	if len(batch) != 2 {
+		t.Fatalf("len(batch) = %d, want 2", len(batch))
+	}
+}
+
+func TestNotifier_RedactsAndTruncatesRaw(t *testing.T) {
+	_, mockBus, received := newNotifier(t, Config{
+		BatchSize:      1,
+		BatchInterval:  time.Minute,
+		RedactPatterns: []string{"token"},
+	})
+
+	e := toolCallEvent("shell_exec")
+	e.Raw = `{"method":"tools/call","params":{"name":"shell_exec","token":"secret-value"}}`
+	mockBus.Publish(e)
+
+	batch := recvBatch(t, received)
+	if len(batch) != 1 {
+		t.Fatalf("len(batch) = %d, want 1", len(batch))
+	}
+	if batch[0].Truncated {
+		t.Error("expected a short payload not to be marked truncated")
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(batch[0].Raw), &decoded); err != nil {
+		t.Fatalf("Raw isn't valid JSON: %v", err)
+	}
+	params := decoded["params"].(map[string]interface{})
+	if params["token"] != "***REDACTED***" {
+		t.Errorf("token = %v, want redacted", params["token"])
+	}
+}
+
+func TestNotifier_RuleMatchEventIncludesRuleName(t *testing.T) {
+	_, mockBus, received := newNotifier(t, Config{BatchSize: 1, BatchInterval: time.Minute})
+
+	mockBus.Publish(&event.RuleMatchEvent{Rule: "shell-tool", Method: "tools/call", Tool: "shell_exec"})
+
+	batch := recvBatch(t, received)
+	if len(batch) != 1 {
+		t.Fatalf("len(batch) = %d, want 1", len(batch))
+	}
+	if batch[0].Rule != "shell-tool" {
+		t.Errorf("Rule = %q, want %q", batch[0].Rule, "shell-tool")
+	}
+}
+
+func TestNotifier_CloseFlushesPendingBatch(t *testing.T) {
+	received := make(chan []Notification, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []Notification
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Fatalf("failed to decode webhook payload: %v", err)
+		}
+		received <- batch
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	mockBus := tu.NewMockBus()
+	n, err := New(mockBus, Config{URL: server.URL, BatchSize: 10, BatchInterval: time.Minute})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	mockBus.Publish(toolCallEvent("shell_exec"))
+	n.Close()
+
+	batch := recvBatch(t, received)
+	if len(batch) != 1 {
+		t.Fatalf("len(batch) = %d, want 1", len(batch))
+	}
+}